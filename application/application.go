@@ -1,10 +1,13 @@
 package application
 
 import (
+	"context"
 	"errors"
 	"log"
 	"os"
+	"os/signal"
 	"path"
+	"syscall"
 	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/gobble"
@@ -55,6 +58,44 @@ func (a Application) mailClient() *mail.Client {
 	})
 }
 
+func (a Application) transport(name, webhookURL string, smtpPool *mail.Pool) mail.Transport {
+	switch name {
+	case mail.TransportWebhook:
+		return mail.NewWebhookTransport(mail.WebhookConfig{
+			URL:           webhookURL,
+			SkipVerifySSL: !a.env.VerifySSL,
+		})
+	case mail.TransportSpool:
+		return mail.NewSpoolTransport(a.env.SpoolDirectory)
+	default:
+		return smtpPool
+	}
+}
+
+func (a Application) transports() mail.TransportSelector {
+	smtpPool := mail.NewPool(mail.PoolConfig{
+		Size:                     a.env.SMTPPoolSize,
+		MaxMessagesPerConnection: a.env.SMTPMaxMessagesPerConnection,
+		IdleTimeout:              time.Duration(a.env.SMTPIdleTimeout) * time.Millisecond,
+	}, a.mailClient)
+
+	var routes []mail.TransportRoute
+	for _, route := range a.env.TransportRoutes {
+		webhookURL := route.URL
+		if webhookURL == "" {
+			webhookURL = a.env.WebhookURL
+		}
+
+		routes = append(routes, mail.TransportRoute{
+			ClientID:  route.ClientID,
+			KindID:    route.KindID,
+			Transport: a.transport(route.Transport, webhookURL, smtpPool),
+		})
+	}
+
+	return mail.NewTransportSelector(a.transport(a.env.DefaultTransport, a.env.WebhookURL, smtpPool), routes)
+}
+
 func (a Application) Run() {
 
 	a.VerifySMTPConfiguration()
@@ -73,14 +114,16 @@ func (a Application) Run() {
 	a.migrator.Migrate()
 
 	a.StartQueueGauge()
-	a.StartWorkers(validator)
+	deliveries := a.StartWorkers(validator)
 	a.StartMessageGC()
 	a.StartKeyRefresher(validator)
-	a.StartServer(a.logger, validator)
+	server := a.StartServer(a.logger, validator)
+
+	a.WaitForShutdown(server, deliveries)
 }
 
 func (a Application) VerifySMTPConfiguration() {
-	if a.env.TestMode {
+	if a.env.TestMode || !a.env.UsesTransport(mail.TransportSMTP) {
 		return
 	}
 
@@ -134,8 +177,8 @@ func (a Application) StartKeyRefresher(validator *uaa.TokenValidator) {
 	}()
 }
 
-func (a Application) StartWorkers(validator *uaa.TokenValidator) {
-	postal.Boot(a.mailClient, a.dbProvider.sqlDB, postal.Config{
+func (a Application) StartWorkers(validator *uaa.TokenValidator) postal.Deliveries {
+	return postal.Boot(a.transports(), a.dbProvider.sqlDB, postal.Config{
 		UAAClientID:          a.env.UAAClientID,
 		UAAClientSecret:      a.env.UAAClientSecret,
 		UAATokenValidator:    validator,
@@ -148,24 +191,37 @@ func (a Application) StartWorkers(validator *uaa.TokenValidator) {
 		DBLoggingEnabled:     a.env.DBLoggingEnabled,
 		Sender:               a.env.Sender,
 		Domain:               a.env.Domain,
+		PublicURL:            a.env.PublicURL,
+		StrictTemplates:      a.env.StrictTemplates,
+		DefaultLocale:        a.env.DefaultLocale,
 		QueueWaitMaxDuration: a.env.GobbleWaitMaxDuration,
+		QueueBatchSize:       a.env.GobbleBatchSize,
+		HighPriorityWorkers:  a.env.GobbleHighPriorityWorkers,
 		CCHost:               a.env.CCHost,
+
+		Throttle: postal.ThrottleConfig{
+			MessagesPerSecond:       a.env.SMTPMaxMessagesPerSecond,
+			DomainMessagesPerSecond: a.env.SMTPMaxMessagesPerSecondPerDomain,
+		},
 	})
 }
 
 func (a Application) StartMessageGC() {
 	messageLifetime := 24 * time.Hour
+	idempotencyKeyLifetime := time.Duration(a.env.IdempotencyKeyLifetime) * time.Millisecond
 	db := a.dbProvider.Database()
 	messagesRepo := a.dbProvider.MessagesRepo()
+	idempotencyKeysRepo := a.dbProvider.IdempotencyKeysRepo()
+	sendRatesRepo := a.dbProvider.SendRatesRepo()
 	pollingInterval := 1 * time.Hour
 
 	logger := log.New(os.Stdout, "", 0)
-	messageGC := postal.NewMessageGC(messageLifetime, db, messagesRepo, pollingInterval, logger)
+	messageGC := postal.NewMessageGC(messageLifetime, idempotencyKeyLifetime, db, messagesRepo, idempotencyKeysRepo, sendRatesRepo, pollingInterval, logger)
 	messageGC.Run()
 }
 
-func (a Application) StartServer(logger lager.Logger, validator *uaa.TokenValidator) {
-	web.NewServer().Run(web.Config{
+func (a Application) StartServer(logger lager.Logger, validator *uaa.TokenValidator) web.Server {
+	server := web.NewServer(web.Config{
 		DBLoggingEnabled:     a.env.DBLoggingEnabled,
 		SkipVerifySSL:        !a.env.VerifySSL,
 		Port:                 a.env.Port,
@@ -174,6 +230,8 @@ func (a Application) StartServer(logger lager.Logger, validator *uaa.TokenValida
 		SQLDB:                a.dbProvider.sqlDB,
 		Queue:                a.dbProvider.Queue(),
 		QueueWaitMaxDuration: a.env.GobbleWaitMaxDuration,
+		MaxUsersBatchSize:    a.env.MaxUsersBatchSize,
+		EncryptionKey:        a.env.EncryptionKey,
 
 		UAATokenValidator: validator,
 		UAAHost:           a.env.UAAHost,
@@ -182,6 +240,42 @@ func (a Application) StartServer(logger lager.Logger, validator *uaa.TokenValida
 		DefaultUAAScopes:  a.env.DefaultUAAScopes,
 		CCHost:            a.env.CCHost,
 	})
+
+	go func() {
+		err := server.Run()
+		if err != nil {
+			logger.Fatal("listen-and-serve-errored", err)
+		}
+	}()
+
+	return server
+}
+
+// WaitForShutdown blocks until the process is asked to terminate. It then
+// stops accepting requests and gives the requests and deliveries in flight
+// until the shutdown timeout to finish. Jobs that were reserved but not
+// delivered are released for other instances.
+func (a Application) WaitForShutdown(server web.Server, deliveries postal.Deliveries) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
+	sig := <-signals
+	a.logger.Info("shutting-down", lager.Data{"signal": sig.String()})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(a.env.ShutdownTimeout)*time.Millisecond)
+	defer cancel()
+
+	err := server.Shutdown(ctx)
+	if err != nil {
+		a.logger.Error("server-shutdown-errored", err)
+	}
+
+	deadline, _ := ctx.Deadline()
+	if !deliveries.Stop(time.Until(deadline)) {
+		a.logger.Info("shutdown-timed-out-with-deliveries-in-flight")
+	}
+
+	a.logger.Info("shut-down")
 }
 
 // This is a hack to get the logs output to the loggregator before the process exits
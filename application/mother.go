@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"io/ioutil"
 	"log"
+	"net/url"
 	"os"
 	"path"
 	"time"
@@ -28,11 +29,15 @@ func NewDBProvider(env Environment) *DBProvider {
 	var err error
 	databaseURL := env.DatabaseURL
 	if env.DatabaseCACertFile != "" {
-		registerTLSConfig(env)
-		databaseURL += "&tls=custom"
+		if env.DatabaseDriver == db.DialectPostgres {
+			databaseURL = postgresTLSURL(env)
+		} else {
+			registerTLSConfig(env)
+			databaseURL += "&tls=custom"
+		}
 	}
 
-	sqlDB, err := sql.Open("mysql", databaseURL)
+	sqlDB, err := sql.Open(env.DatabaseDriver, databaseURL)
 	if err != nil {
 		panic(err)
 	}
@@ -74,6 +79,34 @@ func (d *DBProvider) MessagesRepo() v1models.MessagesRepo {
 	return v1models.NewMessagesRepo(util.NewIDGenerator(rand.Reader).Generate)
 }
 
+func (d *DBProvider) IdempotencyKeysRepo() v1models.IdempotencyKeysRepo {
+	return v1models.NewIdempotencyKeysRepo()
+}
+
+func (d *DBProvider) SendRatesRepo() v1models.SendRatesRepo {
+	return v1models.NewSendRatesRepo()
+}
+
+// postgresTLSURL asks lib/pq to verify the server against the configured CA,
+// checking the hostname only when identity verification is enabled.
+func postgresTLSURL(env Environment) string {
+	parsedURL, err := url.Parse(env.DatabaseURL)
+	if err != nil {
+		panic(err)
+	}
+
+	query := parsedURL.Query()
+	query.Set("sslrootcert", env.DatabaseCACertFile)
+	if env.DatabaseEnableIdentityVerification {
+		query.Set("sslmode", "verify-full")
+	} else {
+		query.Set("sslmode", "verify-ca")
+	}
+	parsedURL.RawQuery = query.Encode()
+
+	return parsedURL.String()
+}
+
 func registerTLSConfig(env Environment) {
 	ca, err := ioutil.ReadFile(env.DatabaseCACertFile)
 	if err != nil {
@@ -20,6 +20,20 @@ type ConnectionInterface interface {
 
 type Connection struct {
 	*gorp.DbMap
+	dialect Dialect
+}
+
+// NewConnection maps the database handle using the dialect of its driver.
+func NewConnection(sqlDB *sql.DB) *Connection {
+	dialect := DialectOf(sqlDB)
+
+	return &Connection{
+		DbMap: &gorp.DbMap{
+			Db:      sqlDB,
+			Dialect: dialect.GorpDialect(),
+		},
+		dialect: dialect,
+	}
 }
 
 func (conn *Connection) Transaction() TransactionInterface {
@@ -29,3 +43,23 @@ func (conn *Connection) Transaction() TransactionInterface {
 func (conn *Connection) GetDbMap() *gorp.DbMap {
 	return conn.DbMap
 }
+
+func (conn *Connection) Dialect() Dialect {
+	return conn.dialect
+}
+
+func (conn *Connection) Select(holder interface{}, query string, args ...interface{}) ([]interface{}, error) {
+	return conn.DbMap.Select(holder, conn.dialect.Rebind(query), args...)
+}
+
+func (conn *Connection) SelectOne(holder interface{}, query string, args ...interface{}) error {
+	return conn.DbMap.SelectOne(holder, conn.dialect.Rebind(query), args...)
+}
+
+func (conn *Connection) SelectInt(query string, args ...interface{}) (int64, error) {
+	return conn.DbMap.SelectInt(conn.dialect.Rebind(query), args...)
+}
+
+func (conn *Connection) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return conn.DbMap.Exec(conn.dialect.Rebind(query), args...)
+}
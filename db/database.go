@@ -20,19 +20,9 @@ type DatabaseInterface interface {
 }
 
 func NewDatabase(db *sql.DB, config Config) *DB {
-	connection := &Connection{
-		DbMap: &gorp.DbMap{
-			Db: db,
-			Dialect: gorp.MySQLDialect{
-				Engine:   "InnoDB",
-				Encoding: "UTF8",
-			},
-		},
-	}
-
 	database := &DB{
 		config:     config,
-		connection: connection,
+		connection: NewConnection(db),
 	}
 
 	return database
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"gopkg.in/gorp.v1"
+)
+
+const (
+	DialectMySQL    = "mysql"
+	DialectPostgres = "postgres"
+)
+
+// Dialect describes the SQL flavour spoken by the underlying database. The
+// name doubles as the database/sql driver name and the sql-migrate dialect.
+type Dialect struct {
+	Name string
+	gorp gorp.Dialect
+}
+
+var (
+	MySQL = Dialect{
+		Name: DialectMySQL,
+		gorp: gorp.MySQLDialect{
+			Engine:   "InnoDB",
+			Encoding: "UTF8",
+		},
+	}
+
+	Postgres = Dialect{
+		Name: DialectPostgres,
+		gorp: gorp.PostgresDialect{},
+	}
+)
+
+// DialectOf inspects the driver behind an open database handle. Anything that
+// is not PostgreSQL is treated as MySQL.
+func DialectOf(sqlDB *sql.DB) Dialect {
+	if _, ok := sqlDB.Driver().(*pq.Driver); ok {
+		return Postgres
+	}
+
+	return MySQL
+}
+
+// DialectOfMap reports the dialect a table map was configured with.
+func DialectOfMap(dbMap *gorp.DbMap) Dialect {
+	if dbMap != nil {
+		if _, ok := dbMap.Dialect.(gorp.PostgresDialect); ok {
+			return Postgres
+		}
+	}
+
+	return MySQL
+}
+
+func (d Dialect) GorpDialect() gorp.Dialect {
+	return d.gorp
+}
+
+// Rebind rewrites a query written in the MySQL style used throughout the
+// repositories, with backtick quoted identifiers and ? placeholders, into the
+// form the dialect expects. Single quoted string literals are left untouched.
+func (d Dialect) Rebind(query string) string {
+	if d.Name != DialectPostgres {
+		return query
+	}
+
+	var rebound strings.Builder
+	inLiteral := false
+	position := 0
+
+	for _, r := range query {
+		switch {
+		case r == '\'':
+			inLiteral = !inLiteral
+			rebound.WriteRune(r)
+		case inLiteral:
+			rebound.WriteRune(r)
+		case r == '`':
+			rebound.WriteRune('"')
+		case r == '?':
+			position++
+			fmt.Fprintf(&rebound, "$%d", position)
+		default:
+			rebound.WriteRune(r)
+		}
+	}
+
+	return rebound.String()
+}
+
+// IsDuplicateError reports whether err was caused by a unique constraint
+// violation in either database.
+func IsDuplicateError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if pqErr, ok := err.(*pq.Error); ok {
+		return pqErr.Code == "23505"
+	}
+
+	return strings.Contains(err.Error(), "Duplicate entry")
+}
@@ -0,0 +1,23 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE `messages` ADD `client_id` varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE `messages` ADD `kind_id` varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE `messages` ADD `user_guid` varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE `messages` ADD `email` varchar(255) NOT NULL DEFAULT '';
+CREATE INDEX `messages_client_id_kind_id_updated_at` ON `messages` (`client_id`, `kind_id`, `updated_at`);
+CREATE INDEX `messages_user_guid_updated_at` ON `messages` (`user_guid`, `updated_at`);
+CREATE INDEX `messages_email_updated_at` ON `messages` (`email`, `updated_at`);
+CREATE INDEX `messages_status_updated_at` ON `messages` (`status`, `updated_at`);
+CREATE INDEX `messages_updated_at` ON `messages` (`updated_at`);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP INDEX `messages_updated_at` ON `messages`;
+DROP INDEX `messages_status_updated_at` ON `messages`;
+DROP INDEX `messages_email_updated_at` ON `messages`;
+DROP INDEX `messages_user_guid_updated_at` ON `messages`;
+DROP INDEX `messages_client_id_kind_id_updated_at` ON `messages`;
+ALTER TABLE `messages` DROP COLUMN `email`;
+ALTER TABLE `messages` DROP COLUMN `user_guid`;
+ALTER TABLE `messages` DROP COLUMN `kind_id`;
+ALTER TABLE `messages` DROP COLUMN `client_id`;
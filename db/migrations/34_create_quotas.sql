@@ -0,0 +1,29 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS `quotas` (
+      `primary` int(11) NOT NULL AUTO_INCREMENT,
+      `client_id` varchar(255) NOT NULL,
+      `kind_id` varchar(255) NOT NULL DEFAULT '',
+      `requests_per_minute` int(11) NOT NULL DEFAULT 0,
+      `recipients_per_hour` int(11) NOT NULL DEFAULT 0,
+      `created_at` datetime NOT NULL,
+      `updated_at` datetime NOT NULL,
+      PRIMARY KEY (`primary`),
+      UNIQUE KEY `client_id_kind_id` (`client_id`, `kind_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE IF NOT EXISTS `quota_usages` (
+      `primary` int(11) NOT NULL AUTO_INCREMENT,
+      `client_id` varchar(255) NOT NULL,
+      `kind_id` varchar(255) NOT NULL DEFAULT '',
+      `measure` varchar(255) NOT NULL,
+      `window_start` datetime NOT NULL,
+      `count` int(11) NOT NULL DEFAULT 0,
+      PRIMARY KEY (`primary`),
+      UNIQUE KEY `client_id_kind_id_measure_window_start` (`client_id`, `kind_id`, `measure`, `window_start`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE `quota_usages`;
+DROP TABLE `quotas`;
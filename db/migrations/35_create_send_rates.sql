@@ -0,0 +1,14 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS `send_rates` (
+      `primary` int(11) NOT NULL AUTO_INCREMENT,
+      `scope` varchar(255) NOT NULL,
+      `window_start` datetime NOT NULL,
+      `count` int(11) NOT NULL DEFAULT 0,
+      PRIMARY KEY (`primary`),
+      UNIQUE KEY `scope_window_start` (`scope`, `window_start`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE `send_rates`;
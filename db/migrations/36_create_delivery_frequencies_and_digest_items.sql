@@ -0,0 +1,34 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS `delivery_frequencies` (
+      `primary` int(11) NOT NULL AUTO_INCREMENT,
+      `user_id` varchar(255) NOT NULL,
+      `client_id` varchar(255) NOT NULL DEFAULT '',
+      `kind_id` varchar(255) NOT NULL DEFAULT '',
+      `frequency` varchar(255) NOT NULL,
+      PRIMARY KEY (`primary`),
+      UNIQUE KEY `user_id_client_id_kind_id` (`user_id`, `client_id`, `kind_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE IF NOT EXISTS `digest_items` (
+      `primary` int(11) NOT NULL AUTO_INCREMENT,
+      `user_id` varchar(255) NOT NULL,
+      `email` varchar(255) NOT NULL DEFAULT '',
+      `frequency` varchar(255) NOT NULL,
+      `message_id` varchar(255) NOT NULL DEFAULT '',
+      `client_id` varchar(255) NOT NULL DEFAULT '',
+      `kind_id` varchar(255) NOT NULL DEFAULT '',
+      `source_description` varchar(255) NOT NULL DEFAULT '',
+      `kind_description` varchar(255) NOT NULL DEFAULT '',
+      `subject` text,
+      `text` longtext,
+      `html` longtext,
+      `created_at` datetime NOT NULL,
+      PRIMARY KEY (`primary`),
+      KEY `user_id_frequency` (`user_id`, `frequency`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE `digest_items`;
+DROP TABLE `delivery_frequencies`;
@@ -0,0 +1,15 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS `quiet_hours` (
+      `primary` int(11) NOT NULL AUTO_INCREMENT,
+      `user_id` varchar(255) NOT NULL,
+      `time_zone` varchar(255) NOT NULL DEFAULT '',
+      `start_time` varchar(5) NOT NULL DEFAULT '',
+      `end_time` varchar(5) NOT NULL DEFAULT '',
+      PRIMARY KEY (`primary`),
+      UNIQUE KEY `user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE `quiet_hours`;
@@ -0,0 +1,17 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS `preference_jobs` (
+      `id` varchar(255) NOT NULL,
+      `status` varchar(255) NOT NULL,
+      `total` int(11) NOT NULL DEFAULT 0,
+      `processed` int(11) NOT NULL DEFAULT 0,
+      `failed` int(11) NOT NULL DEFAULT 0,
+      `last_error` text NOT NULL,
+      `created_at` datetime NOT NULL,
+      `updated_at` datetime NOT NULL,
+      PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE `preference_jobs`;
@@ -0,0 +1,20 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS `preference_changes` (
+      `primary` int(11) NOT NULL AUTO_INCREMENT,
+      `user_id` varchar(255) NOT NULL,
+      `actor` varchar(255) NOT NULL DEFAULT '',
+      `source` varchar(255) NOT NULL,
+      `client_id` varchar(255) NOT NULL DEFAULT '',
+      `kind_id` varchar(255) NOT NULL DEFAULT '',
+      `field` varchar(255) NOT NULL,
+      `old_value` varchar(255) NOT NULL DEFAULT '',
+      `new_value` varchar(255) NOT NULL DEFAULT '',
+      `created_at` datetime NOT NULL,
+      PRIMARY KEY (`primary`),
+      KEY `user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE `preference_changes`;
@@ -0,0 +1,21 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS messages (
+      id varchar(255) PRIMARY KEY,
+      status varchar(255) NOT NULL,
+      client_id varchar(255) NOT NULL DEFAULT '',
+      kind_id varchar(255) NOT NULL DEFAULT '',
+      user_guid varchar(255) NOT NULL DEFAULT '',
+      email varchar(255) NOT NULL DEFAULT '',
+      job_id integer NOT NULL DEFAULT 0,
+      updated_at timestamp NOT NULL
+);
+CREATE INDEX messages_client_id_kind_id_updated_at ON messages (client_id, kind_id, updated_at);
+CREATE INDEX messages_user_guid_updated_at ON messages (user_guid, updated_at);
+CREATE INDEX messages_email_updated_at ON messages (email, updated_at);
+CREATE INDEX messages_status_updated_at ON messages (status, updated_at);
+CREATE INDEX messages_updated_at ON messages (updated_at);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE messages;
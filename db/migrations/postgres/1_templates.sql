@@ -0,0 +1,19 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS templates (
+      "primary" serial PRIMARY KEY,
+      id varchar(255) NOT NULL DEFAULT '',
+      name varchar(255) NOT NULL DEFAULT '',
+      subject varchar(255) NOT NULL DEFAULT '',
+      text text NOT NULL DEFAULT '',
+      html text NOT NULL DEFAULT '',
+      metadata text NOT NULL DEFAULT '{}',
+      overridden boolean NOT NULL DEFAULT false,
+      created_at timestamp NOT NULL,
+      updated_at timestamp NOT NULL,
+      UNIQUE (id)
+);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE templates;
@@ -0,0 +1,18 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS message_events (
+      "primary" serial PRIMARY KEY,
+      message_id varchar(255) NOT NULL,
+      previous_status varchar(255) NOT NULL DEFAULT '',
+      status varchar(255) NOT NULL,
+      retry_count integer NOT NULL DEFAULT 0,
+      smtp_code integer NOT NULL DEFAULT 0,
+      smtp_text text NOT NULL DEFAULT '',
+      recipient varchar(255) NOT NULL DEFAULT '',
+      created_at timestamp NOT NULL
+);
+CREATE INDEX message_events_message_id ON message_events (message_id);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE message_events;
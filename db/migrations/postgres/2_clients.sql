@@ -0,0 +1,14 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS clients (
+      "primary" serial PRIMARY KEY,
+      id varchar(255) NOT NULL,
+      description varchar(255) NOT NULL DEFAULT '',
+      template_id varchar(255) NOT NULL DEFAULT 'default',
+      created_at timestamp NOT NULL,
+      UNIQUE (id)
+);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE clients;
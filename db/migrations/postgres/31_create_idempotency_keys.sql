@@ -0,0 +1,16 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+      "primary" serial PRIMARY KEY,
+      client_id varchar(255) NOT NULL,
+      idempotency_key varchar(255) NOT NULL,
+      request_hash varchar(64) NOT NULL,
+      response text NOT NULL DEFAULT '',
+      created_at timestamp NOT NULL,
+      UNIQUE (client_id, idempotency_key)
+);
+CREATE INDEX idempotency_keys_created_at ON idempotency_keys (created_at);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE idempotency_keys;
@@ -0,0 +1,15 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS suppressions (
+      "primary" serial PRIMARY KEY,
+      email varchar(255) NOT NULL,
+      reason varchar(255) NOT NULL,
+      detail text NOT NULL DEFAULT '',
+      created_at timestamp NOT NULL,
+      updated_at timestamp NOT NULL,
+      UNIQUE (email)
+);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE suppressions;
@@ -0,0 +1,27 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS quotas (
+      "primary" serial PRIMARY KEY,
+      client_id varchar(255) NOT NULL,
+      kind_id varchar(255) NOT NULL DEFAULT '',
+      requests_per_minute integer NOT NULL DEFAULT 0,
+      recipients_per_hour integer NOT NULL DEFAULT 0,
+      created_at timestamp NOT NULL,
+      updated_at timestamp NOT NULL,
+      UNIQUE (client_id, kind_id)
+);
+
+CREATE TABLE IF NOT EXISTS quota_usages (
+      "primary" serial PRIMARY KEY,
+      client_id varchar(255) NOT NULL,
+      kind_id varchar(255) NOT NULL DEFAULT '',
+      measure varchar(255) NOT NULL,
+      window_start timestamp NOT NULL,
+      count integer NOT NULL DEFAULT 0,
+      UNIQUE (client_id, kind_id, measure, window_start)
+);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE quota_usages;
+DROP TABLE quotas;
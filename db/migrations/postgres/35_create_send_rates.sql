@@ -0,0 +1,13 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS send_rates (
+      "primary" serial PRIMARY KEY,
+      scope varchar(255) NOT NULL,
+      window_start timestamp NOT NULL,
+      count integer NOT NULL DEFAULT 0,
+      UNIQUE (scope, window_start)
+);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE send_rates;
@@ -0,0 +1,33 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS delivery_frequencies (
+      "primary" serial PRIMARY KEY,
+      user_id varchar(255) NOT NULL,
+      client_id varchar(255) NOT NULL DEFAULT '',
+      kind_id varchar(255) NOT NULL DEFAULT '',
+      frequency varchar(255) NOT NULL,
+      UNIQUE (user_id, client_id, kind_id)
+);
+
+CREATE TABLE IF NOT EXISTS digest_items (
+      "primary" serial PRIMARY KEY,
+      user_id varchar(255) NOT NULL,
+      email varchar(255) NOT NULL DEFAULT '',
+      frequency varchar(255) NOT NULL,
+      message_id varchar(255) NOT NULL DEFAULT '',
+      client_id varchar(255) NOT NULL DEFAULT '',
+      kind_id varchar(255) NOT NULL DEFAULT '',
+      source_description varchar(255) NOT NULL DEFAULT '',
+      kind_description varchar(255) NOT NULL DEFAULT '',
+      subject text,
+      text text,
+      html text,
+      created_at timestamp NOT NULL
+);
+
+CREATE INDEX digest_items_user_id_frequency ON digest_items (user_id, frequency);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE digest_items;
+DROP TABLE delivery_frequencies;
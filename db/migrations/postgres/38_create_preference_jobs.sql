@@ -0,0 +1,16 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS preference_jobs (
+      id varchar(255) PRIMARY KEY,
+      status varchar(255) NOT NULL,
+      total integer NOT NULL DEFAULT 0,
+      processed integer NOT NULL DEFAULT 0,
+      failed integer NOT NULL DEFAULT 0,
+      last_error text NOT NULL DEFAULT '',
+      created_at timestamp NOT NULL,
+      updated_at timestamp NOT NULL
+);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE preference_jobs;
@@ -0,0 +1,19 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS preference_changes (
+      "primary" serial PRIMARY KEY,
+      user_id varchar(255) NOT NULL,
+      actor varchar(255) NOT NULL DEFAULT '',
+      source varchar(255) NOT NULL,
+      client_id varchar(255) NOT NULL DEFAULT '',
+      kind_id varchar(255) NOT NULL DEFAULT '',
+      field varchar(255) NOT NULL,
+      old_value varchar(255) NOT NULL DEFAULT '',
+      new_value varchar(255) NOT NULL DEFAULT '',
+      created_at timestamp NOT NULL
+);
+CREATE INDEX preference_changes_user_id ON preference_changes (user_id);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE preference_changes;
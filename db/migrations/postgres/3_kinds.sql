@@ -0,0 +1,17 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS kinds (
+      "primary" serial PRIMARY KEY,
+      id varchar(255) NOT NULL,
+      description varchar(255) NOT NULL DEFAULT '',
+      critical boolean NOT NULL DEFAULT false,
+      client_id varchar(255) NOT NULL,
+      template_id varchar(255) NOT NULL DEFAULT 'default',
+      created_at timestamp NOT NULL,
+      updated_at timestamp NOT NULL DEFAULT '2000-01-01 00:00:00',
+      UNIQUE (id, client_id)
+);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE kinds;
@@ -0,0 +1,19 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS template_versions (
+      "primary" serial PRIMARY KEY,
+      template_id varchar(255) NOT NULL,
+      version integer NOT NULL,
+      name varchar(255) NOT NULL DEFAULT '',
+      subject varchar(255) NOT NULL DEFAULT '',
+      text text NOT NULL DEFAULT '',
+      html text NOT NULL DEFAULT '',
+      metadata text NOT NULL DEFAULT '{}',
+      client_id varchar(255) NOT NULL DEFAULT '',
+      created_at timestamp NOT NULL,
+      UNIQUE (template_id, version)
+);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE template_versions;
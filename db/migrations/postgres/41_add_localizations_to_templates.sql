@@ -0,0 +1,9 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+ALTER TABLE templates ADD COLUMN localizations text NOT NULL DEFAULT '{}';
+ALTER TABLE template_versions ADD COLUMN localizations text NOT NULL DEFAULT '{}';
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+ALTER TABLE templates DROP COLUMN localizations;
+ALTER TABLE template_versions DROP COLUMN localizations;
@@ -0,0 +1,12 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS user_locales (
+      "primary" serial PRIMARY KEY,
+      user_id varchar(255) NOT NULL,
+      locale varchar(255) NOT NULL DEFAULT '',
+      UNIQUE (user_id)
+);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE user_locales;
@@ -0,0 +1,15 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS receipts (
+      "primary" serial PRIMARY KEY,
+      user_guid varchar(255) NOT NULL,
+      client_id varchar(255) NOT NULL,
+      kind_id varchar(255) NOT NULL,
+      count integer NOT NULL DEFAULT 0,
+      created_at timestamp NOT NULL,
+      UNIQUE (user_guid, client_id, kind_id)
+);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE receipts;
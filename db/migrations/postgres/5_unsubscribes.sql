@@ -0,0 +1,14 @@
+-- +migrate Up
+-- SQL in section 'Up' is executed when this migration is applied
+CREATE TABLE IF NOT EXISTS unsubscribes (
+      "primary" serial PRIMARY KEY,
+      user_id varchar(255) NOT NULL,
+      client_id varchar(255) NOT NULL,
+      kind_id varchar(255) NOT NULL,
+      created_at timestamp NOT NULL,
+      UNIQUE (user_id, client_id, kind_id)
+);
+
+-- +migrate Down
+-- SQL section 'Down' is executed when this migration is rolled back
+DROP TABLE unsubscribes;
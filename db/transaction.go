@@ -47,7 +47,7 @@ func (transaction *Transaction) Delete(v ...interface{}) (int64, error) {
 }
 
 func (transaction *Transaction) Exec(query string, v ...interface{}) (sql.Result, error) {
-	return transaction.txn.Exec(query, v...)
+	return transaction.txn.Exec(transaction.conn.dialect.Rebind(query), v...)
 }
 
 func (transaction *Transaction) Insert(v ...interface{}) error {
@@ -59,11 +59,11 @@ func (transaction *Transaction) Rollback() error {
 }
 
 func (transaction *Transaction) Select(holder interface{}, query string, args ...interface{}) ([]interface{}, error) {
-	return transaction.txn.Select(holder, query, args...)
+	return transaction.txn.Select(holder, transaction.conn.dialect.Rebind(query), args...)
 }
 
 func (transaction *Transaction) SelectOne(holder interface{}, query string, args ...interface{}) error {
-	return transaction.txn.SelectOne(holder, query, args...)
+	return transaction.txn.SelectOne(holder, transaction.conn.dialect.Rebind(query), args...)
 }
 
 func (transaction *Transaction) Get(i interface{}, keys ...interface{}) (interface{}, error) {
@@ -4,4 +4,9 @@ import "time"
 
 type Config struct {
 	WaitMaxDuration time.Duration
+
+	// BatchSize is the number of jobs claimed in a single round trip to the
+	// database. Jobs beyond the first are handed to the next workers that
+	// reserve.
+	BatchSize int
 }
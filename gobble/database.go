@@ -3,10 +3,9 @@ package gobble
 import (
 	"database/sql"
 
+	"github.com/cloudfoundry-incubator/notifications/db"
 	sql_migrate "github.com/rubenv/sql-migrate"
 	"gopkg.in/gorp.v1"
-
-	_ "github.com/go-sql-driver/mysql"
 )
 
 type DatabaseInterface interface {
@@ -17,20 +16,26 @@ type ConnectionInterface interface {
 	Insert(...interface{}) error
 }
 
+type ExecConnectionInterface interface {
+	Exec(string, ...interface{}) (sql.Result, error)
+}
+
+type DeadJobsConnectionInterface interface {
+	GetDbMap() *gorp.DbMap
+	Insert(...interface{}) error
+	Select(interface{}, string, ...interface{}) ([]interface{}, error)
+	SelectOne(interface{}, string, ...interface{}) error
+	Exec(string, ...interface{}) (sql.Result, error)
+}
+
 type DB struct {
-	Connection *gorp.DbMap
+	Connection *db.Connection
 }
 
-func NewDatabase(db *sql.DB) *DB {
-	conn := &gorp.DbMap{
-		Db: db,
-		Dialect: gorp.MySQLDialect{
-			Engine:   "InnoDB",
-			Encoding: "UTF8",
-		},
-	}
+func NewDatabase(sqlDB *sql.DB) *DB {
+	conn := db.NewConnection(sqlDB)
 
-	Initializer{}.InitializeDBMap(conn)
+	Initializer{}.InitializeDBMap(conn.DbMap)
 
 	return &DB{Connection: conn}
 }
@@ -39,16 +44,17 @@ type Initializer struct{}
 
 func (Initializer) InitializeDBMap(dbMap *gorp.DbMap) {
 	dbMap.AddTableWithName(Job{}, "jobs").SetKeys(true, "ID").SetVersionCol("Version")
+	dbMap.AddTableWithName(DeadJob{}, "dead_jobs").SetKeys(true, "ID")
 }
 
-func (db DB) Migrate(migrationsPath string) {
+func (database DB) Migrate(migrationsPath string) {
 	sql_migrate.SetTable("gobble_model_migrations")
 
 	migrations := &sql_migrate.FileMigrationSource{
 		Dir: migrationsPath,
 	}
 
-	_, err := sql_migrate.Exec(db.Connection.Db, "mysql", migrations, sql_migrate.Up)
+	_, err := sql_migrate.Exec(database.Connection.Db, database.Connection.Dialect().Name, migrations, sql_migrate.Up)
 	if err != nil {
 		panic(err)
 	}
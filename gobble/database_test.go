@@ -1,6 +1,7 @@
 package gobble_test
 
 import (
+	"github.com/cloudfoundry-incubator/notifications/db"
 	"github.com/cloudfoundry-incubator/notifications/gobble"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -16,7 +17,12 @@ var _ = Describe("Database", func() {
 	It("has a jobs table", func() {
 		database := gobble.NewDatabase(sqlDB)
 
-		rows, err := database.Connection.Db.Query("SHOW TABLES")
+		currentSchema := "DATABASE()"
+		if database.Connection.Dialect().Name == db.DialectPostgres {
+			currentSchema = "current_schema()"
+		}
+
+		rows, err := database.Connection.Db.Query("SELECT table_name FROM information_schema.tables WHERE table_schema = " + currentSchema)
 		Expect(err).NotTo(HaveOccurred())
 
 		defer rows.Close()
@@ -32,7 +38,7 @@ var _ = Describe("Database", func() {
 
 		Expect(tables).To(ContainElement("jobs"))
 
-		rows, err = database.Connection.Db.Query("SELECT COLUMN_NAME, DATA_TYPE FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_NAME = 'jobs'")
+		rows, err = database.Connection.Db.Query("SELECT column_name, data_type FROM information_schema.columns WHERE table_schema = " + currentSchema + " AND table_name = 'jobs'")
 		Expect(err).NotTo(HaveOccurred())
 
 		defer rows.Close()
@@ -49,29 +55,30 @@ var _ = Describe("Database", func() {
 			})
 		}
 
-		Expect(columns).To(ContainElement(Column{
-			Field: "id",
-			Type:  "int",
-		}))
-		Expect(columns).To(ContainElement(Column{
-			Field: "worker_id",
-			Type:  "varchar",
-		}))
-		Expect(columns).To(ContainElement(Column{
-			Field: "payload",
-			Type:  "longtext",
-		}))
-		Expect(columns).To(ContainElement(Column{
-			Field: "version",
-			Type:  "bigint",
-		}))
-		Expect(columns).To(ContainElement(Column{
-			Field: "retry_count",
-			Type:  "int",
-		}))
-		Expect(columns).To(ContainElement(Column{
-			Field: "active_at",
-			Type:  "timestamp",
-		}))
+		expectedTypes := map[string]string{
+			"id":          "int",
+			"worker_id":   "varchar",
+			"payload":     "longtext",
+			"version":     "bigint",
+			"retry_count": "int",
+			"active_at":   "timestamp",
+		}
+		if database.Connection.Dialect().Name == db.DialectPostgres {
+			expectedTypes = map[string]string{
+				"id":          "integer",
+				"worker_id":   "character varying",
+				"payload":     "text",
+				"version":     "bigint",
+				"retry_count": "integer",
+				"active_at":   "timestamp without time zone",
+			}
+		}
+
+		for field, fieldType := range expectedTypes {
+			Expect(columns).To(ContainElement(Column{
+				Field: field,
+				Type:  fieldType,
+			}))
+		}
 	})
 })
@@ -0,0 +1,16 @@
+package gobble
+
+import "time"
+
+// DeadJob is a job that exhausted its retries. It keeps the payload so the
+// job can be inspected and replayed later.
+type DeadJob struct {
+	ID         int       `db:"id"`
+	JobID      int       `db:"job_id"`
+	Payload    string    `db:"payload"`
+	RetryCount int       `db:"retry_count"`
+	Priority   int       `db:"priority"`
+	LastError  string    `db:"last_error"`
+	ActiveAt   time.Time `db:"active_at"`
+	FailedAt   time.Time `db:"failed_at"`
+}
@@ -5,14 +5,25 @@ import (
 	"time"
 )
 
+// Jobs with a higher priority are reserved ahead of jobs with a lower one,
+// however long the lower priority jobs have been waiting.
+const (
+	PriorityLow    = -1
+	PriorityNormal = 0
+	PriorityHigh   = 1
+)
+
 type Job struct {
 	ID          int       `db:"id"`
 	WorkerID    string    `db:"worker_id"`
 	Payload     string    `db:"payload"`
 	Version     int64     `db:"version"`
 	RetryCount  int       `db:"retry_count"`
+	Priority    int       `db:"priority"`
 	ActiveAt    time.Time `db:"active_at"`
 	ShouldRetry bool      `db:"-"`
+	ShouldBury  bool      `db:"-"`
+	LastError   string    `db:"-"`
 }
 
 func NewJob(data interface{}) *Job {
@@ -37,6 +48,22 @@ func (job *Job) Retry(duration time.Duration) {
 	job.ShouldRetry = true
 }
 
+// Defer puts the job back in the queue until the given time without counting
+// it as a retry, for jobs that are postponed rather than failed.
+func (job *Job) Defer(until time.Time) {
+	job.WorkerID = ""
+	job.ActiveAt = until
+	job.ShouldRetry = true
+}
+
+// Bury marks the job to be moved to the dead jobs table instead of being
+// dequeued once the worker is done with it.
+func (job *Job) Bury(reason string) {
+	job.ShouldRetry = false
+	job.ShouldBury = true
+	job.LastError = reason
+}
+
 func (job *Job) State() (int, time.Time) {
 	return job.RetryCount, job.ActiveAt
 }
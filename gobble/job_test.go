@@ -57,6 +57,35 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("Defer", func() {
+		It("sets up the job to be reserved again later without counting a retry", func() {
+			job := gobble.NewJob("the data")
+			job.RetryCount = 1
+			job.WorkerID = "my-id"
+			until := time.Now().Add(6 * time.Hour)
+
+			job.Defer(until)
+
+			Expect(job.WorkerID).To(Equal(""))
+			Expect(job.RetryCount).To(Equal(1))
+			Expect(job.ActiveAt).To(Equal(until))
+			Expect(job.ShouldRetry).To(BeTrue())
+		})
+	})
+
+	Describe("Bury", func() {
+		It("marks the job to be moved to the dead jobs table", func() {
+			job := gobble.NewJob("the data")
+			job.ShouldRetry = true
+
+			job.Bury("server timeout")
+
+			Expect(job.ShouldBury).To(BeTrue())
+			Expect(job.ShouldRetry).To(BeFalse())
+			Expect(job.LastError).To(Equal("server timeout"))
+		})
+	})
+
 	Describe("State", func() {
 		It("returns the current retry count and active at values", func() {
 			expectedActiveAt := time.Now().Add(-5 * time.Minute)
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE `jobs` ADD `priority` int(11) NOT NULL DEFAULT '0';
+CREATE INDEX `jobs_priority_active_at` ON `jobs` (`priority`, `active_at`);
+ALTER TABLE `dead_jobs` ADD `priority` int(11) NOT NULL DEFAULT '0';
+
+-- +migrate Down
+ALTER TABLE `dead_jobs` DROP COLUMN `priority`;
+DROP INDEX `jobs_priority_active_at` ON `jobs`;
+ALTER TABLE `jobs` DROP COLUMN `priority`;
@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS jobs (
+  id serial PRIMARY KEY,
+  worker_id varchar(255) NOT NULL DEFAULT '',
+  payload text NOT NULL DEFAULT '',
+  version bigint NOT NULL DEFAULT 0,
+  retry_count integer NOT NULL DEFAULT 0,
+  active_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +migrate Down
+DROP TABLE jobs;
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS dead_jobs (
+  id serial PRIMARY KEY,
+  job_id integer NOT NULL DEFAULT 0,
+  payload text NOT NULL DEFAULT '',
+  retry_count integer NOT NULL DEFAULT 0,
+  last_error text NOT NULL DEFAULT '',
+  active_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  failed_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX dead_jobs_failed_at ON dead_jobs (failed_at);
+
+-- +migrate Down
+DROP TABLE dead_jobs;
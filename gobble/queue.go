@@ -1,21 +1,31 @@
 package gobble
 
 import (
-	"database/sql"
 	"math/rand"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/cloudfoundry-incubator/notifications/db"
 	"gopkg.in/gorp.v1"
 )
 
 var WaitMaxDuration = 5 * time.Second
 
+// ClaimedJobLifetime bounds how long a job claimed as part of a batch may wait
+// in memory for a worker. Older claims are released, so that they never get
+// close to the expiry window that lets other workers take over a reservation.
+var ClaimedJobLifetime = 30 * time.Second
+
+const reservationExpiry = 2 * time.Minute
+
 type QueueInterface interface {
 	Enqueue(*Job, ConnectionInterface) (*Job, error)
 	Reserve(string) <-chan *Job
+	ReservePriority(string, int) <-chan *Job
 	Dequeue(*Job)
 	Requeue(*Job)
+	Bury(*Job)
 	Len() (int, error)
 }
 
@@ -27,7 +37,16 @@ type Queue struct {
 	config   Config
 	database *DB
 	clock    clock
-	closed   bool
+
+	mutex   sync.Mutex
+	closed  bool
+	done    chan struct{}
+	claimed []claimedJob
+}
+
+type claimedJob struct {
+	job       *Job
+	claimedAt time.Time
 }
 
 func NewQueue(database DatabaseInterface, clock clock, config Config) *Queue {
@@ -35,10 +54,15 @@ func NewQueue(database DatabaseInterface, clock clock, config Config) *Queue {
 		config.WaitMaxDuration = WaitMaxDuration
 	}
 
+	if config.BatchSize < 1 {
+		config.BatchSize = 1
+	}
+
 	return &Queue{
 		database: database.(*DB),
 		clock:    clock,
 		config:   config,
+		done:     make(chan struct{}),
 	}
 }
 
@@ -55,6 +79,22 @@ func (queue *Queue) Enqueue(job *Job, connection ConnectionInterface) (*Job, err
 	return job, nil
 }
 
+// Cancel deletes a job that has not been reserved by a worker, reporting
+// whether a job was removed.
+func (queue *Queue) Cancel(jobID int, connection ExecConnectionInterface) (bool, error) {
+	result, err := connection.Exec("DELETE FROM `jobs` WHERE `id` = ? AND `worker_id` = ''", jobID)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count == 1, nil
+}
+
 func (queue *Queue) Requeue(job *Job) {
 	_, err := queue.database.Connection.Update(job)
 	if err != nil {
@@ -67,44 +107,178 @@ func (queue *Queue) Len() (int, error) {
 	return int(length), err
 }
 
+// Close stops the queue from handing out jobs. Jobs claimed in a batch that
+// no worker has taken yet, and jobs reserved for workers that have stopped
+// listening, are released so that other instances can pick them up straight
+// away instead of waiting for their reservation to expire.
 func (queue *Queue) Close() {
+	queue.mutex.Lock()
+	if queue.closed {
+		queue.mutex.Unlock()
+		return
+	}
 	queue.closed = true
+	close(queue.done)
+	claimed := queue.claimed
+	queue.claimed = nil
+	queue.mutex.Unlock()
+
+	for _, claim := range claimed {
+		queue.updateJob(claim.job, "")
+	}
+}
+
+func (queue *Queue) isClosed() bool {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	return queue.closed
 }
 
 func (queue *Queue) Reserve(workerID string) <-chan *Job {
+	return queue.ReservePriority(workerID, PriorityLow)
+}
+
+// ReservePriority reserves the next job with at least the given priority.
+func (queue *Queue) ReservePriority(workerID string, minimum int) <-chan *Job {
 	channel := make(chan *Job)
-	go queue.reserve(channel, workerID)
+	go queue.reserve(channel, workerID, minimum)
 
 	return channel
 }
 
-func (queue *Queue) reserve(channel chan *Job, workerID string) {
+func (queue *Queue) reserve(channel chan *Job, workerID string, minimum int) {
 	var job *Job
 	for job == nil {
+		if queue.isClosed() {
+			return
+		}
+
 		var err error
+		job, err = queue.nextJob(workerID, minimum)
+		if err != nil {
+			panic(err)
+		}
 
-		job = queue.findJob()
-		if queue.closed {
-			return
+		if job == nil {
+			queue.waitUpTo(queue.config.WaitMaxDuration)
 		}
+	}
 
-		job, err = queue.updateJob(job, workerID)
+	select {
+	case channel <- job:
+	case <-queue.done:
+		queue.updateJob(job, "")
+	}
+}
+
+// nextJob hands out a job left over from an earlier batch claim, or claims a
+// new batch. It returns nil only when there is nothing to claim.
+func (queue *Queue) nextJob(workerID string, minimum int) (*Job, error) {
+	job := queue.popClaimed(minimum)
+	if job != nil {
+		job.WorkerID = workerID
+		return job, nil
+	}
+
+	jobs, err := queue.claim(workerID, minimum, queue.config.BatchSize)
+	if err != nil || len(jobs) == 0 {
+		return nil, err
+	}
+
+	queue.pushClaimed(jobs[1:])
+
+	return jobs[0], nil
+}
+
+// claim reserves up to limit active jobs of at least the minimum priority,
+// highest priority first and then oldest first. Rows that another
+// worker is claiming at the same moment are skipped rather than waited on,
+// so concurrent workers never contend for the same job.
+func (queue *Queue) claim(workerID string, minimum, limit int) ([]*Job, error) {
+	transaction := db.NewTransaction(queue.database.Connection)
+	err := transaction.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expired := now.Add(-reservationExpiry)
+	results, err := transaction.Select(Job{}, "SELECT * FROM `jobs` WHERE ( ( `worker_id` = '' AND `active_at` <= ? ) OR `active_at` <= ? ) AND `priority` >= ? ORDER BY `priority` DESC, `active_at`, `id` LIMIT ? FOR UPDATE SKIP LOCKED", now, expired, minimum, limit)
+	if err != nil {
+		transaction.Rollback()
+		return nil, err
+	}
+
+	jobs := []*Job{}
+	for _, result := range results {
+		job := result.(*Job)
+		job.WorkerID = workerID
+		job.ActiveAt = now
+
+		_, err = transaction.Update(job)
 		if err != nil {
+			transaction.Rollback()
 			if _, ok := err.(gorp.OptimisticLockError); ok {
-				job = nil
-				continue
-			} else {
-				panic(err)
+				return nil, nil
 			}
+			return nil, err
 		}
+
+		jobs = append(jobs, job)
 	}
 
+	return jobs, transaction.Commit()
+}
+
+func (queue *Queue) pushClaimed(jobs []*Job) {
+	now := time.Now()
+
+	queue.mutex.Lock()
 	if queue.closed {
-		queue.updateJob(job, "")
+		queue.mutex.Unlock()
+		for _, job := range jobs {
+			queue.updateJob(job, "")
+		}
 		return
 	}
+	defer queue.mutex.Unlock()
 
-	channel <- job
+	for _, job := range jobs {
+		queue.claimed = append(queue.claimed, claimedJob{
+			job:       job,
+			claimedAt: now,
+		})
+	}
+}
+
+// popClaimed returns the first job of at least the minimum priority claimed
+// in an earlier batch, releasing any claims that have been held for longer
+// than ClaimedJobLifetime.
+func (queue *Queue) popClaimed(minimum int) *Job {
+	var stale []*Job
+	var job *Job
+
+	queue.mutex.Lock()
+	remaining := queue.claimed[:0]
+	for _, claim := range queue.claimed {
+		switch {
+		case time.Since(claim.claimedAt) > ClaimedJobLifetime:
+			stale = append(stale, claim.job)
+		case job == nil && claim.job.Priority >= minimum:
+			job = claim.job
+		default:
+			remaining = append(remaining, claim)
+		}
+	}
+	queue.claimed = remaining
+	queue.mutex.Unlock()
+
+	for _, staleJob := range stale {
+		queue.updateJob(staleJob, "")
+	}
+
+	return job
 }
 
 func (queue *Queue) Dequeue(job *Job) {
@@ -117,23 +291,118 @@ func (queue *Queue) Dequeue(job *Job) {
 	}
 }
 
-func (queue *Queue) findJob() *Job {
-	var job *Job
-	for job == nil {
-		job = &Job{}
-		now := time.Now()
-		expired := now.Add(-2 * time.Minute)
-		err := queue.database.Connection.SelectOne(job, "SELECT * FROM `jobs` WHERE ( `worker_id` = \"\" AND `active_at` <= ? ) OR `active_at` <= ? LIMIT 1", now, expired)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				job = nil
-				queue.waitUpTo(queue.config.WaitMaxDuration)
-				continue
-			}
-			panic(err)
+// Bury moves a job that has exhausted its retries into the dead jobs table.
+func (queue *Queue) Bury(job *Job) {
+	transaction, err := queue.database.Connection.Begin()
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = transaction.Delete(job)
+	if err != nil {
+		transaction.Rollback()
+		if _, ok := err.(gorp.OptimisticLockError); ok && strings.Contains(err.Error(), "no row found") {
+			return
 		}
+		panic(err)
 	}
-	return job
+
+	err = transaction.Insert(&DeadJob{
+		JobID:      job.ID,
+		Payload:    job.Payload,
+		RetryCount: job.RetryCount,
+		Priority:   job.Priority,
+		LastError:  job.LastError,
+		ActiveAt:   job.ActiveAt,
+		FailedAt:   queue.clock.Now(),
+	})
+	if err != nil {
+		transaction.Rollback()
+		panic(err)
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (queue *Queue) DeadJobs(connection DeadJobsConnectionInterface, limit, offset int) ([]DeadJob, error) {
+	deadJobs := []DeadJob{}
+	_, err := connection.Select(&deadJobs, "SELECT * FROM `dead_jobs` ORDER BY `failed_at` DESC, `id` DESC LIMIT ? OFFSET ?", limit, offset)
+	if err != nil {
+		return deadJobs, err
+	}
+
+	return deadJobs, nil
+}
+
+func (queue *Queue) CountDeadJobs(connection DeadJobsConnectionInterface) (int, error) {
+	var count int
+	err := connection.SelectOne(&count, "SELECT COUNT(*) FROM `dead_jobs`")
+	return count, err
+}
+
+// FindDeadJob returns sql.ErrNoRows when there is no dead job with the given ID.
+func (queue *Queue) FindDeadJob(connection DeadJobsConnectionInterface, id int) (DeadJob, error) {
+	deadJob := DeadJob{}
+	err := connection.SelectOne(&deadJob, "SELECT * FROM `dead_jobs` WHERE `id` = ?", id)
+	return deadJob, err
+}
+
+// ReplayDeadJob inserts the payload of a dead job back into the jobs table as
+// a fresh job and removes the dead job, returning the ID of the new job. It
+// should be called inside a transaction.
+func (queue *Queue) ReplayDeadJob(connection DeadJobsConnectionInterface, id int) (int, error) {
+	deadJob, err := queue.FindDeadJob(connection, id)
+	if err != nil {
+		return 0, err
+	}
+
+	Initializer{}.InitializeDBMap(connection.GetDbMap())
+
+	job := &Job{
+		Payload:  deadJob.Payload,
+		Priority: deadJob.Priority,
+		ActiveAt: queue.clock.Now(),
+	}
+
+	err = connection.Insert(job)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = connection.Exec("DELETE FROM `dead_jobs` WHERE `id` = ?", id)
+	if err != nil {
+		return 0, err
+	}
+
+	return job.ID, nil
+}
+
+// DeleteDeadJob reports whether a dead job with the given ID was deleted.
+func (queue *Queue) DeleteDeadJob(connection DeadJobsConnectionInterface, id int) (bool, error) {
+	result, err := connection.Exec("DELETE FROM `dead_jobs` WHERE `id` = ?", id)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return count == 1, nil
+}
+
+func (queue *Queue) DeleteDeadJobs(connection DeadJobsConnectionInterface) (int, error) {
+	result, err := connection.Exec("DELETE FROM `dead_jobs`")
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	return int(count), err
 }
 
 func (queue *Queue) updateJob(job *Job, workerID string) (*Job, error) {
@@ -153,5 +422,9 @@ func (queue *Queue) updateJob(job *Job, workerID string) (*Job, error) {
 func (queue *Queue) waitUpTo(max time.Duration) {
 	rand.Seed(time.Now().UnixNano())
 	waitTime := rand.Int63n(int64(max))
-	<-time.After(time.Duration(waitTime))
+
+	select {
+	case <-time.After(time.Duration(waitTime)):
+	case <-queue.done:
+	}
 }
@@ -1,6 +1,7 @@
 package gobble_test
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/db"
@@ -56,7 +57,7 @@ var _ = Describe("Queue", func() {
 					"testing": true,
 				})
 
-				transaction := db.NewTransaction(&db.Connection{DbMap: database.Connection})
+				transaction := db.NewTransaction(database.Connection)
 				transaction.Begin()
 
 				job, err := queue.Enqueue(job, transaction)
@@ -76,6 +77,38 @@ var _ = Describe("Queue", func() {
 		})
 	})
 
+	Describe("Cancel", func() {
+		It("deletes a job that has not been reserved", func() {
+			job, err := queue.Enqueue(gobble.NewJob("scheduled"), database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			canceled, err := queue.Cancel(job.ID, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(canceled).To(BeTrue())
+
+			length, err := queue.Len()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(length).To(Equal(0))
+		})
+
+		It("does not delete a job that a worker has reserved", func() {
+			job, err := queue.Enqueue(gobble.NewJob("scheduled"), database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			job.WorkerID = "some-worker"
+			_, err = database.Connection.Update(job)
+			Expect(err).NotTo(HaveOccurred())
+
+			canceled, err := queue.Cancel(job.ID, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(canceled).To(BeFalse())
+
+			length, err := queue.Len()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(length).To(Equal(1))
+		})
+	})
+
 	Describe("Requeue", func() {
 		It("updates the queue in the database", func() {
 			job := gobble.NewJob(map[string]bool{
@@ -170,6 +203,128 @@ var _ = Describe("Queue", func() {
 			Expect(job.ID).To(Equal(job2.ID))
 		})
 
+		It("reserves the job that has been active the longest", func() {
+			_, err := queue.Enqueue(&gobble.Job{
+				ActiveAt: time.Now().Add(-1 * time.Minute),
+			}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			oldest, err := queue.Enqueue(&gobble.Job{
+				ActiveAt: time.Now().Add(-90 * time.Second),
+			}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			job := <-queue.Reserve("worker-id")
+
+			Expect(job.ID).To(Equal(oldest.ID))
+		})
+
+		It("reserves higher priority jobs ahead of older ones", func() {
+			_, err := queue.Enqueue(&gobble.Job{
+				ActiveAt: time.Now().Add(-1 * time.Minute),
+			}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			urgent, err := queue.Enqueue(&gobble.Job{
+				Priority: gobble.PriorityHigh,
+			}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			job := <-queue.Reserve("worker-id")
+
+			Expect(job.ID).To(Equal(urgent.ID))
+		})
+
+		Context("when reserving a minimum priority", func() {
+			It("ignores jobs with a lower priority", func() {
+				_, err := queue.Enqueue(&gobble.Job{}, database.Connection)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobChannel := queue.ReservePriority("worker-id", gobble.PriorityHigh)
+				Consistently(jobChannel).ShouldNot(Receive())
+
+				urgent, err := queue.Enqueue(&gobble.Job{
+					Priority: gobble.PriorityHigh,
+				}, database.Connection)
+				Expect(err).NotTo(HaveOccurred())
+
+				var job *gobble.Job
+				Eventually(jobChannel).Should(Receive(&job))
+				Expect(job.ID).To(Equal(urgent.ID))
+			})
+		})
+
+		It("skips jobs that are locked by another reservation", func() {
+			locked, err := queue.Enqueue(&gobble.Job{
+				ActiveAt: time.Now().Add(-1 * time.Minute),
+			}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			unlocked, err := queue.Enqueue(&gobble.Job{}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			transaction := db.NewTransaction(database.Connection)
+			Expect(transaction.Begin()).To(Succeed())
+			defer transaction.Rollback()
+
+			_, err = transaction.Select(gobble.Job{}, "SELECT * FROM `jobs` WHERE `id` = ? FOR UPDATE", locked.ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			var job *gobble.Job
+			Eventually(queue.Reserve("worker-id")).Should(Receive(&job))
+
+			Expect(job.ID).To(Equal(unlocked.ID))
+		})
+
+		Context("when the batch size is larger than one", func() {
+			BeforeEach(func() {
+				queue = gobble.NewQueue(database, clock, gobble.Config{
+					WaitMaxDuration: 50 * time.Millisecond,
+					BatchSize:       3,
+				})
+			})
+
+			It("claims a batch of jobs in a single reservation", func() {
+				for i := 0; i < 4; i++ {
+					_, err := queue.Enqueue(&gobble.Job{
+						ActiveAt: time.Now().Add(time.Duration(i-10) * time.Second),
+					}, database.Connection)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				<-queue.Reserve("worker-1")
+
+				results, err := database.Connection.Select(gobble.Job{}, "SELECT * FROM `jobs` WHERE `worker_id` = ''")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(1))
+			})
+
+			It("hands the rest of the batch to the next workers that reserve", func() {
+				ids := []int{}
+				for i := 0; i < 3; i++ {
+					job, err := queue.Enqueue(&gobble.Job{
+						ActiveAt: time.Now().Add(time.Duration(i-10) * time.Second),
+					}, database.Connection)
+					Expect(err).NotTo(HaveOccurred())
+					ids = append(ids, job.ID)
+				}
+
+				first := <-queue.Reserve("worker-1")
+				Expect(first.ID).To(Equal(ids[0]))
+
+				_, err := database.Connection.Exec("DELETE FROM `jobs`")
+				Expect(err).NotTo(HaveOccurred())
+
+				second := <-queue.Reserve("worker-2")
+				Expect(second.ID).To(Equal(ids[1]))
+				Expect(second.WorkerID).To(Equal("worker-2"))
+
+				third := <-queue.Reserve("worker-3")
+				Expect(third.ID).To(Equal(ids[2]))
+				Expect(third.WorkerID).To(Equal("worker-3"))
+			})
+		})
+
 		Context("when the worker id is set", func() {
 			Context("when active_at is in the future", func() {
 				It("should not grab the job", func() {
@@ -262,6 +417,199 @@ var _ = Describe("Queue", func() {
 		})
 	})
 
+	Describe("Bury", func() {
+		It("moves the job into the dead jobs table", func() {
+			job, err := queue.Enqueue(gobble.NewJob("exhausted"), database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			job.RetryCount = 10
+			job.Bury("server timeout")
+			queue.Bury(job)
+
+			length, err := queue.Len()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(length).To(Equal(0))
+
+			deadJobs, err := queue.DeadJobs(database.Connection, 10, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadJobs).To(HaveLen(1))
+			Expect(deadJobs[0].JobID).To(Equal(job.ID))
+			Expect(deadJobs[0].Payload).To(Equal(job.Payload))
+			Expect(deadJobs[0].RetryCount).To(Equal(10))
+			Expect(deadJobs[0].LastError).To(Equal("server timeout"))
+			Expect(deadJobs[0].FailedAt).To(BeTemporally("~", clock.NowCall.Returns.Time, time.Second))
+		})
+
+		It("keeps the priority of the job so that a replay keeps it too", func() {
+			job := gobble.NewJob("exhausted")
+			job.Priority = gobble.PriorityHigh
+			job, err := queue.Enqueue(job, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			job.Bury("server timeout")
+			queue.Bury(job)
+
+			deadJobs, err := queue.DeadJobs(database.Connection, 10, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadJobs[0].Priority).To(Equal(gobble.PriorityHigh))
+
+			jobID, err := queue.ReplayDeadJob(database.Connection, deadJobs[0].ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			replayed := gobble.Job{}
+			err = database.Connection.SelectOne(&replayed, "SELECT * FROM `jobs` WHERE `id` = ?", jobID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replayed.Priority).To(Equal(gobble.PriorityHigh))
+		})
+
+		It("ignores jobs that are already gone", func() {
+			job, err := queue.Enqueue(gobble.NewJob("exhausted"), database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+			queue.Dequeue(job)
+
+			Expect(func() {
+				queue.Bury(job)
+			}).NotTo(Panic())
+
+			count, err := queue.CountDeadJobs(database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(0))
+		})
+	})
+
+	Describe("dead jobs", func() {
+		var deadJobIDs []int
+
+		BeforeEach(func() {
+			deadJobIDs = []int{}
+			for _, payload := range []string{"first", "second", "third"} {
+				job, err := queue.Enqueue(gobble.NewJob(payload), database.Connection)
+				Expect(err).NotTo(HaveOccurred())
+
+				job.Bury("server timeout")
+				queue.Bury(job)
+
+				deadJobs, err := queue.DeadJobs(database.Connection, 1, 0)
+				Expect(err).NotTo(HaveOccurred())
+				deadJobIDs = append(deadJobIDs, deadJobs[0].ID)
+			}
+		})
+
+		It("lists and counts dead jobs, newest first", func() {
+			count, err := queue.CountDeadJobs(database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(3))
+
+			deadJobs, err := queue.DeadJobs(database.Connection, 2, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadJobs).To(HaveLen(2))
+			Expect(deadJobs[0].ID).To(Equal(deadJobIDs[1]))
+			Expect(deadJobs[1].ID).To(Equal(deadJobIDs[0]))
+		})
+
+		It("finds a dead job by ID", func() {
+			deadJob, err := queue.FindDeadJob(database.Connection, deadJobIDs[1])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadJob.Payload).To(Equal(`"second"`))
+
+			_, err = queue.FindDeadJob(database.Connection, -1)
+			Expect(err).To(Equal(sql.ErrNoRows))
+		})
+
+		It("replays a dead job as a fresh job", func() {
+			jobID, err := queue.ReplayDeadJob(database.Connection, deadJobIDs[0])
+			Expect(err).NotTo(HaveOccurred())
+
+			job := gobble.Job{}
+			err = database.Connection.SelectOne(&job, "SELECT * FROM `jobs` WHERE `id` = ?", jobID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(job.Payload).To(Equal(`"first"`))
+			Expect(job.RetryCount).To(Equal(0))
+			Expect(job.WorkerID).To(Equal(""))
+
+			count, err := queue.CountDeadJobs(database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(2))
+		})
+
+		It("deletes a single dead job", func() {
+			deleted, err := queue.DeleteDeadJob(database.Connection, deadJobIDs[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			deleted, err = queue.DeleteDeadJob(database.Connection, deadJobIDs[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+		})
+
+		It("deletes all dead jobs", func() {
+			count, err := queue.DeleteDeadJobs(database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(3))
+
+			count, err = queue.CountDeadJobs(database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(0))
+		})
+	})
+
+	Describe("Close", func() {
+		It("stops reserving jobs", func() {
+			queue.Close()
+
+			_, err := queue.Enqueue(&gobble.Job{}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			Consistently(queue.Reserve("worker-1")).ShouldNot(Receive())
+		})
+
+		It("releases a job reserved for a worker that stopped listening", func() {
+			job, err := queue.Enqueue(&gobble.Job{}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			queue.Reserve("worker-1")
+
+			workerID := func() (string, error) {
+				reserved, err := database.Connection.Get(gobble.Job{}, job.ID)
+				if err != nil {
+					return "", err
+				}
+				return reserved.(*gobble.Job).WorkerID, nil
+			}
+			Eventually(workerID).Should(Equal("worker-1"))
+
+			queue.Close()
+
+			Eventually(workerID).Should(Equal(""))
+		})
+
+		It("releases jobs claimed in a batch that no worker has taken", func() {
+			queue = gobble.NewQueue(database, clock, gobble.Config{
+				WaitMaxDuration: 50 * time.Millisecond,
+				BatchSize:       3,
+			})
+
+			for i := 0; i < 3; i++ {
+				_, err := queue.Enqueue(&gobble.Job{
+					ActiveAt: time.Now().Add(time.Duration(i-10) * time.Second),
+				}, database.Connection)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			<-queue.Reserve("worker-1")
+			queue.Close()
+
+			results, err := database.Connection.Select(gobble.Job{}, "SELECT * FROM `jobs` WHERE `worker_id` = ''")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(2))
+		})
+
+		It("can be called more than once", func() {
+			queue.Close()
+			queue.Close()
+		})
+	})
+
 	Describe("Len", func() {
 		It("returns the length of the queue", func() {
 			job, err := queue.Enqueue(&gobble.Job{}, database.Connection)
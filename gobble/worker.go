@@ -16,28 +16,39 @@ type Worker struct {
 	callback func(*Job)
 	beater   heartbeater
 	halt     chan bool
+	priority int
 }
 
 func NewWorker(id int, queue QueueInterface, callback func(*Job), beater heartbeater) Worker {
+	return NewPriorityWorker(id, PriorityLow, queue, callback, beater)
+}
+
+// NewPriorityWorker builds a worker that only performs jobs with at least the
+// given priority, so that it stays free for urgent work.
+func NewPriorityWorker(id, priority int, queue QueueInterface, callback func(*Job), beater heartbeater) Worker {
 	return Worker{
 		ID:       fmt.Sprintf("worker-%d-%d", id, os.Getpid()),
 		queue:    queue,
 		callback: callback,
 		beater:   beater,
 		halt:     make(chan bool),
+		priority: priority,
 	}
 }
 
 func (worker *Worker) Perform() int {
 	select {
-	case job := <-worker.queue.Reserve(worker.ID):
+	case job := <-worker.reserve():
 		go worker.beater.Beat(job)
 		defer worker.beater.Halt()
 		worker.callback(job)
 
-		if job.ShouldRetry {
+		switch {
+		case job.ShouldRetry:
 			worker.queue.Requeue(job)
-		} else {
+		case job.ShouldBury:
+			worker.queue.Bury(job)
+		default:
 			worker.queue.Dequeue(job)
 		}
 		return 0
@@ -46,6 +57,14 @@ func (worker *Worker) Perform() int {
 	}
 }
 
+func (worker *Worker) reserve() <-chan *Job {
+	if worker.priority > PriorityLow {
+		return worker.queue.ReservePriority(worker.ID, worker.priority)
+	}
+
+	return worker.queue.Reserve(worker.ID)
+}
+
 func (worker *Worker) Work() {
 	go func() {
 		for {
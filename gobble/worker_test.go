@@ -97,6 +97,28 @@ var _ = Describe("Worker", func() {
 			Expect(retriedJob.ActiveAt).To(BeTemporally("~", time.Now().Add(1*time.Minute), 1*time.Minute))
 		})
 
+		It("moves jobs that are marked for burial into the dead jobs table", func() {
+			callback = func(job *gobble.Job) {
+				job.Bury("server timeout")
+			}
+			worker = gobble.NewWorker(1, queue, callback, heartbeater)
+
+			job, err := queue.Enqueue(&gobble.Job{Payload: "the-payload"}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			worker.Perform()
+
+			results, err := database.Connection.Select(gobble.Job{}, "SELECT * FROM `jobs`")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(0))
+
+			deadJobs, err := queue.DeadJobs(database.Connection, 10, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadJobs).To(HaveLen(1))
+			Expect(deadJobs[0].JobID).To(Equal(job.ID))
+			Expect(deadJobs[0].LastError).To(Equal("server timeout"))
+		})
+
 		It("heartbeats for job ownership while the job executes", func() {
 			job, err := queue.Enqueue(&gobble.Job{
 				Payload: "the-payload",
@@ -149,5 +171,37 @@ var _ = Describe("Worker", func() {
 
 			worker.Halt()
 		})
+
+		It("finishes the job it is performing before it halts", func() {
+			hold := make(chan struct{})
+			callback = func(*gobble.Job) {
+				<-hold
+			}
+			worker = gobble.NewWorker(1, queue, callback, &MockHeartbeater{})
+
+			_, err := queue.Enqueue(&gobble.Job{
+				Payload: "the-payload",
+			}, database.Connection)
+			Expect(err).NotTo(HaveOccurred())
+
+			worker.Work()
+
+			Eventually(func() (int64, error) {
+				return database.Connection.SelectInt("SELECT COUNT(*) FROM `jobs` WHERE `worker_id` <> ''")
+			}).Should(BeEquivalentTo(1))
+
+			halted := make(chan struct{})
+			go func() {
+				worker.Halt()
+				close(halted)
+			}()
+
+			Consistently(halted).ShouldNot(BeClosed())
+
+			close(hold)
+
+			Eventually(halted).Should(BeClosed())
+			Expect(database.Connection.SelectInt("SELECT COUNT(*) FROM `jobs`")).To(BeEquivalentTo(0))
+		})
 	})
 })
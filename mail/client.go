@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net"
 	"net/smtp"
+	"net/textproto"
 	"strings"
 	"time"
 
@@ -117,6 +118,36 @@ func (c *Client) Send(msg Message, logger lager.Logger) error {
 		return nil
 	}
 
+	err := c.Open(logger)
+	if err != nil {
+		return err
+	}
+
+	err = c.Transaction(msg, logger)
+	if err != nil {
+		c.quit(logger)
+		return err
+	}
+
+	c.PrintLog(logger, "quiting")
+	err = c.Quit()
+	if err != nil {
+		return c.Error(logger, err)
+	}
+	c.PrintLog(logger, "disconnected")
+
+	return nil
+}
+
+// Open connects to the server and completes the EHLO, STARTTLS and AUTH
+// handshake, leaving the session ready for one or more transactions.
+func (c *Client) Open(logger lager.Logger) error {
+	logger = c.createLoggerSession(logger)
+
+	if c.config.TestMode {
+		return nil
+	}
+
 	err := c.Connect(logger)
 	if err != nil {
 		return c.Error(logger, err)
@@ -125,7 +156,7 @@ func (c *Client) Send(msg Message, logger lager.Logger) error {
 	c.PrintLog(logger, "hello-initiating")
 	err = c.Hello()
 	if err != nil {
-		return c.Error(logger, err)
+		return c.Error(logger, newSMTPError("EHLO", err))
 	}
 	c.PrintLog(logger, "hello-complete")
 
@@ -133,45 +164,77 @@ func (c *Client) Send(msg Message, logger lager.Logger) error {
 		c.PrintLog(logger, "tls-starting")
 		err = c.StartTLS()
 		if err != nil {
-			return c.Error(logger, err)
+			return c.Error(logger, newSMTPError("STARTTLS", err))
 		}
 		c.PrintLog(logger, "tls-connected")
 
 		c.PrintLog(logger, "authentication-starting")
 		err = c.Auth(logger)
 		if err != nil {
-			return c.Error(logger, err)
+			return c.Error(logger, newSMTPError("AUTH", err))
 		}
 		c.PrintLog(logger, "authenticated")
 	}
 
+	return nil
+}
+
+// Transaction sends a single message over an open session. When the server
+// rejects the message, the session is left open so that it can be reset and
+// used for the next one. The session is quit on any other failure.
+func (c *Client) Transaction(msg Message, logger lager.Logger) error {
+	logger = c.createLoggerSession(logger)
+
+	if c.config.TestMode {
+		logger.Info("test-mode")
+		return nil
+	}
+
 	c.PrintLog(logger, "setting-msg-from", lager.Data{"from": msg.From})
-	err = c.client.Mail(msg.From)
+	err := c.client.Mail(msg.From)
 	if err != nil {
-		return c.Error(logger, err)
+		return c.transactionError(logger, "MAIL", err)
 	}
 
 	c.PrintLog(logger, "setting-msg-to", lager.Data{"to": msg.To})
 	err = c.client.Rcpt(msg.To)
 	if err != nil {
-		return c.Error(logger, err)
+		return c.transactionError(logger, "RCPT", err)
 	}
 
 	c.PrintLog(logger, "setting-msg-data", lager.Data{"message-data": base64.StdEncoding.EncodeToString([]byte(msg.Data()))})
 	err = c.Data(msg)
 	if err != nil {
-		return c.Error(logger, err)
+		return c.transactionError(logger, "DATA", err)
 	}
 	c.PrintLog(logger, "msg-data-sent")
 
-	c.PrintLog(logger, "quiting")
-	err = c.Quit()
-	if err != nil {
-		return c.Error(logger, err)
+	return nil
+}
+
+func (c *Client) transactionError(logger lager.Logger, command string, err error) error {
+	var protocolError *textproto.Error
+	if errors.As(err, &protocolError) {
+		err = newSMTPError(command, err)
+		logger.Error("failed", err)
+		return err
 	}
-	c.PrintLog(logger, "disconnected")
 
-	return nil
+	return c.Error(logger, err)
+}
+
+// Reset aborts any transaction in progress with RSET, which also verifies that
+// an idle session is still usable.
+func (c *Client) Reset() error {
+	if c.config.TestMode {
+		return nil
+	}
+
+	if c.client == nil {
+		return errors.New("not connected")
+	}
+
+	return newSMTPError("RSET", c.client.Reset())
 }
 
 func (c *Client) Hello() error {
@@ -249,7 +312,14 @@ func (c *Client) Data(msg Message) error {
 }
 
 func (c *Client) Quit() error {
+	if c.client == nil {
+		return nil
+	}
+
 	err := c.client.Quit()
+	if err != nil {
+		c.client.Close()
+	}
 	c.client = nil
 	if err != nil {
 		return err
@@ -258,19 +328,28 @@ func (c *Client) Quit() error {
 	return nil
 }
 
+// Error quits the session and returns the error that made it fail. Servers
+// often hang up right after rejecting a message, so a failure to quit is only
+// logged, leaving the original error for the caller to act on.
 func (c *Client) Error(logger lager.Logger, err error) error {
-	if c.client != nil {
-		failure := c.Quit()
-		if failure != nil {
-			return failure
-		}
-	}
+	c.quit(logger)
 
 	logger.Error("failed", err)
 
 	return err
 }
 
+func (c *Client) quit(logger lager.Logger) {
+	if c.client == nil {
+		return
+	}
+
+	err := c.Quit()
+	if err != nil {
+		logger.Error("quit-failed", err)
+	}
+}
+
 func (c *Client) PrintLog(logger lager.Logger, action string, data ...lager.Data) {
 	if c.config.LoggingEnabled {
 		logger.Info(action, data...)
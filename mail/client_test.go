@@ -294,6 +294,43 @@ var _ = Describe("Mail", func() {
 			})
 		})
 
+		Context("when the server rejects the recipient", func() {
+			It("returns a typed error carrying the reply and enhanced status codes", func() {
+				mailServer.RejectsRcpt = true
+
+				err := client.Send(mail.Message{
+					From: "me@example.com",
+					To:   "nobody@example.com",
+				}, logger)
+				Expect(err).To(MatchError(mail.SMTPError{
+					Command:      "RCPT",
+					Code:         550,
+					EnhancedCode: "5.1.1",
+					Message:      "5.1.1 no such user",
+				}))
+			})
+
+			Context("and closes the connection right away", func() {
+				It("still returns the typed error rather than the failure to quit", func() {
+					mailServer.RejectsRcpt = true
+					mailServer.HangsUpOnReject = true
+
+					err := client.Send(mail.Message{
+						From: "me@example.com",
+						To:   "nobody@example.com",
+					}, logger)
+					Expect(err).To(MatchError(mail.SMTPError{
+						Command:      "RCPT",
+						Code:         550,
+						EnhancedCode: "5.1.1",
+						Message:      "5.1.1 no such user",
+					}))
+
+					Expect(buffer.String()).To(ContainSubstring("quit-failed"))
+				})
+			})
+		})
+
 		Context("when configured to not use TLS", func() {
 			BeforeEach(func() {
 				mailServer.SupportsTLS = false
@@ -0,0 +1,68 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"regexp"
+	"strings"
+)
+
+var enhancedCodePattern = regexp.MustCompile(`^[245]\.\d{1,3}\.\d{1,3}\b`)
+
+// SMTPError is returned when the server replies to a command with an error
+// code. EnhancedCode holds the RFC 3463 status code when the server sent one.
+type SMTPError struct {
+	Command      string
+	Code         int
+	EnhancedCode string
+	Message      string
+}
+
+func newSMTPError(command string, err error) error {
+	var protocolError *textproto.Error
+	if !errors.As(err, &protocolError) {
+		return err
+	}
+
+	return SMTPError{
+		Command:      command,
+		Code:         protocolError.Code,
+		EnhancedCode: enhancedCodePattern.FindString(protocolError.Msg),
+		Message:      protocolError.Msg,
+	}
+}
+
+func (e SMTPError) Error() string {
+	return fmt.Sprintf("%03d %s", e.Code, e.Message)
+}
+
+// Permanent reports whether the server rejected the message itself with a 5xx
+// reply, in which case sending it again will not succeed.
+func (e SMTPError) Permanent() bool {
+	if e.Code < 500 || e.Code > 599 {
+		return false
+	}
+
+	switch e.Command {
+	case "MAIL", "RCPT", "DATA":
+		return true
+	}
+
+	return false
+}
+
+// RecipientRejected reports whether the server permanently refused the
+// recipient address, as opposed to the sender or the content of the message.
+func (e SMTPError) RecipientRejected() bool {
+	if !e.Permanent() {
+		return false
+	}
+
+	return e.Command == "RCPT" || strings.HasPrefix(e.EnhancedCode, "5.1.")
+}
+
+// Transient reports whether the server asked us to try again later.
+func (e SMTPError) Transient() bool {
+	return e.Code >= 400 && e.Code <= 499
+}
@@ -0,0 +1,59 @@
+package mail_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/mail"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SMTPError", func() {
+	Describe("Error", func() {
+		It("formats the reply like the server sent it", func() {
+			err := mail.SMTPError{Command: "RCPT", Code: 550, Message: "5.1.1 no such user"}
+
+			Expect(err.Error()).To(Equal("550 5.1.1 no such user"))
+		})
+	})
+
+	Describe("Permanent", func() {
+		It("is true for 5xx replies that reject the message", func() {
+			for _, command := range []string{"MAIL", "RCPT", "DATA"} {
+				Expect(mail.SMTPError{Command: command, Code: 550}.Permanent()).To(BeTrue())
+			}
+		})
+
+		It("is false for 5xx replies to the session handshake", func() {
+			for _, command := range []string{"EHLO", "STARTTLS", "AUTH", "RSET"} {
+				Expect(mail.SMTPError{Command: command, Code: 535}.Permanent()).To(BeFalse())
+			}
+		})
+
+		It("is false for 4xx replies", func() {
+			Expect(mail.SMTPError{Command: "RCPT", Code: 451}.Permanent()).To(BeFalse())
+		})
+	})
+
+	Describe("RecipientRejected", func() {
+		It("is true for permanent rejections of the recipient", func() {
+			Expect(mail.SMTPError{Command: "RCPT", Code: 550}.RecipientRejected()).To(BeTrue())
+			Expect(mail.SMTPError{Command: "DATA", Code: 550, EnhancedCode: "5.1.1"}.RecipientRejected()).To(BeTrue())
+		})
+
+		It("is false for rejections of the sender or the content", func() {
+			Expect(mail.SMTPError{Command: "MAIL", Code: 553, EnhancedCode: "5.7.1"}.RecipientRejected()).To(BeFalse())
+			Expect(mail.SMTPError{Command: "DATA", Code: 554, EnhancedCode: "5.6.0"}.RecipientRejected()).To(BeFalse())
+		})
+
+		It("is false for transient failures", func() {
+			Expect(mail.SMTPError{Command: "RCPT", Code: 450, EnhancedCode: "4.1.1"}.RecipientRejected()).To(BeFalse())
+		})
+	})
+
+	Describe("Transient", func() {
+		It("is true only for 4xx replies", func() {
+			Expect(mail.SMTPError{Command: "RCPT", Code: 451}.Transient()).To(BeTrue())
+			Expect(mail.SMTPError{Command: "RCPT", Code: 550}.Transient()).To(BeFalse())
+		})
+	})
+})
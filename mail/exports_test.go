@@ -5,3 +5,7 @@ import "time"
 func (c *Client) ConnectTimeout() time.Duration {
 	return c.config.ConnectTimeout
 }
+
+func (t *WebhookTransport) Timeout() time.Duration {
+	return t.config.Timeout
+}
@@ -86,6 +86,11 @@ type SMTPServer struct {
 	halt            chan bool
 	ConnectionState string
 	FailsHello      bool
+	DropsConnection bool
+	RejectsRcpt     bool
+	HangsUpOnReject bool
+	Connections     int
+	Resets          int
 }
 
 type Delivery struct {
@@ -154,6 +159,7 @@ func (server *SMTPServer) Close() {
 func (server *SMTPServer) Respond(conn net.Conn) {
 	<-time.After(server.ConnectWait)
 	server.ConnectionState = StateConnected
+	server.Connections++
 
 	input := bufio.NewReader(conn)
 	output := bufio.NewWriter(conn)
@@ -161,7 +167,11 @@ func (server *SMTPServer) Respond(conn net.Conn) {
 
 Loop:
 	for {
-		msg, _ := input.ReadString('\n')
+		msg, err := input.ReadString('\n')
+		if err != nil {
+			break Loop
+		}
+
 		switch {
 		case strings.Contains(msg, "EHLO"):
 			server.RespondToEHLO(output)
@@ -173,9 +183,18 @@ Loop:
 			server.RespondToMailFrom(output, msg)
 		case strings.Contains(msg, "RCPT TO"):
 			server.RespondToRcptTo(output, msg)
+			if server.RejectsRcpt && server.HangsUpOnReject {
+				conn.Close()
+				break Loop
+			}
+		case strings.Contains(msg, "RSET"):
+			server.RespondToReset(output)
 		case strings.Contains(msg, "DATA"):
 			server.RespondToData(output)
 			server.RecordData(output, input)
+			if server.DropsConnection {
+				conn.Close()
+			}
 		case strings.Contains(msg, "QUIT"):
 			server.RespondToQuit(output)
 			break Loop
@@ -246,6 +265,12 @@ func (server *SMTPServer) RespondToRcptTo(output *bufio.Writer, msg string) {
 	recipient = strings.Trim(recipient, "<>")
 	server.CurrentDelivery.Recipient = recipient
 
+	if server.RejectsRcpt {
+		output.WriteString("550 5.1.1 no such user\r\n")
+		output.Flush()
+		return
+	}
+
 	output.WriteString("250 OK\r\n")
 	output.Flush()
 }
@@ -271,6 +296,15 @@ func (server *SMTPServer) RecordData(output *bufio.Writer, input *bufio.Reader)
 	output.Flush()
 }
 
+func (server *SMTPServer) RespondToReset(output *bufio.Writer) {
+	server.Resets++
+	server.Deliveries = append(server.Deliveries, server.CurrentDelivery)
+	server.CurrentDelivery = Delivery{UsedTLS: server.CurrentDelivery.UsedTLS}
+
+	output.WriteString("250 OK\r\n")
+	output.Flush()
+}
+
 func (server *SMTPServer) RespondToQuit(output *bufio.Writer) {
 	output.WriteString("221 BYE\r\n")
 	output.Flush()
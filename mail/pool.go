@@ -0,0 +1,158 @@
+package mail
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/pivotal-golang/lager"
+)
+
+type PoolConfig struct {
+	Size                     int
+	MaxMessagesPerConnection int
+	IdleTimeout              time.Duration
+}
+
+// Pool shares a bounded set of authenticated SMTP sessions between delivery
+// workers. Idle sessions are reset with RSET before they are reused, and are
+// replaced when they turn out to be broken.
+type Pool struct {
+	config    PoolConfig
+	newClient func() *Client
+	tokens    chan struct{}
+
+	mutex sync.Mutex
+	idle  []*pooledSession
+}
+
+type pooledSession struct {
+	client   *Client
+	messages int
+	idleAt   time.Time
+}
+
+func NewPool(config PoolConfig, newClient func() *Client) *Pool {
+	if config.Size < 1 {
+		config.Size = 1
+	}
+
+	return &Pool{
+		config:    config,
+		newClient: newClient,
+		tokens:    make(chan struct{}, config.Size),
+	}
+}
+
+func (p *Pool) Connect(logger lager.Logger) error {
+	return nil
+}
+
+func (p *Pool) Send(msg Message, logger lager.Logger) error {
+	p.tokens <- struct{}{}
+	defer func() { <-p.tokens }()
+
+	session, err := p.checkout(logger)
+	if err != nil {
+		return err
+	}
+
+	err = session.client.Transaction(msg, logger)
+	if err != nil {
+		// A session whose message was rejected is still usable, unlike one
+		// that failed on the connection itself.
+		var smtpError SMTPError
+		if errors.As(err, &smtpError) {
+			p.release(session, logger)
+		}
+		return err
+	}
+
+	session.messages++
+	p.checkin(session)
+
+	return nil
+}
+
+func (p *Pool) checkout(logger lager.Logger) (*pooledSession, error) {
+	for {
+		session := p.pop()
+		if session == nil {
+			break
+		}
+
+		err := session.client.Reset()
+		if err == nil {
+			return session, nil
+		}
+
+		logger.Info("smtp-session-broken", lager.Data{"error": err.Error()})
+		session.client.Quit()
+	}
+
+	client := p.newClient()
+	err := client.Open(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pooledSession{client: client}, nil
+}
+
+// release resets a session after a rejected message and checks it back in,
+// quitting it instead if it cannot be reset.
+func (p *Pool) release(session *pooledSession, logger lager.Logger) {
+	err := session.client.Reset()
+	if err != nil {
+		logger.Info("smtp-session-broken", lager.Data{"error": err.Error()})
+		session.client.Quit()
+		return
+	}
+
+	p.checkin(session)
+}
+
+func (p *Pool) checkin(session *pooledSession) {
+	if p.config.MaxMessagesPerConnection > 0 && session.messages >= p.config.MaxMessagesPerConnection {
+		session.client.Quit()
+		return
+	}
+
+	session.idleAt = time.Now()
+
+	p.mutex.Lock()
+	p.idle = append(p.idle, session)
+	p.mutex.Unlock()
+}
+
+// pop returns the most recently used idle session, quitting any sessions that
+// have been idle for longer than the idle timeout along the way.
+func (p *Pool) pop() *pooledSession {
+	var expired []*pooledSession
+	var session *pooledSession
+
+	p.mutex.Lock()
+	if p.config.IdleTimeout > 0 {
+		fresh := p.idle[:0]
+		for _, s := range p.idle {
+			if time.Since(s.idleAt) > p.config.IdleTimeout {
+				expired = append(expired, s)
+			} else {
+				fresh = append(fresh, s)
+			}
+		}
+		p.idle = fresh
+	}
+
+	if len(p.idle) > 0 {
+		session = p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+	}
+	p.mutex.Unlock()
+
+	for _, s := range expired {
+		s.client.Quit()
+	}
+
+	return session
+}
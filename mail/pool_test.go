@@ -0,0 +1,186 @@
+package mail_test
+
+import (
+	"bytes"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/pivotal-golang/lager"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pool", func() {
+	var (
+		mailServer *SMTPServer
+		pool       *mail.Pool
+		poolConfig mail.PoolConfig
+		config     mail.Config
+		logger     lager.Logger
+		msg        mail.Message
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		logger = lager.NewLogger("notifications")
+		logger.RegisterSink(lager.NewWriterSink(bytes.NewBuffer([]byte{}), lager.DEBUG))
+
+		mailServer = NewSMTPServer("user", "pass")
+		mailServer.SupportsTLS = true
+
+		config = mail.Config{
+			User:          "user",
+			Pass:          "pass",
+			SkipVerifySSL: true,
+		}
+		config.Host, config.Port, err = net.SplitHostPort(mailServer.URL.Host)
+		Expect(err).NotTo(HaveOccurred())
+
+		poolConfig = mail.PoolConfig{
+			Size:                     2,
+			MaxMessagesPerConnection: 100,
+			IdleTimeout:              time.Minute,
+		}
+
+		msg = mail.Message{
+			From:    "me@example.com",
+			To:      "you@example.com",
+			Subject: "Urgent! Read now!",
+			Body: []mail.Part{
+				{
+					ContentType: "text/plain",
+					Content:     "This email is the most important thing you will read all day!",
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		pool = mail.NewPool(poolConfig, func() *mail.Client {
+			return mail.NewClient(config)
+		})
+	})
+
+	AfterEach(func() {
+		mailServer.Close()
+	})
+
+	Describe("Send", func() {
+		It("reuses an authenticated session, resetting it between messages", func() {
+			for i := 0; i < 3; i++ {
+				Expect(pool.Send(msg, logger)).To(Succeed())
+			}
+
+			Expect(mailServer.Connections).To(Equal(1))
+			Expect(mailServer.Resets).To(Equal(2))
+			Expect(mailServer.Deliveries).To(HaveLen(2))
+			Expect(mailServer.Deliveries[0].Recipient).To(Equal("you@example.com"))
+			Expect(mailServer.Deliveries[1].UsedTLS).To(BeTrue())
+		})
+
+		Context("when senders outnumber the pool size", func() {
+			BeforeEach(func() {
+				poolConfig.Size = 1
+			})
+
+			It("makes them wait for a session instead of opening more", func() {
+				var wg sync.WaitGroup
+				errs := make(chan error, 5)
+				for i := 0; i < 5; i++ {
+					wg.Add(1)
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+						errs <- pool.Send(msg, logger)
+					}()
+				}
+				wg.Wait()
+				close(errs)
+
+				for err := range errs {
+					Expect(err).NotTo(HaveOccurred())
+				}
+				Expect(mailServer.Connections).To(Equal(1))
+				Expect(mailServer.Resets).To(Equal(4))
+			})
+		})
+
+		Context("when a session reaches the maximum number of messages", func() {
+			BeforeEach(func() {
+				poolConfig.MaxMessagesPerConnection = 2
+			})
+
+			It("quits the session and opens a new one", func() {
+				for i := 0; i < 3; i++ {
+					Expect(pool.Send(msg, logger)).To(Succeed())
+				}
+
+				Expect(mailServer.Connections).To(Equal(2))
+				Expect(mailServer.Resets).To(Equal(1))
+			})
+		})
+
+		Context("when a session has been idle for longer than the idle timeout", func() {
+			BeforeEach(func() {
+				poolConfig.IdleTimeout = 10 * time.Millisecond
+			})
+
+			It("quits the session and opens a new one", func() {
+				Expect(pool.Send(msg, logger)).To(Succeed())
+				time.Sleep(50 * time.Millisecond)
+				Expect(pool.Send(msg, logger)).To(Succeed())
+
+				Expect(mailServer.Connections).To(Equal(2))
+				Expect(mailServer.Resets).To(Equal(0))
+			})
+		})
+
+		Context("when an idle session has been dropped by the server", func() {
+			BeforeEach(func() {
+				mailServer.DropsConnection = true
+			})
+
+			It("replaces the broken session transparently", func() {
+				Expect(pool.Send(msg, logger)).To(Succeed())
+				Expect(pool.Send(msg, logger)).To(Succeed())
+
+				Expect(mailServer.Connections).To(Equal(2))
+			})
+		})
+
+		Context("when the server rejects a message", func() {
+			BeforeEach(func() {
+				mailServer.RejectsRcpt = true
+			})
+
+			It("returns the error and keeps the session for the next message", func() {
+				err := pool.Send(msg, logger)
+				Expect(err).To(BeAssignableToTypeOf(mail.SMTPError{}))
+
+				mailServer.RejectsRcpt = false
+				Expect(pool.Send(msg, logger)).To(Succeed())
+
+				Expect(mailServer.Connections).To(Equal(1))
+			})
+		})
+
+		Context("when a session cannot be opened", func() {
+			It("returns the error", func() {
+				config.Port = "1"
+
+				Expect(pool.Send(msg, logger)).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Connect", func() {
+		It("does not open a session until a message is sent", func() {
+			Expect(pool.Connect(logger)).To(Succeed())
+			Expect(mailServer.Connections).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,71 @@
+package mail
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/pivotal-golang/lager"
+)
+
+var spoolSequence uint64
+
+// SpoolTransport writes each message into a maildir, so that deliveries can be
+// inspected or forwarded without a reachable mail server.
+type SpoolTransport struct {
+	directory string
+	hostname  string
+}
+
+func NewSpoolTransport(directory string) *SpoolTransport {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+
+	return &SpoolTransport{
+		directory: directory,
+		hostname:  hostname,
+	}
+}
+
+func (t *SpoolTransport) Unthrottled() {}
+
+func (t *SpoolTransport) Connect(logger lager.Logger) error {
+	for _, subdirectory := range []string{"tmp", "new", "cur"} {
+		err := os.MkdirAll(filepath.Join(t.directory, subdirectory), 0755)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *SpoolTransport) Send(msg Message, logger lager.Logger) error {
+	err := t.Connect(logger)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%d.P%dQ%d.%s", time.Now().Unix(), os.Getpid(), atomic.AddUint64(&spoolSequence, 1), t.hostname)
+	tmpPath := filepath.Join(t.directory, "tmp", name)
+
+	err = ioutil.WriteFile(tmpPath, []byte(msg.Data()), 0644)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmpPath, filepath.Join(t.directory, "new", name))
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	logger.Session("spool").Info("delivered", lager.Data{"file": name})
+
+	return nil
+}
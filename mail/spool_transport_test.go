@@ -0,0 +1,94 @@
+package mail_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/pivotal-golang/lager"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpoolTransport", func() {
+	var (
+		directory string
+		transport *mail.SpoolTransport
+		logger    lager.Logger
+		message   mail.Message
+	)
+
+	BeforeEach(func() {
+		var err error
+		directory, err = ioutil.TempDir("", "spool")
+		Expect(err).NotTo(HaveOccurred())
+
+		logger = lager.NewLogger("notifications")
+		logger.RegisterSink(lager.NewWriterSink(bytes.NewBuffer([]byte{}), lager.DEBUG))
+
+		transport = mail.NewSpoolTransport(filepath.Join(directory, "maildir"))
+
+		message = mail.Message{
+			From:    "me@example.com",
+			To:      "you@example.com",
+			Subject: "Urgent! Read now!",
+			Body: []mail.Part{
+				{
+					ContentType: "text/plain",
+					Content:     "This email is the most important thing you will read all day!",
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(directory)).To(Succeed())
+	})
+
+	Describe("Connect", func() {
+		It("creates the maildir layout", func() {
+			Expect(transport.Connect(logger)).To(Succeed())
+
+			for _, subdirectory := range []string{"tmp", "new", "cur"} {
+				info, err := os.Stat(filepath.Join(directory, "maildir", subdirectory))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.IsDir()).To(BeTrue())
+			}
+		})
+	})
+
+	Describe("Send", func() {
+		It("delivers each message into its own file in the new directory", func() {
+			Expect(transport.Send(message, logger)).To(Succeed())
+			Expect(transport.Send(message, logger)).To(Succeed())
+
+			files, err := ioutil.ReadDir(filepath.Join(directory, "maildir", "new"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(HaveLen(2))
+
+			contents, err := ioutil.ReadFile(filepath.Join(directory, "maildir", "new", files[0].Name()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(ContainSubstring("To: you@example.com"))
+			Expect(string(contents)).To(ContainSubstring("Subject: Urgent! Read now!"))
+			Expect(string(contents)).To(ContainSubstring("This email is the most important thing you will read all day!"))
+
+			tmpFiles, err := ioutil.ReadDir(filepath.Join(directory, "maildir", "tmp"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tmpFiles).To(BeEmpty())
+		})
+
+		Context("when the spool directory cannot be created", func() {
+			It("returns an error", func() {
+				blocker := filepath.Join(directory, "blocker")
+				Expect(ioutil.WriteFile(blocker, []byte{}, 0644)).To(Succeed())
+
+				transport = mail.NewSpoolTransport(blocker)
+
+				Expect(transport.Send(message, logger)).NotTo(Succeed())
+			})
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package mail
+
+import "github.com/pivotal-golang/lager"
+
+const (
+	TransportSMTP    = "smtp"
+	TransportWebhook = "webhook"
+	TransportSpool   = "spool"
+)
+
+var TransportNames = []string{TransportSMTP, TransportWebhook, TransportSpool}
+
+type Transport interface {
+	Connect(lager.Logger) error
+	Send(Message, lager.Logger) error
+}
+
+// Unthrottled is implemented by the transports that do not hand messages to
+// a mail server, and so are not held to the send rate a mail server accepts.
+type Unthrottled interface {
+	Unthrottled()
+}
+
+type TransportRoute struct {
+	ClientID  string
+	KindID    string
+	Transport Transport
+}
+
+type TransportSelector struct {
+	defaultTransport Transport
+	routes           []TransportRoute
+}
+
+func NewTransportSelector(defaultTransport Transport, routes []TransportRoute) TransportSelector {
+	return TransportSelector{
+		defaultTransport: defaultTransport,
+		routes:           routes,
+	}
+}
+
+// Select prefers a route matching both the client and the kind, then a route
+// matching only the client, before falling back to the default transport.
+func (s TransportSelector) Select(clientID, kindID string) Transport {
+	var clientTransport Transport
+
+	for _, route := range s.routes {
+		if route.ClientID != clientID {
+			continue
+		}
+
+		switch route.KindID {
+		case kindID:
+			return route.Transport
+		case "":
+			if clientTransport == nil {
+				clientTransport = route.Transport
+			}
+		}
+	}
+
+	if clientTransport != nil {
+		return clientTransport
+	}
+
+	return s.defaultTransport
+}
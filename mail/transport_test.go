@@ -0,0 +1,68 @@
+package mail_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TransportSelector", func() {
+	var (
+		defaultTransport *mocks.MailClient
+		clientTransport  *mocks.MailClient
+		kindTransport    *mocks.MailClient
+		selector         mail.TransportSelector
+	)
+
+	BeforeEach(func() {
+		defaultTransport = mocks.NewMailClient()
+		clientTransport = mocks.NewMailClient()
+		kindTransport = mocks.NewMailClient()
+
+		selector = mail.NewTransportSelector(defaultTransport, []mail.TransportRoute{
+			{ClientID: "some-client", Transport: clientTransport},
+			{ClientID: "some-client", KindID: "some-kind", Transport: kindTransport},
+		})
+	})
+
+	Describe("Select", func() {
+		It("prefers a route matching both the client and the kind", func() {
+			Expect(selector.Select("some-client", "some-kind")).To(BeIdenticalTo(kindTransport))
+		})
+
+		It("falls back to a route matching only the client", func() {
+			Expect(selector.Select("some-client", "other-kind")).To(BeIdenticalTo(clientTransport))
+		})
+
+		It("falls back to the default transport when no route matches", func() {
+			Expect(selector.Select("other-client", "some-kind")).To(BeIdenticalTo(defaultTransport))
+		})
+
+		It("returns the default transport when there are no routes", func() {
+			selector = mail.NewTransportSelector(defaultTransport, nil)
+
+			Expect(selector.Select("some-client", "some-kind")).To(BeIdenticalTo(defaultTransport))
+		})
+	})
+})
+
+var _ = Describe("Unthrottled", func() {
+	isUnthrottled := func(transport mail.Transport) bool {
+		_, ok := transport.(mail.Unthrottled)
+		return ok
+	}
+
+	It("is implemented by the transports that do not use a mail server", func() {
+		Expect(isUnthrottled(mail.NewWebhookTransport(mail.WebhookConfig{}))).To(BeTrue())
+		Expect(isUnthrottled(mail.NewSpoolTransport("/tmp/spool"))).To(BeTrue())
+	})
+
+	It("is not implemented by the SMTP transports", func() {
+		newClient := func() *mail.Client { return mail.NewClient(mail.Config{}) }
+
+		Expect(isUnthrottled(newClient())).To(BeFalse())
+		Expect(isUnthrottled(mail.NewPool(mail.PoolConfig{}, newClient))).To(BeFalse())
+	})
+})
@@ -0,0 +1,106 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pivotal-golang/lager"
+)
+
+type WebhookConfig struct {
+	URL           string
+	SkipVerifySSL bool
+	Timeout       time.Duration
+}
+
+type WebhookTransport struct {
+	config WebhookConfig
+	client *http.Client
+}
+
+type webhookPart struct {
+	ContentType string `json:"content_type"`
+	Content     string `json:"content"`
+}
+
+type webhookPayload struct {
+	From    string        `json:"from"`
+	ReplyTo string        `json:"reply_to,omitempty"`
+	To      string        `json:"to"`
+	Subject string        `json:"subject"`
+	Headers []string      `json:"headers"`
+	Parts   []webhookPart `json:"parts"`
+	Raw     string        `json:"raw"`
+}
+
+func NewWebhookTransport(config WebhookConfig) *WebhookTransport {
+	if config.Timeout == 0 {
+		config.Timeout = 15 * time.Second
+	}
+
+	return &WebhookTransport{
+		config: config,
+		client: &http.Client{
+			Timeout: config.Timeout,
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: config.SkipVerifySSL,
+				},
+			},
+		},
+	}
+}
+
+func (t *WebhookTransport) Unthrottled() {}
+
+func (t *WebhookTransport) Connect(logger lager.Logger) error {
+	return nil
+}
+
+func (t *WebhookTransport) Send(msg Message, logger lager.Logger) error {
+	logger = logger.Session("webhook", lager.Data{"url": t.config.URL})
+
+	payload := webhookPayload{
+		From:    msg.From,
+		ReplyTo: msg.ReplyTo,
+		To:      msg.To,
+		Subject: msg.Subject,
+		Headers: msg.Headers,
+		Parts:   []webhookPart{},
+		Raw:     msg.Data(),
+	}
+
+	for _, part := range msg.Body {
+		payload.Parts = append(payload.Parts, webhookPart{
+			ContentType: part.ContentType,
+			Content:     part.Content,
+		})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	response, err := t.client.Post(t.config.URL, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with unexpected status %d", response.StatusCode)
+	}
+
+	logger.Info("delivered")
+
+	return nil
+}
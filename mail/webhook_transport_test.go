@@ -0,0 +1,126 @@
+package mail_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/pivotal-golang/lager"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebhookTransport", func() {
+	var (
+		server      *httptest.Server
+		transport   *mail.WebhookTransport
+		logger      lager.Logger
+		message     mail.Message
+		requests    []*http.Request
+		bodies      [][]byte
+		replyStatus int
+	)
+
+	BeforeEach(func() {
+		requests = []*http.Request{}
+		bodies = [][]byte{}
+		replyStatus = http.StatusAccepted
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			body, err := ioutil.ReadAll(req.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			requests = append(requests, req)
+			bodies = append(bodies, body)
+			w.WriteHeader(replyStatus)
+		}))
+
+		logger = lager.NewLogger("notifications")
+		logger.RegisterSink(lager.NewWriterSink(bytes.NewBuffer([]byte{}), lager.DEBUG))
+
+		transport = mail.NewWebhookTransport(mail.WebhookConfig{
+			URL: server.URL + "/deliveries",
+		})
+
+		message = mail.Message{
+			From:    "me@example.com",
+			ReplyTo: "reply@example.com",
+			To:      "you@example.com",
+			Subject: "Urgent! Read now!",
+			Body: []mail.Part{
+				{
+					ContentType: "text/plain",
+					Content:     "This email is the most important thing you will read all day!",
+				},
+			},
+			Headers: []string{"X-CF-Client-ID: some-client"},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewWebhookTransport", func() {
+		It("defaults the timeout to 15 seconds", func() {
+			Expect(transport.Timeout()).To(Equal(15 * time.Second))
+		})
+	})
+
+	Describe("Connect", func() {
+		It("does not make any requests", func() {
+			Expect(transport.Connect(logger)).To(Succeed())
+			Expect(requests).To(BeEmpty())
+		})
+	})
+
+	Describe("Send", func() {
+		It("posts the rendered message as JSON to the configured url", func() {
+			Expect(transport.Send(message, logger)).To(Succeed())
+
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Method).To(Equal("POST"))
+			Expect(requests[0].URL.Path).To(Equal("/deliveries"))
+			Expect(requests[0].Header.Get("Content-Type")).To(Equal("application/json"))
+
+			var payload map[string]interface{}
+			Expect(json.Unmarshal(bodies[0], &payload)).To(Succeed())
+
+			Expect(payload["from"]).To(Equal("me@example.com"))
+			Expect(payload["reply_to"]).To(Equal("reply@example.com"))
+			Expect(payload["to"]).To(Equal("you@example.com"))
+			Expect(payload["subject"]).To(Equal("Urgent! Read now!"))
+			Expect(payload["headers"]).To(Equal([]interface{}{"X-CF-Client-ID: some-client"}))
+			Expect(payload["parts"]).To(Equal([]interface{}{
+				map[string]interface{}{
+					"content_type": "text/plain",
+					"content":      "This email is the most important thing you will read all day!",
+				},
+			}))
+			Expect(payload["raw"]).To(ContainSubstring("Subject: Urgent! Read now!"))
+			Expect(payload["raw"]).To(ContainSubstring("X-CF-Client-ID: some-client"))
+		})
+
+		Context("when the webhook responds with a non-2xx status", func() {
+			It("returns an error", func() {
+				replyStatus = http.StatusBadGateway
+
+				err := transport.Send(message, logger)
+				Expect(err).To(MatchError("webhook responded with unexpected status 502"))
+			})
+		})
+
+		Context("when the webhook cannot be reached", func() {
+			It("returns an error", func() {
+				server.Close()
+
+				Expect(transport.Send(message, logger)).NotTo(Succeed())
+			})
+		})
+	})
+})
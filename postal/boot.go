@@ -3,11 +3,14 @@ package postal
 import (
 	"crypto/rand"
 	"database/sql"
+	"encoding/json"
+	"io/ioutil"
 	"log"
 	"os"
 	"path"
 	"time"
 
+	"github.com/cloudfoundry-incubator/notifications/cf"
 	"github.com/cloudfoundry-incubator/notifications/db"
 	"github.com/cloudfoundry-incubator/notifications/gobble"
 	"github.com/cloudfoundry-incubator/notifications/mail"
@@ -16,6 +19,7 @@ import (
 	"github.com/cloudfoundry-incubator/notifications/uaa"
 	"github.com/cloudfoundry-incubator/notifications/util"
 	v1models "github.com/cloudfoundry-incubator/notifications/v1/models"
+	v1services "github.com/cloudfoundry-incubator/notifications/v1/services"
 	"github.com/pivotal-golang/conceal"
 	"github.com/pivotal-golang/lager"
 )
@@ -33,7 +37,13 @@ type Config struct {
 	RootPath             string
 	Sender               string
 	Domain               string
+	PublicURL            string
+	StrictTemplates      bool
+	DefaultLocale        string
 	QueueWaitMaxDuration int
+	QueueBatchSize       int
+	HighPriorityWorkers  int
+	Throttle             ThrottleConfig
 	CCHost               string
 }
 
@@ -49,7 +59,41 @@ func database(db *sql.DB, dbLoggingEnabled bool, rootPath string) db.DatabaseInt
 	return database
 }
 
-func Boot(mailClient func() *mail.Client, db *sql.DB, config Config) {
+func digestTemplates(rootPath string) common.Templates {
+	bytes, err := ioutil.ReadFile(path.Join(rootPath, "templates", "digest.json"))
+	if err != nil {
+		panic(err)
+	}
+
+	var templates common.Templates
+	err = json.Unmarshal(bytes, &templates)
+	if err != nil {
+		panic(err)
+	}
+
+	return templates
+}
+
+// Deliveries are the delivery workers and the digest scheduler started by
+// Boot, along with the queue they use.
+type Deliveries struct {
+	workers         []Worker
+	digestScheduler DigestScheduler
+	queue           *gobble.Queue
+}
+
+// Stop halts the workers and the digest scheduler, giving jobs that are being
+// delivered up to the timeout to finish, and then closes the queue so that
+// jobs reserved on this instance but not yet delivered are released. It
+// reports whether every worker stopped in time.
+func (d Deliveries) Stop(timeout time.Duration) bool {
+	halted := HaltWorkers(append([]Worker{d.digestScheduler}, d.workers...), timeout)
+	d.queue.Close()
+
+	return halted
+}
+
+func Boot(transports mail.TransportSelector, db *sql.DB, config Config) Deliveries {
 	uaaClient := uaa.NewZonedUAAClient(config.UAAClientID, config.UAAClientSecret, config.VerifySSL, config.UAATokenValidator)
 
 	logger := lager.NewLogger("notifications")
@@ -62,6 +106,7 @@ func Boot(mailClient func() *mail.Client, db *sql.DB, config Config) {
 	gobbleDatabase := gobble.NewDatabase(db)
 	gobbleQueue := gobble.NewQueue(gobbleDatabase, clock, gobble.Config{
 		WaitMaxDuration: time.Duration(config.QueueWaitMaxDuration) * time.Millisecond,
+		BatchSize:       config.QueueBatchSize,
 	})
 
 	cloak, err := conceal.NewCloak(config.EncryptionKey)
@@ -75,18 +120,53 @@ func Boot(mailClient func() *mail.Client, db *sql.DB, config Config) {
 	receiptsRepo := v1models.NewReceiptsRepo()
 	unsubscribesRepo := v1models.NewUnsubscribesRepo()
 	globalUnsubscribesRepo := v1models.NewGlobalUnsubscribesRepo()
+	suppressionsRepo := v1models.NewSuppressionsRepo()
 	messagesRepo := v1models.NewMessagesRepo(guidGenerator.Generate)
 	clientsRepo := v1models.NewClientsRepo()
 	kindsRepo := v1models.NewKindsRepo()
 	templatesRepo := v1models.NewTemplatesRepo()
-	v1TemplateLoader := v1.NewTemplatesLoader(database, clientsRepo, kindsRepo, templatesRepo)
+	v1TemplateLoader := v1.NewTemplatesLoader(database, clientsRepo, kindsRepo, templatesRepo, config.DefaultLocale)
 	deliveryFailureHandler := common.NewDeliveryFailureHandler()
-	messageStatusUpdater := v1.NewMessageStatusUpdater(messagesRepo)
+	messageEventsRepo := v1models.NewMessageEventsRepo()
+	messageStatusUpdater := v1.NewMessageStatusUpdater(messagesRepo, messageEventsRepo)
 	userLoader := common.NewUserLoader(uaaClient)
 	tokenLoader := uaa.NewTokenLoader(uaaClient)
-	packager := common.NewPackager(v1TemplateLoader, cloak)
+	packager := common.NewPackager(v1TemplateLoader, cloak, config.PublicURL, config.StrictTemplates)
+	throttle := NewThrottle(config.Throttle, database, v1models.NewSendRatesRepo(), clock, time.Sleep)
+	deliveryFrequenciesRepo := v1models.NewDeliveryFrequenciesRepo()
+	digestItemsRepo := v1models.NewDigestItemsRepo()
+	quietHoursRepo := v1models.NewQuietHoursRepo()
+	userLocalesRepo := v1models.NewUserLocalesRepo()
+	preferenceJobsRepo := v1models.NewPreferenceJobsRepo(guidGenerator.Generate)
+	preferenceChangesRepo := v1models.NewPreferenceChangesRepo()
+	cloudController := cf.NewCloudController(config.CCHost, !config.VerifySSL)
+	findsUserIDs := v1services.NewFindsUserIDs(cloudController, uaaClient)
+	preferenceUpdater := v1services.NewPreferenceUpdater(globalUnsubscribesRepo, unsubscribesRepo, kindsRepo, deliveryFrequenciesRepo, quietHoursRepo, userLocalesRepo, preferenceChangesRepo)
+
+	digestJobProcessor := v1.NewDigestJobProcessor(v1.DigestJobProcessorConfig{
+		Sender:    config.Sender,
+		Domain:    config.Domain,
+		Templates: digestTemplates(config.RootPath),
+
+		Transports:             transports,
+		Throttle:               throttle,
+		Database:               database,
+		MessageStatusUpdater:   messageStatusUpdater,
+		DeliveryFailureHandler: deliveryFailureHandler,
+	})
+
+	preferencesJobProcessor := v1.NewPreferencesJobProcessor(v1.PreferencesJobProcessorConfig{
+		UAAHost: config.UAAHost,
+
+		Database:               database,
+		TokenLoader:            tokenLoader,
+		FindsUserIDs:           findsUserIDs,
+		PreferenceUpdater:      preferenceUpdater,
+		PreferenceJobsRepo:     preferenceJobsRepo,
+		DeliveryFailureHandler: deliveryFailureHandler,
+	})
 
-	WorkerGenerator{
+	workers := WorkerGenerator{
 		InstanceIndex: config.InstanceIndex,
 		Count:         config.WorkerCount,
 	}.Work(func(index int) Worker {
@@ -98,7 +178,8 @@ func Boot(mailClient func() *mail.Client, db *sql.DB, config Config) {
 			Domain:  config.Domain,
 
 			Packager:    packager,
-			MailClient:  mailClient(),
+			Transports:  transports,
+			Throttle:    throttle,
 			Database:    database,
 			TokenLoader: tokenLoader,
 			UserLoader:  userLoader,
@@ -107,8 +188,15 @@ func Boot(mailClient func() *mail.Client, db *sql.DB, config Config) {
 			ReceiptsRepo:           receiptsRepo,
 			UnsubscribesRepo:       unsubscribesRepo,
 			GlobalUnsubscribesRepo: globalUnsubscribesRepo,
+			SuppressionsRepo:       suppressionsRepo,
 			MessageStatusUpdater:   messageStatusUpdater,
 			DeliveryFailureHandler: deliveryFailureHandler,
+
+			DeliveryFrequenciesRepo: deliveryFrequenciesRepo,
+			DigestItemsRepo:         digestItemsRepo,
+			QuietHoursRepo:          quietHoursRepo,
+			UserLocalesRepo:         userLocalesRepo,
+			Clock:                   clock,
 		})
 
 		worker := NewDeliveryWorker(v1DeliveryJobProcessor, DeliveryWorkerConfig{
@@ -116,7 +204,11 @@ func Boot(mailClient func() *mail.Client, db *sql.DB, config Config) {
 			UAAHost: config.UAAHost,
 			DBTrace: config.DBLoggingEnabled,
 
-			DeliveryFailureHandler: deliveryFailureHandler,
+			HighPriorityOnly: (index-1)%config.WorkerCount < config.HighPriorityWorkers,
+
+			DeliveryFailureHandler:  deliveryFailureHandler,
+			DigestJobProcessor:      digestJobProcessor,
+			PreferencesJobProcessor: preferencesJobProcessor,
 
 			Logger: logger.Session("worker", lager.Data{"worker_id": index}),
 			Queue:  gobbleQueue,
@@ -124,4 +216,13 @@ func Boot(mailClient func() *mail.Client, db *sql.DB, config Config) {
 
 		return &worker
 	})
+
+	digestScheduler := NewDigestScheduler(database, digestItemsRepo, gobbleQueue, gobble.Initializer{}, clock, 5*time.Minute, logger.Session("digest-scheduler"))
+	digestScheduler.Work()
+
+	return Deliveries{
+		workers:         workers,
+		digestScheduler: digestScheduler,
+		queue:           gobbleQueue,
+	}
 }
@@ -0,0 +1,38 @@
+package common
+
+import (
+	"errors"
+	"net/textproto"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+)
+
+type DeliveryAttempt struct {
+	Recipient  string
+	RetryCount int
+	SMTPCode   int
+	SMTPText   string
+}
+
+func (a DeliveryAttempt) WithSMTPError(err error) DeliveryAttempt {
+	if err == nil {
+		return a
+	}
+
+	var smtpError mail.SMTPError
+	if errors.As(err, &smtpError) {
+		a.SMTPCode = smtpError.Code
+		a.SMTPText = smtpError.Message
+		return a
+	}
+
+	var protocolError *textproto.Error
+	if errors.As(err, &protocolError) {
+		a.SMTPCode = protocolError.Code
+		a.SMTPText = protocolError.Msg
+		return a
+	}
+
+	a.SMTPText = err.Error()
+	return a
+}
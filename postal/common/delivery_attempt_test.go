@@ -0,0 +1,57 @@
+package common_test
+
+import (
+	"errors"
+	"net/textproto"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeliveryAttempt", func() {
+	var attempt common.DeliveryAttempt
+
+	BeforeEach(func() {
+		attempt = common.DeliveryAttempt{
+			Recipient:  "user@example.com",
+			RetryCount: 2,
+		}
+	})
+
+	Describe("WithSMTPError", func() {
+		It("records the reply code and text of SMTP protocol errors", func() {
+			Expect(attempt.WithSMTPError(&textproto.Error{Code: 550, Msg: "mailbox unavailable"})).To(Equal(common.DeliveryAttempt{
+				Recipient:  "user@example.com",
+				RetryCount: 2,
+				SMTPCode:   550,
+				SMTPText:   "mailbox unavailable",
+			}))
+		})
+
+		It("records the reply code and text of typed SMTP errors", func() {
+			err := mail.SMTPError{Command: "RCPT", Code: 550, EnhancedCode: "5.1.1", Message: "5.1.1 no such user"}
+
+			Expect(attempt.WithSMTPError(err)).To(Equal(common.DeliveryAttempt{
+				Recipient:  "user@example.com",
+				RetryCount: 2,
+				SMTPCode:   550,
+				SMTPText:   "5.1.1 no such user",
+			}))
+		})
+
+		It("records the text of other errors", func() {
+			Expect(attempt.WithSMTPError(errors.New("server timeout"))).To(Equal(common.DeliveryAttempt{
+				Recipient:  "user@example.com",
+				RetryCount: 2,
+				SMTPText:   "server timeout",
+			}))
+		})
+
+		It("leaves the attempt untouched when there is no error", func() {
+			Expect(attempt.WithSMTPError(nil)).To(Equal(attempt))
+		})
+	})
+})
@@ -10,6 +10,7 @@ import (
 
 type Retryable interface {
 	Retry(duration time.Duration)
+	Bury(reason string)
 	State() (retryCount int, activeAt time.Time)
 }
 
@@ -19,9 +20,21 @@ func NewDeliveryFailureHandler() DeliveryFailureHandler {
 	return DeliveryFailureHandler{}
 }
 
-func (h DeliveryFailureHandler) Handle(job Retryable, logger lager.Logger) {
+func (h DeliveryFailureHandler) Handle(job Retryable, cause error, logger lager.Logger) {
 	retryCount, _ := job.State()
 	if retryCount > 9 {
+		reason := "retries exhausted"
+		if cause != nil {
+			reason = cause.Error()
+		}
+
+		job.Bury(reason)
+		logger.Info("delivery-failed-giving-up", lager.Data{
+			"retry_count": retryCount,
+			"last_error":  reason,
+		})
+
+		metrics.GetOrRegisterCounter("notifications.worker.dead", nil).Inc(1)
 		return
 	}
 
@@ -2,6 +2,7 @@ package common_test
 
 import (
 	"bytes"
+	"errors"
 	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/postal/common"
@@ -46,7 +47,7 @@ var _ = Describe("DeliveryFailureHandler", func() {
 		for retryCount, duration := range backoffDurations {
 			job.StateCall.Returns.Count = retryCount
 
-			handler.Handle(job, logger)
+			handler.Handle(job, errors.New("server timeout"), logger)
 
 			Expect(job.RetryCall.Receives.Duration).To(Equal(duration))
 		}
@@ -55,17 +56,48 @@ var _ = Describe("DeliveryFailureHandler", func() {
 	It("gives up after 9 retries", func() {
 		job.StateCall.Returns.Count = 10
 
-		handler.Handle(job, logger)
+		handler.Handle(job, errors.New("server timeout"), logger)
 
 		Expect(job.RetryCall.WasCalled).To(BeFalse())
 	})
 
+	It("buries the job with the last error once it gives up", func() {
+		job.StateCall.Returns.Count = 10
+
+		handler.Handle(job, errors.New("server timeout"), logger)
+
+		Expect(job.BuryCall.WasCalled).To(BeTrue())
+		Expect(job.BuryCall.Receives.Reason).To(Equal("server timeout"))
+
+		lines, err := parseLogLines(buffer.Bytes())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lines).To(HaveLen(1))
+		Expect(lines[0].Message).To(Equal("notifications.delivery-failed-giving-up"))
+		Expect(lines[0].Data).To(HaveKeyWithValue("last_error", "server timeout"))
+	})
+
+	It("buries the job even when the failure has no error", func() {
+		job.StateCall.Returns.Count = 10
+
+		handler.Handle(job, nil, logger)
+
+		Expect(job.BuryCall.Receives.Reason).To(Equal("retries exhausted"))
+	})
+
+	It("does not bury jobs that will be retried", func() {
+		job.StateCall.Returns.Count = 9
+
+		handler.Handle(job, errors.New("server timeout"), logger)
+
+		Expect(job.BuryCall.WasCalled).To(BeFalse())
+	})
+
 	It("logs the retry attempt", func() {
 		expectedActiveAt := time.Now().Truncate(time.Second)
 		job.StateCall.Returns.Time = expectedActiveAt
 		job.StateCall.Returns.Count = 4
 
-		handler.Handle(job, logger)
+		handler.Handle(job, errors.New("server timeout"), logger)
 
 		lines, err := parseLogLines(buffer.Bytes())
 		Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,162 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+)
+
+const DigestJobType = "digest"
+
+// Digest is the job that sends a user the notifications that waited for
+// their daily or weekly digest, in one email.
+type Digest struct {
+	JobType   string
+	UserGUID  string
+	Email     string
+	Frequency string
+	Items     []DigestItem
+}
+
+// DigestItem is a notification rendered to be included in a digest.
+type DigestItem struct {
+	MessageID         string
+	ClientID          string
+	KindID            string
+	SourceDescription string
+	KindDescription   string
+	Subject           string
+	Text              string
+	HTML              string
+	CreatedAt         time.Time
+}
+
+type DigestContext struct {
+	From      string
+	To        string
+	Domain    string
+	Frequency string
+	Items     []DigestItem
+}
+
+// CompileDigestItem renders the text and HTML of a notification the way they
+// would appear in a message of its own. The HTML is not wrapped into a
+// document, since the digest it ends up in provides one.
+func (packager Packager) CompileDigestItem(context MessageContext) (DigestItem, error) {
+	var err error
+
+	item := DigestItem{
+		MessageID:         context.MessageID,
+		ClientID:          context.ClientID,
+		SourceDescription: context.SourceDescription,
+		KindDescription:   context.KindDescription,
+		Subject:           context.Subject,
+	}
+
+	context.Endorsement, err = packager.compileTemplate(context, "endorsement", context.Endorsement, false)
+	if err != nil {
+		return DigestItem{}, err
+	}
+
+	if context.Text != "" {
+		item.Text, err = packager.compileTemplate(context, "text", context.TextTemplate, false)
+		if err != nil {
+			return DigestItem{}, err
+		}
+	}
+
+	if context.HTML != "" {
+		item.HTML, err = packager.compileTemplate(context, "html", context.HTMLTemplate, true)
+		if err != nil {
+			return DigestItem{}, err
+		}
+	}
+
+	return item, nil
+}
+
+// PackDigest renders the digest into a message through the digest templates.
+// The HTML of the items is already rendered and is used as it is, while
+// everything else is escaped for the HTML part.
+func PackDigest(digest Digest, sender, domain string, templates Templates) (mail.Message, error) {
+	context := DigestContext{
+		From:      sender,
+		To:        digest.Email,
+		Domain:    domain,
+		Frequency: digest.Frequency,
+		Items:     digest.Items,
+	}
+
+	subject, err := compileDigestTemplate(context, templates.Subject)
+	if err != nil {
+		return mail.Message{}, err
+	}
+
+	text, err := compileDigestTemplate(context, templates.Text)
+	if err != nil {
+		return mail.Message{}, err
+	}
+
+	htmlPart, err := compileDigestTemplate(context.escape(), templates.HTML)
+	if err != nil {
+		return mail.Message{}, err
+	}
+
+	return mail.Message{
+		From:    sender,
+		To:      digest.Email,
+		Subject: subject,
+		Body: []mail.Part{
+			{
+				ContentType: "text/plain",
+				Content:     text,
+			},
+			{
+				ContentType: "text/html",
+				Content:     htmlPart,
+			},
+		},
+		Headers: []string{
+			fmt.Sprintf("X-CF-Notification-Digest: %s", digest.Frequency),
+			fmt.Sprintf("X-CF-Notification-Timestamp: %s", time.Now().Format(time.RFC3339Nano)),
+		},
+	}, nil
+}
+
+func (context DigestContext) escape() DigestContext {
+	context.From = html.EscapeString(context.From)
+	context.To = html.EscapeString(context.To)
+
+	items := make([]DigestItem, len(context.Items))
+	for index, item := range context.Items {
+		item.SourceDescription = html.EscapeString(item.SourceDescription)
+		item.KindDescription = html.EscapeString(item.KindDescription)
+		item.Subject = html.EscapeString(item.Subject)
+		item.Text = html.EscapeString(item.Text)
+		items[index] = item
+	}
+	context.Items = items
+
+	return context
+}
+
+func compileDigestTemplate(context DigestContext, theTemplate string) (string, error) {
+	buffer := bytes.NewBuffer([]byte{})
+
+	source, err := template.New("compileDigestTemplate").Parse(theTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	err = source.Execute(buffer, context)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
@@ -0,0 +1,88 @@
+package common_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PackDigest", func() {
+	var (
+		digest    common.Digest
+		templates common.Templates
+	)
+
+	BeforeEach(func() {
+		digest = common.Digest{
+			UserGUID:  "user-123",
+			Email:     "user-123@example.com",
+			Frequency: "weekly",
+			Items: []common.DigestItem{
+				{
+					SourceDescription: "Cloud <Controller>",
+					Subject:           "First",
+					Text:              "first <text>",
+					HTML:              "<p>first html</p>",
+				},
+				{
+					SourceDescription: "Login",
+					Subject:           "Second",
+					Text:              "second text",
+				},
+			},
+		}
+
+		templates = common.Templates{
+			Subject: "Your {{.Frequency}} digest of {{len .Items}} notifications",
+			Text:    "{{range .Items}}{{.SourceDescription}}: {{.Text}}\n{{end}}",
+			HTML:    "{{range .Items}}<h3>{{.SourceDescription}}</h3>{{if .HTML}}{{.HTML}}{{else}}<pre>{{.Text}}</pre>{{end}}{{end}}",
+		}
+	})
+
+	It("renders the digest into a message", func() {
+		message, err := common.PackDigest(digest, "from@example.com", "example.com", templates)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(message.From).To(Equal("from@example.com"))
+		Expect(message.To).To(Equal("user-123@example.com"))
+		Expect(message.Subject).To(Equal("Your weekly digest of 2 notifications"))
+		Expect(message.Headers).To(ContainElement("X-CF-Notification-Digest: weekly"))
+		Expect(message.Body).To(Equal([]mail.Part{
+			{
+				ContentType: "text/plain",
+				Content:     "Cloud <Controller>: first <text>\nLogin: second text",
+			},
+			{
+				ContentType: "text/html",
+				Content:     "<h3>Cloud &lt;Controller&gt;</h3><p>first html</p><h3>Login</h3><pre>second text</pre>",
+			},
+		}))
+	})
+
+	It("does not modify the items of the digest when escaping them", func() {
+		_, err := common.PackDigest(digest, "from@example.com", "example.com", templates)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(digest.Items[0].SourceDescription).To(Equal("Cloud <Controller>"))
+	})
+
+	Context("when a template cannot be parsed", func() {
+		It("returns the error", func() {
+			templates.Text = "{{.Missing"
+
+			_, err := common.PackDigest(digest, "from@example.com", "example.com", templates)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when a template cannot be executed", func() {
+		It("returns the error", func() {
+			templates.HTML = "{{.Missing}}"
+
+			_, err := common.PackDigest(digest, "from@example.com", "example.com", templates)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
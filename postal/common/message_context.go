@@ -20,6 +20,7 @@ type Options struct {
 	Role              string
 	Endorsement       string
 	TemplateID        string
+	Locale            string
 }
 
 type Delivery struct {
@@ -72,6 +73,7 @@ type MessageContext struct {
 	Organization      string
 	OrganizationGUID  string
 	UnsubscribeID     string
+	UnsubscribeURL    string
 	Scope             string
 	Endorsement       string
 	OrganizationRole  string
@@ -149,4 +151,5 @@ func (context *MessageContext) Escape() {
 	context.Space = html.EscapeString(context.Space)
 	context.Organization = html.EscapeString(context.Organization)
 	context.Endorsement = html.EscapeString(context.Endorsement)
+	context.UnsubscribeURL = html.EscapeString(context.UnsubscribeURL)
 }
@@ -20,28 +20,42 @@ const HTMLWrapperTemplate = `{{.HTMLComponents.Doctype}}
 </html>`
 
 type templatesLoader interface {
-	LoadTemplates(clientID, kindID, templateID string) (Templates, error)
+	LoadTemplates(clientID, kindID, templateID, locale string) (Templates, error)
 }
 
 type Packager struct {
 	templates templatesLoader
 	cloak     conceal.CloakInterface
+	publicURL string
+	strict    bool
 }
 
-func NewPackager(templates templatesLoader, cloak conceal.CloakInterface) Packager {
+// NewPackager returns a Packager. The public URL is where the notifications
+// service is reached by the recipients of notifications; when it is empty,
+// notifications carry no unsubscribe link. A strict packager fails to pack a
+// message whose templates cannot be executed, rather than leaving out
+// whatever they failed to render.
+func NewPackager(templates templatesLoader, cloak conceal.CloakInterface, publicURL string, strict bool) Packager {
 	return Packager{
 		templates: templates,
 		cloak:     cloak,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+		strict:    strict,
 	}
 }
 
 func (packager Packager) PrepareContext(delivery Delivery, sender, domain string) (MessageContext, error) {
-	templates, err := packager.templates.LoadTemplates(delivery.ClientID, delivery.Options.KindID, delivery.Options.TemplateID)
+	templates, err := packager.templates.LoadTemplates(delivery.ClientID, delivery.Options.KindID, delivery.Options.TemplateID, delivery.Options.Locale)
 	if err != nil {
 		return MessageContext{}, err
 	}
 
-	return NewMessageContext(delivery, sender, domain, packager.cloak, templates), nil
+	context := NewMessageContext(delivery, sender, domain, packager.cloak, templates)
+	if packager.publicURL != "" && delivery.UserGUID != "" && delivery.Options.KindID != "" {
+		context.UnsubscribeURL = packager.publicURL + "/unsubscribe/" + context.UnsubscribeID
+	}
+
+	return context, nil
 }
 
 func (packager Packager) Pack(context MessageContext) (mail.Message, error) {
@@ -50,37 +64,78 @@ func (packager Packager) Pack(context MessageContext) (mail.Message, error) {
 		return mail.Message{}, err
 	}
 
-	compiledSubject, err := packager.compileTemplate(context, context.SubjectTemplate, false)
+	compiledSubject, err := packager.compileTemplate(context, "subject", context.SubjectTemplate, false)
 	if err != nil {
 		return mail.Message{}, err
 	}
 
+	headers := []string{
+		fmt.Sprintf("X-CF-Client-ID: %s", context.ClientID),
+		fmt.Sprintf("X-CF-Notification-ID: %s", context.MessageID),
+		fmt.Sprintf("X-CF-Notification-Timestamp: %s", time.Now().Format(time.RFC3339Nano)),
+		fmt.Sprintf("X-CF-Notification-Request-Received: %s", context.RequestReceived.Format(time.RFC3339Nano)),
+	}
+
+	if context.UnsubscribeURL != "" {
+		headers = append(headers,
+			fmt.Sprintf("List-Unsubscribe: <%s>", context.UnsubscribeURL),
+			"List-Unsubscribe-Post: List-Unsubscribe=One-Click",
+		)
+	}
+
 	return mail.Message{
 		From:    context.From,
 		ReplyTo: context.ReplyTo,
 		To:      context.To,
 		Subject: compiledSubject,
 		Body:    parts,
-		Headers: []string{
-			fmt.Sprintf("X-CF-Client-ID: %s", context.ClientID),
-			fmt.Sprintf("X-CF-Notification-ID: %s", context.MessageID),
-			fmt.Sprintf("X-CF-Notification-Timestamp: %s", time.Now().Format(time.RFC3339Nano)),
-			fmt.Sprintf("X-CF-Notification-Request-Received: %s", context.RequestReceived.Format(time.RFC3339Nano)),
-		},
+		Headers: headers,
 	}, nil
 }
 
+// Render packs the context into a message the way Pack does, but fails
+// rather than leave a template half rendered when it cannot be executed. The
+// subject, text and HTML templates are all compiled, whether or not the
+// context has the text or HTML they are used for, and their failures are
+// returned together as TemplateErrors.
+func (packager Packager) Render(context MessageContext) (mail.Message, error) {
+	packager.strict = true
+
+	var templateErrs TemplateErrors
+	templates := []struct {
+		name          string
+		text          string
+		escapeContext bool
+	}{
+		{"subject", context.SubjectTemplate, false},
+		{"text", context.TextTemplate, false},
+		{"html", context.HTMLTemplate, true},
+	}
+	for _, part := range templates {
+		_, err := packager.compileTemplate(context, part.name, part.text, part.escapeContext)
+		if err != nil {
+			templateErrs = append(templateErrs, err.(TemplateError))
+		}
+	}
+
+	if len(templateErrs) > 0 {
+		return mail.Message{}, templateErrs
+	}
+
+	return packager.Pack(context)
+}
+
 func (packager Packager) CompileParts(context MessageContext) ([]mail.Part, error) {
 	var parts []mail.Part
 	var err error
 
-	context.Endorsement, err = packager.compileTemplate(context, context.Endorsement, false)
+	context.Endorsement, err = packager.compileTemplate(context, "endorsement", context.Endorsement, false)
 	if err != nil {
 		return parts, err
 	}
 
 	if context.Text != "" {
-		plainText, err := packager.compileTemplate(context, context.TextTemplate, false)
+		plainText, err := packager.compileTemplate(context, "text", context.TextTemplate, false)
 		if err != nil {
 			return parts, err
 		}
@@ -95,12 +150,12 @@ func (packager Packager) CompileParts(context MessageContext) ([]mail.Part, erro
 	if context.HTML != "" {
 		var err error
 
-		context.HTMLComponents.BodyContent, err = packager.compileTemplate(context, context.HTMLTemplate, true)
+		context.HTMLComponents.BodyContent, err = packager.compileTemplate(context, "html", context.HTMLTemplate, true)
 		if err != nil {
 			return parts, err
 		}
 
-		htmlPart, err := packager.compileTemplate(context, HTMLWrapperTemplate, true)
+		htmlPart, err := packager.compileTemplate(context, "html", HTMLWrapperTemplate, true)
 		if err != nil {
 			return parts, err
 		}
@@ -114,19 +169,25 @@ func (packager Packager) CompileParts(context MessageContext) ([]mail.Part, erro
 	return parts, nil
 }
 
-func (packager Packager) compileTemplate(context MessageContext, theTemplate string, escapeContext bool) (string, error) {
+// compileTemplate executes the named template against the context. Failures
+// to execute the template are only reported by a strict packager; otherwise
+// whatever was rendered up to the failure is used.
+func (packager Packager) compileTemplate(context MessageContext, name, theTemplate string, escapeContext bool) (string, error) {
 	buffer := bytes.NewBuffer([]byte{})
 
-	source, err := template.New("compileTemplate").Parse(theTemplate)
+	source, err := template.New(name).Parse(theTemplate)
 	if err != nil {
-		return "", err
+		return "", newTemplateError(name, theTemplate, err)
 	}
 
 	if escapeContext {
 		context.Escape()
 	}
 
-	source.Execute(buffer, context)
+	err = source.Execute(buffer, context)
+	if err != nil && packager.strict {
+		return "", newTemplateError(name, theTemplate, err)
+	}
 	compiledTemplate := strings.TrimSuffix(buffer.String(), "\n")
 
 	return compiledTemplate, nil
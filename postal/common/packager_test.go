@@ -33,6 +33,7 @@ var _ = Describe("Packager", func() {
 				Subject:    "Some crazy subject",
 				TemplateID: "some-template-id",
 				KindID:     "some-kind-id",
+				Locale:     "pt-BR",
 				HTML: common.HTML{
 					BodyContent:    "<p>user supplied banana html</p>",
 					BodyAttributes: "class=\"bananaBody\"",
@@ -43,7 +44,7 @@ var _ = Describe("Packager", func() {
 			},
 		}
 
-		packager = common.NewPackager(templatesLoader, cloak)
+		packager = common.NewPackager(templatesLoader, cloak, "https://notifications.example.com/", false)
 
 		requestReceivedTime, _ := time.Parse(time.RFC3339Nano, "2015-06-08T14:38:03.180764129-07:00")
 
@@ -93,18 +94,20 @@ var _ = Describe("Packager", func() {
 			Expect(templatesLoader.LoadTemplatesCall.Receives.ClientID).To(Equal("some-client-id"))
 			Expect(templatesLoader.LoadTemplatesCall.Receives.KindID).To(Equal("some-kind-id"))
 			Expect(templatesLoader.LoadTemplatesCall.Receives.TemplateID).To(Equal("some-template-id"))
+			Expect(templatesLoader.LoadTemplatesCall.Receives.Locale).To(Equal("pt-BR"))
 
 			Expect(cloak.VeilCall.Receives.PlainText).To(Equal([]byte("some-user-guid|some-client-id|some-kind-id")))
 
 			Expect(context).To(Equal(common.MessageContext{
-				UnsubscribeID: "some-encrypted-text",
-				Domain:        "example.com",
-				From:          "some-sender@example.com",
-				Subject:       "Some crazy subject",
-				UserGUID:      "some-user-guid",
-				ClientID:      "some-client-id",
-				Text:          "some-text",
-				HTML:          "<p>user supplied banana html</p>",
+				UnsubscribeID:  "some-encrypted-text",
+				UnsubscribeURL: "https://notifications.example.com/unsubscribe/some-encrypted-text",
+				Domain:         "example.com",
+				From:           "some-sender@example.com",
+				Subject:        "Some crazy subject",
+				UserGUID:       "some-user-guid",
+				ClientID:       "some-client-id",
+				Text:           "some-text",
+				HTML:           "<p>user supplied banana html</p>",
 				HTMLComponents: common.HTML{
 					BodyContent:    "<p>user supplied banana html</p>",
 					BodyAttributes: "class=\"bananaBody\"",
@@ -119,6 +122,23 @@ var _ = Describe("Packager", func() {
 			}))
 		})
 
+		It("leaves out the unsubscribe link when the notification is not sent to a user", func() {
+			delivery.UserGUID = ""
+			delivery.Email = "fake-user@example.com"
+
+			context, err := packager.PrepareContext(delivery, "some-sender@example.com", "example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(context.UnsubscribeURL).To(BeEmpty())
+		})
+
+		It("leaves out the unsubscribe link when there is no public URL", func() {
+			packager = common.NewPackager(templatesLoader, cloak, "", false)
+
+			context, err := packager.PrepareContext(delivery, "some-sender@example.com", "example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(context.UnsubscribeURL).To(BeEmpty())
+		})
+
 		Context("when the template cannot be loaded", func() {
 			It("returns an error", func() {
 				templatesLoader.LoadTemplatesCall.Returns.Error = errors.New("some error")
@@ -129,6 +149,30 @@ var _ = Describe("Packager", func() {
 		})
 	})
 
+	Describe("CompileDigestItem", func() {
+		It("renders the text and html of the notification without wrapping them in a document", func() {
+			item, err := packager.CompileDigestItem(context)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(item).To(Equal(common.DigestItem{
+				MessageID: "4'4",
+				ClientID:  "3&3",
+				Subject:   "we will be eaten",
+				Text:      "Banana preamble User <supplied> \"banana\" text 3&3 4'4 user-123\nThis is an endorsement for the development space and banana org.",
+				HTML:      "<header>This is an endorsement for the development space and banana org.</header>\nBanana preamble <p>user supplied banana html</p> User &lt;supplied&gt; &#34;banana&#34; text 3&amp;3 4&#39;4 user-123",
+			}))
+		})
+
+		It("leaves out the parts the notification does not have", func() {
+			context.HTML = ""
+
+			item, err := packager.CompileDigestItem(context)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(item.HTML).To(BeEmpty())
+			Expect(item.Text).NotTo(BeEmpty())
+		})
+	})
+
 	Describe("Pack", func() {
 		It("packs a message for delivery", func() {
 			msg, err := packager.Pack(context)
@@ -165,6 +209,115 @@ var _ = Describe("Packager", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(timestamp).To(BeTemporally("~", time.Now(), 2*time.Second))
 		})
+
+		It("leaves out what a template fails to execute", func() {
+			context.SubjectTemplate = "The Subject: {{.Subject}}{{.Subject.Name}}"
+
+			message, err := packager.Pack(context)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(message.Subject).To(Equal("The Subject: we will be eaten"))
+		})
+
+		It("fails when a template cannot be executed by a strict packager", func() {
+			packager = common.NewPackager(templatesLoader, cloak, "", true)
+			context.SubjectTemplate = "The Subject: {{.Subject}}{{.Subject.Name}}"
+
+			_, err := packager.Pack(context)
+			Expect(err).To(Equal(common.TemplateError{
+				Template: "subject",
+				Line:     1,
+				Column:   36,
+				Message:  "at <.Subject.Name>: can't evaluate field Name in type string",
+			}))
+		})
+
+		It("reports the line and column of a template that cannot be parsed", func() {
+			context.SubjectTemplate = "The Subject: {{.Subject}"
+
+			_, err := packager.Pack(context)
+			Expect(err).To(Equal(common.TemplateError{
+				Template: "subject",
+				Line:     1,
+				Column:   14,
+				Message:  "bad character U+007D '}'",
+			}))
+		})
+
+		It("offers a one-click unsubscribe to mail clients", func() {
+			context.UnsubscribeURL = "https://notifications.example.com/unsubscribe/some-token"
+
+			msg, err := packager.Pack(context)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(msg.Headers).To(ContainElement("List-Unsubscribe: <https://notifications.example.com/unsubscribe/some-token>"))
+			Expect(msg.Headers).To(ContainElement("List-Unsubscribe-Post: List-Unsubscribe=One-Click"))
+		})
+
+		It("leaves out the unsubscribe headers when there is no unsubscribe link", func() {
+			msg, err := packager.Pack(context)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, header := range msg.Headers {
+				Expect(header).NotTo(HavePrefix("List-Unsubscribe"))
+			}
+		})
+	})
+
+	Describe("Render", func() {
+		It("packs the message as Pack does", func() {
+			message, err := packager.Render(context)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(message.Subject).To(Equal("The Subject: we will be eaten"))
+			Expect(message.To).To(Equal("endless monkeys"))
+			Expect(message.Body).To(HaveLen(2))
+			Expect(message.Body[0].Content).To(Equal("Banana preamble User <supplied> \"banana\" text 3&3 4'4 user-123\nThis is an endorsement for the development space and banana org."))
+		})
+
+		It("reports the templates that cannot be executed, with their line and column", func() {
+			context.SubjectTemplate = "The Subject: {{.Subject.Name}}"
+			context.HTMLTemplate = "<p>\n  {{index .Space 12}}</p>"
+
+			_, err := packager.Render(context)
+			Expect(err).To(Equal(common.TemplateErrors{
+				{
+					Template: "subject",
+					Line:     1,
+					Column:   24,
+					Message:  "at <.Subject.Name>: can't evaluate field Name in type string",
+				},
+				{
+					Template: "html",
+					Line:     2,
+					Column:   5,
+					Message:  "at <index .Space 12>: error calling index: index out of range: 12",
+				},
+			}))
+			Expect(err.Error()).To(Equal("subject template: line 1, column 24: at <.Subject.Name>: can't evaluate field Name in type string; " +
+				"html template: line 2, column 5: at <index .Space 12>: error calling index: index out of range: 12"))
+		})
+
+		It("reports the templates that cannot be parsed, with their line and column", func() {
+			context.TextTemplate = "Hello\n{{.Text}} and {{.ClientID | shout}}"
+
+			_, err := packager.Render(context)
+			Expect(err).To(Equal(common.TemplateErrors{
+				{
+					Template: "text",
+					Line:     2,
+					Column:   15,
+					Message:  `function "shout" not defined`,
+				},
+			}))
+		})
+
+		It("reports the text and HTML templates even when the context has no text or HTML", func() {
+			context.Text = ""
+			context.HTML = ""
+			context.TextTemplate = "{{.Nope}}"
+
+			_, err := packager.Render(context)
+			Expect(err).To(MatchError(ContainSubstring("text template: line 1, column 3")))
+		})
 	})
 
 	Describe("CompileParts", func() {
@@ -0,0 +1,183 @@
+package common
+
+import (
+	"errors"
+	htmltemplate "html/template"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+var messageContextType = reflect.TypeOf(MessageContext{})
+
+// CheckTemplate parses the named template and checks that the fields it
+// refers to are fields of the MessageContext it is executed with. HTML
+// templates are also parsed as html/template, so that markup which cannot be
+// escaped safely is reported. Every failure is returned, located by line and
+// column.
+func CheckTemplate(name, text string, html bool) TemplateErrors {
+	source, err := template.New(name).Parse(text)
+	if err != nil {
+		return TemplateErrors{newTemplateError(name, text, err)}
+	}
+
+	checker := fieldChecker{
+		name: name,
+		tree: source.Tree,
+	}
+	if source.Tree != nil {
+		checker.walk(source.Tree.Root, messageContextType)
+	}
+
+	if html && len(checker.errs) == 0 {
+		if templateErr, ok := checkHTMLEscaping(name, text); ok {
+			checker.errs = append(checker.errs, templateErr)
+		}
+	}
+
+	return checker.errs
+}
+
+// checkHTMLEscaping reports the markup of the template that html/template
+// cannot escape, which it only finds once the template is executed.
+func checkHTMLEscaping(name, text string) (TemplateError, bool) {
+	source, err := htmltemplate.New(name).Parse(text)
+	if err != nil {
+		return newTemplateError(name, text, err), true
+	}
+
+	err = source.Execute(io.Discard, MessageContext{})
+
+	var escapeErr *htmltemplate.Error
+	if !errors.As(err, &escapeErr) {
+		return TemplateError{}, false
+	}
+
+	// The description ends with a dump of the escaper's state, which
+	// means nothing to the author of the template.
+	message, _, _ := strings.Cut(escapeErr.Description, ": {")
+
+	return TemplateError{
+		Template: name,
+		Line:     escapeErr.Line,
+		Message:  message,
+	}, true
+}
+
+type fieldChecker struct {
+	name string
+	tree *parse.Tree
+	errs TemplateErrors
+}
+
+// walk checks the fields referred to under the node, where dot has the given
+// type. A nil type is one that cannot be told before execution, such as the
+// elements of a range, under which nothing is checked.
+func (c *fieldChecker) walk(node parse.Node, dot reflect.Type) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			c.walk(child, dot)
+		}
+	case *parse.ActionNode:
+		c.checkPipe(n.Pipe, dot)
+	case *parse.IfNode:
+		c.checkPipe(n.Pipe, dot)
+		c.walk(n.List, dot)
+		c.walk(n.ElseList, dot)
+	case *parse.WithNode:
+		c.walk(n.List, c.checkPipe(n.Pipe, dot))
+		c.walk(n.ElseList, dot)
+	case *parse.RangeNode:
+		c.checkPipe(n.Pipe, dot)
+		c.walk(n.List, nil)
+		c.walk(n.ElseList, dot)
+	case *parse.TemplateNode:
+		c.checkPipe(n.Pipe, dot)
+	}
+}
+
+// checkPipe checks the fields referred to in the pipeline and returns the
+// type it evaluates to, when that is a field.
+func (c *fieldChecker) checkPipe(pipe *parse.PipeNode, dot reflect.Type) reflect.Type {
+	if pipe == nil {
+		return nil
+	}
+
+	var result reflect.Type
+	for _, cmd := range pipe.Cmds {
+		result = nil
+		for _, arg := range cmd.Args {
+			result = c.checkArg(arg, dot)
+		}
+		if len(cmd.Args) != 1 {
+			result = nil
+		}
+	}
+
+	return result
+}
+
+func (c *fieldChecker) checkArg(arg parse.Node, dot reflect.Type) reflect.Type {
+	switch n := arg.(type) {
+	case *parse.FieldNode:
+		return c.resolve(n, dot, "", n.Ident)
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" {
+			return c.resolve(n, messageContextType, "$", n.Ident[1:])
+		}
+	case *parse.ChainNode:
+		c.checkArg(n.Node, dot)
+	case *parse.PipeNode:
+		return c.checkPipe(n, dot)
+	case *parse.DotNode:
+		return dot
+	}
+
+	return nil
+}
+
+// resolve follows the field names from the type and reports the first one
+// that is not a field of the type it is looked up in. Nothing is checked past
+// a method, a map or a type that cannot be told.
+func (c *fieldChecker) resolve(node parse.Node, typ reflect.Type, prefix string, idents []string) reflect.Type {
+	for i, ident := range idents {
+		if typ == nil {
+			return nil
+		}
+
+		if _, ok := typ.MethodByName(ident); ok {
+			return nil
+		}
+		if _, ok := reflect.PointerTo(typ).MethodByName(ident); ok {
+			return nil
+		}
+
+		switch typ.Kind() {
+		case reflect.Struct:
+			field, ok := typ.FieldByName(ident)
+			if !ok || field.PkgPath != "" {
+				c.report(node, "unknown field "+prefix+"."+strings.Join(idents[:i+1], "."))
+				return nil
+			}
+			typ = field.Type
+		case reflect.Map, reflect.Interface:
+			return nil
+		default:
+			c.report(node, "unknown field "+prefix+"."+strings.Join(idents[:i+1], "."))
+			return nil
+		}
+	}
+
+	return typ
+}
+
+func (c *fieldChecker) report(node parse.Node, message string) {
+	location, _ := c.tree.ErrorContext(node)
+	c.errs = append(c.errs, newTemplateError(c.name, "", errors.New("template: "+location+": "+message)))
+}
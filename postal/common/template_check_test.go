@@ -0,0 +1,53 @@
+package common_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckTemplate", func() {
+	It("accepts templates that only refer to fields of the message context", func() {
+		errs := common.CheckTemplate("text", "Hello {{.To}},\n{{.Text}}\n{{if .UnsubscribeURL}}{{.UnsubscribeURL}}{{end}}", false)
+		Expect(errs).To(BeEmpty())
+	})
+
+	It("accepts nested fields, methods and variables", func() {
+		errs := common.CheckTemplate("html", `{{with .HTMLComponents}}{{.BodyContent}} {{$.Organization}}{{end}} {{.RequestReceived.Year}} {{printf "%s" .Space}}`, true)
+		Expect(errs).To(BeEmpty())
+	})
+
+	It("reports every field that is not a field of the message context", func() {
+		errs := common.CheckTemplate("text", "Hello {{.Orgnization}}\n{{with .HTMLComponents}}{{.Title}}{{end}} {{$.Space.Name}}", false)
+		Expect(errs).To(Equal(common.TemplateErrors{
+			{Template: "text", Line: 1, Column: 9, Message: "unknown field .Orgnization"},
+			{Template: "text", Line: 2, Column: 27, Message: "unknown field .Title"},
+			{Template: "text", Line: 2, Column: 46, Message: "unknown field $.Space.Name"},
+		}))
+	})
+
+	It("does not check fields where dot cannot be told", func() {
+		errs := common.CheckTemplate("text", "{{range .Subject}}{{.Anything}}{{end}}", false)
+		Expect(errs).To(BeEmpty())
+	})
+
+	It("reports templates that cannot be parsed", func() {
+		errs := common.CheckTemplate("subject", "Hello {{.To}", false)
+		Expect(errs).To(Equal(common.TemplateErrors{
+			{Template: "subject", Line: 1, Column: 7, Message: "bad character U+007D '}'"},
+		}))
+	})
+
+	It("reports HTML that cannot be escaped", func() {
+		errs := common.CheckTemplate("html", `<p>Hello</p>`+"\n"+`<a href="{{.UnsubscribeURL}}>unsubscribe</a>`, true)
+		Expect(errs).To(Equal(common.TemplateErrors{
+			{Template: "html", Message: "ends in a non-text context"},
+		}))
+	})
+
+	It("does not check the escaping of text templates", func() {
+		errs := common.CheckTemplate("text", `<a href="{{.UnsubscribeURL}}>unsubscribe</a>`, false)
+		Expect(errs).To(BeEmpty())
+	})
+})
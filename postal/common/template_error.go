@@ -0,0 +1,102 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var templateErrorLocation = regexp.MustCompile(`^template: [^:]*:(\d+)(?::(\d+))?: (?:executing "[^"]*" )?(.*)$`)
+
+// TemplateError is a failure to parse or execute one of the templates of a
+// notification. Line and Column count from 1; Column is 0 when the failure
+// cannot be placed on its line.
+type TemplateError struct {
+	Template string
+	Line     int
+	Column   int
+	Message  string
+}
+
+func newTemplateError(name, text string, err error) TemplateError {
+	matches := templateErrorLocation.FindStringSubmatch(err.Error())
+	if matches == nil {
+		return TemplateError{
+			Template: name,
+			Message:  err.Error(),
+		}
+	}
+
+	templateErr := TemplateError{
+		Template: name,
+		Message:  matches[3],
+	}
+	templateErr.Line, _ = strconv.Atoi(matches[1])
+
+	// text/template reports the byte offset of an execution failure on its
+	// line, but does not place parse failures on their line at all.
+	if matches[2] != "" {
+		offset, _ := strconv.Atoi(matches[2])
+		templateErr.Column = offset + 1
+	} else {
+		templateErr.Column = parseErrorColumn(text, templateErr.Line)
+	}
+
+	return templateErr
+}
+
+func (e TemplateError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s template: %s", e.Template, e.Message)
+	}
+
+	if e.Column == 0 {
+		return fmt.Sprintf("%s template: line %d: %s", e.Template, e.Line, e.Message)
+	}
+
+	return fmt.Sprintf("%s template: line %d, column %d: %s", e.Template, e.Line, e.Column, e.Message)
+}
+
+// TemplateErrors are the failures of several templates, reported together.
+type TemplateErrors []TemplateError
+
+func (e TemplateErrors) Error() string {
+	var messages []string
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// parseErrorColumn places a parse failure at the first action on its line
+// that does not parse on its own.
+func parseErrorColumn(text string, line int) int {
+	lines := strings.Split(text, "\n")
+	if line < 1 || line > len(lines) {
+		return 0
+	}
+	content := lines[line-1]
+
+	for offset := 0; ; {
+		start := strings.Index(content[offset:], "{{")
+		if start < 0 {
+			return 0
+		}
+		start += offset
+
+		action := content[start:]
+		if end := strings.Index(action, "}}"); end >= 0 {
+			action = action[:end+2]
+		}
+
+		_, err := template.New("action").Parse(action)
+		if err != nil {
+			return start + 1
+		}
+
+		offset = start + len(action)
+	}
+}
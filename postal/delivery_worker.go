@@ -24,51 +24,62 @@ type campaignJobProcessor interface {
 }
 
 type messageStatusUpdater interface {
-	Update(conn db.ConnectionInterface, messageID, messageStatus, campaignID string, logger lager.Logger)
+	Update(conn db.ConnectionInterface, messageID, messageStatus, campaignID string, attempt common.DeliveryAttempt, logger lager.Logger)
 }
 
 type deliveryFailureHandler interface {
-	Handle(job common.Retryable, logger lager.Logger)
+	Handle(job common.Retryable, cause error, logger lager.Logger)
 }
 
 type DeliveryWorkerConfig struct {
-	ID                     int
-	UAAHost                string
-	Logger                 lager.Logger
-	Queue                  gobble.QueueInterface
-	DBTrace                bool
-	Database               db.DatabaseInterface
-	CampaignJobProcessor   campaignJobProcessor
-	DeliveryFailureHandler deliveryFailureHandler
-	MessageStatusUpdater   messageStatusUpdater
+	ID                      int
+	HighPriorityOnly        bool
+	UAAHost                 string
+	Logger                  lager.Logger
+	Queue                   gobble.QueueInterface
+	DBTrace                 bool
+	Database                db.DatabaseInterface
+	CampaignJobProcessor    campaignJobProcessor
+	DigestJobProcessor      DeliveryJobProcessor
+	PreferencesJobProcessor DeliveryJobProcessor
+	DeliveryFailureHandler  deliveryFailureHandler
+	MessageStatusUpdater    messageStatusUpdater
 }
 
 type DeliveryWorker struct {
 	gobble.Worker
 
-	uaaHost                string
-	DeliveryJobProcessor   DeliveryJobProcessor
-	V2DeliveryJobProcessor v2DeliveryJobProcessor
-	logger                 lager.Logger
-	database               db.DatabaseInterface
-	campaignJobProcessor   campaignJobProcessor
-	deliveryFailureHandler deliveryFailureHandler
-	messageStatusUpdater   messageStatusUpdater
+	uaaHost                 string
+	DeliveryJobProcessor    DeliveryJobProcessor
+	DigestJobProcessor      DeliveryJobProcessor
+	PreferencesJobProcessor DeliveryJobProcessor
+	V2DeliveryJobProcessor  v2DeliveryJobProcessor
+	logger                  lager.Logger
+	database                db.DatabaseInterface
+	campaignJobProcessor    campaignJobProcessor
+	deliveryFailureHandler  deliveryFailureHandler
+	messageStatusUpdater    messageStatusUpdater
 }
 
 func NewDeliveryWorker(v1DeliveryJobProcessor DeliveryJobProcessor, config DeliveryWorkerConfig) DeliveryWorker {
 	worker := DeliveryWorker{
-		DeliveryJobProcessor:   v1DeliveryJobProcessor,
-		uaaHost:                config.UAAHost,
-		logger:                 config.Logger,
-		database:               config.Database,
-		campaignJobProcessor:   config.CampaignJobProcessor,
-		deliveryFailureHandler: config.DeliveryFailureHandler,
-		messageStatusUpdater:   config.MessageStatusUpdater,
+		DeliveryJobProcessor:    v1DeliveryJobProcessor,
+		DigestJobProcessor:      config.DigestJobProcessor,
+		PreferencesJobProcessor: config.PreferencesJobProcessor,
+		uaaHost:                 config.UAAHost,
+		logger:                  config.Logger,
+		database:                config.Database,
+		campaignJobProcessor:    config.CampaignJobProcessor,
+		deliveryFailureHandler:  config.DeliveryFailureHandler,
+		messageStatusUpdater:    config.MessageStatusUpdater,
 	}
 	ticker := gobble.NewTicker(time.NewTicker, 30*time.Second)
 	heartbeater := gobble.NewHeartbeater(config.Queue, ticker)
-	worker.Worker = gobble.NewWorker(config.ID, config.Queue, worker.Deliver, heartbeater)
+	if config.HighPriorityOnly {
+		worker.Worker = gobble.NewPriorityWorker(config.ID, gobble.PriorityHigh, config.Queue, worker.Deliver, heartbeater)
+	} else {
+		worker.Worker = gobble.NewWorker(config.ID, config.Queue, worker.Deliver, heartbeater)
+	}
 
 	return worker
 }
@@ -82,9 +93,16 @@ func (worker DeliveryWorker) Deliver(job *gobble.Job) {
 	if err != nil {
 		metrics.GetOrRegisterCounter("notifications.worker.panic.json", nil).Inc(1)
 
-		worker.deliveryFailureHandler.Handle(job, worker.logger)
+		worker.deliveryFailureHandler.Handle(job, err, worker.logger)
 		return
 	}
 
-	worker.DeliveryJobProcessor.Process(job, worker.logger)
+	switch typedJob.JobType {
+	case common.DigestJobType:
+		worker.DigestJobProcessor.Process(job, worker.logger)
+	case services.PreferencesJobType:
+		worker.PreferencesJobProcessor.Process(job, worker.logger)
+	default:
+		worker.DeliveryJobProcessor.Process(job, worker.logger)
+	}
 }
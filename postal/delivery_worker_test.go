@@ -8,6 +8,7 @@ import (
 	"github.com/cloudfoundry-incubator/notifications/postal"
 	"github.com/cloudfoundry-incubator/notifications/postal/common"
 	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
 	"github.com/pivotal-golang/lager"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -23,7 +24,10 @@ var _ = Describe("DeliveryWorker", func() {
 		queue                  *mocks.Queue
 		deliveryFailureHandler *mocks.DeliveryFailureHandler
 		v1DeliveryJobProcessor *mocks.V1DeliveryJobProcessor
+		digestJobProcessor     *mocks.V1DeliveryJobProcessor
+		preferencesProcessor   *mocks.V1DeliveryJobProcessor
 		connection             *mocks.Connection
+		database               *mocks.Database
 		messageStatusUpdater   *mocks.MessageStatusUpdater
 	)
 
@@ -34,18 +38,23 @@ var _ = Describe("DeliveryWorker", func() {
 		queue = mocks.NewQueue()
 		deliveryFailureHandler = mocks.NewDeliveryFailureHandler()
 		connection = mocks.NewConnection()
-		database := mocks.NewDatabase()
+		database = mocks.NewDatabase()
 		database.ConnectionCall.Returns.Connection = connection
 		messageStatusUpdater = mocks.NewMessageStatusUpdater()
 
+		digestJobProcessor = mocks.NewV1DeliveryJobProcessor()
+		preferencesProcessor = mocks.NewV1DeliveryJobProcessor()
+
 		config := postal.DeliveryWorkerConfig{
-			ID:                     42,
-			Logger:                 logger,
-			Queue:                  queue,
-			DeliveryFailureHandler: deliveryFailureHandler,
-			Database:               database,
-			UAAHost:                "my-uaa-host",
-			MessageStatusUpdater:   messageStatusUpdater,
+			ID:                      42,
+			Logger:                  logger,
+			Queue:                   queue,
+			DeliveryFailureHandler:  deliveryFailureHandler,
+			Database:                database,
+			UAAHost:                 "my-uaa-host",
+			MessageStatusUpdater:    messageStatusUpdater,
+			DigestJobProcessor:      digestJobProcessor,
+			PreferencesJobProcessor: preferencesProcessor,
 		}
 
 		v1DeliveryJobProcessor = mocks.NewV1DeliveryJobProcessor()
@@ -68,6 +77,35 @@ var _ = Describe("DeliveryWorker", func() {
 			Expect(v1DeliveryJobProcessor.ProcessCall.CallCount).To(Equal(1))
 		})
 
+		Context("when the worker only handles high priority jobs", func() {
+			It("reserves only high priority jobs", func() {
+				reserveChan := make(chan *gobble.Job)
+				go func() {
+					reserveChan <- gobble.NewJob(delivery)
+				}()
+				queue.ReservePriorityCall.Returns.Chan = reserveChan
+
+				worker = postal.NewDeliveryWorker(v1DeliveryJobProcessor, postal.DeliveryWorkerConfig{
+					ID:                     42,
+					Logger:                 logger,
+					Queue:                  queue,
+					DeliveryFailureHandler: deliveryFailureHandler,
+					Database:               database,
+					MessageStatusUpdater:   messageStatusUpdater,
+					HighPriorityOnly:       true,
+				})
+
+				worker.Work()
+
+				<-time.After(10 * time.Millisecond)
+				worker.Halt()
+
+				Expect(v1DeliveryJobProcessor.ProcessCall.CallCount).To(Equal(1))
+				Expect(queue.ReservePriorityCall.Receives.Priority).To(Equal(gobble.PriorityHigh))
+				Expect(queue.ReserveCall.Receives.ID).To(BeEmpty())
+			})
+		})
+
 		It("can be halted", func() {
 			go func() {
 				worker.Halt()
@@ -92,6 +130,34 @@ var _ = Describe("DeliveryWorker", func() {
 
 			Expect(v1DeliveryJobProcessor.ProcessCall.Receives.Job).To(Equal(job))
 			Expect(v1DeliveryJobProcessor.ProcessCall.Receives.Logger).ToNot(BeNil())
+			Expect(digestJobProcessor.ProcessCall.CallCount).To(Equal(0))
+		})
+
+		It("should hand digest jobs to the digest workflow", func() {
+			job = gobble.NewJob(common.Digest{
+				JobType:  common.DigestJobType,
+				UserGUID: "some-user",
+			})
+
+			worker.Deliver(job)
+
+			Expect(digestJobProcessor.ProcessCall.Receives.Job).To(Equal(job))
+			Expect(digestJobProcessor.ProcessCall.Receives.Logger).ToNot(BeNil())
+			Expect(v1DeliveryJobProcessor.ProcessCall.CallCount).To(Equal(0))
+		})
+
+		It("should hand preferences jobs to the preferences workflow", func() {
+			job = gobble.NewJob(services.PreferencesJob{
+				JobType: services.PreferencesJobType,
+				ID:      "some-job-id",
+			})
+
+			worker.Deliver(job)
+
+			Expect(preferencesProcessor.ProcessCall.Receives.Job).To(Equal(job))
+			Expect(preferencesProcessor.ProcessCall.Receives.Logger).ToNot(BeNil())
+			Expect(digestJobProcessor.ProcessCall.CallCount).To(Equal(0))
+			Expect(v1DeliveryJobProcessor.ProcessCall.CallCount).To(Equal(0))
 		})
 
 		Context("when the job cannot be unmarshalled", func() {
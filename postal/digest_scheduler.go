@@ -0,0 +1,168 @@
+package postal
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/pivotal-golang/lager"
+	"gopkg.in/gorp.v1"
+)
+
+var digestPeriods = map[string]time.Duration{
+	models.FrequencyDaily:  24 * time.Hour,
+	models.FrequencyWeekly: 7 * 24 * time.Hour,
+}
+
+type digestItemsRepo interface {
+	FindDue(models.ConnectionInterface, string, time.Time) ([]string, error)
+	Take(models.ConnectionInterface, string, string) ([]models.DigestItem, error)
+}
+
+type digestQueue interface {
+	Enqueue(*gobble.Job, gobble.ConnectionInterface) (*gobble.Job, error)
+}
+
+type gobbleInitializer interface {
+	InitializeDBMap(*gorp.DbMap)
+}
+
+// DigestScheduler turns the notifications waiting for a digest into digest
+// jobs. A user's digest is due once their oldest waiting notification has
+// waited for a day or a week, so that each user receives at most one digest
+// of each frequency per period. Every instance runs a scheduler; the items
+// of a digest are taken in the same transaction that enqueues its job, so
+// that only one of them sends it. The scheduler is a Worker, so that it is
+// halted along with the delivery workers.
+type DigestScheduler struct {
+	database          db.DatabaseInterface
+	digestItems       digestItemsRepo
+	queue             digestQueue
+	gobbleInitializer gobbleInitializer
+	clock             clock
+	pollingInterval   time.Duration
+	logger            lager.Logger
+	done              chan struct{}
+	halted            chan struct{}
+}
+
+func NewDigestScheduler(database db.DatabaseInterface, digestItems digestItemsRepo, queue digestQueue, gobbleInitializer gobbleInitializer, clock clock, pollingInterval time.Duration, logger lager.Logger) DigestScheduler {
+	return DigestScheduler{
+		database:          database,
+		digestItems:       digestItems,
+		queue:             queue,
+		gobbleInitializer: gobbleInitializer,
+		clock:             clock,
+		pollingInterval:   pollingInterval,
+		logger:            logger,
+		done:              make(chan struct{}),
+		halted:            make(chan struct{}),
+	}
+}
+
+func (s DigestScheduler) Schedule() {
+	now := s.clock.Now().UTC()
+
+	for _, frequency := range []string{models.FrequencyDaily, models.FrequencyWeekly} {
+		userIDs, err := s.digestItems.FindDue(s.database.Connection(), frequency, now.Add(-digestPeriods[frequency]))
+		if err != nil {
+			s.logger.Error("digest-schedule-failed", err, lager.Data{"frequency": frequency})
+			continue
+		}
+
+		for _, userID := range userIDs {
+			err = s.schedule(userID, frequency)
+			if err != nil {
+				s.logger.Error("digest-schedule-failed", err, lager.Data{
+					"frequency": frequency,
+					"user_guid": userID,
+				})
+			}
+		}
+	}
+}
+
+func (s DigestScheduler) schedule(userID, frequency string) error {
+	transaction := s.database.Connection().Transaction()
+	err := transaction.Begin()
+	if err != nil {
+		return err
+	}
+
+	items, err := s.digestItems.Take(transaction, userID, frequency)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if len(items) == 0 {
+		return transaction.Rollback()
+	}
+
+	digest := common.Digest{
+		JobType:   common.DigestJobType,
+		UserGUID:  userID,
+		Email:     items[len(items)-1].Email,
+		Frequency: frequency,
+	}
+
+	for _, item := range items {
+		digest.Items = append(digest.Items, common.DigestItem{
+			MessageID:         item.MessageID,
+			ClientID:          item.ClientID,
+			KindID:            item.KindID,
+			SourceDescription: item.SourceDescription,
+			KindDescription:   item.KindDescription,
+			Subject:           item.Subject,
+			Text:              item.Text,
+			HTML:              item.HTML,
+			CreatedAt:         item.CreatedAt,
+		})
+	}
+
+	s.gobbleInitializer.InitializeDBMap(transaction.GetDbMap())
+
+	_, err = s.queue.Enqueue(gobble.NewJob(digest), transaction)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("digest-scheduled", lager.Data{
+		"frequency": frequency,
+		"user_guid": userID,
+		"items":     len(items),
+	})
+
+	return nil
+}
+
+func (s DigestScheduler) Work() {
+	go func() {
+		defer close(s.halted)
+
+		for {
+			s.Schedule()
+
+			select {
+			case <-s.done:
+				return
+			case <-time.After(s.pollingInterval):
+			}
+		}
+	}()
+}
+
+// Halt stops the scheduler and waits for a pass that is in progress to
+// finish, so that no digest job is enqueued once it returns.
+func (s DigestScheduler) Halt() {
+	close(s.done)
+	<-s.halted
+}
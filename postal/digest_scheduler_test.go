@@ -0,0 +1,200 @@
+package postal_test
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/postal"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/pivotal-golang/lager"
+	"gopkg.in/gorp.v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DigestScheduler", func() {
+	var (
+		scheduler   postal.DigestScheduler
+		repo        *mocks.DigestItemsRepo
+		queue       *mocks.Queue
+		initializer *mocks.GobbleInitializer
+		database    *mocks.Database
+		conn        *mocks.Connection
+		transaction *mocks.Transaction
+		dbMap       *gorp.DbMap
+		clock       *mocks.Clock
+		buffer      *bytes.Buffer
+		now         time.Time
+		createdAt   time.Time
+	)
+
+	BeforeEach(func() {
+		repo = mocks.NewDigestItemsRepo()
+		queue = mocks.NewQueue()
+		initializer = mocks.NewGobbleInitializer()
+
+		dbMap = &gorp.DbMap{}
+		transaction = mocks.NewTransaction()
+		transaction.Connection = mocks.NewConnection()
+		transaction.GetDbMapCall.Returns.DbMap = dbMap
+
+		conn = mocks.NewConnection()
+		conn.TransactionCall.Returns.Transaction = transaction
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		now = time.Date(2015, time.June, 8, 12, 0, 0, 0, time.UTC)
+		createdAt = now.Add(-30 * time.Hour)
+		clock = mocks.NewClock()
+		clock.NowCall.Returns.Time = now
+
+		buffer = bytes.NewBuffer([]byte{})
+		logger := lager.NewLogger("notifications")
+		logger.RegisterSink(lager.NewWriterSink(buffer, lager.DEBUG))
+
+		repo.FindDueCall.Returns.UserIDs = map[string][]string{
+			models.FrequencyDaily: {"user-123"},
+		}
+		repo.TakeCall.Returns.Items = []models.DigestItem{
+			{
+				UserID:            "user-123",
+				Email:             "old@example.com",
+				Frequency:         models.FrequencyDaily,
+				MessageID:         "message-1",
+				ClientID:          "some-client",
+				KindID:            "some-kind",
+				SourceDescription: "Some Client",
+				KindDescription:   "Some Kind",
+				Subject:           "First",
+				Text:              "first text",
+				HTML:              "<p>first html</p>",
+				CreatedAt:         createdAt,
+			},
+			{
+				UserID:    "user-123",
+				Email:     "new@example.com",
+				Frequency: models.FrequencyDaily,
+				MessageID: "message-2",
+				Subject:   "Second",
+				CreatedAt: createdAt.Add(time.Hour),
+			},
+		}
+
+		scheduler = postal.NewDigestScheduler(database, repo, queue, initializer, clock, 5*time.Minute, logger)
+	})
+
+	It("looks for daily and weekly digests whose oldest item has waited a full period", func() {
+		scheduler.Schedule()
+
+		Expect(repo.FindDueCall.Receives.Connection).To(Equal(conn))
+		Expect(repo.FindDueCall.Receives.Frequencies).To(Equal([]string{models.FrequencyDaily, models.FrequencyWeekly}))
+		Expect(repo.FindDueCall.Receives.Befores).To(Equal([]time.Time{
+			now.Add(-24 * time.Hour),
+			now.Add(-7 * 24 * time.Hour),
+		}))
+	})
+
+	It("takes the items of each due user and enqueues them as a digest job in one transaction", func() {
+		scheduler.Schedule()
+
+		Expect(transaction.BeginCall.WasCalled).To(BeTrue())
+		Expect(repo.TakeCall.Receives.Connection).To(Equal(transaction))
+		Expect(repo.TakeCall.Receives.UserIDs).To(Equal([]string{"user-123"}))
+		Expect(repo.TakeCall.Receives.Frequency).To(Equal(models.FrequencyDaily))
+
+		Expect(initializer.InitializeDBMapCall.Receives.DbMap).To(Equal(dbMap))
+		Expect(queue.EnqueueCall.Receives.Connection).To(Equal(transaction))
+		Expect(queue.EnqueueCall.Receives.Jobs).To(HaveLen(1))
+
+		var digest common.Digest
+		err := queue.EnqueueCall.Receives.Jobs[0].Unmarshal(&digest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(digest).To(Equal(common.Digest{
+			JobType:   common.DigestJobType,
+			UserGUID:  "user-123",
+			Email:     "new@example.com",
+			Frequency: models.FrequencyDaily,
+			Items: []common.DigestItem{
+				{
+					MessageID:         "message-1",
+					ClientID:          "some-client",
+					KindID:            "some-kind",
+					SourceDescription: "Some Client",
+					KindDescription:   "Some Kind",
+					Subject:           "First",
+					Text:              "first text",
+					HTML:              "<p>first html</p>",
+					CreatedAt:         createdAt,
+				},
+				{
+					MessageID: "message-2",
+					Subject:   "Second",
+					CreatedAt: createdAt.Add(time.Hour),
+				},
+			},
+		}))
+
+		Expect(transaction.CommitCall.WasCalled).To(BeTrue())
+		Expect(transaction.RollbackCall.WasCalled).To(BeFalse())
+	})
+
+	Context("when another instance has already taken the items", func() {
+		BeforeEach(func() {
+			repo.TakeCall.Returns.Items = []models.DigestItem{}
+		})
+
+		It("does not enqueue an empty digest", func() {
+			scheduler.Schedule()
+
+			Expect(queue.EnqueueCall.Receives.Jobs).To(BeEmpty())
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+		})
+	})
+
+	Describe("Halt", func() {
+		It("stops the scheduler once its current pass has finished", func() {
+			scheduler = postal.NewDigestScheduler(database, repo, queue, initializer, clock, time.Millisecond, lager.NewLogger("notifications"))
+			scheduler.Work()
+			scheduler.Halt()
+
+			frequencies := len(repo.FindDueCall.Receives.Frequencies)
+			Expect(frequencies).To(BeNumerically(">=", 2))
+			Expect(frequencies % 2).To(Equal(0))
+			Consistently(func() int {
+				return len(repo.FindDueCall.Receives.Frequencies)
+			}, 20*time.Millisecond).Should(Equal(frequencies))
+		})
+	})
+
+	Context("when finding the due digests fails", func() {
+		BeforeEach(func() {
+			repo.FindDueCall.Returns.Error = errors.New("database is down")
+		})
+
+		It("logs the error and schedules nothing", func() {
+			scheduler.Schedule()
+
+			Expect(repo.TakeCall.Receives.UserIDs).To(BeEmpty())
+			Expect(buffer.String()).To(ContainSubstring("digest-schedule-failed"))
+			Expect(buffer.String()).To(ContainSubstring("database is down"))
+		})
+	})
+
+	Context("when enqueueing the digest fails", func() {
+		BeforeEach(func() {
+			queue.EnqueueCall.Returns.Error = errors.New("queue is full")
+		})
+
+		It("rolls back so that the items are kept for the next run", func() {
+			scheduler.Schedule()
+
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+			Expect(buffer.String()).To(ContainSubstring("queue is full"))
+		})
+	})
+})
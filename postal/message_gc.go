@@ -12,20 +12,41 @@ type messagesDeleter interface {
 	DeleteBefore(models.ConnectionInterface, time.Time) (int, error)
 }
 
+type idempotencyKeysDeleter interface {
+	DeleteBefore(models.ConnectionInterface, time.Time) (int, error)
+}
+
+type sendRatesDeleter interface {
+	DeleteBefore(models.ConnectionInterface, time.Time) (int, error)
+}
+
+// sendRatesLifetime is how long the send counts of the throttle are kept.
+// Only the current second is counted in, the rest leaves room for the clocks
+// of the instances to differ.
+const sendRatesLifetime = time.Minute
+
 type MessageGC struct {
 	messages        messagesDeleter
+	idempotencyKeys idempotencyKeysDeleter
+	sendRates       sendRatesDeleter
 	db              db.DatabaseInterface
 	lifetime        time.Duration
+	keyLifetime     time.Duration
 	logger          *log.Logger
 	timer           <-chan time.Time
 	pollingInterval time.Duration
 }
 
-func NewMessageGC(lifetime time.Duration, db db.DatabaseInterface, messages messagesDeleter, pollingInterval time.Duration, logger *log.Logger) MessageGC {
+// NewMessageGC makes a collector that deletes messages after the lifetime,
+// and forgets idempotency keys after their own lifetime.
+func NewMessageGC(lifetime, keyLifetime time.Duration, db db.DatabaseInterface, messages messagesDeleter, idempotencyKeys idempotencyKeysDeleter, sendRates sendRatesDeleter, pollingInterval time.Duration, logger *log.Logger) MessageGC {
 	return MessageGC{
 		messages:        messages,
+		idempotencyKeys: idempotencyKeys,
+		sendRates:       sendRates,
 		db:              db,
 		lifetime:        lifetime,
+		keyLifetime:     keyLifetime,
 		logger:          logger,
 		pollingInterval: pollingInterval,
 		timer:           time.After(0),
@@ -34,10 +55,22 @@ func NewMessageGC(lifetime time.Duration, db db.DatabaseInterface, messages mess
 
 func (gc MessageGC) Collect() {
 	threshold := time.Now().Add(-1 * gc.lifetime)
-	_, err := gc.messages.DeleteBefore(gc.db.Connection(), threshold)
+	conn := gc.db.Connection()
+
+	_, err := gc.messages.DeleteBefore(conn, threshold)
 	if err != nil {
 		gc.logger.Printf("MessageGC.Collect() failed: " + err.Error())
 	}
+
+	_, err = gc.idempotencyKeys.DeleteBefore(conn, time.Now().Add(-1*gc.keyLifetime))
+	if err != nil {
+		gc.logger.Printf("MessageGC.Collect() failed to delete idempotency keys: " + err.Error())
+	}
+
+	_, err = gc.sendRates.DeleteBefore(conn, time.Now().Add(-1*sendRatesLifetime))
+	if err != nil {
+		gc.logger.Printf("MessageGC.Collect() failed to delete send rates: " + err.Error())
+	}
 }
 
 func (gc MessageGC) Run() {
@@ -18,10 +18,13 @@ var _ = Describe("MessageGC", func() {
 	var (
 		messageGC       postal.MessageGC
 		repo            *mocks.MessagesRepo
+		idempotencyKeys *mocks.IdempotencyKeysRepo
+		sendRates       *mocks.SendRatesRepo
 		database        *mocks.Database
 		conn            db.ConnectionInterface
 		loggerBuffer    *bytes.Buffer
 		lifetime        time.Duration
+		keyLifetime     time.Duration
 		pollingInterval time.Duration
 	)
 
@@ -34,11 +37,14 @@ var _ = Describe("MessageGC", func() {
 		database.ConnectionCall.Returns.Connection = conn
 
 		repo = mocks.NewMessagesRepo()
+		idempotencyKeys = mocks.NewIdempotencyKeysRepo()
+		sendRates = mocks.NewSendRatesRepo()
 
 		lifetime = 2 * time.Minute
+		keyLifetime = 5 * time.Minute
 		pollingInterval = 500 * time.Millisecond
 
-		messageGC = postal.NewMessageGC(lifetime, database, repo, pollingInterval, logger)
+		messageGC = postal.NewMessageGC(lifetime, keyLifetime, database, repo, idempotencyKeys, sendRates, pollingInterval, logger)
 	})
 
 	Describe("Run", func() {
@@ -64,6 +70,20 @@ var _ = Describe("MessageGC", func() {
 			Expect(repo.DeleteBeforeCall.Receives.ThresholdTime).To(BeTemporally("~", time.Now().Add(-2*time.Minute), 10*time.Second))
 		})
 
+		It("Deletes idempotency keys older than their own lifetime", func() {
+			messageGC.Collect()
+
+			Expect(idempotencyKeys.DeleteBeforeCall.Receives.Connection).To(Equal(conn))
+			Expect(idempotencyKeys.DeleteBeforeCall.Receives.ThresholdTime).To(BeTemporally("~", time.Now().Add(-5*time.Minute), 10*time.Second))
+		})
+
+		It("Deletes the send rates of windows older than a minute", func() {
+			messageGC.Collect()
+
+			Expect(sendRates.DeleteBeforeCall.Receives.Connection).To(Equal(conn))
+			Expect(sendRates.DeleteBeforeCall.Receives.ThresholdTime).To(BeTemporally("~", time.Now().Add(-1*time.Minute), 10*time.Second))
+		})
+
 		Context("When the repo errors unexpectantly", func() {
 			It("logs the error", func() {
 				repo.DeleteBeforeCall.Returns.Error = errors.New("messages table is totally corrupt")
@@ -72,6 +92,30 @@ var _ = Describe("MessageGC", func() {
 
 				Expect(loggerBuffer.String()).To(ContainSubstring("messages table is totally corrupt"))
 			})
+
+			It("still deletes the idempotency keys", func() {
+				repo.DeleteBeforeCall.Returns.Error = errors.New("messages table is totally corrupt")
+
+				messageGC.Collect()
+
+				Expect(idempotencyKeys.DeleteBeforeCall.CallCount).To(Equal(1))
+			})
+
+			It("logs idempotency key errors", func() {
+				idempotencyKeys.DeleteBeforeCall.Returns.Error = errors.New("idempotency keys table is totally corrupt")
+
+				messageGC.Collect()
+
+				Expect(loggerBuffer.String()).To(ContainSubstring("idempotency keys table is totally corrupt"))
+			})
+
+			It("logs send rate errors", func() {
+				sendRates.DeleteBeforeCall.Returns.Error = errors.New("send rates table is totally corrupt")
+
+				messageGC.Collect()
+
+				Expect(loggerBuffer.String()).To(ContainSubstring("send rates table is totally corrupt"))
+			})
 		})
 
 	})
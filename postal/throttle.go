@@ -0,0 +1,99 @@
+package postal
+
+import (
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/pivotal-golang/lager"
+	"github.com/rcrowley/go-metrics"
+)
+
+type sendRatesRepo interface {
+	Increment(models.ConnectionInterface, string, time.Time) (int, error)
+}
+
+type clock interface {
+	Now() time.Time
+}
+
+type ThrottleConfig struct {
+	MessagesPerSecond       int
+	DomainMessagesPerSecond int
+}
+
+// Throttle keeps the delivery workers from sending messages faster than the
+// mail server accepts them. Sends are counted per second in the database, so
+// that every instance shares the same limits. A limit of zero means there is
+// no limit.
+type Throttle struct {
+	config   ThrottleConfig
+	database db.DatabaseInterface
+	repo     sendRatesRepo
+	clock    clock
+	sleep    func(time.Duration)
+}
+
+func NewThrottle(config ThrottleConfig, database db.DatabaseInterface, repo sendRatesRepo, clock clock, sleep func(time.Duration)) Throttle {
+	metrics.GetOrRegisterGauge("notifications.throttle.limit", nil).Update(int64(config.MessagesPerSecond))
+	metrics.GetOrRegisterGauge("notifications.throttle.domain-limit", nil).Update(int64(config.DomainMessagesPerSecond))
+
+	return Throttle{
+		config:   config,
+		database: database,
+		repo:     repo,
+		clock:    clock,
+		sleep:    sleep,
+	}
+}
+
+// Wait blocks until a message may be sent to the recipient. The recipient's
+// domain is waited on first, so that a send does not hold on to a share of
+// the global limit while its domain is busy. When the sends cannot be
+// counted, the message is let through rather than held up indefinitely.
+func (t Throttle) Wait(recipient string, logger lager.Logger) {
+	start := t.clock.Now()
+
+	if t.config.DomainMessagesPerSecond > 0 {
+		domain := recipientDomain(recipient)
+		if domain != "" {
+			t.acquire(models.SendRateScopeForDomain(domain), t.config.DomainMessagesPerSecond, logger)
+		}
+	}
+
+	if t.config.MessagesPerSecond > 0 {
+		t.acquire(models.SendRateScopeGlobal, t.config.MessagesPerSecond, logger)
+	}
+
+	metrics.GetOrRegisterTimer("notifications.throttle.wait", nil).Update(t.clock.Now().Sub(start))
+}
+
+func (t Throttle) acquire(scope string, limit int, logger lager.Logger) {
+	for {
+		now := t.clock.Now().UTC()
+		windowStart := now.Truncate(time.Second)
+
+		count, err := t.repo.Increment(t.database.Connection(), scope, windowStart)
+		if err != nil {
+			logger.Error("throttle-failed", err, lager.Data{"scope": scope})
+			return
+		}
+
+		if count <= limit {
+			return
+		}
+
+		logger.Debug("throttled", lager.Data{"scope": scope})
+		t.sleep(windowStart.Add(time.Second).Sub(now))
+	}
+}
+
+func recipientDomain(recipient string) string {
+	index := strings.LastIndex(recipient, "@")
+	if index < 0 {
+		return ""
+	}
+
+	return strings.ToLower(recipient[index+1:])
+}
@@ -0,0 +1,152 @@
+package postal_test
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/postal"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/pivotal-golang/lager"
+	"github.com/rcrowley/go-metrics"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Throttle", func() {
+	var (
+		throttle postal.Throttle
+		repo     *mocks.SendRatesRepo
+		database *mocks.Database
+		conn     *mocks.Connection
+		clock    *mocks.Clock
+		sleeps   []time.Duration
+		logger   lager.Logger
+		buffer   *bytes.Buffer
+		now      time.Time
+		config   postal.ThrottleConfig
+	)
+
+	BeforeEach(func() {
+		repo = mocks.NewSendRatesRepo()
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		now = time.Date(2015, time.June, 1, 12, 0, 0, 250*int(time.Millisecond), time.UTC)
+		clock = mocks.NewClock()
+		clock.NowCall.Returns.Time = now
+
+		sleeps = []time.Duration{}
+
+		buffer = bytes.NewBuffer([]byte{})
+		logger = lager.NewLogger("notifications")
+		logger.RegisterSink(lager.NewWriterSink(buffer, lager.DEBUG))
+
+		config = postal.ThrottleConfig{
+			MessagesPerSecond: 50,
+		}
+	})
+
+	JustBeforeEach(func() {
+		throttle = postal.NewThrottle(config, database, repo, clock, func(duration time.Duration) {
+			sleeps = append(sleeps, duration)
+		})
+	})
+
+	It("exports the limits as metrics", func() {
+		config.DomainMessagesPerSecond = 5
+		postal.NewThrottle(config, database, repo, clock, nil)
+
+		Expect(metrics.GetOrRegisterGauge("notifications.throttle.limit", nil).Value()).To(Equal(int64(50)))
+		Expect(metrics.GetOrRegisterGauge("notifications.throttle.domain-limit", nil).Value()).To(Equal(int64(5)))
+	})
+
+	It("records how long the sends wait", func() {
+		before := metrics.GetOrRegisterTimer("notifications.throttle.wait", nil).Count()
+
+		throttle.Wait("user@example.com", logger)
+
+		Expect(metrics.GetOrRegisterTimer("notifications.throttle.wait", nil).Count()).To(Equal(before + 1))
+	})
+
+	Context("when the limit has not been reached", func() {
+		BeforeEach(func() {
+			repo.IncrementCall.Returns.Counts = []int{50}
+		})
+
+		It("counts the send in the current second without waiting", func() {
+			throttle.Wait("user@example.com", logger)
+
+			Expect(repo.IncrementCall.Receives.Connection).To(Equal(conn))
+			Expect(repo.IncrementCall.Receives.Scopes).To(Equal([]string{models.SendRateScopeGlobal}))
+			Expect(repo.IncrementCall.Receives.WindowStarts).To(Equal([]time.Time{now.Truncate(time.Second)}))
+			Expect(sleeps).To(BeEmpty())
+		})
+	})
+
+	Context("when the limit has been reached", func() {
+		BeforeEach(func() {
+			repo.IncrementCall.Returns.Counts = []int{51, 52, 1}
+		})
+
+		It("sleeps until the next second and tries again", func() {
+			throttle.Wait("user@example.com", logger)
+
+			Expect(repo.IncrementCall.CallCount).To(Equal(3))
+			Expect(sleeps).To(Equal([]time.Duration{750 * time.Millisecond, 750 * time.Millisecond}))
+		})
+	})
+
+	Context("when there is no limit", func() {
+		BeforeEach(func() {
+			config.MessagesPerSecond = 0
+		})
+
+		It("does not count the send", func() {
+			throttle.Wait("user@example.com", logger)
+
+			Expect(repo.IncrementCall.CallCount).To(Equal(0))
+		})
+	})
+
+	Context("when there is a limit per recipient domain", func() {
+		BeforeEach(func() {
+			config.DomainMessagesPerSecond = 5
+			repo.IncrementCall.Returns.Counts = []int{6, 5, 1}
+		})
+
+		It("waits for the recipient's domain before the global limit", func() {
+			throttle.Wait("user@Example.com", logger)
+
+			Expect(repo.IncrementCall.Receives.Scopes).To(Equal([]string{
+				models.SendRateScopeForDomain("example.com"),
+				models.SendRateScopeForDomain("example.com"),
+				models.SendRateScopeGlobal,
+			}))
+			Expect(sleeps).To(HaveLen(1))
+		})
+
+		It("only applies the global limit when the recipient has no domain", func() {
+			throttle.Wait("user", logger)
+
+			Expect(repo.IncrementCall.Receives.Scopes).To(Equal([]string{models.SendRateScopeGlobal}))
+		})
+	})
+
+	Context("when the send cannot be counted", func() {
+		BeforeEach(func() {
+			repo.IncrementCall.Returns.Error = errors.New("database is down")
+		})
+
+		It("lets the send through and logs the error", func() {
+			throttle.Wait("user@example.com", logger)
+
+			Expect(sleeps).To(BeEmpty())
+			Expect(buffer.String()).To(ContainSubstring("throttle-failed"))
+			Expect(buffer.String()).To(ContainSubstring("database is down"))
+		})
+	})
+})
@@ -1,7 +1,10 @@
 package v1
 
 import (
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/db"
 	"github.com/cloudfoundry-incubator/notifications/gobble"
@@ -17,9 +20,12 @@ type tokenLoader interface {
 	Load(string) (string, error)
 }
 
-type mailSender interface {
-	Connect(lager.Logger) error
-	Send(mail.Message, lager.Logger) error
+type transportSelector interface {
+	Select(clientID, kindID string) mail.Transport
+}
+
+type throttle interface {
+	Wait(recipient string, logger lager.Logger)
 }
 
 type userLoader interface {
@@ -27,11 +33,11 @@ type userLoader interface {
 }
 
 type messageStatusUpdater interface {
-	Update(conn db.ConnectionInterface, messageID, messageStatus, campaignID string, logger lager.Logger)
+	Update(conn db.ConnectionInterface, messageID, messageStatus, campaignID string, attempt common.DeliveryAttempt, logger lager.Logger)
 }
 
 type deliveryFailureHandler interface {
-	Handle(job common.Retryable, logger lager.Logger)
+	Handle(job common.Retryable, cause error, logger lager.Logger)
 }
 
 type kindsFinder interface {
@@ -50,6 +56,31 @@ type globalUnsubscribesGetter interface {
 	Get(connection models.ConnectionInterface, userGUID string) (bool, error)
 }
 
+type deliveryFrequencyGetter interface {
+	Get(connection models.ConnectionInterface, userID, clientID, kindID string) (string, error)
+}
+
+type digestItemsCreator interface {
+	Create(connection models.ConnectionInterface, item models.DigestItem) (models.DigestItem, error)
+}
+
+type quietHoursGetter interface {
+	Get(connection models.ConnectionInterface, userID string) (models.QuietHours, error)
+}
+
+type userLocaleGetter interface {
+	Get(connection models.ConnectionInterface, userID string) (string, error)
+}
+
+type clock interface {
+	Now() time.Time
+}
+
+type suppressionsRepo interface {
+	IsSuppressed(connection models.ConnectionInterface, email string) (bool, error)
+	Upsert(connection models.ConnectionInterface, suppression models.Suppression) (models.Suppression, error)
+}
+
 type DeliveryJobProcessorConfig struct {
 	DBTrace bool
 	UAAHost string
@@ -57,7 +88,8 @@ type DeliveryJobProcessorConfig struct {
 	Domain  string
 
 	Packager    common.Packager
-	MailClient  mailSender
+	Transports  transportSelector
+	Throttle    throttle
 	Database    db.DatabaseInterface
 	TokenLoader tokenLoader
 	UserLoader  userLoader
@@ -66,8 +98,15 @@ type DeliveryJobProcessorConfig struct {
 	ReceiptsRepo           receiptsCreator
 	UnsubscribesRepo       unsubscribesGetter
 	GlobalUnsubscribesRepo globalUnsubscribesGetter
+	SuppressionsRepo       suppressionsRepo
 	MessageStatusUpdater   messageStatusUpdater
 	DeliveryFailureHandler deliveryFailureHandler
+
+	DeliveryFrequenciesRepo deliveryFrequencyGetter
+	DigestItemsRepo         digestItemsCreator
+	QuietHoursRepo          quietHoursGetter
+	UserLocalesRepo         userLocaleGetter
+	Clock                   clock
 }
 
 type DeliveryJobProcessor struct {
@@ -77,7 +116,8 @@ type DeliveryJobProcessor struct {
 	domain  string
 
 	packager    common.Packager
-	mailClient  mailSender
+	transports  transportSelector
+	throttle    throttle
 	database    db.DatabaseInterface
 	tokenLoader tokenLoader
 	userLoader  userLoader
@@ -86,8 +126,15 @@ type DeliveryJobProcessor struct {
 	receiptsRepo           receiptsCreator
 	unsubscribesRepo       unsubscribesGetter
 	globalUnsubscribesRepo globalUnsubscribesGetter
+	suppressionsRepo       suppressionsRepo
 	messageStatusUpdater   messageStatusUpdater
 	deliveryFailureHandler deliveryFailureHandler
+
+	deliveryFrequenciesRepo deliveryFrequencyGetter
+	digestItemsRepo         digestItemsCreator
+	quietHoursRepo          quietHoursGetter
+	userLocalesRepo         userLocaleGetter
+	clock                   clock
 }
 
 func NewDeliveryJobProcessor(config DeliveryJobProcessorConfig) DeliveryJobProcessor {
@@ -98,7 +145,8 @@ func NewDeliveryJobProcessor(config DeliveryJobProcessorConfig) DeliveryJobProce
 		domain:  config.Domain,
 
 		packager:    config.Packager,
-		mailClient:  config.MailClient,
+		transports:  config.Transports,
+		throttle:    config.Throttle,
 		database:    config.Database,
 		tokenLoader: config.TokenLoader,
 		userLoader:  config.UserLoader,
@@ -107,8 +155,15 @@ func NewDeliveryJobProcessor(config DeliveryJobProcessorConfig) DeliveryJobProce
 		receiptsRepo:           config.ReceiptsRepo,
 		unsubscribesRepo:       config.UnsubscribesRepo,
 		globalUnsubscribesRepo: config.GlobalUnsubscribesRepo,
+		suppressionsRepo:       config.SuppressionsRepo,
 		messageStatusUpdater:   config.MessageStatusUpdater,
 		deliveryFailureHandler: config.DeliveryFailureHandler,
+
+		deliveryFrequenciesRepo: config.DeliveryFrequenciesRepo,
+		digestItemsRepo:         config.DigestItemsRepo,
+		quietHoursRepo:          config.QuietHoursRepo,
+		userLocalesRepo:         config.UserLocalesRepo,
+		clock:                   config.Clock,
 	}
 }
 
@@ -118,7 +173,7 @@ func (p DeliveryJobProcessor) Process(job *gobble.Job, logger lager.Logger) erro
 	if err != nil {
 		metrics.GetOrRegisterCounter("notifications.worker.panic.json", nil).Inc(1)
 
-		p.deliveryFailureHandler.Handle(job, logger)
+		p.deliveryFailureHandler.Handle(job, err, logger)
 		return nil
 	}
 
@@ -131,9 +186,25 @@ func (p DeliveryJobProcessor) Process(job *gobble.Job, logger lager.Logger) erro
 		p.database.TraceOn("", gorpCompatibleLogger{logger})
 	}
 
+	// The quiet hours are checked first, so that a deferred job does not
+	// record its receipt again each time it is picked up.
+	critical := p.isCritical(p.database.Connection(), delivery.Options.KindID, delivery.ClientID)
+	frequency := p.frequency(delivery, critical, logger)
+	if frequency == models.FrequencyImmediate {
+		if until, ok := p.quietUntil(delivery, critical, logger); ok {
+			job.Defer(until)
+			logger.Info("delivery-deferred", lager.Data{
+				"active_at": until.Format(time.RFC3339),
+			})
+
+			metrics.GetOrRegisterCounter("notifications.worker.deferred", nil).Inc(1)
+			return nil
+		}
+	}
+
 	err = p.receiptsRepo.CreateReceipts(p.database.Connection(), []string{delivery.UserGUID}, delivery.ClientID, delivery.Options.KindID)
 	if err != nil {
-		p.deliveryFailureHandler.Handle(job, logger)
+		p.deliveryFailureHandler.Handle(job, err, logger)
 		return nil
 	}
 
@@ -142,13 +213,18 @@ func (p DeliveryJobProcessor) Process(job *gobble.Job, logger lager.Logger) erro
 
 		token, err = p.tokenLoader.Load(p.uaaHost)
 		if err != nil {
-			p.deliveryFailureHandler.Handle(job, logger)
+			p.deliveryFailureHandler.Handle(job, err, logger)
 			return nil
 		}
 
 		users, err := p.userLoader.Load([]string{delivery.UserGUID}, token)
-		if err != nil || len(users) < 1 {
-			p.deliveryFailureHandler.Handle(job, logger)
+		if err != nil {
+			p.deliveryFailureHandler.Handle(job, err, logger)
+			return nil
+		}
+
+		if len(users) < 1 {
+			p.deliveryFailureHandler.Handle(job, fmt.Errorf("user %q could not be loaded", delivery.UserGUID), logger)
 			return nil
 		}
 
@@ -162,14 +238,39 @@ func (p DeliveryJobProcessor) Process(job *gobble.Job, logger lager.Logger) erro
 		"recipient": delivery.Email,
 	})
 
-	if p.shouldDeliver(delivery, logger) {
-		status := p.process(delivery, logger)
+	delivery.Options.Locale = p.locale(delivery, logger)
+
+	retryCount, _ := job.State()
+	attempt := common.DeliveryAttempt{
+		Recipient:  delivery.Email,
+		RetryCount: retryCount,
+	}
 
-		if status != common.StatusDelivered {
-			p.deliveryFailureHandler.Handle(job, logger)
+	if p.shouldDeliver(delivery, critical, attempt, logger) {
+		if frequency != models.FrequencyImmediate {
+			status, err := p.digest(delivery, frequency, attempt, logger)
+
+			switch status {
+			case common.StatusDigested:
+				metrics.GetOrRegisterCounter("notifications.worker.digested", nil).Inc(1)
+			case common.StatusUndeliverable:
+				metrics.GetOrRegisterCounter("notifications.worker.undeliverable", nil).Inc(1)
+			default:
+				p.deliveryFailureHandler.Handle(job, err, logger)
+			}
 			return nil
-		} else {
+		}
+
+		status, err := p.process(delivery, critical, attempt, logger)
+
+		switch status {
+		case common.StatusDelivered:
 			metrics.GetOrRegisterCounter("notifications.worker.delivered", nil).Inc(1)
+		case common.StatusUndeliverable:
+			metrics.GetOrRegisterCounter("notifications.worker.undeliverable", nil).Inc(1)
+		default:
+			p.deliveryFailureHandler.Handle(job, err, logger)
+			return nil
 		}
 	} else {
 		metrics.GetOrRegisterCounter("notifications.worker.unsubscribed", nil).Inc(1)
@@ -178,78 +279,228 @@ func (p DeliveryJobProcessor) Process(job *gobble.Job, logger lager.Logger) erro
 	return nil
 }
 
-func (p DeliveryJobProcessor) process(delivery common.Delivery, logger lager.Logger) string {
+func (p DeliveryJobProcessor) process(delivery common.Delivery, critical bool, attempt common.DeliveryAttempt, logger lager.Logger) (string, error) {
 	context, err := p.packager.PrepareContext(delivery, p.sender, p.domain)
 	if err != nil {
 		panic(err)
 	}
 
+	// Critical notifications cannot be unsubscribed from, so they do not
+	// offer an unsubscribe link.
+	if critical {
+		context.UnsubscribeURL = ""
+	}
+
+	// A template that cannot be parsed or executed fails the same way on
+	// every retry, so the message is not retried.
 	message, err := p.packager.Pack(context)
 	if err != nil {
 		logger.Info("template-pack-failed")
-		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusFailed, "", logger)
-		return common.StatusFailed
+		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusUndeliverable, "", attempt, logger)
+		return common.StatusUndeliverable, err
+	}
+
+	transport := p.transports.Select(delivery.ClientID, delivery.Options.KindID)
+	status, err := sendMail(transport, p.throttle, message, logger)
+	p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, status, "", attempt.WithSMTPError(err), logger)
+
+	var smtpError mail.SMTPError
+	if errors.As(err, &smtpError) && smtpError.RecipientRejected() {
+		p.suppress(delivery.Email, smtpError, logger)
+	}
+
+	return status, err
+}
+
+// frequency returns how often the recipient wants to receive notifications
+// of the kind. Critical notifications, and notifications sent to an email
+// address rather than a user, are always delivered immediately.
+func (p DeliveryJobProcessor) frequency(delivery common.Delivery, critical bool, logger lager.Logger) string {
+	if critical || delivery.UserGUID == "" {
+		return models.FrequencyImmediate
+	}
+
+	frequency, err := p.deliveryFrequenciesRepo.Get(p.database.Connection(), delivery.UserGUID, delivery.ClientID, delivery.Options.KindID)
+	if err != nil {
+		logger.Error("delivery-frequency-unavailable", err)
+		return models.FrequencyImmediate
+	}
+
+	return frequency
+}
+
+// quietUntil returns when the quiet hours of the recipient end, if the
+// notification would otherwise be sent during them. Critical notifications
+// are sent regardless.
+func (p DeliveryJobProcessor) quietUntil(delivery common.Delivery, critical bool, logger lager.Logger) (time.Time, bool) {
+	if critical || delivery.UserGUID == "" {
+		return time.Time{}, false
+	}
+
+	quietHours, err := p.quietHoursRepo.Get(p.database.Connection(), delivery.UserGUID)
+	if err != nil {
+		logger.Error("quiet-hours-unavailable", err)
+		return time.Time{}, false
+	}
+
+	until, ok := quietHours.Until(p.clock.Now())
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return until.UTC(), true
+}
+
+// locale returns the locale the notification is rendered in, which is the
+// one it was sent with or else the one the recipient prefers. Notifications
+// with neither are rendered in the default locale.
+func (p DeliveryJobProcessor) locale(delivery common.Delivery, logger lager.Logger) string {
+	if delivery.Options.Locale != "" || delivery.UserGUID == "" {
+		return delivery.Options.Locale
+	}
+
+	locale, err := p.userLocalesRepo.Get(p.database.Connection(), delivery.UserGUID)
+	if err != nil {
+		logger.Error("user-locale-unavailable", err)
+		return ""
+	}
+
+	return locale
+}
+
+// digest renders the notification and stores it for the recipient's next
+// digest instead of sending it.
+func (p DeliveryJobProcessor) digest(delivery common.Delivery, frequency string, attempt common.DeliveryAttempt, logger lager.Logger) (string, error) {
+	context, err := p.packager.PrepareContext(delivery, p.sender, p.domain)
+	if err != nil {
+		return common.StatusFailed, err
+	}
+
+	item, err := p.packager.CompileDigestItem(context)
+	if err != nil {
+		logger.Info("template-pack-failed")
+		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusUndeliverable, "", attempt, logger)
+		return common.StatusUndeliverable, err
 	}
 
-	status := p.sendMail(delivery.MessageID, message, logger)
-	p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, status, "", logger)
+	_, err = p.digestItemsRepo.Create(p.database.Connection(), models.DigestItem{
+		UserID:            delivery.UserGUID,
+		Email:             delivery.Email,
+		Frequency:         frequency,
+		MessageID:         item.MessageID,
+		ClientID:          item.ClientID,
+		KindID:            delivery.Options.KindID,
+		SourceDescription: item.SourceDescription,
+		KindDescription:   item.KindDescription,
+		Subject:           item.Subject,
+		Text:              item.Text,
+		HTML:              item.HTML,
+	})
+	if err != nil {
+		return common.StatusFailed, err
+	}
+
+	p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusDigested, "", attempt, logger)
+	logger.Info("message-digested", lager.Data{"frequency": frequency})
+
+	return common.StatusDigested, nil
+}
+
+func (p DeliveryJobProcessor) suppress(email string, smtpError mail.SMTPError, logger lager.Logger) {
+	_, err := p.suppressionsRepo.Upsert(p.database.Connection(), models.Suppression{
+		Email:  email,
+		Reason: models.SuppressionReasonBounce,
+		Detail: smtpError.Error(),
+	})
+	if err != nil {
+		logger.Error("suppression-failed", err)
+		return
+	}
 
-	return status
+	logger.Info("recipient-suppressed")
 }
 
-func (p DeliveryJobProcessor) shouldDeliver(delivery common.Delivery, logger lager.Logger) bool {
+func (p DeliveryJobProcessor) shouldDeliver(delivery common.Delivery, critical bool, attempt common.DeliveryAttempt, logger lager.Logger) bool {
 	conn := p.database.Connection()
-	if p.isCritical(conn, delivery.Options.KindID, delivery.ClientID) {
-		return true
+	if critical {
+		return !p.isSuppressed(conn, delivery, attempt, logger)
 	}
 
 	globallyUnsubscribed, err := p.globalUnsubscribesRepo.Get(conn, delivery.UserGUID)
 	if err != nil || globallyUnsubscribed {
 		logger.Info("user-unsubscribed")
-		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusUndeliverable, "", logger)
+		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusUndeliverable, "", attempt, logger)
 		return false
 	}
 
 	isUnsubscribed, err := p.unsubscribesRepo.Get(conn, delivery.UserGUID, delivery.ClientID, delivery.Options.KindID)
 	if err != nil || isUnsubscribed {
 		logger.Info("user-unsubscribed")
-		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusUndeliverable, "", logger)
+		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusUndeliverable, "", attempt, logger)
 		return false
 	}
 
 	if delivery.Email == "" {
 		logger.Info("no-email-address-for-user")
-		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusUndeliverable, "", logger)
+		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusUndeliverable, "", attempt, logger)
 		return false
 	}
 
 	if !strings.Contains(delivery.Email, "@") {
 		logger.Info("malformatted-email-address")
-		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusUndeliverable, "", logger)
+		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusUndeliverable, "", attempt, logger)
 		return false
 	}
 
-	return true
+	return !p.isSuppressed(conn, delivery, attempt, logger)
 }
 
-func (p DeliveryJobProcessor) sendMail(messageID string, message mail.Message, logger lager.Logger) string {
-	err := p.mailClient.Connect(logger)
+// isSuppressed is consulted for critical notifications too, since an address
+// that bounced or complained will not accept them either.
+func (p DeliveryJobProcessor) isSuppressed(conn db.ConnectionInterface, delivery common.Delivery, attempt common.DeliveryAttempt, logger lager.Logger) bool {
+	if delivery.Email == "" {
+		return false
+	}
+
+	suppressed, err := p.suppressionsRepo.IsSuppressed(conn, delivery.Email)
+	if err != nil || suppressed {
+		logger.Info("recipient-suppressed")
+		p.messageStatusUpdater.Update(p.database.Connection(), delivery.MessageID, common.StatusUndeliverable, "", attempt, logger)
+		return true
+	}
+
+	return false
+}
+
+func sendMail(transport mail.Transport, throttle throttle, message mail.Message, logger lager.Logger) (string, error) {
+	// Only a mail server limits how fast it accepts messages.
+	if _, ok := transport.(mail.Unthrottled); !ok {
+		throttle.Wait(message.To, logger)
+	}
+
+	err := transport.Connect(logger)
 	if err != nil {
 		logger.Error("smtp-connection-error", err)
-		return common.StatusFailed
+		return common.StatusFailed, err
 	}
 
 	logger.Info("delivery-start")
 
-	err = p.mailClient.Send(message, logger)
+	err = transport.Send(message, logger)
 	if err != nil {
 		logger.Error("delivery-failed-smtp-error", err)
-		return common.StatusFailed
+
+		var smtpError mail.SMTPError
+		if errors.As(err, &smtpError) && smtpError.Permanent() {
+			return common.StatusUndeliverable, err
+		}
+
+		return common.StatusFailed, err
 	}
 
 	logger.Info("message-sent")
 
-	return common.StatusDelivered
+	return common.StatusDelivered, nil
 }
 
 func (p DeliveryJobProcessor) isCritical(conn db.ConnectionInterface, kindID, clientID string) bool {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/md5"
 	"errors"
+	"net/textproto"
 	"strings"
 	"time"
 
@@ -24,12 +25,15 @@ import (
 var _ = Describe("DeliveryJobProcessor", func() {
 	var (
 		mailClient             *mocks.MailClient
+		transports             *mocks.TransportSelector
+		throttle               *mocks.Throttle
 		processor              v1.DeliveryJobProcessor
 		logger                 lager.Logger
 		buffer                 *bytes.Buffer
 		delivery               common.Delivery
 		unsubscribesRepo       *mocks.UnsubscribesRepo
 		globalUnsubscribesRepo *mocks.GlobalUnsubscribesRepo
+		suppressionsRepo       *mocks.SuppressionsRepo
 		kindsRepo              *mocks.KindsRepo
 		database               *mocks.Database
 		conn                   *mocks.Connection
@@ -42,6 +46,12 @@ var _ = Describe("DeliveryJobProcessor", func() {
 		messageID              string
 		messageStatusUpdater   *mocks.MessageStatusUpdater
 		deliveryFailureHandler *mocks.DeliveryFailureHandler
+		frequenciesRepo        *mocks.DeliveryFrequenciesRepo
+		digestItemsRepo        *mocks.DigestItemsRepo
+		quietHoursRepo         *mocks.QuietHoursRepo
+		userLocalesRepo        *mocks.UserLocalesRepo
+		clock                  *mocks.Clock
+		now                    time.Time
 	)
 
 	BeforeEach(func() {
@@ -52,8 +62,12 @@ var _ = Describe("DeliveryJobProcessor", func() {
 		logger = logger.Session("worker", lager.Data{"worker_id": 1234})
 
 		mailClient = mocks.NewMailClient()
+		transports = mocks.NewTransportSelector()
+		transports.SelectCall.Returns.Transport = mailClient
+		throttle = mocks.NewThrottle()
 		unsubscribesRepo = mocks.NewUnsubscribesRepo()
 		globalUnsubscribesRepo = mocks.NewGlobalUnsubscribesRepo()
+		suppressionsRepo = mocks.NewSuppressionsRepo()
 
 		kindsRepo = mocks.NewKindsRepo()
 		kindsRepo.FindCall.Returns.Kinds = []models.Kind{
@@ -87,6 +101,14 @@ var _ = Describe("DeliveryJobProcessor", func() {
 		receiptsRepo = mocks.NewReceiptsRepo()
 		messageStatusUpdater = mocks.NewMessageStatusUpdater()
 		deliveryFailureHandler = mocks.NewDeliveryFailureHandler()
+		frequenciesRepo = mocks.NewDeliveryFrequenciesRepo()
+		frequenciesRepo.GetCall.Returns.Frequency = models.FrequencyImmediate
+		digestItemsRepo = mocks.NewDigestItemsRepo()
+		quietHoursRepo = mocks.NewQuietHoursRepo()
+		userLocalesRepo = mocks.NewUserLocalesRepo()
+		now = time.Date(2015, time.June, 1, 23, 30, 0, 0, time.UTC)
+		clock = mocks.NewClock()
+		clock.NowCall.Returns.Time = now
 
 		cloak, err := conceal.NewCloak(encryptionKey)
 		Expect(err).NotTo(HaveOccurred())
@@ -97,8 +119,9 @@ var _ = Describe("DeliveryJobProcessor", func() {
 			Sender:  "from@example.com",
 			Domain:  "example.com",
 
-			Packager:    common.NewPackager(templateLoader, cloak),
-			MailClient:  mailClient,
+			Packager:    common.NewPackager(templateLoader, cloak, "https://notifications.example.com", true),
+			Transports:  transports,
+			Throttle:    throttle,
 			Database:    database,
 			TokenLoader: tokenLoader,
 			UserLoader:  userLoader,
@@ -107,8 +130,15 @@ var _ = Describe("DeliveryJobProcessor", func() {
 			ReceiptsRepo:           receiptsRepo,
 			UnsubscribesRepo:       unsubscribesRepo,
 			GlobalUnsubscribesRepo: globalUnsubscribesRepo,
+			SuppressionsRepo:       suppressionsRepo,
 			MessageStatusUpdater:   messageStatusUpdater,
 			DeliveryFailureHandler: deliveryFailureHandler,
+
+			DeliveryFrequenciesRepo: frequenciesRepo,
+			DigestItemsRepo:         digestItemsRepo,
+			QuietHoursRepo:          quietHoursRepo,
+			UserLocalesRepo:         userLocalesRepo,
+			Clock:                   clock,
 		})
 
 		messageID = "randomly-generated-guid"
@@ -162,6 +192,41 @@ var _ = Describe("DeliveryJobProcessor", func() {
 			Expect(templateLoader.LoadTemplatesCall.Receives.TemplateID).To(Equal("some-template-id"))
 		})
 
+		Context("when the recipient has a locale", func() {
+			BeforeEach(func() {
+				userLocalesRepo.GetCall.Returns.Locale = "pt-BR"
+			})
+
+			It("loads the template in the locale of the recipient", func() {
+				processor.Process(job, logger)
+
+				Expect(userLocalesRepo.GetCall.Receives.Connection).To(Equal(conn))
+				Expect(userLocalesRepo.GetCall.Receives.UserID).To(Equal("user-123"))
+				Expect(templateLoader.LoadTemplatesCall.Receives.Locale).To(Equal("pt-BR"))
+			})
+
+			It("loads the template in the locale the notification was sent with instead, when there is one", func() {
+				delivery.Options.Locale = "fr"
+				job = gobble.NewJob(delivery)
+
+				processor.Process(job, logger)
+
+				Expect(userLocalesRepo.GetCall.WasCalled).To(BeFalse())
+				Expect(templateLoader.LoadTemplatesCall.Receives.Locale).To(Equal("fr"))
+			})
+
+			Context("and the locale cannot be loaded", func() {
+				It("sends the email in the default locale", func() {
+					userLocalesRepo.GetCall.Returns.Error = errors.New("database is down")
+
+					processor.Process(job, logger)
+
+					Expect(templateLoader.LoadTemplatesCall.Receives.Locale).To(BeEmpty())
+					Expect(mailClient.SendCall.CallCount).To(Equal(1))
+				})
+			})
+		})
+
 		It("logs successful delivery", func() {
 			processor.Process(job, logger)
 
@@ -193,8 +258,9 @@ var _ = Describe("DeliveryJobProcessor", func() {
 				Sender:  "from@example.com",
 				Domain:  "example.com",
 
-				Packager:    common.NewPackager(templateLoader, cloak),
-				MailClient:  mailClient,
+				Packager:    common.NewPackager(templateLoader, cloak, "", false),
+				Transports:  transports,
+				Throttle:    throttle,
 				Database:    database,
 				TokenLoader: tokenLoader,
 				UserLoader:  userLoader,
@@ -203,8 +269,15 @@ var _ = Describe("DeliveryJobProcessor", func() {
 				ReceiptsRepo:           receiptsRepo,
 				UnsubscribesRepo:       unsubscribesRepo,
 				GlobalUnsubscribesRepo: globalUnsubscribesRepo,
+				SuppressionsRepo:       suppressionsRepo,
 				MessageStatusUpdater:   messageStatusUpdater,
 				DeliveryFailureHandler: deliveryFailureHandler,
+
+				DeliveryFrequenciesRepo: frequenciesRepo,
+				DigestItemsRepo:         digestItemsRepo,
+				QuietHoursRepo:          quietHoursRepo,
+				UserLocalesRepo:         userLocalesRepo,
+				Clock:                   clock,
 			})
 			processor.Process(job, logger)
 
@@ -224,9 +297,20 @@ var _ = Describe("DeliveryJobProcessor", func() {
 			Expect(messageStatusUpdater.UpdateCall.Receives.Connection).To(Equal(conn))
 			Expect(messageStatusUpdater.UpdateCall.Receives.MessageID).To(Equal(messageID))
 			Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusDelivered))
+			Expect(messageStatusUpdater.UpdateCall.Receives.Attempt).To(Equal(common.DeliveryAttempt{
+				Recipient:  "user-123@example.com",
+				RetryCount: 0,
+			}))
 			Expect(messageStatusUpdater.UpdateCall.Receives.Logger.SessionName()).To(Equal("notifications.worker"))
 		})
 
+		It("records the retry count of the job with the status update", func() {
+			job.RetryCount = 4
+			processor.Process(job, logger)
+
+			Expect(messageStatusUpdater.UpdateCall.Receives.Attempt.RetryCount).To(Equal(4))
+		})
+
 		It("creates a reciept for the delivery", func() {
 			processor.Process(job, logger)
 
@@ -242,6 +326,7 @@ var _ = Describe("DeliveryJobProcessor", func() {
 				processor.Process(job, logger)
 
 				Expect(deliveryFailureHandler.HandleCall.Receives.Job).To(Equal(job))
+				Expect(deliveryFailureHandler.HandleCall.Receives.Cause).To(MatchError("something happened"))
 				Expect(deliveryFailureHandler.HandleCall.Receives.Logger.SessionName()).To(Equal("notifications.worker"))
 			})
 		})
@@ -254,6 +339,7 @@ var _ = Describe("DeliveryJobProcessor", func() {
 				processor.Process(job, logger)
 
 				Expect(deliveryFailureHandler.HandleCall.Receives.Job).To(Equal(job))
+				Expect(deliveryFailureHandler.HandleCall.Receives.Cause).To(MatchError("failed to load a zoned UAA token"))
 				Expect(deliveryFailureHandler.HandleCall.Receives.Logger.SessionName()).To(Equal("notifications.worker"))
 			})
 		})
@@ -291,6 +377,59 @@ var _ = Describe("DeliveryJobProcessor", func() {
 			Expect(timestamp).To(BeTemporally("~", time.Now(), 2*time.Second))
 		})
 
+		It("offers a one-click unsubscribe from the kind", func() {
+			processor.Process(job, logger)
+
+			Expect(mailClient.SendCall.CallCount).To(Equal(1))
+			msg := mailClient.SendCall.Receives.Message
+			Expect(msg.Headers).To(ContainElement(HavePrefix("List-Unsubscribe: <https://notifications.example.com/unsubscribe/")))
+			Expect(msg.Headers).To(ContainElement("List-Unsubscribe-Post: List-Unsubscribe=One-Click"))
+		})
+
+		It("does not offer to unsubscribe from critical notifications", func() {
+			kindsRepo.FindCall.Returns.Kinds = []models.Kind{
+				{
+					ID:       "some-kind",
+					ClientID: "some-client",
+					Critical: true,
+				},
+			}
+
+			processor.Process(job, logger)
+
+			Expect(mailClient.SendCall.CallCount).To(Equal(1))
+			msg := mailClient.SendCall.Receives.Message
+			Expect(msg.Headers).NotTo(ContainElement(HavePrefix("List-Unsubscribe")))
+		})
+
+		It("sends the message through the transport selected for the client and kind", func() {
+			processor.Process(job, logger)
+
+			Expect(transports.SelectCall.CallCount).To(Equal(1))
+			Expect(transports.SelectCall.Receives.ClientID).To(Equal("some-client"))
+			Expect(transports.SelectCall.Receives.KindID).To(Equal("some-kind"))
+			Expect(mailClient.SendCall.CallCount).To(Equal(1))
+		})
+
+		It("waits for the throttle before sending the message to the recipient", func() {
+			processor.Process(job, logger)
+
+			Expect(throttle.WaitCall.CallCount).To(Equal(1))
+			Expect(throttle.WaitCall.Receives.Recipient).To(Equal("user-123@example.com"))
+			Expect(throttle.WaitCall.Receives.Logger.SessionName()).To(Equal("notifications.worker"))
+			Expect(mailClient.SendCall.CallCount).To(Equal(1))
+		})
+
+		It("does not wait for the throttle when the transport does not use a mail server", func() {
+			transport := mocks.NewUnthrottledTransport()
+			transports.SelectCall.Returns.Transport = transport
+
+			processor.Process(job, logger)
+
+			Expect(throttle.WaitCall.CallCount).To(Equal(0))
+			Expect(transport.SendCall.CallCount).To(Equal(1))
+		})
+
 		It("should connect and send the message with the worker's logger session", func() {
 			processor.Process(job, logger)
 			Expect(mailClient.ConnectCall.Receives.Logger.SessionName()).To(Equal("notifications.worker"))
@@ -337,8 +476,96 @@ var _ = Describe("DeliveryJobProcessor", func() {
 					Expect(messageStatusUpdater.UpdateCall.Receives.Connection).To(Equal(conn))
 					Expect(messageStatusUpdater.UpdateCall.Receives.MessageID).To(Equal(messageID))
 					Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusFailed))
+					Expect(messageStatusUpdater.UpdateCall.Receives.Attempt.SMTPText).To(Equal("Error sending message!!!"))
 					Expect(messageStatusUpdater.UpdateCall.Receives.Logger.SessionName()).To(Equal("notifications.worker"))
 				})
+
+				Context("when the SMTP server replies with an error code", func() {
+					It("records the reply code and text with the status update", func() {
+						mailClient.SendCall.Returns.Error = &textproto.Error{Code: 452, Msg: "Requested action not taken: insufficient system storage"}
+						processor.Process(job, logger)
+
+						Expect(messageStatusUpdater.UpdateCall.Receives.Attempt).To(Equal(common.DeliveryAttempt{
+							Recipient: "user-123@example.com",
+							SMTPCode:  452,
+							SMTPText:  "Requested action not taken: insufficient system storage",
+						}))
+					})
+				})
+			})
+
+			Context("because the SMTP server permanently rejected the message", func() {
+				BeforeEach(func() {
+					mailClient.SendCall.Returns.Error = mail.SMTPError{
+						Command:      "RCPT",
+						Code:         550,
+						EnhancedCode: "5.1.1",
+						Message:      "5.1.1 no such user",
+					}
+				})
+
+				It("marks the message as undeliverable", func() {
+					processor.Process(job, logger)
+
+					Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusUndeliverable))
+					Expect(messageStatusUpdater.UpdateCall.Receives.Attempt.SMTPCode).To(Equal(550))
+					Expect(messageStatusUpdater.UpdateCall.Receives.Attempt.SMTPText).To(Equal("5.1.1 no such user"))
+				})
+
+				It("does not retry the job", func() {
+					processor.Process(job, logger)
+
+					Expect(deliveryFailureHandler.HandleCall.WasCalled).To(BeFalse())
+				})
+
+				It("suppresses the recipient address", func() {
+					processor.Process(job, logger)
+
+					Expect(suppressionsRepo.UpsertCall.Receives.Connection).To(Equal(conn))
+					Expect(suppressionsRepo.UpsertCall.Receives.Suppression).To(Equal(models.Suppression{
+						Email:  "user-123@example.com",
+						Reason: models.SuppressionReasonBounce,
+						Detail: "550 5.1.1 no such user",
+					}))
+				})
+
+				Context("when the rejection is not about the recipient", func() {
+					BeforeEach(func() {
+						mailClient.SendCall.Returns.Error = mail.SMTPError{
+							Command:      "DATA",
+							Code:         554,
+							EnhancedCode: "5.6.0",
+							Message:      "5.6.0 message content rejected",
+						}
+					})
+
+					It("does not suppress the recipient address", func() {
+						processor.Process(job, logger)
+
+						Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusUndeliverable))
+						Expect(suppressionsRepo.UpsertCall.CallCount).To(Equal(0))
+					})
+				})
+			})
+
+			Context("because the SMTP server temporarily rejected the message", func() {
+				BeforeEach(func() {
+					mailClient.SendCall.Returns.Error = mail.SMTPError{
+						Command:      "RCPT",
+						Code:         451,
+						EnhancedCode: "4.7.1",
+						Message:      "4.7.1 greylisted, try again later",
+					}
+				})
+
+				It("marks the message as failed and retries the job", func() {
+					processor.Process(job, logger)
+
+					Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusFailed))
+					Expect(deliveryFailureHandler.HandleCall.Receives.Job).To(Equal(job))
+					Expect(deliveryFailureHandler.HandleCall.Receives.Cause).To(MatchError("451 4.7.1 greylisted, try again later"))
+					Expect(suppressionsRepo.UpsertCall.CallCount).To(Equal(0))
+				})
 			})
 
 			Context("and the error is a connect error", func() {
@@ -383,6 +610,179 @@ var _ = Describe("DeliveryJobProcessor", func() {
 			})
 		})
 
+		Context("when the recipient receives notifications of the kind in a digest", func() {
+			BeforeEach(func() {
+				frequenciesRepo.GetCall.Returns.Frequency = models.FrequencyDaily
+			})
+
+			It("stores the rendered notification for the digest instead of sending it", func() {
+				processor.Process(job, logger)
+
+				Expect(frequenciesRepo.GetCall.Receives.Connection).To(Equal(conn))
+				Expect(frequenciesRepo.GetCall.Receives.UserID).To(Equal("user-123"))
+				Expect(frequenciesRepo.GetCall.Receives.ClientID).To(Equal("some-client"))
+				Expect(frequenciesRepo.GetCall.Receives.KindID).To(Equal("some-kind"))
+
+				Expect(mailClient.SendCall.CallCount).To(Equal(0))
+				Expect(digestItemsRepo.CreateCall.Receives.Connection).To(Equal(conn))
+
+				item := digestItemsRepo.CreateCall.Receives.Item
+				Expect(item.UserID).To(Equal("user-123"))
+				Expect(item.Email).To(Equal("user-123@example.com"))
+				Expect(item.Frequency).To(Equal(models.FrequencyDaily))
+				Expect(item.MessageID).To(Equal("randomly-generated-guid"))
+				Expect(item.ClientID).To(Equal("some-client"))
+				Expect(item.KindID).To(Equal("some-kind"))
+				Expect(item.Subject).To(Equal("the subject"))
+				Expect(item.Text).To(Equal("body content example.com"))
+				Expect(item.HTML).To(BeEmpty())
+			})
+
+			It("updates the message status as digested", func() {
+				processor.Process(job, logger)
+
+				Expect(messageStatusUpdater.UpdateCall.Receives.MessageID).To(Equal(messageID))
+				Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusDigested))
+				Expect(deliveryFailureHandler.HandleCall.WasCalled).To(BeFalse())
+			})
+
+			Context("when the item cannot be stored", func() {
+				It("retries the job", func() {
+					digestItemsRepo.CreateCall.Returns.Error = errors.New("database is down")
+
+					processor.Process(job, logger)
+
+					Expect(deliveryFailureHandler.HandleCall.WasCalled).To(BeTrue())
+					Expect(mailClient.SendCall.CallCount).To(Equal(0))
+				})
+			})
+
+			Context("when the template cannot be executed", func() {
+				It("updates the message status as undeliverable and does not retry the job", func() {
+					templateLoader.LoadTemplatesCall.Returns.Templates = common.Templates{
+						Text:    "{{.Text}} from {{.Organization.Name}}",
+						HTML:    "<p>{{.HTML}}</p>",
+						Subject: "Test: {{.Subject}}",
+					}
+
+					processor.Process(job, logger)
+
+					Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusUndeliverable))
+					Expect(digestItemsRepo.CreateCall.Receives.Item).To(Equal(models.DigestItem{}))
+					Expect(deliveryFailureHandler.HandleCall.WasCalled).To(BeFalse())
+				})
+			})
+
+			Context("and the notification is registered as critical", func() {
+				BeforeEach(func() {
+					kindsRepo.FindCall.Returns.Kinds = []models.Kind{
+						{
+							ID:       "some-kind",
+							ClientID: "some-client",
+							Critical: true,
+						},
+					}
+				})
+
+				It("sends the email immediately", func() {
+					processor.Process(job, logger)
+
+					Expect(mailClient.SendCall.CallCount).To(Equal(1))
+					Expect(digestItemsRepo.CreateCall.Receives.Item).To(Equal(models.DigestItem{}))
+				})
+			})
+
+			Context("and the delivery frequency cannot be loaded", func() {
+				It("sends the email immediately", func() {
+					frequenciesRepo.GetCall.Returns.Error = errors.New("database is down")
+
+					processor.Process(job, logger)
+
+					Expect(mailClient.SendCall.CallCount).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when the notification would be sent during the quiet hours of the recipient", func() {
+			BeforeEach(func() {
+				quietHoursRepo.GetCall.Returns.QuietHours = models.QuietHours{
+					UserID:   "user-123",
+					TimeZone: "UTC",
+					Start:    "22:00",
+					End:      "07:00",
+				}
+			})
+
+			It("defers the job until the quiet hours end without counting a retry", func() {
+				processor.Process(job, logger)
+
+				Expect(quietHoursRepo.GetCall.Receives.Connection).To(Equal(conn))
+				Expect(quietHoursRepo.GetCall.Receives.UserID).To(Equal("user-123"))
+
+				Expect(mailClient.SendCall.CallCount).To(Equal(0))
+				Expect(job.ShouldRetry).To(BeTrue())
+				Expect(job.RetryCount).To(Equal(0))
+				Expect(job.ActiveAt).To(Equal(time.Date(2015, time.June, 2, 7, 0, 0, 0, time.UTC)))
+				Expect(deliveryFailureHandler.HandleCall.WasCalled).To(BeFalse())
+			})
+
+			It("does not create a receipt for the deferred job", func() {
+				processor.Process(job, logger)
+
+				Expect(receiptsRepo.CreateReceiptsCall.WasCalled).To(BeFalse())
+			})
+
+			It("sends the email once the quiet hours are over", func() {
+				clock.NowCall.Returns.Time = time.Date(2015, time.June, 2, 7, 0, 0, 0, time.UTC)
+
+				processor.Process(job, logger)
+
+				Expect(mailClient.SendCall.CallCount).To(Equal(1))
+				Expect(job.ShouldRetry).To(BeFalse())
+			})
+
+			Context("and the notification is registered as critical", func() {
+				BeforeEach(func() {
+					kindsRepo.FindCall.Returns.Kinds = []models.Kind{
+						{
+							ID:       "some-kind",
+							ClientID: "some-client",
+							Critical: true,
+						},
+					}
+				})
+
+				It("sends the email immediately", func() {
+					processor.Process(job, logger)
+
+					Expect(quietHoursRepo.GetCall.WasCalled).To(BeFalse())
+					Expect(mailClient.SendCall.CallCount).To(Equal(1))
+					Expect(job.ShouldRetry).To(BeFalse())
+				})
+			})
+
+			Context("and the recipient receives notifications of the kind in a digest", func() {
+				It("stores the notification for the digest right away", func() {
+					frequenciesRepo.GetCall.Returns.Frequency = models.FrequencyWeekly
+
+					processor.Process(job, logger)
+
+					Expect(digestItemsRepo.CreateCall.Receives.Item.MessageID).To(Equal(messageID))
+					Expect(job.ShouldRetry).To(BeFalse())
+				})
+			})
+
+			Context("and the quiet hours cannot be loaded", func() {
+				It("sends the email", func() {
+					quietHoursRepo.GetCall.Returns.Error = errors.New("database is down")
+
+					processor.Process(job, logger)
+
+					Expect(mailClient.SendCall.CallCount).To(Equal(1))
+				})
+			})
+		})
+
 		Context("when recipient has globally unsubscribed", func() {
 			BeforeEach(func() {
 				globalUnsubscribesRepo.GetCall.Returns.Unsubscribed = true
@@ -420,6 +820,66 @@ var _ = Describe("DeliveryJobProcessor", func() {
 			})
 		})
 
+		Context("when the recipient address is suppressed", func() {
+			BeforeEach(func() {
+				suppressionsRepo.IsSuppressedCall.Returns.Suppressed = true
+			})
+
+			It("does not send the email", func() {
+				processor.Process(job, logger)
+
+				Expect(suppressionsRepo.IsSuppressedCall.Receives.Connection).To(Equal(conn))
+				Expect(suppressionsRepo.IsSuppressedCall.Receives.Email).To(Equal("user-123@example.com"))
+				Expect(mailClient.SendCall.CallCount).To(Equal(0))
+				Expect(throttle.WaitCall.CallCount).To(Equal(0))
+			})
+
+			It("logs that the recipient is suppressed", func() {
+				processor.Process(job, logger)
+
+				lines, err := parseLogLines(buffer.Bytes())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(lines).To(ContainElement(logLine{
+					Source:   "notifications",
+					Message:  "notifications.worker.recipient-suppressed",
+					LogLevel: int(lager.INFO),
+					Data: map[string]interface{}{
+						"session":         "1",
+						"recipient":       "user-123@example.com",
+						"worker_id":       float64(1234),
+						"message_id":      "randomly-generated-guid",
+						"vcap_request_id": "some-request-id",
+					},
+				}))
+			})
+
+			It("updates the message status as undeliverable", func() {
+				processor.Process(job, logger)
+
+				Expect(messageStatusUpdater.UpdateCall.Receives.MessageID).To(Equal(messageID))
+				Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusUndeliverable))
+			})
+
+			Context("and the notification is registered as critical", func() {
+				BeforeEach(func() {
+					kindsRepo.FindCall.Returns.Kinds = []models.Kind{
+						{
+							ID:       "some-kind",
+							ClientID: "some-client",
+							Critical: true,
+						},
+					}
+				})
+
+				It("does not send the email either", func() {
+					processor.Process(job, logger)
+
+					Expect(mailClient.SendCall.CallCount).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when the recipient hasn't unsubscribed, but doesn't have a valid email address", func() {
 			Context("when the recipient has no emails", func() {
 				BeforeEach(func() {
@@ -572,6 +1032,30 @@ var _ = Describe("DeliveryJobProcessor", func() {
 			})
 		})
 
+		Context("when the template cannot be executed", func() {
+			BeforeEach(func() {
+				templateLoader.LoadTemplatesCall.Returns.Templates = common.Templates{
+					Text:    "{{.Text}} from {{.Organization.Name}}",
+					HTML:    "<p>{{.HTML}}</p>",
+					Subject: "Test: {{.Subject}}",
+				}
+				job = gobble.NewJob(delivery)
+			})
+
+			It("does not send a half-rendered message", func() {
+				processor.Process(job, logger)
+
+				Expect(mailClient.SendCall.CallCount).To(Equal(0))
+			})
+
+			It("updates the message status as undeliverable and does not retry the job", func() {
+				processor.Process(job, logger)
+
+				Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusUndeliverable))
+				Expect(deliveryFailureHandler.HandleCall.WasCalled).To(BeFalse())
+			})
+		})
+
 		Context("when the template contains syntax errors", func() {
 			BeforeEach(func() {
 				templateLoader.LoadTemplatesCall.Returns.Templates = common.Templates{
@@ -588,11 +1072,10 @@ var _ = Describe("DeliveryJobProcessor", func() {
 				}).ToNot(Panic())
 			})
 
-			It("marks the job for retry later", func() {
+			It("does not retry the job", func() {
 				processor.Process(job, logger)
 
-				Expect(deliveryFailureHandler.HandleCall.Receives.Job).To(Equal(job))
-				Expect(deliveryFailureHandler.HandleCall.Receives.Logger.SessionName()).To(Equal("notifications.worker"))
+				Expect(deliveryFailureHandler.HandleCall.WasCalled).To(BeFalse())
 			})
 
 			It("logs that the packer errored", func() {
@@ -615,12 +1098,12 @@ var _ = Describe("DeliveryJobProcessor", func() {
 				}))
 			})
 
-			It("updates the message status as failed", func() {
+			It("updates the message status as undeliverable", func() {
 				processor.Process(job, logger)
 
 				Expect(messageStatusUpdater.UpdateCall.Receives.Connection).To(Equal(conn))
 				Expect(messageStatusUpdater.UpdateCall.Receives.MessageID).To(Equal(messageID))
-				Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusFailed))
+				Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusUndeliverable))
 				Expect(messageStatusUpdater.UpdateCall.Receives.Logger.SessionName()).To(Equal("notifications.worker"))
 			})
 		})
@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/pivotal-golang/lager"
+	"github.com/rcrowley/go-metrics"
+)
+
+type DigestJobProcessorConfig struct {
+	Sender    string
+	Domain    string
+	Templates common.Templates
+
+	Transports             transportSelector
+	Throttle               throttle
+	Database               db.DatabaseInterface
+	MessageStatusUpdater   messageStatusUpdater
+	DeliveryFailureHandler deliveryFailureHandler
+}
+
+// DigestJobProcessor sends the digest jobs the digest scheduler enqueues.
+// Each digest is rendered through the digest templates and sent through the
+// default transport, and the status of every notification it contains
+// follows the status of the digest.
+type DigestJobProcessor struct {
+	sender    string
+	domain    string
+	templates common.Templates
+
+	transports             transportSelector
+	throttle               throttle
+	database               db.DatabaseInterface
+	messageStatusUpdater   messageStatusUpdater
+	deliveryFailureHandler deliveryFailureHandler
+}
+
+func NewDigestJobProcessor(config DigestJobProcessorConfig) DigestJobProcessor {
+	return DigestJobProcessor{
+		sender:    config.Sender,
+		domain:    config.Domain,
+		templates: config.Templates,
+
+		transports:             config.Transports,
+		throttle:               config.Throttle,
+		database:               config.Database,
+		messageStatusUpdater:   config.MessageStatusUpdater,
+		deliveryFailureHandler: config.DeliveryFailureHandler,
+	}
+}
+
+func (p DigestJobProcessor) Process(job *gobble.Job, logger lager.Logger) error {
+	var digest common.Digest
+	err := job.Unmarshal(&digest)
+	if err != nil {
+		metrics.GetOrRegisterCounter("notifications.worker.panic.json", nil).Inc(1)
+
+		p.deliveryFailureHandler.Handle(job, err, logger)
+		return nil
+	}
+
+	logger = logger.WithData(lager.Data{
+		"user_guid": digest.UserGUID,
+		"recipient": digest.Email,
+		"frequency": digest.Frequency,
+	})
+
+	if len(digest.Items) == 0 {
+		return nil
+	}
+
+	retryCount, _ := job.State()
+	attempt := common.DeliveryAttempt{
+		Recipient:  digest.Email,
+		RetryCount: retryCount,
+	}
+
+	message, err := common.PackDigest(digest, p.sender, p.domain, p.templates)
+	if err != nil {
+		logger.Error("digest-pack-failed", err)
+		p.updateStatuses(digest, common.StatusFailed, attempt, logger)
+		p.deliveryFailureHandler.Handle(job, err, logger)
+		return nil
+	}
+
+	status, err := sendMail(p.transports.Select("", ""), p.throttle, message, logger)
+	p.updateStatuses(digest, status, attempt.WithSMTPError(err), logger)
+
+	switch status {
+	case common.StatusDelivered:
+		metrics.GetOrRegisterCounter("notifications.worker.digest.delivered", nil).Inc(1)
+	case common.StatusUndeliverable:
+		metrics.GetOrRegisterCounter("notifications.worker.digest.undeliverable", nil).Inc(1)
+	default:
+		p.deliveryFailureHandler.Handle(job, err, logger)
+	}
+
+	return nil
+}
+
+func (p DigestJobProcessor) updateStatuses(digest common.Digest, status string, attempt common.DeliveryAttempt, logger lager.Logger) {
+	conn := p.database.Connection()
+	for _, item := range digest.Items {
+		p.messageStatusUpdater.Update(conn, item.MessageID, status, "", attempt, logger.WithData(lager.Data{
+			"message_id": item.MessageID,
+		}))
+	}
+}
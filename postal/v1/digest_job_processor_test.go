@@ -0,0 +1,196 @@
+package v1_test
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/postal/v1"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/pivotal-golang/lager"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DigestJobProcessor", func() {
+	var (
+		processor              v1.DigestJobProcessor
+		mailClient             *mocks.MailClient
+		transports             *mocks.TransportSelector
+		throttle               *mocks.Throttle
+		database               *mocks.Database
+		conn                   *mocks.Connection
+		messageStatusUpdater   *mocks.MessageStatusUpdater
+		deliveryFailureHandler *mocks.DeliveryFailureHandler
+		logger                 lager.Logger
+		buffer                 *bytes.Buffer
+		digest                 common.Digest
+		job                    *gobble.Job
+	)
+
+	BeforeEach(func() {
+		buffer = bytes.NewBuffer([]byte{})
+		logger = lager.NewLogger("notifications")
+		logger.RegisterSink(lager.NewWriterSink(buffer, lager.DEBUG))
+
+		mailClient = mocks.NewMailClient()
+		transports = mocks.NewTransportSelector()
+		transports.SelectCall.Returns.Transport = mailClient
+		throttle = mocks.NewThrottle()
+
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		messageStatusUpdater = mocks.NewMessageStatusUpdater()
+		deliveryFailureHandler = mocks.NewDeliveryFailureHandler()
+
+		processor = v1.NewDigestJobProcessor(v1.DigestJobProcessorConfig{
+			Sender: "from@example.com",
+			Domain: "example.com",
+			Templates: common.Templates{
+				Subject: "Your {{.Frequency}} digest",
+				Text:    "{{range .Items}}{{.Subject}}: {{.Text}}\n{{end}}",
+				HTML:    "{{range .Items}}<h3>{{.Subject}}</h3>{{.HTML}}{{end}}",
+			},
+
+			Transports:             transports,
+			Throttle:               throttle,
+			Database:               database,
+			MessageStatusUpdater:   messageStatusUpdater,
+			DeliveryFailureHandler: deliveryFailureHandler,
+		})
+
+		digest = common.Digest{
+			JobType:   common.DigestJobType,
+			UserGUID:  "user-123",
+			Email:     "user-123@example.com",
+			Frequency: "daily",
+			Items: []common.DigestItem{
+				{
+					MessageID: "message-1",
+					Subject:   "First",
+					Text:      "first text",
+					HTML:      "<p>first html</p>",
+					CreatedAt: time.Now(),
+				},
+				{
+					MessageID: "message-2",
+					Subject:   "Second & last",
+					Text:      "second text",
+					CreatedAt: time.Now(),
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		job = gobble.NewJob(digest)
+	})
+
+	It("sends the digest through the default transport", func() {
+		err := processor.Process(job, logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(transports.SelectCall.Receives.ClientID).To(Equal(""))
+		Expect(throttle.WaitCall.Receives.Recipient).To(Equal("user-123@example.com"))
+		Expect(mailClient.SendCall.CallCount).To(Equal(1))
+
+		message := mailClient.SendCall.Receives.Message
+		Expect(message.From).To(Equal("from@example.com"))
+		Expect(message.To).To(Equal("user-123@example.com"))
+		Expect(message.Subject).To(Equal("Your daily digest"))
+		Expect(message.Headers).To(ContainElement("X-CF-Notification-Digest: daily"))
+		Expect(message.Body).To(Equal([]mail.Part{
+			{
+				ContentType: "text/plain",
+				Content:     "First: first text\nSecond & last: second text",
+			},
+			{
+				ContentType: "text/html",
+				Content:     "<h3>First</h3><p>first html</p><h3>Second &amp; last</h3>",
+			},
+		}))
+	})
+
+	It("marks the notifications in the digest as delivered", func() {
+		processor.Process(job, logger)
+
+		Expect(messageStatusUpdater.UpdateCall.Receives.Connection).To(Equal(conn))
+		Expect(messageStatusUpdater.UpdateCall.Receives.MessageID).To(Equal("message-2"))
+		Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusDelivered))
+		Expect(messageStatusUpdater.UpdateCall.Receives.Attempt.Recipient).To(Equal("user-123@example.com"))
+		Expect(deliveryFailureHandler.HandleCall.WasCalled).To(BeFalse())
+	})
+
+	Context("when the digest has no items", func() {
+		BeforeEach(func() {
+			digest.Items = nil
+		})
+
+		It("sends nothing", func() {
+			processor.Process(job, logger)
+
+			Expect(mailClient.SendCall.CallCount).To(Equal(0))
+			Expect(deliveryFailureHandler.HandleCall.WasCalled).To(BeFalse())
+		})
+	})
+
+	Context("when the digest template cannot be rendered", func() {
+		BeforeEach(func() {
+			processor = v1.NewDigestJobProcessor(v1.DigestJobProcessorConfig{
+				Templates:              common.Templates{Subject: "{{.Missing"},
+				Transports:             transports,
+				Throttle:               throttle,
+				Database:               database,
+				MessageStatusUpdater:   messageStatusUpdater,
+				DeliveryFailureHandler: deliveryFailureHandler,
+			})
+		})
+
+		It("marks the notifications as failed and hands the job to the failure handler", func() {
+			processor.Process(job, logger)
+
+			Expect(mailClient.SendCall.CallCount).To(Equal(0))
+			Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusFailed))
+			Expect(deliveryFailureHandler.HandleCall.WasCalled).To(BeTrue())
+			Expect(deliveryFailureHandler.HandleCall.Receives.Job).To(Equal(job))
+		})
+	})
+
+	Context("when sending the digest fails", func() {
+		BeforeEach(func() {
+			mailClient.SendCall.Returns.Error = errors.New("connection reset")
+		})
+
+		It("marks the notifications as failed and hands the job to the failure handler", func() {
+			processor.Process(job, logger)
+
+			Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusFailed))
+			Expect(deliveryFailureHandler.HandleCall.WasCalled).To(BeTrue())
+			Expect(deliveryFailureHandler.HandleCall.Receives.Cause).To(MatchError("connection reset"))
+		})
+	})
+
+	Context("when the SMTP server permanently rejects the digest", func() {
+		BeforeEach(func() {
+			mailClient.SendCall.Returns.Error = mail.SMTPError{
+				Command:      "RCPT",
+				Code:         550,
+				EnhancedCode: "5.1.1",
+				Message:      "5.1.1 no such user",
+			}
+		})
+
+		It("marks the notifications as undeliverable without retrying", func() {
+			processor.Process(job, logger)
+
+			Expect(messageStatusUpdater.UpdateCall.Receives.MessageStatus).To(Equal(common.StatusUndeliverable))
+			Expect(deliveryFailureHandler.HandleCall.WasCalled).To(BeFalse())
+		})
+	})
+})
@@ -2,32 +2,66 @@ package v1
 
 import (
 	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/pivotal-golang/lager"
 )
 
 type MessageStatusUpdater struct {
-	messagesRepo MessageUpserter
+	messagesRepo      messagesRepository
+	messageEventsRepo messageEventCreator
 }
 
-type MessageUpserter interface {
+type messagesRepository interface {
+	FindByID(conn models.ConnectionInterface, messageID string) (models.Message, error)
 	Upsert(conn models.ConnectionInterface, message models.Message) (models.Message, error)
 }
 
-func NewMessageStatusUpdater(messagesRepo MessageUpserter) MessageStatusUpdater {
+type messageEventCreator interface {
+	Create(conn models.ConnectionInterface, event models.MessageEvent) (models.MessageEvent, error)
+}
+
+func NewMessageStatusUpdater(messagesRepo messagesRepository, messageEventsRepo messageEventCreator) MessageStatusUpdater {
 	return MessageStatusUpdater{
-		messagesRepo: messagesRepo,
+		messagesRepo:      messagesRepo,
+		messageEventsRepo: messageEventsRepo,
 	}
 }
 
-func (mu MessageStatusUpdater) Update(conn db.ConnectionInterface, messageID, messageStatus, campaignID string, logger lager.Logger) {
-	_, err := mu.messagesRepo.Upsert(conn, models.Message{
-		ID:         messageID,
-		Status:     messageStatus,
-	})
+func (mu MessageStatusUpdater) Update(conn db.ConnectionInterface, messageID, messageStatus, campaignID string, attempt common.DeliveryAttempt, logger lager.Logger) {
+	var previousStatus string
+	message, err := mu.messagesRepo.FindByID(conn, messageID)
+	if err == nil {
+		previousStatus = message.Status
+	} else {
+		message = models.Message{ID: messageID}
+	}
+
+	message.Status = messageStatus
+	if message.Email == "" {
+		message.Email = attempt.Recipient
+	}
+
+	_, err = mu.messagesRepo.Upsert(conn, message)
 	if err != nil {
 		logger.Session("message-updater").Error("failed-message-status-upsert", err, lager.Data{
 			"status": messageStatus,
 		})
+		return
+	}
+
+	_, err = mu.messageEventsRepo.Create(conn, models.MessageEvent{
+		MessageID:      messageID,
+		PreviousStatus: previousStatus,
+		Status:         messageStatus,
+		RetryCount:     attempt.RetryCount,
+		SMTPCode:       attempt.SMTPCode,
+		SMTPText:       attempt.SMTPText,
+		Recipient:      attempt.Recipient,
+	})
+	if err != nil {
+		logger.Session("message-updater").Error("failed-message-event-create", err, lager.Data{
+			"status": messageStatus,
+		})
 	}
 }
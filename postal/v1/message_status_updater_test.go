@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
 	"github.com/cloudfoundry-incubator/notifications/postal/v1"
 	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
@@ -15,11 +16,13 @@ import (
 
 var _ = Describe("MessageStatusUpdater", func() {
 	var (
-		updater      v1.MessageStatusUpdater
-		messagesRepo *mocks.MessagesRepo
-		logger       lager.Logger
-		buffer       *bytes.Buffer
-		conn         *mocks.Connection
+		updater           v1.MessageStatusUpdater
+		messagesRepo      *mocks.MessagesRepo
+		messageEventsRepo *mocks.MessageEventsRepo
+		attempt           common.DeliveryAttempt
+		logger            lager.Logger
+		buffer            *bytes.Buffer
+		conn              *mocks.Connection
 	)
 
 	BeforeEach(func() {
@@ -32,20 +35,83 @@ var _ = Describe("MessageStatusUpdater", func() {
 			},
 		}
 
+		messagesRepo.FindByIDCall.Returns.Message = models.Message{
+			ID:       "some-message-id",
+			Status:   "queued",
+			ClientID: "some-client",
+			KindID:   "some-kind",
+			UserGUID: "some-user-guid",
+		}
+		messageEventsRepo = mocks.NewMessageEventsRepo()
+
+		attempt = common.DeliveryAttempt{
+			Recipient:  "user@example.com",
+			RetryCount: 3,
+			SMTPCode:   451,
+			SMTPText:   "try again later",
+		}
+
 		buffer = bytes.NewBuffer([]byte{})
 		logger = lager.NewLogger("notifications")
 		logger.RegisterSink(lager.NewWriterSink(buffer, lager.INFO))
 
-		updater = v1.NewMessageStatusUpdater(messagesRepo)
+		updater = v1.NewMessageStatusUpdater(messagesRepo, messageEventsRepo)
 	})
 
 	It("updates the status of the message", func() {
-		updater.Update(conn, "some-message-id", "message-status", "campaign-id", logger)
+		updater.Update(conn, "some-message-id", "message-status", "campaign-id", attempt, logger)
 
 		Expect(messagesRepo.UpsertCall.Receives.Connection).To(Equal(conn))
+		Expect(messagesRepo.UpsertCall.Receives.Messages[0]).To(Equal(models.Message{
+			ID:       "some-message-id",
+			Status:   "message-status",
+			ClientID: "some-client",
+			KindID:   "some-kind",
+			UserGUID: "some-user-guid",
+			Email:    "user@example.com",
+		}))
+	})
+
+	It("does not overwrite a recipient email that was already recorded", func() {
+		messagesRepo.FindByIDCall.Returns.Message.Email = "original@example.com"
+
+		updater.Update(conn, "some-message-id", "message-status", "campaign-id", attempt, logger)
+
+		Expect(messagesRepo.UpsertCall.Receives.Messages[0].Email).To(Equal("original@example.com"))
+	})
+
+	It("records an event for the status transition", func() {
+		updater.Update(conn, "some-message-id", "message-status", "campaign-id", attempt, logger)
+
+		Expect(messagesRepo.FindByIDCall.Receives.Connection).To(Equal(conn))
+		Expect(messagesRepo.FindByIDCall.Receives.MessageID).To(Equal("some-message-id"))
+
+		Expect(messageEventsRepo.CreateCall.Receives.Connection).To(Equal(conn))
+		Expect(messageEventsRepo.CreateCall.Receives.Events).To(Equal([]models.MessageEvent{
+			{
+				MessageID:      "some-message-id",
+				PreviousStatus: "queued",
+				Status:         "message-status",
+				RetryCount:     3,
+				SMTPCode:       451,
+				SMTPText:       "try again later",
+				Recipient:      "user@example.com",
+			},
+		}))
+	})
+
+	It("records an event without a previous status when the message cannot be found", func() {
+		messagesRepo.FindByIDCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+		updater.Update(conn, "some-message-id", "message-status", "campaign-id", attempt, logger)
+
+		Expect(messageEventsRepo.CreateCall.Receives.Events).To(HaveLen(1))
+		Expect(messageEventsRepo.CreateCall.Receives.Events[0].PreviousStatus).To(BeEmpty())
+
 		Expect(messagesRepo.UpsertCall.Receives.Messages[0]).To(Equal(models.Message{
 			ID:     "some-message-id",
 			Status: "message-status",
+			Email:  "user@example.com",
 		}))
 	})
 
@@ -53,7 +119,7 @@ var _ = Describe("MessageStatusUpdater", func() {
 		It("logs the error when the repository fails to upsert", func() {
 			messagesRepo.UpsertCall.Returns.Error = errors.New("failed to upsert")
 
-			updater.Update(conn, "some-message-id", "message-status", "campaign-id", logger)
+			updater.Update(conn, "some-message-id", "message-status", "campaign-id", attempt, logger)
 
 			lines, err := parseLogLines(buffer.Bytes())
 			Expect(err).NotTo(HaveOccurred())
@@ -71,6 +137,29 @@ var _ = Describe("MessageStatusUpdater", func() {
 					"status":  "message-status",
 				},
 			}))
+
+			Expect(messageEventsRepo.CreateCall.CallCount).To(Equal(0))
+		})
+
+		It("logs the error when the event fails to be recorded", func() {
+			messageEventsRepo.CreateCall.Returns.Error = errors.New("failed to create")
+
+			updater.Update(conn, "some-message-id", "message-status", "campaign-id", attempt, logger)
+
+			lines, err := parseLogLines(buffer.Bytes())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(lines).To(HaveLen(1))
+			Expect(lines[0]).To(Equal(logLine{
+				Source:   "notifications",
+				Message:  "notifications.message-updater.failed-message-event-create",
+				LogLevel: int(lager.ERROR),
+				Data: map[string]interface{}{
+					"session": "1",
+					"error":   "failed to create",
+					"status":  "message-status",
+				},
+			}))
 		})
 	})
 })
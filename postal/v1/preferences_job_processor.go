@@ -0,0 +1,181 @@
+package v1
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/pivotal-golang/lager"
+	"github.com/rcrowley/go-metrics"
+)
+
+// preferencesJobProgressInterval is how many users are processed between
+// each update of the progress of a preferences job.
+const preferencesJobProgressInterval = 100
+
+type findsUserIDs interface {
+	UserIDsBelongingToOrganization(orgGUID, role, token string) ([]string, error)
+	UserIDsBelongingToSpace(spaceGUID, token string) ([]string, error)
+}
+
+type kindsPreferenceUpdater interface {
+	UpdateKinds(conn services.ConnectionInterface, userID string, preferences []models.Preference, actor services.Actor) error
+}
+
+type preferenceJobsRepo interface {
+	Find(conn models.ConnectionInterface, jobID string) (models.PreferenceJob, error)
+	Update(conn models.ConnectionInterface, job models.PreferenceJob) (models.PreferenceJob, error)
+}
+
+type PreferencesJobProcessorConfig struct {
+	UAAHost string
+
+	Database               db.DatabaseInterface
+	TokenLoader            tokenLoader
+	FindsUserIDs           findsUserIDs
+	PreferenceUpdater      kindsPreferenceUpdater
+	PreferenceJobsRepo     preferenceJobsRepo
+	DeliveryFailureHandler deliveryFailureHandler
+}
+
+// PreferencesJobProcessor applies the preferences of a preferences job to
+// each of its users, every user in a transaction of its own, and reports
+// its progress on the preference job record. A user whose preferences
+// cannot be updated is counted as failed without failing the job, while a
+// job whose users cannot be looked up is retried.
+type PreferencesJobProcessor struct {
+	uaaHost string
+
+	database               db.DatabaseInterface
+	tokenLoader            tokenLoader
+	findsUserIDs           findsUserIDs
+	preferenceUpdater      kindsPreferenceUpdater
+	preferenceJobsRepo     preferenceJobsRepo
+	deliveryFailureHandler deliveryFailureHandler
+}
+
+func NewPreferencesJobProcessor(config PreferencesJobProcessorConfig) PreferencesJobProcessor {
+	return PreferencesJobProcessor{
+		uaaHost: config.UAAHost,
+
+		database:               config.Database,
+		tokenLoader:            config.TokenLoader,
+		findsUserIDs:           config.FindsUserIDs,
+		preferenceUpdater:      config.PreferenceUpdater,
+		preferenceJobsRepo:     config.PreferenceJobsRepo,
+		deliveryFailureHandler: config.DeliveryFailureHandler,
+	}
+}
+
+func (p PreferencesJobProcessor) Process(job *gobble.Job, logger lager.Logger) error {
+	var payload services.PreferencesJob
+	err := job.Unmarshal(&payload)
+	if err != nil {
+		metrics.GetOrRegisterCounter("notifications.worker.panic.json", nil).Inc(1)
+
+		p.deliveryFailureHandler.Handle(job, err, logger)
+		return nil
+	}
+
+	logger = logger.WithData(lager.Data{
+		"preference_job_id": payload.ID,
+	})
+
+	conn := p.database.Connection()
+	preferenceJob, err := p.preferenceJobsRepo.Find(conn, payload.ID)
+	if err != nil {
+		logger.Error("preference-job-not-found", err)
+		p.deliveryFailureHandler.Handle(job, err, logger)
+		return nil
+	}
+
+	userIDs, err := p.userIDs(payload)
+	if err != nil {
+		logger.Error("preference-job-users-not-found", err)
+		p.deliveryFailureHandler.Handle(job, err, logger)
+
+		preferenceJob.LastError = err.Error()
+		if job.ShouldBury {
+			preferenceJob.Status = models.PreferenceJobStatusFailed
+		}
+		p.update(conn, preferenceJob, logger)
+		return nil
+	}
+
+	preferenceJob.Status = models.PreferenceJobStatusRunning
+	preferenceJob.Total = len(userIDs)
+	preferenceJob.Processed = 0
+	preferenceJob.Failed = 0
+	preferenceJob = p.update(conn, preferenceJob, logger)
+
+	for _, userID := range userIDs {
+		err := p.apply(conn, userID, payload.Preferences, payload.Actor)
+		if err != nil {
+			logger.Error("preference-job-user-failed", err, lager.Data{
+				"user_id": userID,
+			})
+
+			preferenceJob.Failed++
+			preferenceJob.LastError = err.Error()
+		}
+
+		preferenceJob.Processed++
+		if preferenceJob.Processed%preferencesJobProgressInterval == 0 {
+			preferenceJob = p.update(conn, preferenceJob, logger)
+		}
+	}
+
+	preferenceJob.Status = models.PreferenceJobStatusCompleted
+	p.update(conn, preferenceJob, logger)
+
+	logger.Info("preference-job-completed", lager.Data{
+		"total":  preferenceJob.Total,
+		"failed": preferenceJob.Failed,
+	})
+	metrics.GetOrRegisterCounter("notifications.worker.preferences.updated", nil).Inc(int64(preferenceJob.Processed - preferenceJob.Failed))
+
+	return nil
+}
+
+func (p PreferencesJobProcessor) userIDs(payload services.PreferencesJob) ([]string, error) {
+	if payload.OrganizationGUID == "" && payload.SpaceGUID == "" {
+		return payload.UserIDs, nil
+	}
+
+	token, err := p.tokenLoader.Load(p.uaaHost)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.SpaceGUID != "" {
+		return p.findsUserIDs.UserIDsBelongingToSpace(payload.SpaceGUID, token)
+	}
+
+	return p.findsUserIDs.UserIDsBelongingToOrganization(payload.OrganizationGUID, payload.OrganizationRole, token)
+}
+
+func (p PreferencesJobProcessor) apply(conn db.ConnectionInterface, userID string, preferences []models.Preference, actor services.Actor) error {
+	transaction := conn.Transaction()
+	err := transaction.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = p.preferenceUpdater.UpdateKinds(transaction, userID, preferences, actor)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+func (p PreferencesJobProcessor) update(conn db.ConnectionInterface, preferenceJob models.PreferenceJob, logger lager.Logger) models.PreferenceJob {
+	updated, err := p.preferenceJobsRepo.Update(conn, preferenceJob)
+	if err != nil {
+		logger.Error("preference-job-update-failed", err)
+		return preferenceJob
+	}
+
+	return updated
+}
@@ -0,0 +1,283 @@
+package v1_test
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/postal/v1"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/pivotal-golang/lager"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PreferencesJobProcessor", func() {
+	var (
+		processor              v1.PreferencesJobProcessor
+		database               *mocks.Database
+		conn                   *mocks.Connection
+		transaction            *mocks.Transaction
+		tokenLoader            *mocks.TokenLoader
+		findsUserIDs           *mocks.FindsUserIDs
+		preferenceUpdater      *mocks.PreferenceUpdater
+		preferenceJobsRepo     *mocks.PreferenceJobsRepo
+		deliveryFailureHandler *mocks.DeliveryFailureHandler
+		logger                 lager.Logger
+		preferences            []models.Preference
+	)
+
+	BeforeEach(func() {
+		logger = lager.NewLogger("notifications")
+		logger.RegisterSink(lager.NewWriterSink(bytes.NewBuffer([]byte{}), lager.DEBUG))
+
+		transaction = mocks.NewTransaction()
+		conn = mocks.NewConnection()
+		conn.TransactionCall.Returns.Transaction = transaction
+		transaction.Connection = conn
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		tokenLoader = mocks.NewTokenLoader()
+		tokenLoader.LoadCall.Returns.Token = "some-token"
+
+		findsUserIDs = mocks.NewFindsUserIDs()
+		preferenceUpdater = mocks.NewPreferenceUpdater()
+
+		preferenceJobsRepo = mocks.NewPreferenceJobsRepo()
+		preferenceJobsRepo.FindCall.Returns.Job = models.PreferenceJob{
+			ID:     "some-job-id",
+			Status: models.PreferenceJobStatusQueued,
+		}
+
+		deliveryFailureHandler = mocks.NewDeliveryFailureHandler()
+
+		preferences = []models.Preference{
+			{
+				ClientID:  "dogs",
+				KindID:    "barking",
+				Frequency: models.FrequencyWeekly,
+			},
+		}
+
+		processor = v1.NewPreferencesJobProcessor(v1.PreferencesJobProcessorConfig{
+			UAAHost: "https://uaa.example.com",
+
+			Database:               database,
+			TokenLoader:            tokenLoader,
+			FindsUserIDs:           findsUserIDs,
+			PreferenceUpdater:      preferenceUpdater,
+			PreferenceJobsRepo:     preferenceJobsRepo,
+			DeliveryFailureHandler: deliveryFailureHandler,
+		})
+	})
+
+	It("applies the preferences to each of the listed users and reports the progress", func() {
+		job := gobble.NewJob(services.PreferencesJob{
+			JobType:     services.PreferencesJobType,
+			ID:          "some-job-id",
+			UserIDs:     []string{"user-1", "user-2"},
+			Preferences: preferences,
+			Actor: services.Actor{
+				ID:     "some-admin-client",
+				Source: models.PreferenceChangeSourceAPI,
+			},
+		})
+
+		err := processor.Process(job, logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(preferenceJobsRepo.FindCall.Receives.Connection).To(Equal(conn))
+		Expect(preferenceJobsRepo.FindCall.Receives.JobID).To(Equal("some-job-id"))
+
+		Expect(preferenceUpdater.UpdateKindsCall.Receives.Connection).To(Equal(transaction))
+		Expect(preferenceUpdater.UpdateKindsCall.Receives.UserIDs).To(Equal([]string{"user-1", "user-2"}))
+		Expect(preferenceUpdater.UpdateKindsCall.Receives.Preferences).To(Equal(preferences))
+		Expect(preferenceUpdater.UpdateKindsCall.Receives.Actor).To(Equal(services.Actor{
+			ID:     "some-admin-client",
+			Source: models.PreferenceChangeSourceAPI,
+		}))
+		Expect(transaction.CommitCall.WasCalled).To(BeTrue())
+
+		Expect(tokenLoader.LoadCall.Receives.UAAHost).To(BeEmpty())
+		Expect(deliveryFailureHandler.HandleCall.WasCalled).To(BeFalse())
+
+		Expect(preferenceJobsRepo.UpdateCall.Receives.Jobs).To(Equal([]models.PreferenceJob{
+			{
+				ID:     "some-job-id",
+				Status: models.PreferenceJobStatusRunning,
+				Total:  2,
+			},
+			{
+				ID:        "some-job-id",
+				Status:    models.PreferenceJobStatusCompleted,
+				Total:     2,
+				Processed: 2,
+			},
+		}))
+	})
+
+	It("applies the preferences to the members of a space", func() {
+		findsUserIDs.UserIDsBelongingToSpaceCall.Returns.UserIDs = []string{"user-3"}
+
+		err := processor.Process(gobble.NewJob(services.PreferencesJob{
+			JobType:     services.PreferencesJobType,
+			ID:          "some-job-id",
+			SpaceGUID:   "some-space-guid",
+			Preferences: preferences,
+		}), logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(tokenLoader.LoadCall.Receives.UAAHost).To(Equal("https://uaa.example.com"))
+		Expect(findsUserIDs.UserIDsBelongingToSpaceCall.Receives.SpaceGUID).To(Equal("some-space-guid"))
+		Expect(findsUserIDs.UserIDsBelongingToSpaceCall.Receives.Token).To(Equal("some-token"))
+		Expect(preferenceUpdater.UpdateKindsCall.Receives.UserIDs).To(Equal([]string{"user-3"}))
+	})
+
+	It("applies the preferences to the members of an organization with the role", func() {
+		findsUserIDs.UserIDsBelongingToOrganizationCall.Returns.UserIDs = []string{"user-4", "user-5"}
+
+		err := processor.Process(gobble.NewJob(services.PreferencesJob{
+			JobType:          services.PreferencesJobType,
+			ID:               "some-job-id",
+			OrganizationGUID: "some-org-guid",
+			OrganizationRole: "OrgManager",
+			Preferences:      preferences,
+		}), logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(findsUserIDs.UserIDsBelongingToOrganizationCall.Receives.OrgGUID).To(Equal("some-org-guid"))
+		Expect(findsUserIDs.UserIDsBelongingToOrganizationCall.Receives.Role).To(Equal("OrgManager"))
+		Expect(findsUserIDs.UserIDsBelongingToOrganizationCall.Receives.Token).To(Equal("some-token"))
+		Expect(preferenceUpdater.UpdateKindsCall.Receives.UserIDs).To(Equal([]string{"user-4", "user-5"}))
+	})
+
+	It("counts the users whose preferences cannot be updated as failed", func() {
+		preferenceUpdater.UpdateKindsCall.Returns.Errors = map[string]error{
+			"user-2": errors.New("database is down"),
+		}
+
+		err := processor.Process(gobble.NewJob(services.PreferencesJob{
+			JobType:     services.PreferencesJobType,
+			ID:          "some-job-id",
+			UserIDs:     []string{"user-1", "user-2", "user-3"},
+			Preferences: preferences,
+		}), logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+		Expect(preferenceUpdater.UpdateKindsCall.Receives.UserIDs).To(Equal([]string{"user-1", "user-2", "user-3"}))
+
+		jobs := preferenceJobsRepo.UpdateCall.Receives.Jobs
+		Expect(jobs[len(jobs)-1]).To(Equal(models.PreferenceJob{
+			ID:        "some-job-id",
+			Status:    models.PreferenceJobStatusCompleted,
+			Total:     3,
+			Processed: 3,
+			Failed:    1,
+			LastError: "database is down",
+		}))
+	})
+
+	It("reports the progress every hundred users", func() {
+		var userIDs []string
+		for i := 0; i < 250; i++ {
+			userIDs = append(userIDs, "some-user")
+		}
+
+		err := processor.Process(gobble.NewJob(services.PreferencesJob{
+			JobType:     services.PreferencesJobType,
+			ID:          "some-job-id",
+			UserIDs:     userIDs,
+			Preferences: preferences,
+		}), logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		var processed []int
+		for _, job := range preferenceJobsRepo.UpdateCall.Receives.Jobs {
+			processed = append(processed, job.Processed)
+		}
+		Expect(processed).To(Equal([]int{0, 100, 200, 250}))
+	})
+
+	Context("failure cases", func() {
+		It("hands the job to the failure handler when the payload cannot be read", func() {
+			job := &gobble.Job{Payload: "%%"}
+
+			err := processor.Process(job, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deliveryFailureHandler.HandleCall.Receives.Job).To(Equal(job))
+			Expect(preferenceUpdater.UpdateKindsCall.Receives.UserIDs).To(BeEmpty())
+		})
+
+		It("hands the job to the failure handler when the job record cannot be found", func() {
+			preferenceJobsRepo.FindCall.Returns.Error = errors.New("database is down")
+
+			err := processor.Process(gobble.NewJob(services.PreferencesJob{ID: "some-job-id"}), logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deliveryFailureHandler.HandleCall.Receives.Cause).To(MatchError(errors.New("database is down")))
+			Expect(preferenceJobsRepo.UpdateCall.Receives.Jobs).To(BeEmpty())
+		})
+
+		It("retries the job when the users cannot be looked up", func() {
+			findsUserIDs.UserIDsBelongingToSpaceCall.Returns.Error = errors.New("cloud controller is down")
+
+			job := gobble.NewJob(services.PreferencesJob{
+				ID:        "some-job-id",
+				SpaceGUID: "some-space-guid",
+			})
+
+			err := processor.Process(job, logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deliveryFailureHandler.HandleCall.Receives.Job).To(Equal(job))
+			Expect(deliveryFailureHandler.HandleCall.Receives.Cause).To(MatchError(errors.New("cloud controller is down")))
+			Expect(preferenceUpdater.UpdateKindsCall.Receives.UserIDs).To(BeEmpty())
+
+			Expect(preferenceJobsRepo.UpdateCall.Receives.Jobs).To(Equal([]models.PreferenceJob{
+				{
+					ID:        "some-job-id",
+					Status:    models.PreferenceJobStatusQueued,
+					LastError: "cloud controller is down",
+				},
+			}))
+		})
+
+		It("marks the job as failed once it runs out of retries", func() {
+			tokenLoader.LoadCall.Returns.Error = errors.New("uaa is down")
+
+			processor = v1.NewPreferencesJobProcessor(v1.PreferencesJobProcessorConfig{
+				Database:               database,
+				TokenLoader:            tokenLoader,
+				FindsUserIDs:           findsUserIDs,
+				PreferenceUpdater:      preferenceUpdater,
+				PreferenceJobsRepo:     preferenceJobsRepo,
+				DeliveryFailureHandler: common.NewDeliveryFailureHandler(),
+			})
+
+			job := gobble.NewJob(services.PreferencesJob{
+				ID:               "some-job-id",
+				OrganizationGUID: "some-org-guid",
+			})
+			job.RetryCount = 10
+
+			err := processor.Process(job, logger)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(job.ShouldBury).To(BeTrue())
+
+			Expect(preferenceJobsRepo.UpdateCall.Receives.Jobs).To(Equal([]models.PreferenceJob{
+				{
+					ID:        "some-job-id",
+					Status:    models.PreferenceJobStatusFailed,
+					LastError: "uaa is down",
+				},
+			}))
+		})
+	})
+})
@@ -24,18 +24,28 @@ type TemplatesLoader struct {
 	clientsRepo   clientFinder
 	kindsRepo     kindFinder
 	templatesRepo templateFinder
+	defaultLocale string
 }
 
-func NewTemplatesLoader(database db.DatabaseInterface, clientsRepo clientFinder, kindsRepo kindFinder, templatesRepo templateFinder) TemplatesLoader {
+// NewTemplatesLoader returns a TemplatesLoader. The default locale is the
+// locale that the content of templates, leaving their localizations aside,
+// is in.
+func NewTemplatesLoader(database db.DatabaseInterface, clientsRepo clientFinder, kindsRepo kindFinder, templatesRepo templateFinder, defaultLocale string) TemplatesLoader {
 	return TemplatesLoader{
 		database:      database,
 		clientsRepo:   clientsRepo,
 		kindsRepo:     kindsRepo,
 		templatesRepo: templatesRepo,
+		defaultLocale: defaultLocale,
 	}
 }
 
-func (loader TemplatesLoader) LoadTemplates(clientID, kindID, templateID string) (common.Templates, error) {
+// LoadTemplates returns the templates of the kind, or of the client when the
+// kind has none, in the locale that best matches the one given. The locale
+// falls back to less specific locales, so that "pt-BR" is given the "pt"
+// localization of a template that has no "pt-BR" one, and then to the
+// default locale.
+func (loader TemplatesLoader) LoadTemplates(clientID, kindID, templateID, locale string) (common.Templates, error) {
 	conn := loader.database.Connection()
 
 	if kindID != "" {
@@ -45,7 +55,7 @@ func (loader TemplatesLoader) LoadTemplates(clientID, kindID, templateID string)
 		}
 
 		if kind.TemplateID != models.DefaultTemplateID {
-			return loader.loadTemplate(conn, kind.TemplateID)
+			return loader.loadTemplate(conn, kind.TemplateID, locale)
 		}
 	}
 
@@ -54,15 +64,34 @@ func (loader TemplatesLoader) LoadTemplates(clientID, kindID, templateID string)
 		return common.Templates{}, err
 	}
 
-	return loader.loadTemplate(conn, client.TemplateID)
+	return loader.loadTemplate(conn, client.TemplateID, locale)
 }
 
-func (loader TemplatesLoader) loadTemplate(conn db.ConnectionInterface, templateID string) (common.Templates, error) {
+func (loader TemplatesLoader) loadTemplate(conn db.ConnectionInterface, templateID, locale string) (common.Templates, error) {
 	template, err := loader.templatesRepo.FindByID(conn, templateID)
 	if err != nil {
 		return common.Templates{}, err
 	}
 
+	localizations, err := models.ParseLocalizations(template.Localizations)
+	if err != nil {
+		return common.Templates{}, err
+	}
+
+	for _, fallback := range models.LocaleFallbacks(locale) {
+		if fallback == loader.defaultLocale {
+			break
+		}
+
+		if localization, ok := localizations[fallback]; ok {
+			return common.Templates{
+				Subject: localization.Subject,
+				Text:    localization.Text,
+				HTML:    localization.HTML,
+			}, nil
+		}
+	}
+
 	return common.Templates{
 		Subject: template.Subject,
 		Text:    template.Text,
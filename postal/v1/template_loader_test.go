@@ -32,7 +32,7 @@ var _ = Describe("TemplateLoader", func() {
 		database = mocks.NewDatabase()
 		database.ConnectionCall.Returns.Connection = conn
 
-		loader = v1.NewTemplatesLoader(database, clientsRepo, kindsRepo, templatesRepo)
+		loader = v1.NewTemplatesLoader(database, clientsRepo, kindsRepo, templatesRepo, "en")
 	})
 
 	Describe("LoadTemplates", func() {
@@ -79,7 +79,7 @@ var _ = Describe("TemplateLoader", func() {
 			})
 
 			It("returns the template belonging to the kind", func() {
-				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "")
+				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(templates).To(Equal(common.Templates{
 					HTML:    "<p>kind template</p>",
@@ -109,7 +109,7 @@ var _ = Describe("TemplateLoader", func() {
 			})
 
 			It("returns the template belonging to the client", func() {
-				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "")
+				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(templates).To(Equal(common.Templates{
 					HTML:    "<p>client template</p>",
@@ -124,7 +124,7 @@ var _ = Describe("TemplateLoader", func() {
 
 		Context("when the neither client nor kind has a template", func() {
 			It("returns the default template", func() {
-				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "")
+				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(templates).To(Equal(common.Templates{
 					HTML:    "<p>The default template</p>",
@@ -136,7 +136,7 @@ var _ = Describe("TemplateLoader", func() {
 
 		Context("when kindID is an empty string", func() {
 			It("does not look for a template belonging to the kind", func() {
-				templates, err := loader.LoadTemplates("my-client-id", "", "")
+				templates, err := loader.LoadTemplates("my-client-id", "", "", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(templates).To(Equal(common.Templates{
 					HTML:    "<p>The default template</p>",
@@ -146,11 +146,76 @@ var _ = Describe("TemplateLoader", func() {
 			})
 		})
 
+		Context("when the template has localizations", func() {
+			BeforeEach(func() {
+				templatesRepo.FindByIDCall.Returns.Template.Localizations = `{
+					"pt": {"subject": "assunto", "text": "o modelo", "html": "<p>o modelo</p>"},
+					"pt-BR": {"subject": "assunto brasileiro", "text": "o modelo brasileiro", "html": "<p>o modelo brasileiro</p>"},
+					"en": {"subject": "english subject", "text": "the english template", "html": "<p>the english template</p>"}
+				}`
+			})
+
+			It("returns the localization for the locale", func() {
+				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "pt-BR")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(templates).To(Equal(common.Templates{
+					HTML:    "<p>o modelo brasileiro</p>",
+					Text:    "o modelo brasileiro",
+					Subject: "assunto brasileiro",
+				}))
+			})
+
+			It("falls back to the localization for a less specific locale", func() {
+				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "pt-PT")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(templates).To(Equal(common.Templates{
+					HTML:    "<p>o modelo</p>",
+					Text:    "o modelo",
+					Subject: "assunto",
+				}))
+			})
+
+			It("falls back to the template itself for a locale without a localization", func() {
+				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "fr-CA")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(templates).To(Equal(common.Templates{
+					HTML:    "<p>The default template</p>",
+					Text:    "The default template",
+					Subject: "default subject",
+				}))
+			})
+
+			It("uses the template itself for the default locale", func() {
+				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "en-US")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(templates).To(Equal(common.Templates{
+					HTML:    "<p>The default template</p>",
+					Text:    "The default template",
+					Subject: "default subject",
+				}))
+			})
+
+			It("uses the template itself when there is no locale", func() {
+				templates, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(templates.Subject).To(Equal("default subject"))
+			})
+		})
+
+		Context("when the localizations of the template are malformed", func() {
+			It("bubbles up the error", func() {
+				templatesRepo.FindByIDCall.Returns.Template.Localizations = "{"
+
+				_, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "pt")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
 		Context("when the kinds repo has an error", func() {
 			It("bubbles up the error", func() {
 				kindsRepo.FindCall.Returns.Error = errors.New("BOOM!")
 
-				_, err := loader.LoadTemplates("my-client-id", "my-kind-id", "")
+				_, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "")
 				Expect(err).To(HaveOccurred())
 			})
 
@@ -160,7 +225,7 @@ var _ = Describe("TemplateLoader", func() {
 			It("bubbles up the error", func() {
 				clientsRepo.FindCall.Returns.Error = errors.New("BOOM!")
 
-				_, err := loader.LoadTemplates("my-client-id", "my-kind-id", "")
+				_, err := loader.LoadTemplates("my-client-id", "my-kind-id", "", "")
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -1,5 +1,10 @@
 package postal
 
+import (
+	"sync"
+	"time"
+)
+
 type WorkerGenerator struct {
 	InstanceIndex int
 	Count         int
@@ -7,11 +12,45 @@ type WorkerGenerator struct {
 
 type Worker interface {
 	Work()
+	Halt()
 }
 
-func (w WorkerGenerator) Work(workerFunc func(id int) Worker) {
+func (w WorkerGenerator) Work(workerFunc func(id int) Worker) []Worker {
+	var workers []Worker
+
 	firstID := w.InstanceIndex*w.Count + 1
 	for i := 0; i < w.Count; i++ {
-		workerFunc(firstID + i).Work()
+		worker := workerFunc(firstID + i)
+		worker.Work()
+		workers = append(workers, worker)
+	}
+
+	return workers
+}
+
+// HaltWorkers halts the workers concurrently. A worker only halts once it has
+// finished the job it is performing, so HaltWorkers waits up to the timeout
+// and reports whether every worker stopped in time.
+func HaltWorkers(workers []Worker, timeout time.Duration) bool {
+	var group sync.WaitGroup
+	for _, worker := range workers {
+		group.Add(1)
+		go func(worker Worker) {
+			defer group.Done()
+			worker.Halt()
+		}(worker)
+	}
+
+	halted := make(chan struct{})
+	go func() {
+		group.Wait()
+		close(halted)
+	}()
+
+	select {
+	case <-halted:
+		return true
+	case <-time.After(timeout):
+		return false
 	}
 }
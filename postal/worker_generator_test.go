@@ -1,6 +1,8 @@
 package postal_test
 
 import (
+	"time"
+
 	"github.com/cloudfoundry-incubator/notifications/postal"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -13,11 +15,24 @@ func (m *mockWorker) Work() {
 	*m++
 }
 
+func (m *mockWorker) Halt() {}
+
+type blockedWorker struct {
+	release chan struct{}
+}
+
+func (w blockedWorker) Work() {}
+
+func (w blockedWorker) Halt() {
+	<-w.release
+}
+
 var _ = Describe("WorkerGenerator", func() {
 	Describe("#Work", func() {
 		var (
 			workerIDs []int
 			worker    mockWorker
+			workers   []postal.Worker
 		)
 
 		BeforeEach(func() {
@@ -28,7 +43,7 @@ var _ = Describe("WorkerGenerator", func() {
 				InstanceIndex: 2,
 			}
 
-			generator.Work(func(id int) postal.Worker {
+			workers = generator.Work(func(id int) postal.Worker {
 				workerIDs = append(workerIDs, id)
 				return &worker
 			})
@@ -41,5 +56,24 @@ var _ = Describe("WorkerGenerator", func() {
 		It("should do work on each worker", func() {
 			Expect(worker).To(BeEquivalentTo(5))
 		})
+
+		It("returns the workers so that they can be halted", func() {
+			Expect(workers).To(HaveLen(5))
+		})
+	})
+
+	Describe("HaltWorkers", func() {
+		It("reports that every worker halted", func() {
+			worker := mockWorker(0)
+			Expect(postal.HaltWorkers([]postal.Worker{&worker, &worker}, time.Second)).To(BeTrue())
+		})
+
+		It("gives up on workers that do not halt before the timeout", func() {
+			blocked := blockedWorker{release: make(chan struct{})}
+			defer close(blocked.release)
+
+			worker := mockWorker(0)
+			Expect(postal.HaltWorkers([]postal.Worker{&worker, blocked}, 10*time.Millisecond)).To(BeFalse())
+		})
 	})
 })
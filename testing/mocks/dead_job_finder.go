@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
+
+type DeadJobFinder struct {
+	ListCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+			Page     int
+			PerPage  int
+		}
+		Returns struct {
+			DeadJobList services.DeadJobList
+			Error       error
+		}
+	}
+
+	FindCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+			ID       int
+		}
+		Returns struct {
+			DeadJob gobble.DeadJob
+			Error   error
+		}
+	}
+}
+
+func NewDeadJobFinder() *DeadJobFinder {
+	return &DeadJobFinder{}
+}
+
+func (f *DeadJobFinder) List(database services.DatabaseInterface, page, perPage int) (services.DeadJobList, error) {
+	f.ListCall.Receives.Database = database
+	f.ListCall.Receives.Page = page
+	f.ListCall.Receives.PerPage = perPage
+
+	return f.ListCall.Returns.DeadJobList, f.ListCall.Returns.Error
+}
+
+func (f *DeadJobFinder) Find(database services.DatabaseInterface, id int) (gobble.DeadJob, error) {
+	f.FindCall.Receives.Database = database
+	f.FindCall.Receives.ID = id
+
+	return f.FindCall.Returns.DeadJob, f.FindCall.Returns.Error
+}
@@ -0,0 +1,42 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/services"
+
+type DeadJobPurger struct {
+	PurgeCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+			ID       int
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	PurgeAllCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+		}
+		Returns struct {
+			Count int
+			Error error
+		}
+	}
+}
+
+func NewDeadJobPurger() *DeadJobPurger {
+	return &DeadJobPurger{}
+}
+
+func (p *DeadJobPurger) Purge(database services.DatabaseInterface, id int) error {
+	p.PurgeCall.Receives.Database = database
+	p.PurgeCall.Receives.ID = id
+
+	return p.PurgeCall.Returns.Error
+}
+
+func (p *DeadJobPurger) PurgeAll(database services.DatabaseInterface) (int, error) {
+	p.PurgeAllCall.Receives.Database = database
+
+	return p.PurgeAllCall.Returns.Count, p.PurgeAllCall.Returns.Error
+}
@@ -0,0 +1,27 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/services"
+
+type DeadJobReplayer struct {
+	ReplayCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+			ID       int
+		}
+		Returns struct {
+			JobID int
+			Error error
+		}
+	}
+}
+
+func NewDeadJobReplayer() *DeadJobReplayer {
+	return &DeadJobReplayer{}
+}
+
+func (r *DeadJobReplayer) Replay(database services.DatabaseInterface, id int) (int, error) {
+	r.ReplayCall.Receives.Database = database
+	r.ReplayCall.Receives.ID = id
+
+	return r.ReplayCall.Returns.JobID, r.ReplayCall.Returns.Error
+}
@@ -0,0 +1,53 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type DeliveryFrequenciesRepo struct {
+	GetCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			UserID     string
+			ClientID   string
+			KindID     string
+		}
+		Returns struct {
+			Frequency string
+			Error     error
+		}
+	}
+
+	SetCall struct {
+		Receives struct {
+			Connection  models.ConnectionInterface
+			Frequencies []models.DeliveryFrequency
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func NewDeliveryFrequenciesRepo() *DeliveryFrequenciesRepo {
+	return &DeliveryFrequenciesRepo{}
+}
+
+func (r *DeliveryFrequenciesRepo) Get(conn models.ConnectionInterface, userID, clientID, kindID string) (string, error) {
+	r.GetCall.Receives.Connection = conn
+	r.GetCall.Receives.UserID = userID
+	r.GetCall.Receives.ClientID = clientID
+	r.GetCall.Receives.KindID = kindID
+
+	return r.GetCall.Returns.Frequency, r.GetCall.Returns.Error
+}
+
+func (r *DeliveryFrequenciesRepo) Set(conn models.ConnectionInterface, userID, clientID, kindID, frequency string) error {
+	r.SetCall.Receives.Connection = conn
+	r.SetCall.Receives.Frequencies = append(r.SetCall.Receives.Frequencies, models.DeliveryFrequency{
+		UserID:    userID,
+		ClientID:  clientID,
+		KindID:    kindID,
+		Frequency: frequency,
+	})
+
+	return r.SetCall.Returns.Error
+}
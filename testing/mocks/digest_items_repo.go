@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+type DigestItemsRepo struct {
+	CreateCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Item       models.DigestItem
+		}
+		Returns struct {
+			Item  models.DigestItem
+			Error error
+		}
+	}
+
+	FindDueCall struct {
+		Receives struct {
+			Connection  models.ConnectionInterface
+			Frequencies []string
+			Befores     []time.Time
+		}
+		Returns struct {
+			UserIDs map[string][]string
+			Error   error
+		}
+	}
+
+	TakeCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			UserIDs    []string
+			Frequency  string
+		}
+		Returns struct {
+			Items []models.DigestItem
+			Error error
+		}
+	}
+}
+
+func NewDigestItemsRepo() *DigestItemsRepo {
+	return &DigestItemsRepo{}
+}
+
+func (r *DigestItemsRepo) Create(conn models.ConnectionInterface, item models.DigestItem) (models.DigestItem, error) {
+	r.CreateCall.Receives.Connection = conn
+	r.CreateCall.Receives.Item = item
+
+	return r.CreateCall.Returns.Item, r.CreateCall.Returns.Error
+}
+
+func (r *DigestItemsRepo) FindDue(conn models.ConnectionInterface, frequency string, before time.Time) ([]string, error) {
+	r.FindDueCall.Receives.Connection = conn
+	r.FindDueCall.Receives.Frequencies = append(r.FindDueCall.Receives.Frequencies, frequency)
+	r.FindDueCall.Receives.Befores = append(r.FindDueCall.Receives.Befores, before)
+
+	return r.FindDueCall.Returns.UserIDs[frequency], r.FindDueCall.Returns.Error
+}
+
+func (r *DigestItemsRepo) Take(conn models.ConnectionInterface, userID, frequency string) ([]models.DigestItem, error) {
+	r.TakeCall.Receives.Connection = conn
+	r.TakeCall.Receives.UserIDs = append(r.TakeCall.Receives.UserIDs, userID)
+	r.TakeCall.Receives.Frequency = frequency
+
+	return r.TakeCall.Returns.Items, r.TakeCall.Returns.Error
+}
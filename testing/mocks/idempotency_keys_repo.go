@@ -0,0 +1,131 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+type IdempotencyKeysRepo struct {
+	CreateCall struct {
+		CallCount int
+		Receives  struct {
+			Connection     models.ConnectionInterface
+			IdempotencyKey models.IdempotencyKey
+		}
+		Returns struct {
+			IdempotencyKey models.IdempotencyKey
+			Error          error
+		}
+	}
+
+	FindCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			ClientID   string
+			Key        string
+		}
+		Returns struct {
+			IdempotencyKey models.IdempotencyKey
+			Error          error
+		}
+	}
+
+	UpdateCall struct {
+		CallCount int
+		Receives  struct {
+			Connection     models.ConnectionInterface
+			IdempotencyKey models.IdempotencyKey
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	ReclaimCall struct {
+		CallCount int
+		Receives  struct {
+			Connection     models.ConnectionInterface
+			IdempotencyKey models.IdempotencyKey
+		}
+		Returns struct {
+			IdempotencyKey models.IdempotencyKey
+			Error          error
+		}
+	}
+
+	DeleteCall struct {
+		CallCount int
+		Receives  struct {
+			Connection     models.ConnectionInterface
+			IdempotencyKey models.IdempotencyKey
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	DeleteBeforeCall struct {
+		CallCount int
+		Receives  struct {
+			Connection    models.ConnectionInterface
+			ThresholdTime time.Time
+		}
+		Returns struct {
+			RowsAffected int
+			Error        error
+		}
+	}
+}
+
+func NewIdempotencyKeysRepo() *IdempotencyKeysRepo {
+	return &IdempotencyKeysRepo{}
+}
+
+func (r *IdempotencyKeysRepo) Create(conn models.ConnectionInterface, key models.IdempotencyKey) (models.IdempotencyKey, error) {
+	r.CreateCall.CallCount++
+	r.CreateCall.Receives.Connection = conn
+	r.CreateCall.Receives.IdempotencyKey = key
+
+	return r.CreateCall.Returns.IdempotencyKey, r.CreateCall.Returns.Error
+}
+
+func (r *IdempotencyKeysRepo) Find(conn models.ConnectionInterface, clientID, key string) (models.IdempotencyKey, error) {
+	r.FindCall.Receives.Connection = conn
+	r.FindCall.Receives.ClientID = clientID
+	r.FindCall.Receives.Key = key
+
+	return r.FindCall.Returns.IdempotencyKey, r.FindCall.Returns.Error
+}
+
+func (r *IdempotencyKeysRepo) Update(conn models.ConnectionInterface, key models.IdempotencyKey) (models.IdempotencyKey, error) {
+	r.UpdateCall.CallCount++
+	r.UpdateCall.Receives.Connection = conn
+	r.UpdateCall.Receives.IdempotencyKey = key
+
+	return key, r.UpdateCall.Returns.Error
+}
+
+func (r *IdempotencyKeysRepo) Reclaim(conn models.ConnectionInterface, key models.IdempotencyKey) (models.IdempotencyKey, error) {
+	r.ReclaimCall.CallCount++
+	r.ReclaimCall.Receives.Connection = conn
+	r.ReclaimCall.Receives.IdempotencyKey = key
+
+	return r.ReclaimCall.Returns.IdempotencyKey, r.ReclaimCall.Returns.Error
+}
+
+func (r *IdempotencyKeysRepo) Delete(conn models.ConnectionInterface, key models.IdempotencyKey) error {
+	r.DeleteCall.CallCount++
+	r.DeleteCall.Receives.Connection = conn
+	r.DeleteCall.Receives.IdempotencyKey = key
+
+	return r.DeleteCall.Returns.Error
+}
+
+func (r *IdempotencyKeysRepo) DeleteBefore(conn models.ConnectionInterface, thresholdTime time.Time) (int, error) {
+	r.DeleteBeforeCall.CallCount++
+	r.DeleteBeforeCall.Receives.Connection = conn
+	r.DeleteBeforeCall.Receives.ThresholdTime = thresholdTime
+
+	return r.DeleteBeforeCall.Returns.RowsAffected, r.DeleteBeforeCall.Returns.Error
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
+
+type KindPreferencesFinder struct {
+	FindCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+			ClientID string
+			KindID   string
+		}
+		Returns struct {
+			Preferences []models.KindPreference
+			Error       error
+		}
+	}
+}
+
+func NewKindPreferencesFinder() *KindPreferencesFinder {
+	return &KindPreferencesFinder{}
+}
+
+func (f *KindPreferencesFinder) Find(database services.DatabaseInterface, clientID, kindID string) ([]models.KindPreference, error) {
+	f.FindCall.Receives.Database = database
+	f.FindCall.Receives.ClientID = clientID
+	f.FindCall.Receives.KindID = kindID
+
+	return f.FindCall.Returns.Preferences, f.FindCall.Returns.Error
+}
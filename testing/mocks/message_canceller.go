@@ -0,0 +1,28 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/services"
+
+type MessageCanceller struct {
+	CancelCall struct {
+		Receives struct {
+			Database  services.DatabaseInterface
+			MessageID string
+			ClientID  string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func NewMessageCanceller() *MessageCanceller {
+	return &MessageCanceller{}
+}
+
+func (c *MessageCanceller) Cancel(database services.DatabaseInterface, messageID, clientID string) error {
+	c.CancelCall.Receives.Database = database
+	c.CancelCall.Receives.MessageID = messageID
+	c.CancelCall.Receives.ClientID = clientID
+
+	return c.CancelCall.Returns.Error
+}
@@ -7,6 +7,7 @@ type MessageFinder struct {
 		Receives struct {
 			Database  services.DatabaseInterface
 			MessageID string
+			ClientID  string
 		}
 		Returns struct {
 			Message services.Message
@@ -19,9 +20,10 @@ func NewMessageFinder() *MessageFinder {
 	return &MessageFinder{}
 }
 
-func (f *MessageFinder) Find(database services.DatabaseInterface, messageID string) (services.Message, error) {
+func (f *MessageFinder) Find(database services.DatabaseInterface, messageID, clientID string) (services.Message, error) {
 	f.FindCall.Receives.Database = database
 	f.FindCall.Receives.MessageID = messageID
+	f.FindCall.Receives.ClientID = clientID
 
 	return f.FindCall.Returns.Message, f.FindCall.Returns.Error
 }
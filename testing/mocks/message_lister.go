@@ -0,0 +1,27 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/services"
+
+type MessageLister struct {
+	ListCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+			Filter   services.MessageFilter
+		}
+		Returns struct {
+			MessageList services.MessageList
+			Error       error
+		}
+	}
+}
+
+func NewMessageLister() *MessageLister {
+	return &MessageLister{}
+}
+
+func (l *MessageLister) List(database services.DatabaseInterface, filter services.MessageFilter) (services.MessageList, error) {
+	l.ListCall.Receives.Database = database
+	l.ListCall.Receives.Filter = filter
+
+	return l.ListCall.Returns.MessageList, l.ListCall.Returns.Error
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -12,6 +13,7 @@ type MessageStatusUpdater struct {
 			MessageID     string
 			MessageStatus string
 			CampaignID    string
+			Attempt       common.DeliveryAttempt
 			Logger        lager.Logger
 		}
 	}
@@ -21,10 +23,11 @@ func NewMessageStatusUpdater() *MessageStatusUpdater {
 	return &MessageStatusUpdater{}
 }
 
-func (msu *MessageStatusUpdater) Update(conn db.ConnectionInterface, messageID, messageStatus, campaignID string, logger lager.Logger) {
+func (msu *MessageStatusUpdater) Update(conn db.ConnectionInterface, messageID, messageStatus, campaignID string, attempt common.DeliveryAttempt, logger lager.Logger) {
 	msu.UpdateCall.Receives.Connection = conn
 	msu.UpdateCall.Receives.MessageID = messageID
 	msu.UpdateCall.Receives.MessageStatus = messageStatus
 	msu.UpdateCall.Receives.CampaignID = campaignID
+	msu.UpdateCall.Receives.Attempt = attempt
 	msu.UpdateCall.Receives.Logger = logger
 }
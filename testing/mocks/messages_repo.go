@@ -41,6 +41,28 @@ type MessagesRepo struct {
 		}
 	}
 
+	ListCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Filter     models.MessagesFilter
+		}
+		Returns struct {
+			Messages []models.Message
+			Error    error
+		}
+	}
+
+	CountCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Filter     models.MessagesFilter
+		}
+		Returns struct {
+			Count int
+			Error error
+		}
+	}
+
 	DeleteBeforeCall struct {
 		InvocationTimes []time.Time
 		CallCount       int
@@ -83,6 +105,20 @@ func (mr *MessagesRepo) FindByID(conn models.ConnectionInterface, messageID stri
 	return mr.FindByIDCall.Returns.Message, mr.FindByIDCall.Returns.Error
 }
 
+func (mr *MessagesRepo) List(conn models.ConnectionInterface, filter models.MessagesFilter) ([]models.Message, error) {
+	mr.ListCall.Receives.Connection = conn
+	mr.ListCall.Receives.Filter = filter
+
+	return mr.ListCall.Returns.Messages, mr.ListCall.Returns.Error
+}
+
+func (mr *MessagesRepo) Count(conn models.ConnectionInterface, filter models.MessagesFilter) (int, error) {
+	mr.CountCall.Receives.Connection = conn
+	mr.CountCall.Receives.Filter = filter
+
+	return mr.CountCall.Returns.Count, mr.CountCall.Returns.Error
+}
+
 func (mr *MessagesRepo) DeleteBefore(conn models.ConnectionInterface, thresholdTime time.Time) (int, error) {
 	mr.DeleteBeforeCall.Receives.Connection = conn
 	mr.DeleteBeforeCall.Receives.ThresholdTime = thresholdTime
@@ -27,6 +27,16 @@ type Packager struct {
 			Error   error
 		}
 	}
+
+	RenderCall struct {
+		Receives struct {
+			MessageContext common.MessageContext
+		}
+		Returns struct {
+			Message mail.Message
+			Error   error
+		}
+	}
 }
 
 func NewPackager() *Packager {
@@ -46,3 +56,9 @@ func (p *Packager) Pack(context common.MessageContext) (mail.Message, error) {
 
 	return p.PackCall.Returns.Message, p.PackCall.Returns.Error
 }
+
+func (p *Packager) Render(context common.MessageContext) (mail.Message, error) {
+	p.RenderCall.Receives.MessageContext = context
+
+	return p.RenderCall.Returns.Message, p.RenderCall.Returns.Error
+}
@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
+
+type PreferenceChangesFinder struct {
+	ListCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+			UserID   string
+		}
+		Returns struct {
+			Changes []models.PreferenceChange
+			Error   error
+		}
+	}
+}
+
+func NewPreferenceChangesFinder() *PreferenceChangesFinder {
+	return &PreferenceChangesFinder{}
+}
+
+func (f *PreferenceChangesFinder) List(database services.DatabaseInterface, userID string) ([]models.PreferenceChange, error) {
+	f.ListCall.Receives.Database = database
+	f.ListCall.Receives.UserID = userID
+
+	return f.ListCall.Returns.Changes, f.ListCall.Returns.Error
+}
@@ -0,0 +1,44 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type PreferenceChangesRepo struct {
+	CreateCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Changes    []models.PreferenceChange
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	ListByUserIDCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			UserID     string
+		}
+		Returns struct {
+			Changes []models.PreferenceChange
+			Error   error
+		}
+	}
+}
+
+func NewPreferenceChangesRepo() *PreferenceChangesRepo {
+	return &PreferenceChangesRepo{}
+}
+
+func (r *PreferenceChangesRepo) Create(conn models.ConnectionInterface, change models.PreferenceChange) (models.PreferenceChange, error) {
+	r.CreateCall.Receives.Connection = conn
+	r.CreateCall.Receives.Changes = append(r.CreateCall.Receives.Changes, change)
+
+	return change, r.CreateCall.Returns.Error
+}
+
+func (r *PreferenceChangesRepo) ListByUserID(conn models.ConnectionInterface, userID string) ([]models.PreferenceChange, error) {
+	r.ListByUserIDCall.Receives.Connection = conn
+	r.ListByUserIDCall.Receives.UserID = userID
+
+	return r.ListByUserIDCall.Returns.Changes, r.ListByUserIDCall.Returns.Error
+}
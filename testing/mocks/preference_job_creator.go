@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
+
+type PreferenceJobCreator struct {
+	CreateCall struct {
+		Receives struct {
+			Connection services.ConnectionInterface
+			Payload    services.PreferencesJob
+		}
+		Returns struct {
+			Job   models.PreferenceJob
+			Error error
+		}
+	}
+}
+
+func NewPreferenceJobCreator() *PreferenceJobCreator {
+	return &PreferenceJobCreator{}
+}
+
+func (c *PreferenceJobCreator) Create(conn services.ConnectionInterface, payload services.PreferencesJob) (models.PreferenceJob, error) {
+	c.CreateCall.Receives.Connection = conn
+	c.CreateCall.Receives.Payload = payload
+
+	return c.CreateCall.Returns.Job, c.CreateCall.Returns.Error
+}
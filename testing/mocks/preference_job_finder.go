@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
+
+type PreferenceJobFinder struct {
+	FindCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+			JobID    string
+		}
+		Returns struct {
+			Job   models.PreferenceJob
+			Error error
+		}
+	}
+}
+
+func NewPreferenceJobFinder() *PreferenceJobFinder {
+	return &PreferenceJobFinder{}
+}
+
+func (f *PreferenceJobFinder) Find(database services.DatabaseInterface, jobID string) (models.PreferenceJob, error) {
+	f.FindCall.Receives.Database = database
+	f.FindCall.Receives.JobID = jobID
+
+	return f.FindCall.Returns.Job, f.FindCall.Returns.Error
+}
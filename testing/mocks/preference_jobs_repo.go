@@ -0,0 +1,62 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type PreferenceJobsRepo struct {
+	CreateCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Job        models.PreferenceJob
+		}
+		Returns struct {
+			Job   models.PreferenceJob
+			Error error
+		}
+	}
+
+	FindCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			JobID      string
+		}
+		Returns struct {
+			Job   models.PreferenceJob
+			Error error
+		}
+	}
+
+	UpdateCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Jobs       []models.PreferenceJob
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func NewPreferenceJobsRepo() *PreferenceJobsRepo {
+	return &PreferenceJobsRepo{}
+}
+
+func (r *PreferenceJobsRepo) Create(conn models.ConnectionInterface, job models.PreferenceJob) (models.PreferenceJob, error) {
+	r.CreateCall.Receives.Connection = conn
+	r.CreateCall.Receives.Job = job
+
+	return r.CreateCall.Returns.Job, r.CreateCall.Returns.Error
+}
+
+func (r *PreferenceJobsRepo) Find(conn models.ConnectionInterface, jobID string) (models.PreferenceJob, error) {
+	r.FindCall.Receives.Connection = conn
+	r.FindCall.Receives.JobID = jobID
+
+	return r.FindCall.Returns.Job, r.FindCall.Returns.Error
+}
+
+func (r *PreferenceJobsRepo) Update(conn models.ConnectionInterface, job models.PreferenceJob) (models.PreferenceJob, error) {
+	r.UpdateCall.Receives.Connection = conn
+	r.UpdateCall.Receives.Jobs = append(r.UpdateCall.Receives.Jobs, job)
+
+	return job, r.UpdateCall.Returns.Error
+}
@@ -11,23 +11,109 @@ type PreferenceUpdater struct {
 			Connection        services.ConnectionInterface
 			Preferences       []models.Preference
 			GlobalUnsubscribe bool
+			Frequency         string
 			UserID            string
+			Actor             services.Actor
 		}
 		Returns struct {
 			Error error
 		}
 	}
+
+	UpdateQuietHoursCall struct {
+		Receives struct {
+			Connection services.ConnectionInterface
+			UserID     string
+			TimeZone   string
+			QuietHours *services.QuietHours
+			Actor      services.Actor
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	UpdateLocaleCall struct {
+		Receives struct {
+			Connection services.ConnectionInterface
+			UserID     string
+			Locale     string
+			Actor      services.Actor
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	ValidateCall struct {
+		Receives struct {
+			Connection  services.ConnectionInterface
+			Preferences []models.Preference
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	UpdateKindsCall struct {
+		Receives struct {
+			Connection  services.ConnectionInterface
+			UserIDs     []string
+			Preferences []models.Preference
+			Actor       services.Actor
+		}
+		Returns struct {
+			Errors map[string]error
+		}
+	}
 }
 
 func NewPreferenceUpdater() *PreferenceUpdater {
 	return &PreferenceUpdater{}
 }
 
-func (pu *PreferenceUpdater) Update(conn services.ConnectionInterface, preferences []models.Preference, globalUnsubscribe bool, userID string) error {
+func (pu *PreferenceUpdater) Update(conn services.ConnectionInterface, preferences []models.Preference, globalUnsubscribe bool, frequency string, userID string, actor services.Actor) error {
 	pu.UpdateCall.Receives.Connection = conn
 	pu.UpdateCall.Receives.Preferences = preferences
 	pu.UpdateCall.Receives.GlobalUnsubscribe = globalUnsubscribe
+	pu.UpdateCall.Receives.Frequency = frequency
 	pu.UpdateCall.Receives.UserID = userID
+	pu.UpdateCall.Receives.Actor = actor
 
 	return pu.UpdateCall.Returns.Error
 }
+
+func (pu *PreferenceUpdater) UpdateQuietHours(conn services.ConnectionInterface, userID string, timeZone string, quietHours *services.QuietHours, actor services.Actor) error {
+	pu.UpdateQuietHoursCall.Receives.Connection = conn
+	pu.UpdateQuietHoursCall.Receives.UserID = userID
+	pu.UpdateQuietHoursCall.Receives.TimeZone = timeZone
+	pu.UpdateQuietHoursCall.Receives.QuietHours = quietHours
+	pu.UpdateQuietHoursCall.Receives.Actor = actor
+
+	return pu.UpdateQuietHoursCall.Returns.Error
+}
+
+func (pu *PreferenceUpdater) UpdateLocale(conn services.ConnectionInterface, userID string, locale string, actor services.Actor) error {
+	pu.UpdateLocaleCall.Receives.Connection = conn
+	pu.UpdateLocaleCall.Receives.UserID = userID
+	pu.UpdateLocaleCall.Receives.Locale = locale
+	pu.UpdateLocaleCall.Receives.Actor = actor
+
+	return pu.UpdateLocaleCall.Returns.Error
+}
+
+func (pu *PreferenceUpdater) Validate(conn services.ConnectionInterface, preferences []models.Preference) error {
+	pu.ValidateCall.Receives.Connection = conn
+	pu.ValidateCall.Receives.Preferences = preferences
+
+	return pu.ValidateCall.Returns.Error
+}
+
+func (pu *PreferenceUpdater) UpdateKinds(conn services.ConnectionInterface, userID string, preferences []models.Preference, actor services.Actor) error {
+	pu.UpdateKindsCall.Receives.Connection = conn
+	pu.UpdateKindsCall.Receives.UserIDs = append(pu.UpdateKindsCall.Receives.UserIDs, userID)
+	pu.UpdateKindsCall.Receives.Preferences = preferences
+	pu.UpdateKindsCall.Receives.Actor = actor
+
+	return pu.UpdateKindsCall.Returns.Errors[userID]
+}
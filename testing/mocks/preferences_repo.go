@@ -13,6 +13,18 @@ type PreferencesRepo struct {
 			Error       error
 		}
 	}
+
+	FindAllByKindCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			ClientID   string
+			KindID     string
+		}
+		Returns struct {
+			Preferences []models.KindPreference
+			Error       error
+		}
+	}
 }
 
 func NewPreferencesRepo() *PreferencesRepo {
@@ -25,3 +37,11 @@ func (pr *PreferencesRepo) FindNonCriticalPreferences(conn models.ConnectionInte
 
 	return pr.FindNonCriticalPreferencesCall.Returns.Preferences, pr.FindNonCriticalPreferencesCall.Returns.Error
 }
+
+func (pr *PreferencesRepo) FindAllByKind(conn models.ConnectionInterface, clientID, kindID string) ([]models.KindPreference, error) {
+	pr.FindAllByKindCall.Receives.Connection = conn
+	pr.FindAllByKindCall.Receives.ClientID = clientID
+	pr.FindAllByKindCall.Receives.KindID = kindID
+
+	return pr.FindAllByKindCall.Returns.Preferences, pr.FindAllByKindCall.Returns.Error
+}
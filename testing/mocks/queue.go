@@ -16,12 +16,94 @@ type Queue struct {
 		Hook func()
 	}
 
+	CancelCall struct {
+		Receives struct {
+			JobID      int
+			Connection gobble.ExecConnectionInterface
+		}
+		Returns struct {
+			Canceled bool
+			Error    error
+		}
+	}
+
 	RequeueCall struct {
 		Receives struct {
 			Job *gobble.Job
 		}
 	}
 
+	BuryCall struct {
+		Receives struct {
+			Job *gobble.Job
+		}
+	}
+
+	DeadJobsCall struct {
+		Receives struct {
+			Connection gobble.DeadJobsConnectionInterface
+			Limit      int
+			Offset     int
+		}
+		Returns struct {
+			DeadJobs []gobble.DeadJob
+			Error    error
+		}
+	}
+
+	CountDeadJobsCall struct {
+		Receives struct {
+			Connection gobble.DeadJobsConnectionInterface
+		}
+		Returns struct {
+			Count int
+			Error error
+		}
+	}
+
+	FindDeadJobCall struct {
+		Receives struct {
+			Connection gobble.DeadJobsConnectionInterface
+			ID         int
+		}
+		Returns struct {
+			DeadJob gobble.DeadJob
+			Error   error
+		}
+	}
+
+	ReplayDeadJobCall struct {
+		Receives struct {
+			Connection gobble.DeadJobsConnectionInterface
+			ID         int
+		}
+		Returns struct {
+			JobID int
+			Error error
+		}
+	}
+
+	DeleteDeadJobCall struct {
+		Receives struct {
+			Connection gobble.DeadJobsConnectionInterface
+			ID         int
+		}
+		Returns struct {
+			Deleted bool
+			Error   error
+		}
+	}
+
+	DeleteDeadJobsCall struct {
+		Receives struct {
+			Connection gobble.DeadJobsConnectionInterface
+		}
+		Returns struct {
+			Count int
+			Error error
+		}
+	}
+
 	DequeueCall struct {
 		Receives struct {
 			Job *gobble.Job
@@ -44,6 +126,16 @@ type Queue struct {
 		}
 	}
 
+	ReservePriorityCall struct {
+		Receives struct {
+			ID       string
+			Priority int
+		}
+		Returns struct {
+			Chan <-chan *gobble.Job
+		}
+	}
+
 	RetryQueueLengthsCall struct {
 		Returns struct {
 			Lengths map[int]int
@@ -67,10 +159,62 @@ func (q *Queue) Enqueue(job *gobble.Job, connection gobble.ConnectionInterface)
 	return q.EnqueueCall.Returns.Job, q.EnqueueCall.Returns.Error
 }
 
+func (q *Queue) Cancel(jobID int, connection gobble.ExecConnectionInterface) (bool, error) {
+	q.CancelCall.Receives.JobID = jobID
+	q.CancelCall.Receives.Connection = connection
+
+	return q.CancelCall.Returns.Canceled, q.CancelCall.Returns.Error
+}
+
 func (q *Queue) Dequeue(job *gobble.Job) {
 	q.DequeueCall.Receives.Job = job
 }
 
+func (q *Queue) Bury(job *gobble.Job) {
+	q.BuryCall.Receives.Job = job
+}
+
+func (q *Queue) DeadJobs(connection gobble.DeadJobsConnectionInterface, limit, offset int) ([]gobble.DeadJob, error) {
+	q.DeadJobsCall.Receives.Connection = connection
+	q.DeadJobsCall.Receives.Limit = limit
+	q.DeadJobsCall.Receives.Offset = offset
+
+	return q.DeadJobsCall.Returns.DeadJobs, q.DeadJobsCall.Returns.Error
+}
+
+func (q *Queue) CountDeadJobs(connection gobble.DeadJobsConnectionInterface) (int, error) {
+	q.CountDeadJobsCall.Receives.Connection = connection
+
+	return q.CountDeadJobsCall.Returns.Count, q.CountDeadJobsCall.Returns.Error
+}
+
+func (q *Queue) FindDeadJob(connection gobble.DeadJobsConnectionInterface, id int) (gobble.DeadJob, error) {
+	q.FindDeadJobCall.Receives.Connection = connection
+	q.FindDeadJobCall.Receives.ID = id
+
+	return q.FindDeadJobCall.Returns.DeadJob, q.FindDeadJobCall.Returns.Error
+}
+
+func (q *Queue) ReplayDeadJob(connection gobble.DeadJobsConnectionInterface, id int) (int, error) {
+	q.ReplayDeadJobCall.Receives.Connection = connection
+	q.ReplayDeadJobCall.Receives.ID = id
+
+	return q.ReplayDeadJobCall.Returns.JobID, q.ReplayDeadJobCall.Returns.Error
+}
+
+func (q *Queue) DeleteDeadJob(connection gobble.DeadJobsConnectionInterface, id int) (bool, error) {
+	q.DeleteDeadJobCall.Receives.Connection = connection
+	q.DeleteDeadJobCall.Receives.ID = id
+
+	return q.DeleteDeadJobCall.Returns.Deleted, q.DeleteDeadJobCall.Returns.Error
+}
+
+func (q *Queue) DeleteDeadJobs(connection gobble.DeadJobsConnectionInterface) (int, error) {
+	q.DeleteDeadJobsCall.Receives.Connection = connection
+
+	return q.DeleteDeadJobsCall.Returns.Count, q.DeleteDeadJobsCall.Returns.Error
+}
+
 func (q *Queue) Requeue(job *gobble.Job) {
 	q.RequeueCall.Receives.Job = job
 }
@@ -85,6 +229,13 @@ func (q *Queue) Reserve(id string) <-chan *gobble.Job {
 	return q.ReserveCall.Returns.Chan
 }
 
+func (q *Queue) ReservePriority(id string, priority int) <-chan *gobble.Job {
+	q.ReservePriorityCall.Receives.ID = id
+	q.ReservePriorityCall.Receives.Priority = priority
+
+	return q.ReservePriorityCall.Returns.Chan
+}
+
 func (q *Queue) RetryQueueLengths() (map[int]int, error) {
 	return q.RetryQueueLengthsCall.Returns.Lengths, q.RetryQueueLengthsCall.Returns.Error
 }
@@ -0,0 +1,48 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type QuietHoursRepo struct {
+	GetCall struct {
+		WasCalled bool
+		Receives  struct {
+			Connection models.ConnectionInterface
+			UserID     string
+		}
+		Returns struct {
+			QuietHours models.QuietHours
+			Error      error
+		}
+	}
+
+	SetCall struct {
+		WasCalled bool
+		Receives  struct {
+			Connection models.ConnectionInterface
+			QuietHours models.QuietHours
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func NewQuietHoursRepo() *QuietHoursRepo {
+	return &QuietHoursRepo{}
+}
+
+func (r *QuietHoursRepo) Get(conn models.ConnectionInterface, userID string) (models.QuietHours, error) {
+	r.GetCall.WasCalled = true
+	r.GetCall.Receives.Connection = conn
+	r.GetCall.Receives.UserID = userID
+
+	return r.GetCall.Returns.QuietHours, r.GetCall.Returns.Error
+}
+
+func (r *QuietHoursRepo) Set(conn models.ConnectionInterface, quietHours models.QuietHours) error {
+	r.SetCall.WasCalled = true
+	r.SetCall.Receives.Connection = conn
+	r.SetCall.Receives.QuietHours = quietHours
+
+	return r.SetCall.Returns.Error
+}
@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
+
+type QuotaFinder struct {
+	ListCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+		}
+		Returns struct {
+			Quotas []models.Quota
+			Error  error
+		}
+	}
+
+	FindCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+			ClientID string
+			KindID   string
+		}
+		Returns struct {
+			Quota models.Quota
+			Error error
+		}
+	}
+}
+
+func NewQuotaFinder() *QuotaFinder {
+	return &QuotaFinder{}
+}
+
+func (f *QuotaFinder) List(database services.DatabaseInterface) ([]models.Quota, error) {
+	f.ListCall.Receives.Database = database
+
+	return f.ListCall.Returns.Quotas, f.ListCall.Returns.Error
+}
+
+func (f *QuotaFinder) Find(database services.DatabaseInterface, clientID, kindID string) (models.Quota, error) {
+	f.FindCall.Receives.Database = database
+	f.FindCall.Receives.ClientID = clientID
+	f.FindCall.Receives.KindID = kindID
+
+	return f.FindCall.Returns.Quota, f.FindCall.Returns.Error
+}
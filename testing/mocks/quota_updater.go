@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
+
+type QuotaUpdater struct {
+	UpdateCall struct {
+		WasCalled bool
+		Receives  struct {
+			Database services.DatabaseInterface
+			Quota    models.Quota
+		}
+		Returns struct {
+			Quota models.Quota
+			Error error
+		}
+	}
+
+	DeleteCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+			ClientID string
+			KindID   string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func NewQuotaUpdater() *QuotaUpdater {
+	return &QuotaUpdater{}
+}
+
+func (u *QuotaUpdater) Update(database services.DatabaseInterface, quota models.Quota) (models.Quota, error) {
+	u.UpdateCall.WasCalled = true
+	u.UpdateCall.Receives.Database = database
+	u.UpdateCall.Receives.Quota = quota
+
+	return u.UpdateCall.Returns.Quota, u.UpdateCall.Returns.Error
+}
+
+func (u *QuotaUpdater) Delete(database services.DatabaseInterface, clientID, kindID string) error {
+	u.DeleteCall.Receives.Database = database
+	u.DeleteCall.Receives.ClientID = clientID
+	u.DeleteCall.Receives.KindID = kindID
+
+	return u.DeleteCall.Returns.Error
+}
@@ -0,0 +1,34 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type QuotaUsagesRepo struct {
+	IncrementCall struct {
+		CallCount int
+		Receives  struct {
+			Connection models.ConnectionInterface
+			Usages     []models.QuotaUsage
+		}
+		Returns struct {
+			Counts []int
+			Error  error
+		}
+	}
+}
+
+func NewQuotaUsagesRepo() *QuotaUsagesRepo {
+	return &QuotaUsagesRepo{}
+}
+
+func (r *QuotaUsagesRepo) Increment(conn models.ConnectionInterface, usage models.QuotaUsage) (int, error) {
+	r.IncrementCall.Receives.Connection = conn
+	r.IncrementCall.Receives.Usages = append(r.IncrementCall.Receives.Usages, usage)
+
+	var count int
+	if len(r.IncrementCall.Returns.Counts) > r.IncrementCall.CallCount {
+		count = r.IncrementCall.Returns.Counts[r.IncrementCall.CallCount]
+	}
+	r.IncrementCall.CallCount++
+
+	return count, r.IncrementCall.Returns.Error
+}
@@ -0,0 +1,102 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type QuotasRepo struct {
+	UpsertCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Quota      models.Quota
+		}
+		Returns struct {
+			Quota models.Quota
+			Error error
+		}
+	}
+
+	FindCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			ClientID   string
+			KindID     string
+		}
+		Returns struct {
+			Quota models.Quota
+			Error error
+		}
+	}
+
+	FindApplicableCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			ClientID   string
+			KindID     string
+		}
+		Returns struct {
+			Quotas []models.Quota
+			Error  error
+		}
+	}
+
+	ListCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+		}
+		Returns struct {
+			Quotas []models.Quota
+			Error  error
+		}
+	}
+
+	DeleteCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			ClientID   string
+			KindID     string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func NewQuotasRepo() *QuotasRepo {
+	return &QuotasRepo{}
+}
+
+func (r *QuotasRepo) Upsert(conn models.ConnectionInterface, quota models.Quota) (models.Quota, error) {
+	r.UpsertCall.Receives.Connection = conn
+	r.UpsertCall.Receives.Quota = quota
+
+	return r.UpsertCall.Returns.Quota, r.UpsertCall.Returns.Error
+}
+
+func (r *QuotasRepo) Find(conn models.ConnectionInterface, clientID, kindID string) (models.Quota, error) {
+	r.FindCall.Receives.Connection = conn
+	r.FindCall.Receives.ClientID = clientID
+	r.FindCall.Receives.KindID = kindID
+
+	return r.FindCall.Returns.Quota, r.FindCall.Returns.Error
+}
+
+func (r *QuotasRepo) FindApplicable(conn models.ConnectionInterface, clientID, kindID string) ([]models.Quota, error) {
+	r.FindApplicableCall.Receives.Connection = conn
+	r.FindApplicableCall.Receives.ClientID = clientID
+	r.FindApplicableCall.Receives.KindID = kindID
+
+	return r.FindApplicableCall.Returns.Quotas, r.FindApplicableCall.Returns.Error
+}
+
+func (r *QuotasRepo) List(conn models.ConnectionInterface) ([]models.Quota, error) {
+	r.ListCall.Receives.Connection = conn
+
+	return r.ListCall.Returns.Quotas, r.ListCall.Returns.Error
+}
+
+func (r *QuotasRepo) Delete(conn models.ConnectionInterface, clientID, kindID string) error {
+	r.DeleteCall.Receives.Connection = conn
+	r.DeleteCall.Receives.ClientID = clientID
+	r.DeleteCall.Receives.KindID = kindID
+
+	return r.DeleteCall.Returns.Error
+}
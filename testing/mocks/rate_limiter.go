@@ -0,0 +1,53 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/services"
+
+type RateLimiter struct {
+	LimitRequestsCall struct {
+		CallCount int
+		Receives  struct {
+			Connection services.ConnectionInterface
+			ClientID   string
+			KindID     string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+
+	LimitRecipientsCall struct {
+		CallCount int
+		Receives  struct {
+			Connection services.ConnectionInterface
+			ClientID   string
+			KindID     string
+			Count      int
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{}
+}
+
+func (l *RateLimiter) LimitRequests(conn services.ConnectionInterface, clientID, kindID string) error {
+	l.LimitRequestsCall.CallCount++
+	l.LimitRequestsCall.Receives.Connection = conn
+	l.LimitRequestsCall.Receives.ClientID = clientID
+	l.LimitRequestsCall.Receives.KindID = kindID
+
+	return l.LimitRequestsCall.Returns.Error
+}
+
+func (l *RateLimiter) LimitRecipients(conn services.ConnectionInterface, clientID, kindID string, count int) error {
+	l.LimitRecipientsCall.CallCount++
+	l.LimitRecipientsCall.Receives.Connection = conn
+	l.LimitRecipientsCall.Receives.ClientID = clientID
+	l.LimitRecipientsCall.Receives.KindID = kindID
+	l.LimitRecipientsCall.Receives.Count = count
+
+	return l.LimitRecipientsCall.Returns.Error
+}
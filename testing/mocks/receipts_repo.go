@@ -4,7 +4,8 @@ import "github.com/cloudfoundry-incubator/notifications/v1/models"
 
 type ReceiptsRepo struct {
 	CreateReceiptsCall struct {
-		Receives struct {
+		WasCalled bool
+		Receives  struct {
 			Connection models.ConnectionInterface
 			UserGUIDs  []string
 			ClientID   string
@@ -21,6 +22,7 @@ func NewReceiptsRepo() *ReceiptsRepo {
 }
 
 func (rr *ReceiptsRepo) CreateReceipts(conn models.ConnectionInterface, userGUIDs []string, clientID, kindID string) error {
+	rr.CreateReceiptsCall.WasCalled = true
 	rr.CreateReceiptsCall.Receives.Connection = conn
 	rr.CreateReceiptsCall.Receives.UserGUIDs = userGUIDs
 	rr.CreateReceiptsCall.Receives.ClientID = clientID
@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+type SendRatesRepo struct {
+	IncrementCall struct {
+		CallCount int
+		Receives  struct {
+			Connection   models.ConnectionInterface
+			Scopes       []string
+			WindowStarts []time.Time
+		}
+		Returns struct {
+			Counts []int
+			Error  error
+		}
+	}
+
+	DeleteBeforeCall struct {
+		CallCount int
+		Receives  struct {
+			Connection    models.ConnectionInterface
+			ThresholdTime time.Time
+		}
+		Returns struct {
+			RowsAffected int
+			Error        error
+		}
+	}
+}
+
+func NewSendRatesRepo() *SendRatesRepo {
+	return &SendRatesRepo{}
+}
+
+func (r *SendRatesRepo) Increment(conn models.ConnectionInterface, scope string, windowStart time.Time) (int, error) {
+	r.IncrementCall.Receives.Connection = conn
+	r.IncrementCall.Receives.Scopes = append(r.IncrementCall.Receives.Scopes, scope)
+	r.IncrementCall.Receives.WindowStarts = append(r.IncrementCall.Receives.WindowStarts, windowStart)
+
+	var count int
+	if len(r.IncrementCall.Returns.Counts) > r.IncrementCall.CallCount {
+		count = r.IncrementCall.Returns.Counts[r.IncrementCall.CallCount]
+	}
+	r.IncrementCall.CallCount++
+
+	return count, r.IncrementCall.Returns.Error
+}
+
+func (r *SendRatesRepo) DeleteBefore(conn models.ConnectionInterface, thresholdTime time.Time) (int, error) {
+	r.DeleteBeforeCall.CallCount++
+	r.DeleteBeforeCall.Receives.Connection = conn
+	r.DeleteBeforeCall.Receives.ThresholdTime = thresholdTime
+
+	return r.DeleteBeforeCall.Returns.RowsAffected, r.DeleteBeforeCall.Returns.Error
+}
@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
+
+type SuppressionFinder struct {
+	ListCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+			Page     int
+			PerPage  int
+		}
+		Returns struct {
+			SuppressionList services.SuppressionList
+			Error           error
+		}
+	}
+
+	FindCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+			Email    string
+		}
+		Returns struct {
+			Suppression models.Suppression
+			Error       error
+		}
+	}
+}
+
+func NewSuppressionFinder() *SuppressionFinder {
+	return &SuppressionFinder{}
+}
+
+func (f *SuppressionFinder) List(database services.DatabaseInterface, page, perPage int) (services.SuppressionList, error) {
+	f.ListCall.Receives.Database = database
+	f.ListCall.Receives.Page = page
+	f.ListCall.Receives.PerPage = perPage
+
+	return f.ListCall.Returns.SuppressionList, f.ListCall.Returns.Error
+}
+
+func (f *SuppressionFinder) Find(database services.DatabaseInterface, email string) (models.Suppression, error) {
+	f.FindCall.Receives.Database = database
+	f.FindCall.Receives.Email = email
+
+	return f.FindCall.Returns.Suppression, f.FindCall.Returns.Error
+}
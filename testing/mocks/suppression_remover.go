@@ -0,0 +1,26 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/services"
+
+type SuppressionRemover struct {
+	RemoveCall struct {
+		Receives struct {
+			Database services.DatabaseInterface
+			Email    string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func NewSuppressionRemover() *SuppressionRemover {
+	return &SuppressionRemover{}
+}
+
+func (r *SuppressionRemover) Remove(database services.DatabaseInterface, email string) error {
+	r.RemoveCall.Receives.Database = database
+	r.RemoveCall.Receives.Email = email
+
+	return r.RemoveCall.Returns.Error
+}
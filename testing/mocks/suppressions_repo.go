@@ -0,0 +1,118 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type SuppressionsRepo struct {
+	UpsertCall struct {
+		CallCount int
+		Receives  struct {
+			Connection  models.ConnectionInterface
+			Suppression models.Suppression
+		}
+		Returns struct {
+			Suppression models.Suppression
+			Error       error
+		}
+	}
+
+	FindCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Email      string
+		}
+		Returns struct {
+			Suppression models.Suppression
+			Error       error
+		}
+	}
+
+	IsSuppressedCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Email      string
+		}
+		Returns struct {
+			Suppressed bool
+			Error      error
+		}
+	}
+
+	ListCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Limit      int
+			Offset     int
+		}
+		Returns struct {
+			Suppressions []models.Suppression
+			Error        error
+		}
+	}
+
+	CountCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+		}
+		Returns struct {
+			Count int
+			Error error
+		}
+	}
+
+	DeleteCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			Email      string
+		}
+		Returns struct {
+			Error error
+		}
+	}
+}
+
+func NewSuppressionsRepo() *SuppressionsRepo {
+	return &SuppressionsRepo{}
+}
+
+func (r *SuppressionsRepo) Upsert(conn models.ConnectionInterface, suppression models.Suppression) (models.Suppression, error) {
+	r.UpsertCall.CallCount++
+	r.UpsertCall.Receives.Connection = conn
+	r.UpsertCall.Receives.Suppression = suppression
+
+	return r.UpsertCall.Returns.Suppression, r.UpsertCall.Returns.Error
+}
+
+func (r *SuppressionsRepo) Find(conn models.ConnectionInterface, email string) (models.Suppression, error) {
+	r.FindCall.Receives.Connection = conn
+	r.FindCall.Receives.Email = email
+
+	return r.FindCall.Returns.Suppression, r.FindCall.Returns.Error
+}
+
+func (r *SuppressionsRepo) IsSuppressed(conn models.ConnectionInterface, email string) (bool, error) {
+	r.IsSuppressedCall.Receives.Connection = conn
+	r.IsSuppressedCall.Receives.Email = email
+
+	return r.IsSuppressedCall.Returns.Suppressed, r.IsSuppressedCall.Returns.Error
+}
+
+func (r *SuppressionsRepo) List(conn models.ConnectionInterface, limit, offset int) ([]models.Suppression, error) {
+	r.ListCall.Receives.Connection = conn
+	r.ListCall.Receives.Limit = limit
+	r.ListCall.Receives.Offset = offset
+
+	return r.ListCall.Returns.Suppressions, r.ListCall.Returns.Error
+}
+
+func (r *SuppressionsRepo) Count(conn models.ConnectionInterface) (int, error) {
+	r.CountCall.Receives.Connection = conn
+
+	return r.CountCall.Returns.Count, r.CountCall.Returns.Error
+}
+
+func (r *SuppressionsRepo) Delete(conn models.ConnectionInterface, email string) error {
+	r.DeleteCall.Receives.Connection = conn
+	r.DeleteCall.Receives.Email = email
+
+	return r.DeleteCall.Returns.Error
+}
@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
+
+type Suppressor struct {
+	SuppressCall struct {
+		WasCalled bool
+		Receives  struct {
+			Database services.DatabaseInterface
+			Email    string
+			Reason   string
+			Detail   string
+		}
+		Returns struct {
+			Suppression models.Suppression
+			Error       error
+		}
+	}
+}
+
+func NewSuppressor() *Suppressor {
+	return &Suppressor{}
+}
+
+func (s *Suppressor) Suppress(database services.DatabaseInterface, email, reason, detail string) (models.Suppression, error) {
+	s.SuppressCall.WasCalled = true
+	s.SuppressCall.Receives.Database = database
+	s.SuppressCall.Receives.Email = email
+	s.SuppressCall.Receives.Reason = reason
+	s.SuppressCall.Receives.Detail = detail
+
+	return s.SuppressCall.Returns.Suppression, s.SuppressCall.Returns.Error
+}
@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
+
+type TemplateRenderer struct {
+	RenderCall struct {
+		Receives struct {
+			Database   services.DatabaseInterface
+			TemplateID string
+			Context    common.MessageContext
+		}
+		Returns struct {
+			Rendering services.TemplateRendering
+			Error     error
+		}
+	}
+
+	RenderTemplateCall struct {
+		Receives struct {
+			Template models.Template
+			Context  common.MessageContext
+		}
+		Returns struct {
+			Rendering services.TemplateRendering
+			Error     error
+		}
+	}
+}
+
+func NewTemplateRenderer() *TemplateRenderer {
+	return &TemplateRenderer{}
+}
+
+func (r *TemplateRenderer) Render(database services.DatabaseInterface, templateID string, context common.MessageContext) (services.TemplateRendering, error) {
+	r.RenderCall.Receives.Database = database
+	r.RenderCall.Receives.TemplateID = templateID
+	r.RenderCall.Receives.Context = context
+
+	return r.RenderCall.Returns.Rendering, r.RenderCall.Returns.Error
+}
+
+func (r *TemplateRenderer) RenderTemplate(template models.Template, context common.MessageContext) (services.TemplateRendering, error) {
+	r.RenderTemplateCall.Receives.Template = template
+	r.RenderTemplateCall.Receives.Context = context
+
+	return r.RenderTemplateCall.Returns.Rendering, r.RenderTemplateCall.Returns.Error
+}
@@ -11,21 +11,45 @@ type TemplateUpdater struct {
 			Database   services.DatabaseInterface
 			TemplateID string
 			Template   models.Template
+			ClientID   string
 		}
 		Returns struct {
 			Error error
 		}
 	}
+
+	RollbackCall struct {
+		Receives struct {
+			Database   services.DatabaseInterface
+			TemplateID string
+			Version    int
+			ClientID   string
+		}
+		Returns struct {
+			Version models.TemplateVersion
+			Error   error
+		}
+	}
 }
 
 func NewTemplateUpdater() *TemplateUpdater {
 	return &TemplateUpdater{}
 }
 
-func (tu *TemplateUpdater) Update(database services.DatabaseInterface, templateID string, template models.Template) error {
+func (tu *TemplateUpdater) Update(database services.DatabaseInterface, templateID string, template models.Template, clientID string) error {
 	tu.UpdateCall.Receives.Database = database
 	tu.UpdateCall.Receives.TemplateID = templateID
 	tu.UpdateCall.Receives.Template = template
+	tu.UpdateCall.Receives.ClientID = clientID
 
 	return tu.UpdateCall.Returns.Error
 }
+
+func (tu *TemplateUpdater) Rollback(database services.DatabaseInterface, templateID string, version int, clientID string) (models.TemplateVersion, error) {
+	tu.RollbackCall.Receives.Database = database
+	tu.RollbackCall.Receives.TemplateID = templateID
+	tu.RollbackCall.Receives.Version = version
+	tu.RollbackCall.Receives.ClientID = clientID
+
+	return tu.RollbackCall.Returns.Version, tu.RollbackCall.Returns.Error
+}
@@ -0,0 +1,72 @@
+package mocks
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
+
+type TemplateVersionsFinder struct {
+	ListCall struct {
+		Receives struct {
+			Database   services.DatabaseInterface
+			TemplateID string
+		}
+		Returns struct {
+			Versions []models.TemplateVersion
+			Error    error
+		}
+	}
+
+	FindCall struct {
+		Receives struct {
+			Database   services.DatabaseInterface
+			TemplateID string
+			Version    int
+		}
+		Returns struct {
+			Version models.TemplateVersion
+			Error   error
+		}
+	}
+
+	DiffCall struct {
+		Receives struct {
+			Database   services.DatabaseInterface
+			TemplateID string
+			Version    int
+			From       int
+		}
+		Returns struct {
+			Diff  services.TemplateDiff
+			Error error
+		}
+	}
+}
+
+func NewTemplateVersionsFinder() *TemplateVersionsFinder {
+	return &TemplateVersionsFinder{}
+}
+
+func (f *TemplateVersionsFinder) List(database services.DatabaseInterface, templateID string) ([]models.TemplateVersion, error) {
+	f.ListCall.Receives.Database = database
+	f.ListCall.Receives.TemplateID = templateID
+
+	return f.ListCall.Returns.Versions, f.ListCall.Returns.Error
+}
+
+func (f *TemplateVersionsFinder) Find(database services.DatabaseInterface, templateID string, version int) (models.TemplateVersion, error) {
+	f.FindCall.Receives.Database = database
+	f.FindCall.Receives.TemplateID = templateID
+	f.FindCall.Receives.Version = version
+
+	return f.FindCall.Returns.Version, f.FindCall.Returns.Error
+}
+
+func (f *TemplateVersionsFinder) Diff(database services.DatabaseInterface, templateID string, version, from int) (services.TemplateDiff, error) {
+	f.DiffCall.Receives.Database = database
+	f.DiffCall.Receives.TemplateID = templateID
+	f.DiffCall.Receives.Version = version
+	f.DiffCall.Receives.From = from
+
+	return f.DiffCall.Returns.Diff, f.DiffCall.Returns.Error
+}
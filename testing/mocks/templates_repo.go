@@ -35,6 +35,17 @@ type TemplatesRepo struct {
 		}
 	}
 
+	FindByIDForUpdateCall struct {
+		Receives struct {
+			Connection models.ConnectionInterface
+			TemplateID string
+		}
+		Returns struct {
+			Template models.Template
+			Error    error
+		}
+	}
+
 	ListIDsAndNamesCall struct {
 		Receives struct {
 			Connection models.ConnectionInterface
@@ -83,6 +94,13 @@ func (tr *TemplatesRepo) FindByID(conn models.ConnectionInterface, templateID st
 	return tr.FindByIDCall.Returns.Template, tr.FindByIDCall.Returns.Error
 }
 
+func (tr *TemplatesRepo) FindByIDForUpdate(conn models.ConnectionInterface, templateID string) (models.Template, error) {
+	tr.FindByIDForUpdateCall.Receives.Connection = conn
+	tr.FindByIDForUpdateCall.Receives.TemplateID = templateID
+
+	return tr.FindByIDForUpdateCall.Returns.Template, tr.FindByIDForUpdateCall.Returns.Error
+}
+
 func (tr *TemplatesRepo) ListIDsAndNames(conn models.ConnectionInterface) ([]models.Template, error) {
 	tr.ListIDsAndNamesCall.Receives.Connection = conn
 
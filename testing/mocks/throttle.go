@@ -0,0 +1,23 @@
+package mocks
+
+import "github.com/pivotal-golang/lager"
+
+type Throttle struct {
+	WaitCall struct {
+		CallCount int
+		Receives  struct {
+			Recipient string
+			Logger    lager.Logger
+		}
+	}
+}
+
+func NewThrottle() *Throttle {
+	return &Throttle{}
+}
+
+func (t *Throttle) Wait(recipient string, logger lager.Logger) {
+	t.WaitCall.CallCount++
+	t.WaitCall.Receives.Recipient = recipient
+	t.WaitCall.Receives.Logger = logger
+}
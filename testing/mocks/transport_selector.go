@@ -0,0 +1,28 @@
+package mocks
+
+import "github.com/cloudfoundry-incubator/notifications/mail"
+
+type TransportSelector struct {
+	SelectCall struct {
+		CallCount int
+		Receives  struct {
+			ClientID string
+			KindID   string
+		}
+		Returns struct {
+			Transport mail.Transport
+		}
+	}
+}
+
+func NewTransportSelector() *TransportSelector {
+	return &TransportSelector{}
+}
+
+func (ts *TransportSelector) Select(clientID, kindID string) mail.Transport {
+	ts.SelectCall.CallCount++
+	ts.SelectCall.Receives.ClientID = clientID
+	ts.SelectCall.Receives.KindID = kindID
+
+	return ts.SelectCall.Returns.Transport
+}
@@ -0,0 +1,15 @@
+package mocks
+
+// UnthrottledTransport is a transport that, like the webhook and spool
+// transports, is not held to the send rate of a mail server.
+type UnthrottledTransport struct {
+	*MailClient
+}
+
+func NewUnthrottledTransport() *UnthrottledTransport {
+	return &UnthrottledTransport{
+		MailClient: NewMailClient(),
+	}
+}
+
+func (t *UnthrottledTransport) Unthrottled() {}
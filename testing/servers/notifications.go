@@ -195,7 +195,7 @@ func freePort() string {
 }
 
 func openDatabase(env application.Environment) *sql.DB {
-	sqlDB, err := sql.Open("mysql", env.DatabaseURL)
+	sqlDB, err := sql.Open(env.DatabaseDriver, env.DatabaseURL)
 	if err != nil {
 		ginkgo.Fail(err.Error(), 1)
 	}
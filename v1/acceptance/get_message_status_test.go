@@ -63,5 +63,19 @@ var _ = Describe("Getting a Message's status", func() {
 				Error:   nil,
 			}))
 		})
+
+		By("retrieving the delivery history", func() {
+			status, history, err := client.Messages.History(clientToken.Access, messageGUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(http.StatusOK))
+
+			Expect(history).To(HaveLen(2))
+			Expect(history[0].Status).To(Equal("queued"))
+			Expect(history[0].Recipient).To(Equal("user@example.com"))
+			Expect(history[1].PreviousStatus).To(Equal("queued"))
+			Expect(history[1].Status).To(Equal("delivered"))
+			Expect(history[1].RetryCount).To(Equal(0))
+			Expect(history[1].Recipient).To(Equal("user@example.com"))
+		})
 	})
 })
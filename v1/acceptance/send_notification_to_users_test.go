@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/acceptance/support"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Send a notification to a batch of users", func() {
+	var (
+		clientID    = "notifications-sender"
+		clientToken = GetClientTokenFor(clientID)
+		client      = support.NewClient(Servers.Notifications.URL())
+	)
+
+	BeforeEach(func() {
+		code, err := client.Notifications.Register(clientToken.Access, support.RegisterClient{
+			SourceName: "Notifications Sender",
+			Notifications: map[string]support.RegisterNotification{
+				"acceptance-test": {
+					Description: "Acceptance Test",
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(code).To(Equal(http.StatusNoContent))
+	})
+
+	It("sends a notification email to each user in the batch", func() {
+		By("sending a notification to the batch", func() {
+			status, responses, err := client.Notify.Users(clientToken.Access, []support.NotifyUser{
+				{GUID: "user-123"},
+				{Email: "someone@example.com"},
+			}, support.Notify{
+				KindID:  "acceptance-test",
+				Text:    "hello from the acceptance test",
+				Subject: "my-special-subject",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(http.StatusOK))
+
+			Expect(responses).To(HaveLen(2))
+			Expect(responses[0].Status).To(Equal("queued"))
+			Expect(responses[0].Recipient).To(Equal("user-123"))
+			Expect(GUIDRegex.MatchString(responses[0].NotificationID)).To(BeTrue())
+			Expect(responses[1].Status).To(Equal("queued"))
+			Expect(responses[1].Recipient).To(Equal("someone@example.com"))
+			Expect(GUIDRegex.MatchString(responses[1].NotificationID)).To(BeTrue())
+		})
+
+		By("verifying that the messages were sent", func() {
+			Eventually(func() int {
+				return len(Servers.SMTP.Deliveries)
+			}, 10*time.Second).Should(Equal(2))
+
+			var recipients []string
+			for _, delivery := range Servers.SMTP.Deliveries {
+				recipients = append(recipients, delivery.Recipients...)
+			}
+			Expect(recipients).To(ConsistOf("user-123@example.com", "someone@example.com"))
+		})
+	})
+
+	It("rejects the batch when any of the entries are invalid", func() {
+		status, _, err := client.Notify.Users(clientToken.Access, []support.NotifyUser{
+			{GUID: "user-123"},
+			{},
+		}, support.Notify{
+			KindID:  "acceptance-test",
+			Text:    "hello from the acceptance test",
+			Subject: "my-special-subject",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(422))
+
+		Consistently(func() int {
+			return len(Servers.SMTP.Deliveries)
+		}).Should(Equal(0))
+	})
+})
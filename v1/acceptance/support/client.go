@@ -134,6 +134,10 @@ func (c Client) ScopesPath(scope string) string {
 	return c.host + "/uaa_scopes/" + scope
 }
 
+func (c Client) BatchUsersPath() string {
+	return c.host + "/users"
+}
+
 func (c Client) UsersPath(user string) string {
 	return c.host + "/users/" + user
 }
@@ -35,13 +35,19 @@ type TemplateAssociation struct {
 }
 
 type notifyRequest struct {
-	To      string `json:"to,omitempty"`
-	Role    string `json:"role,omitempty"`
-	Subject string `json:"subject"`
-	HTML    string `json:"html,omitempty"`
-	Text    string `json:"text,omitempty"`
-	KindID  string `json:"kind_id,omitempty"`
-	ReplyTo string `json:"reply_to,omitempty"`
+	To      string       `json:"to,omitempty"`
+	Role    string       `json:"role,omitempty"`
+	Subject string       `json:"subject"`
+	HTML    string       `json:"html,omitempty"`
+	Text    string       `json:"text,omitempty"`
+	KindID  string       `json:"kind_id,omitempty"`
+	ReplyTo string       `json:"reply_to,omitempty"`
+	Users   []NotifyUser `json:"users,omitempty"`
+}
+
+type NotifyUser struct {
+	GUID  string `json:"guid,omitempty"`
+	Email string `json:"email,omitempty"`
 }
 
 type NotifyResponse struct {
@@ -55,6 +61,16 @@ type Message struct {
 	Status string `json:"status"`
 }
 
+type MessageEvent struct {
+	PreviousStatus string `json:"previous_status"`
+	Status         string `json:"status"`
+	RetryCount     int    `json:"retry_count"`
+	SMTPCode       int    `json:"smtp_code"`
+	SMTPText       string `json:"smtp_text"`
+	Recipient      string `json:"recipient"`
+	Timestamp      string `json:"timestamp"`
+}
+
 type RegisterClient struct {
 	SourceName    string                          `json:"source_name"`
 	Notifications map[string]RegisterNotification `json:"notifications,omitempty"`
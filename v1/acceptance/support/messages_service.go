@@ -17,3 +17,17 @@ func (s MessagesService) Get(token, messageGUID string) (int, Message, error) {
 	err = json.Unmarshal(body, &message)
 	return status, message, err
 }
+
+func (s MessagesService) History(token, messageGUID string) (int, []MessageEvent, error) {
+	var document struct {
+		History []MessageEvent `json:"history"`
+	}
+
+	status, body, err := s.client.makeRequest("GET", s.client.MessagePath(messageGUID), nil, token)
+	if err != nil {
+		return status, document.History, err
+	}
+
+	err = json.Unmarshal(body, &document)
+	return status, document.History, err
+}
@@ -57,6 +57,12 @@ func (s NotifyService) User(token, userGUID string, notify Notify) (int, []Notif
 	return s.notify(token, s.client.UsersPath(userGUID), notify, notifyRequest{})
 }
 
+func (s NotifyService) Users(token string, users []NotifyUser, notify Notify) (int, []NotifyResponse, error) {
+	return s.notify(token, s.client.BatchUsersPath(), notify, notifyRequest{
+		Users: users,
+	})
+}
+
 func (s NotifyService) AllUsers(token string, notify Notify) (int, []NotifyResponse, error) {
 	return s.notify(token, s.client.EveryonePath(), notify, notifyRequest{})
 }
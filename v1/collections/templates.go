@@ -40,31 +40,39 @@ type templatesRepository interface {
 	Destroy(connection models.ConnectionInterface, templateID string) error
 }
 
+type templateVersionsRepository interface {
+	Create(connection models.ConnectionInterface, version models.TemplateVersion) (models.TemplateVersion, error)
+	DestroyAll(connection models.ConnectionInterface, templateID string) error
+}
+
 type TemplateAssociation struct {
 	ClientID       string
 	NotificationID string
 }
 
 type Template struct {
-	ID       string
-	Name     string
-	Text     string
-	HTML     string
-	Subject  string
-	Metadata string
+	ID            string
+	Name          string
+	Text          string
+	HTML          string
+	Subject       string
+	Metadata      string
+	Localizations string
 }
 
 type TemplatesCollection struct {
-	clientsRepo   clientsRepository
-	kindsRepo     kindsRepository
-	templatesRepo templatesRepository
+	clientsRepo          clientsRepository
+	kindsRepo            kindsRepository
+	templatesRepo        templatesRepository
+	templateVersionsRepo templateVersionsRepository
 }
 
-func NewTemplatesCollection(clientsRepo clientsRepository, kindsRepo kindsRepository, templatesRepo templatesRepository) TemplatesCollection {
+func NewTemplatesCollection(clientsRepo clientsRepository, kindsRepo kindsRepository, templatesRepo templatesRepository, templateVersionsRepo templateVersionsRepository) TemplatesCollection {
 	return TemplatesCollection{
-		clientsRepo:   clientsRepo,
-		kindsRepo:     kindsRepo,
-		templatesRepo: templatesRepo,
+		clientsRepo:          clientsRepo,
+		kindsRepo:            kindsRepo,
+		templatesRepo:        templatesRepo,
+		templateVersionsRepo: templateVersionsRepo,
 	}
 }
 
@@ -173,28 +181,56 @@ func (c TemplatesCollection) ListAssociations(conn ConnectionInterface, template
 	return associations, nil
 }
 
-func (c TemplatesCollection) Create(connection ConnectionInterface, template Template) (Template, error) {
+// Create stores the template, keeping its content as the first version of
+// the template, made by the client.
+func (c TemplatesCollection) Create(connection ConnectionInterface, template Template, clientID string) (Template, error) {
 	tmpl, err := c.templatesRepo.Create(connection, models.Template{
-		Name:     template.Name,
-		Text:     template.Text,
-		HTML:     template.HTML,
-		Subject:  template.Subject,
-		Metadata: template.Metadata,
+		Name:          template.Name,
+		Text:          template.Text,
+		HTML:          template.HTML,
+		Subject:       template.Subject,
+		Metadata:      template.Metadata,
+		Localizations: template.Localizations,
 	})
 	if err != nil {
 		return Template{}, err
 	}
 
+	_, err = c.templateVersionsRepo.Create(connection, models.NewTemplateVersion(tmpl, clientID))
+	if err != nil {
+		return Template{}, err
+	}
+
 	return Template{
-		ID:       tmpl.ID,
-		Name:     tmpl.Name,
-		Text:     tmpl.Text,
-		HTML:     tmpl.HTML,
-		Subject:  tmpl.Subject,
-		Metadata: tmpl.Metadata,
+		ID:            tmpl.ID,
+		Name:          tmpl.Name,
+		Text:          tmpl.Text,
+		HTML:          tmpl.HTML,
+		Subject:       tmpl.Subject,
+		Metadata:      tmpl.Metadata,
+		Localizations: tmpl.Localizations,
 	}, nil
 }
 
+// Delete deletes the template along with its versions.
 func (c TemplatesCollection) Delete(connection ConnectionInterface, templateID string) error {
-	return c.templatesRepo.Destroy(connection, templateID)
+	transaction := connection.Transaction()
+	err := transaction.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = c.templateVersionsRepo.DestroyAll(transaction, templateID)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	err = c.templatesRepo.Destroy(transaction, templateID)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	return transaction.Commit()
 }
@@ -16,6 +16,7 @@ var _ = Describe("TemplatesCollection", func() {
 		kindsRepo     *mocks.KindsRepo
 		clientsRepo   *mocks.ClientsRepository
 		templatesRepo *mocks.TemplatesRepo
+		versionsRepo  *mocks.TemplateVersionsRepo
 		conn          *mocks.Connection
 
 		collection collections.TemplatesCollection
@@ -27,8 +28,9 @@ var _ = Describe("TemplatesCollection", func() {
 		clientsRepo = mocks.NewClientsRepository()
 		kindsRepo = mocks.NewKindsRepo()
 		templatesRepo = mocks.NewTemplatesRepo()
+		versionsRepo = mocks.NewTemplateVersionsRepo()
 
-		collection = collections.NewTemplatesCollection(clientsRepo, kindsRepo, templatesRepo)
+		collection = collections.NewTemplatesCollection(clientsRepo, kindsRepo, templatesRepo, versionsRepo)
 	})
 
 	Describe("AssignToClient", func() {
@@ -328,56 +330,122 @@ var _ = Describe("TemplatesCollection", func() {
 	Describe("Create", func() {
 		It("creates a new template via the templates repo", func() {
 			templatesRepo.CreateCall.Returns.Template = models.Template{
-				ID:       "some-template-guid",
-				Name:     "some-template-name",
-				Text:     "some-text",
-				HTML:     "some-html",
-				Subject:  "some-subject",
-				Metadata: "some-metadata",
+				ID:            "some-template-guid",
+				Name:          "some-template-name",
+				Text:          "some-text",
+				HTML:          "some-html",
+				Subject:       "some-subject",
+				Metadata:      "some-metadata",
+				Localizations: `{"fr": {}}`,
 			}
 
 			template, err := collection.Create(conn, collections.Template{
-				Name:     "some-template-name",
-				Text:     "some-text",
-				HTML:     "some-html",
-				Subject:  "some-subject",
-				Metadata: "some-metadata",
-			})
+				Name:          "some-template-name",
+				Text:          "some-text",
+				HTML:          "some-html",
+				Subject:       "some-subject",
+				Metadata:      "some-metadata",
+				Localizations: `{"fr": {}}`,
+			}, "some-client-id")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(template).To(Equal(collections.Template{
-				ID:       "some-template-guid",
-				Name:     "some-template-name",
-				Text:     "some-text",
-				HTML:     "some-html",
-				Subject:  "some-subject",
-				Metadata: "some-metadata",
+				ID:            "some-template-guid",
+				Name:          "some-template-name",
+				Text:          "some-text",
+				HTML:          "some-html",
+				Subject:       "some-subject",
+				Metadata:      "some-metadata",
+				Localizations: `{"fr": {}}`,
 			}))
 
 			Expect(templatesRepo.CreateCall.Receives.Connection).To(Equal(conn))
 			Expect(templatesRepo.CreateCall.Receives.Template).To(Equal(models.Template{
-				Name:     "some-template-name",
-				Text:     "some-text",
-				HTML:     "some-html",
-				Subject:  "some-subject",
-				Metadata: "some-metadata",
+				Name:          "some-template-name",
+				Text:          "some-text",
+				HTML:          "some-html",
+				Subject:       "some-subject",
+				Metadata:      "some-metadata",
+				Localizations: `{"fr": {}}`,
+			}))
+		})
+
+		It("records the first version of the template", func() {
+			templatesRepo.CreateCall.Returns.Template = models.Template{
+				ID:            "some-template-guid",
+				Name:          "some-template-name",
+				Text:          "some-text",
+				HTML:          "some-html",
+				Subject:       "some-subject",
+				Metadata:      "some-metadata",
+				Localizations: `{"fr": {}}`,
+			}
+
+			_, err := collection.Create(conn, collections.Template{
+				Name:          "some-template-name",
+				Text:          "some-text",
+				HTML:          "some-html",
+				Subject:       "some-subject",
+				Metadata:      "some-metadata",
+				Localizations: `{"fr": {}}`,
+			}, "some-client-id")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(versionsRepo.CreateCall.Receives.Connection).To(Equal(conn))
+			Expect(versionsRepo.CreateCall.Receives.Versions).To(Equal([]models.TemplateVersion{
+				{
+					TemplateID:    "some-template-guid",
+					Name:          "some-template-name",
+					Text:          "some-text",
+					HTML:          "some-html",
+					Subject:       "some-subject",
+					Metadata:      "some-metadata",
+					Localizations: `{"fr": {}}`,
+					ClientID:      "some-client-id",
+				},
 			}))
 		})
 
 		It("propagates errors from repo", func() {
 			templatesRepo.CreateCall.Returns.Error = errors.New("Boom!")
 
-			_, err := collection.Create(conn, collections.Template{})
+			_, err := collection.Create(conn, collections.Template{}, "some-client-id")
+			Expect(err).To(Equal(errors.New("Boom!")))
+		})
+
+		It("propagates errors from the versions repo", func() {
+			versionsRepo.CreateCall.Returns.Error = errors.New("Boom!")
+
+			_, err := collection.Create(conn, collections.Template{}, "some-client-id")
 			Expect(err).To(Equal(errors.New("Boom!")))
 		})
 	})
 
 	Describe("Delete", func() {
+		var transaction *mocks.Transaction
+
+		BeforeEach(func() {
+			transaction = mocks.NewTransaction()
+			conn.TransactionCall.Returns.Transaction = transaction
+		})
+
 		It("calls destroy on its repo", func() {
 			err := collection.Delete(conn, "templateID")
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(templatesRepo.DestroyCall.Receives.Connection).To(Equal(conn))
+			Expect(templatesRepo.DestroyCall.Receives.Connection).To(Equal(transaction))
 			Expect(templatesRepo.DestroyCall.Receives.TemplateID).To(Equal("templateID"))
+
+			Expect(transaction.BeginCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeTrue())
+			Expect(transaction.RollbackCall.WasCalled).To(BeFalse())
+		})
+
+		It("deletes the versions of the template in the same transaction", func() {
+			err := collection.Delete(conn, "templateID")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(versionsRepo.DestroyAllCall.Receives.Connection).To(Equal(transaction))
+			Expect(versionsRepo.DestroyAllCall.Receives.TemplateID).To(Equal("templateID"))
 		})
 
 		It("returns an error if repo destroy returns an error", func() {
@@ -385,6 +453,20 @@ var _ = Describe("TemplatesCollection", func() {
 
 			err := collection.Delete(conn, "templateID")
 			Expect(err).To(MatchError(errors.New("Boom!!")))
+
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+		})
+
+		It("returns an error if deleting the versions returns an error", func() {
+			versionsRepo.DestroyAllCall.Returns.Error = errors.New("Boom!!")
+
+			err := collection.Delete(conn, "templateID")
+			Expect(err).To(MatchError(errors.New("Boom!!")))
+
+			Expect(templatesRepo.DestroyCall.Receives.TemplateID).To(BeEmpty())
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeFalse())
 		})
 	})
 })
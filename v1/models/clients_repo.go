@@ -4,7 +4,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
 )
 
 type ClientsRepo struct{}
@@ -86,7 +87,7 @@ func (repo ClientsRepo) FindAllByTemplateID(conn ConnectionInterface, templateID
 func (repo ClientsRepo) create(conn ConnectionInterface, client Client) (Client, error) {
 	err := conn.Insert(&client)
 	if err != nil {
-		if strings.Contains(err.Error(), "Duplicate entry") {
+		if db.IsDuplicateError(err) {
 			err = DuplicateError{errors.New("duplicate record")}
 		}
 		return client, err
@@ -35,4 +35,17 @@ func Setup(database *db.DB) {
 	database.TableMap().AddTableWithName(GlobalUnsubscribe{}, "global_unsubscribes").SetKeys(true, "Primary").ColMap("UserID").SetUnique(true)
 	database.TableMap().AddTableWithName(Template{}, "templates").SetKeys(true, "Primary").ColMap("Name").SetUnique(true)
 	database.TableMap().AddTableWithName(Message{}, "messages").SetKeys(false, "ID")
+	database.TableMap().AddTableWithName(MessageEvent{}, "message_events").SetKeys(true, "Primary")
+	database.TableMap().AddTableWithName(IdempotencyKey{}, "idempotency_keys").SetKeys(true, "Primary").SetUniqueTogether("client_id", "idempotency_key")
+	database.TableMap().AddTableWithName(Suppression{}, "suppressions").SetKeys(true, "Primary").ColMap("Email").SetUnique(true)
+	database.TableMap().AddTableWithName(Quota{}, "quotas").SetKeys(true, "Primary").SetUniqueTogether("client_id", "kind_id")
+	database.TableMap().AddTableWithName(QuotaUsage{}, "quota_usages").SetKeys(true, "Primary").SetUniqueTogether("client_id", "kind_id", "measure", "window_start")
+	database.TableMap().AddTableWithName(SendRate{}, "send_rates").SetKeys(true, "Primary").SetUniqueTogether("scope", "window_start")
+	database.TableMap().AddTableWithName(DeliveryFrequency{}, "delivery_frequencies").SetKeys(true, "Primary").SetUniqueTogether("user_id", "client_id", "kind_id")
+	database.TableMap().AddTableWithName(DigestItem{}, "digest_items").SetKeys(true, "Primary")
+	database.TableMap().AddTableWithName(QuietHours{}, "quiet_hours").SetKeys(true, "Primary").ColMap("UserID").SetUnique(true)
+	database.TableMap().AddTableWithName(PreferenceJob{}, "preference_jobs").SetKeys(false, "ID")
+	database.TableMap().AddTableWithName(PreferenceChange{}, "preference_changes").SetKeys(true, "Primary")
+	database.TableMap().AddTableWithName(TemplateVersion{}, "template_versions").SetKeys(true, "Primary").SetUniqueTogether("template_id", "version")
+	database.TableMap().AddTableWithName(UserLocale{}, "user_locales").SetKeys(true, "Primary").ColMap("UserID").SetUnique(true)
 }
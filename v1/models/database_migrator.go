@@ -6,20 +6,21 @@ import (
 	"io/ioutil"
 	"time"
 
+	"github.com/cloudfoundry-incubator/notifications/db"
 	sql_migrate "github.com/rubenv/sql-migrate"
 )
 
 type DatabaseMigrator struct {
 }
 
-func (d DatabaseMigrator) Migrate(db *sql.DB, migrationsPath string) {
+func (d DatabaseMigrator) Migrate(sqlDB *sql.DB, migrationsPath string) {
 	sql_migrate.SetTable("notifications_model_migrations")
 
 	migrations := &sql_migrate.FileMigrationSource{
 		Dir: migrationsPath,
 	}
 
-	_, err := sql_migrate.Exec(db, "mysql", migrations, sql_migrate.Up)
+	_, err := sql_migrate.Exec(sqlDB, db.DialectOf(sqlDB).Name, migrations, sql_migrate.Up)
 	if err != nil {
 		panic(err)
 	}
@@ -53,12 +54,13 @@ func (d DatabaseMigrator) Seed(database DatabaseInterface, defaultTemplatePath s
 		}
 
 		_, err = repo.Create(conn, Template{
-			ID:       DefaultTemplateID,
-			Name:     template.Name,
-			Subject:  template.Subject,
-			HTML:     template.HTML,
-			Text:     template.Text,
-			Metadata: string(template.Metadata),
+			ID:            DefaultTemplateID,
+			Name:          template.Name,
+			Subject:       template.Subject,
+			HTML:          template.HTML,
+			Text:          template.Text,
+			Metadata:      string(template.Metadata),
+			Localizations: "{}",
 		})
 		if err != nil {
 			panic(err)
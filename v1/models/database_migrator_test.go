@@ -38,7 +38,12 @@ var _ = Describe("DatabaseMigrator", func() {
 			err := connection.Db.Ping()
 			Expect(err).To(BeNil())
 
-			rows, err := connection.Db.Query("SHOW TABLES")
+			currentSchema := "DATABASE()"
+			if connection.Dialect().Name == db.DialectPostgres {
+				currentSchema = "current_schema()"
+			}
+
+			rows, err := connection.Db.Query("SELECT table_name FROM information_schema.tables WHERE table_schema = " + currentSchema)
 			Expect(err).To(BeNil())
 
 			tables := []string{}
@@ -0,0 +1,67 @@
+package models
+
+import "database/sql"
+
+type DeliveryFrequenciesRepo struct{}
+
+func NewDeliveryFrequenciesRepo() DeliveryFrequenciesRepo {
+	return DeliveryFrequenciesRepo{}
+}
+
+// Get returns how often the user receives notifications of the kind. A
+// frequency set for the kind takes precedence over the one the user set for
+// all notifications.
+func (repo DeliveryFrequenciesRepo) Get(conn ConnectionInterface, userID, clientID, kindID string) (string, error) {
+	var frequency DeliveryFrequency
+	err := conn.SelectOne(&frequency, "SELECT * FROM `delivery_frequencies` WHERE `user_id` = ? AND ((`client_id` = ? AND `kind_id` = ?) OR (`client_id` = '' AND `kind_id` = '')) ORDER BY `client_id` DESC LIMIT 1", userID, clientID, kindID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return FrequencyImmediate, nil
+		}
+
+		return "", err
+	}
+
+	return frequency.Frequency, nil
+}
+
+// Set stores the frequency for the user and kind. An empty frequency removes
+// it, so that notifications of the kind follow the user's frequency again.
+func (repo DeliveryFrequenciesRepo) Set(conn ConnectionInterface, userID, clientID, kindID, frequency string) error {
+	var record DeliveryFrequency
+	err := conn.SelectOne(&record, "SELECT * FROM `delivery_frequencies` WHERE `user_id` = ? AND `client_id` = ? AND `kind_id` = ?", userID, clientID, kindID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return err
+		}
+
+		record = DeliveryFrequency{
+			UserID:   userID,
+			ClientID: clientID,
+			KindID:   kindID,
+		}
+	}
+
+	switch {
+	case frequency == "" && record.Primary != 0:
+		_, err = conn.Delete(&record)
+	case frequency != "" && record.Primary == 0:
+		record.Frequency = frequency
+		err = conn.Insert(&record)
+	case frequency != "" && record.Frequency != frequency:
+		record.Frequency = frequency
+		_, err = conn.Update(&record)
+	}
+
+	return err
+}
+
+func (repo DeliveryFrequenciesRepo) FindAllByUserID(conn ConnectionInterface, userID string) (DeliveryFrequencies, error) {
+	frequencies := []DeliveryFrequency{}
+	_, err := conn.Select(&frequencies, "SELECT * FROM `delivery_frequencies` WHERE `user_id` = ?", userID)
+	if err != nil {
+		return DeliveryFrequencies{}, err
+	}
+
+	return DeliveryFrequencies(frequencies), nil
+}
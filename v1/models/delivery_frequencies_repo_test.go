@@ -0,0 +1,84 @@
+package models_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeliveryFrequenciesRepo", func() {
+	var (
+		repo models.DeliveryFrequenciesRepo
+		conn db.ConnectionInterface
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+		repo = models.NewDeliveryFrequenciesRepo()
+	})
+
+	Describe("Get", func() {
+		It("delivers immediately when the user has not set a frequency", func() {
+			frequency, err := repo.Get(conn, "some-user", "some-client", "some-kind")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(frequency).To(Equal(models.FrequencyImmediate))
+		})
+
+		It("returns the frequency the user set for all notifications", func() {
+			err := repo.Set(conn, "some-user", "", "", models.FrequencyDaily)
+			Expect(err).NotTo(HaveOccurred())
+
+			frequency, err := repo.Get(conn, "some-user", "some-client", "some-kind")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(frequency).To(Equal(models.FrequencyDaily))
+		})
+
+		It("prefers the frequency the user set for the kind", func() {
+			err := repo.Set(conn, "some-user", "", "", models.FrequencyDaily)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = repo.Set(conn, "some-user", "some-client", "some-kind", models.FrequencyImmediate)
+			Expect(err).NotTo(HaveOccurred())
+
+			frequency, err := repo.Get(conn, "some-user", "some-client", "some-kind")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(frequency).To(Equal(models.FrequencyImmediate))
+
+			frequency, err = repo.Get(conn, "some-user", "some-client", "other-kind")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(frequency).To(Equal(models.FrequencyDaily))
+		})
+	})
+
+	Describe("Set", func() {
+		It("updates the frequency the user already set", func() {
+			err := repo.Set(conn, "some-user", "some-client", "some-kind", models.FrequencyDaily)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = repo.Set(conn, "some-user", "some-client", "some-kind", models.FrequencyWeekly)
+			Expect(err).NotTo(HaveOccurred())
+
+			frequencies, err := repo.FindAllByUserID(conn, "some-user")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(frequencies).To(HaveLen(1))
+			Expect(frequencies.For("some-client", "some-kind")).To(Equal(models.FrequencyWeekly))
+		})
+
+		It("removes the frequency when it is empty", func() {
+			err := repo.Set(conn, "some-user", "some-client", "some-kind", models.FrequencyDaily)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = repo.Set(conn, "some-user", "some-client", "some-kind", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			frequencies, err := repo.FindAllByUserID(conn, "some-user")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(frequencies).To(BeEmpty())
+		})
+	})
+})
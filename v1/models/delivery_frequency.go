@@ -0,0 +1,42 @@
+package models
+
+const (
+	FrequencyImmediate = "immediate"
+	FrequencyDaily     = "daily"
+	FrequencyWeekly    = "weekly"
+)
+
+// DeliveryFrequency sets how often a user receives non-critical
+// notifications. Without a client and kind it applies to every notification
+// the user receives, otherwise only to notifications of that kind. Users
+// without a delivery frequency receive notifications immediately.
+type DeliveryFrequency struct {
+	Primary   int    `db:"primary"`
+	UserID    string `db:"user_id"`
+	ClientID  string `db:"client_id"`
+	KindID    string `db:"kind_id"`
+	Frequency string `db:"frequency"`
+}
+
+func IsValidFrequency(frequency string) bool {
+	switch frequency {
+	case FrequencyImmediate, FrequencyDaily, FrequencyWeekly:
+		return true
+	}
+
+	return false
+}
+
+type DeliveryFrequencies []DeliveryFrequency
+
+// For returns the frequency set for notifications of the kind, or an empty
+// string when the user has not set one.
+func (frequencies DeliveryFrequencies) For(clientID, kindID string) string {
+	for _, frequency := range frequencies {
+		if frequency.ClientID == clientID && frequency.KindID == kindID {
+			return frequency.Frequency
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/gorp.v1"
+)
+
+// DigestItem is a rendered notification that waits to be sent to a user in
+// their next daily or weekly digest.
+type DigestItem struct {
+	Primary           int       `db:"primary"`
+	UserID            string    `db:"user_id"`
+	Email             string    `db:"email"`
+	Frequency         string    `db:"frequency"`
+	MessageID         string    `db:"message_id"`
+	ClientID          string    `db:"client_id"`
+	KindID            string    `db:"kind_id"`
+	SourceDescription string    `db:"source_description"`
+	KindDescription   string    `db:"kind_description"`
+	Subject           string    `db:"subject"`
+	Text              string    `db:"text"`
+	HTML              string    `db:"html"`
+	CreatedAt         time.Time `db:"created_at"`
+}
+
+func (i *DigestItem) PreInsert(s gorp.SqlExecutor) error {
+	if (i.CreatedAt == time.Time{}) {
+		i.CreatedAt = time.Now().Truncate(1 * time.Second).UTC()
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+type DigestItemsRepo struct{}
+
+func NewDigestItemsRepo() DigestItemsRepo {
+	return DigestItemsRepo{}
+}
+
+func (repo DigestItemsRepo) Create(conn ConnectionInterface, item DigestItem) (DigestItem, error) {
+	err := conn.Insert(&item)
+	if err != nil {
+		return DigestItem{}, err
+	}
+
+	return item, nil
+}
+
+// FindDue returns the users whose oldest item waiting for a digest of the
+// frequency was created before the time.
+func (repo DigestItemsRepo) FindDue(conn ConnectionInterface, frequency string, before time.Time) ([]string, error) {
+	var userIDs []string
+	_, err := conn.Select(&userIDs, "SELECT `user_id` FROM `digest_items` WHERE `frequency` = ? GROUP BY `user_id` HAVING MIN(`created_at`) <= ?", frequency, before)
+	if err != nil {
+		return []string{}, err
+	}
+
+	return userIDs, nil
+}
+
+// Take removes the items waiting for the user's digest of the frequency and
+// returns them, oldest first. It should be called inside a transaction, which
+// keeps other instances from taking the same items.
+func (repo DigestItemsRepo) Take(conn ConnectionInterface, userID, frequency string) ([]DigestItem, error) {
+	items := []DigestItem{}
+	_, err := conn.Select(&items, "SELECT * FROM `digest_items` WHERE `user_id` = ? AND `frequency` = ? ORDER BY `created_at`, `primary` FOR UPDATE", userID, frequency)
+	if err != nil {
+		return []DigestItem{}, err
+	}
+
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	placeholders := make([]string, len(items))
+	primaries := make([]interface{}, len(items))
+	for index, item := range items {
+		placeholders[index] = "?"
+		primaries[index] = item.Primary
+	}
+
+	_, err = conn.Exec("DELETE FROM `digest_items` WHERE `primary` IN ("+strings.Join(placeholders, ", ")+")", primaries...)
+	if err != nil {
+		return []DigestItem{}, err
+	}
+
+	return items, nil
+}
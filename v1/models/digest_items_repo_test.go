@@ -0,0 +1,83 @@
+package models_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DigestItemsRepo", func() {
+	var (
+		repo models.DigestItemsRepo
+		conn db.ConnectionInterface
+		now  time.Time
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+		repo = models.NewDigestItemsRepo()
+		now = time.Now().UTC().Truncate(time.Second)
+	})
+
+	item := func(userID, frequency string, createdAt time.Time) models.DigestItem {
+		return models.DigestItem{
+			UserID:    userID,
+			Email:     userID + "@example.com",
+			Frequency: frequency,
+			MessageID: "message-" + userID,
+			Subject:   "some subject",
+			Text:      "some text",
+			HTML:      "<p>some html</p>",
+			CreatedAt: createdAt,
+		}
+	}
+
+	Describe("FindDue", func() {
+		It("returns the users whose oldest item is older than the time", func() {
+			_, err := repo.Create(conn, item("due-user", models.FrequencyDaily, now.Add(-25*time.Hour)))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = repo.Create(conn, item("due-user", models.FrequencyDaily, now))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = repo.Create(conn, item("waiting-user", models.FrequencyDaily, now.Add(-time.Hour)))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = repo.Create(conn, item("weekly-user", models.FrequencyWeekly, now.Add(-25*time.Hour)))
+			Expect(err).NotTo(HaveOccurred())
+
+			userIDs, err := repo.FindDue(conn, models.FrequencyDaily, now.Add(-24*time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(userIDs).To(ConsistOf("due-user"))
+		})
+	})
+
+	Describe("Take", func() {
+		It("removes and returns the user's items, oldest first", func() {
+			_, err := repo.Create(conn, item("some-user", models.FrequencyDaily, now))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = repo.Create(conn, item("some-user", models.FrequencyDaily, now.Add(-time.Hour)))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = repo.Create(conn, item("some-user", models.FrequencyWeekly, now))
+			Expect(err).NotTo(HaveOccurred())
+
+			items, err := repo.Take(conn, "some-user", models.FrequencyDaily)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(items).To(HaveLen(2))
+			Expect(items[0].CreatedAt).To(BeTemporally("==", now.Add(-time.Hour)))
+			Expect(items[1].CreatedAt).To(BeTemporally("==", now))
+
+			items, err = repo.Take(conn, "some-user", models.FrequencyDaily)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(items).To(BeEmpty())
+
+			items, err = repo.Take(conn, "some-user", models.FrequencyWeekly)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(items).To(HaveLen(1))
+		})
+	})
+})
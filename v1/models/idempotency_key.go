@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/gorp.v1"
+)
+
+type IdempotencyKey struct {
+	Primary     int       `db:"primary"`
+	ClientID    string    `db:"client_id"`
+	Key         string    `db:"idempotency_key"`
+	RequestHash string    `db:"request_hash"`
+	Response    string    `db:"response"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (k *IdempotencyKey) PreInsert(s gorp.SqlExecutor) error {
+	if k.CreatedAt.IsZero() {
+		k.CreatedAt = time.Now().Truncate(1 * time.Second).UTC()
+	}
+
+	return nil
+}
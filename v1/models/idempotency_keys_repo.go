@@ -0,0 +1,91 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+)
+
+type IdempotencyKeysRepo struct{}
+
+func NewIdempotencyKeysRepo() IdempotencyKeysRepo {
+	return IdempotencyKeysRepo{}
+}
+
+func (repo IdempotencyKeysRepo) Create(conn ConnectionInterface, key IdempotencyKey) (IdempotencyKey, error) {
+	err := conn.Insert(&key)
+	if err != nil {
+		if db.IsDuplicateError(err) {
+			err = DuplicateError{errors.New("duplicate record")}
+		}
+		return IdempotencyKey{}, err
+	}
+
+	return key, nil
+}
+
+func (repo IdempotencyKeysRepo) Find(conn ConnectionInterface, clientID, key string) (IdempotencyKey, error) {
+	record := IdempotencyKey{}
+	err := conn.SelectOne(&record, "SELECT * FROM `idempotency_keys` WHERE `client_id` = ? AND `idempotency_key` = ?", clientID, key)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = NotFoundError{fmt.Errorf("Idempotency key %q could not be found", key)}
+		}
+		return IdempotencyKey{}, err
+	}
+
+	return record, nil
+}
+
+func (repo IdempotencyKeysRepo) Update(conn ConnectionInterface, key IdempotencyKey) (IdempotencyKey, error) {
+	_, err := conn.Update(&key)
+	if err != nil {
+		return IdempotencyKey{}, err
+	}
+
+	return key, nil
+}
+
+// Reclaim renews a reservation that was left without a response, so that the
+// request it was made for can be retried. It fails with a NotFoundError when
+// the reservation has been renewed or answered in the meantime.
+func (repo IdempotencyKeysRepo) Reclaim(conn ConnectionInterface, key IdempotencyKey) (IdempotencyKey, error) {
+	createdAt := time.Now().Truncate(1 * time.Second).UTC()
+	result, err := conn.Exec("UPDATE `idempotency_keys` SET `created_at` = ? WHERE `primary` = ? AND `created_at` = ? AND `response` = ''", createdAt, key.Primary, key.CreatedAt.UTC())
+	if err != nil {
+		return IdempotencyKey{}, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return IdempotencyKey{}, err
+	}
+
+	if count == 0 {
+		return IdempotencyKey{}, NotFoundError{fmt.Errorf("Idempotency key %q could not be found", key.Key)}
+	}
+
+	key.CreatedAt = createdAt
+	return key, nil
+}
+
+func (repo IdempotencyKeysRepo) Delete(conn ConnectionInterface, key IdempotencyKey) error {
+	_, err := conn.Delete(&key)
+	return err
+}
+
+func (repo IdempotencyKeysRepo) DeleteBefore(conn ConnectionInterface, threshold time.Time) (int, error) {
+	result, err := conn.Exec("DELETE FROM `idempotency_keys` WHERE `created_at` < ?", threshold.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
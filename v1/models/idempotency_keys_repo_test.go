@@ -0,0 +1,168 @@
+package models_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IdempotencyKeysRepo", func() {
+	var (
+		repo models.IdempotencyKeysRepo
+		conn db.ConnectionInterface
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+
+		repo = models.NewIdempotencyKeysRepo()
+	})
+
+	Describe("Create", func() {
+		It("inserts a key into the database", func() {
+			key, err := repo.Create(conn, models.IdempotencyKey{
+				ClientID:    "some-client",
+				Key:         "some-key",
+				RequestHash: "some-hash",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key.Primary).NotTo(BeZero())
+			Expect(key.CreatedAt).To(BeTemporally("~", time.Now(), 2*time.Second))
+
+			found, err := repo.Find(conn, "some-client", "some-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Primary).To(Equal(key.Primary))
+			Expect(found.RequestHash).To(Equal("some-hash"))
+			Expect(found.Response).To(BeEmpty())
+		})
+
+		It("returns a DuplicateError when the client has already used the key", func() {
+			_, err := repo.Create(conn, models.IdempotencyKey{ClientID: "some-client", Key: "some-key"})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Create(conn, models.IdempotencyKey{ClientID: "some-client", Key: "some-key"})
+			Expect(err).To(MatchError(models.DuplicateError{Err: errors.New("duplicate record")}))
+		})
+
+		It("allows different clients to use the same key", func() {
+			_, err := repo.Create(conn, models.IdempotencyKey{ClientID: "some-client", Key: "some-key"})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Create(conn, models.IdempotencyKey{ClientID: "other-client", Key: "some-key"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("Find", func() {
+		It("returns a NotFoundError when the key does not exist", func() {
+			_, err := repo.Find(conn, "some-client", "missing-key")
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New(`Idempotency key "missing-key" could not be found`)}))
+		})
+	})
+
+	Describe("Update", func() {
+		It("stores the response", func() {
+			key, err := repo.Create(conn, models.IdempotencyKey{ClientID: "some-client", Key: "some-key"})
+			Expect(err).NotTo(HaveOccurred())
+
+			key.Response = `[{"status":"queued"}]`
+			_, err = repo.Update(conn, key)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := repo.Find(conn, "some-client", "some-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Response).To(Equal(`[{"status":"queued"}]`))
+		})
+	})
+
+	Describe("Reclaim", func() {
+		It("renews a reservation that has no response", func() {
+			key, err := repo.Create(conn, models.IdempotencyKey{
+				ClientID:    "some-client",
+				Key:         "some-key",
+				RequestHash: "some-hash",
+				CreatedAt:   time.Now().Add(-1 * time.Hour).Truncate(1 * time.Second).UTC(),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			reclaimed, err := repo.Reclaim(conn, key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reclaimed.Primary).To(Equal(key.Primary))
+			Expect(reclaimed.CreatedAt).To(BeTemporally("~", time.Now(), 2*time.Second))
+
+			found, err := repo.Find(conn, "some-client", "some-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.CreatedAt).To(BeTemporally("~", time.Now(), 2*time.Second))
+		})
+
+		It("returns a NotFoundError when the reservation was already renewed", func() {
+			key, err := repo.Create(conn, models.IdempotencyKey{
+				ClientID:    "some-client",
+				Key:         "some-key",
+				RequestHash: "some-hash",
+				CreatedAt:   time.Now().Add(-1 * time.Hour).Truncate(1 * time.Second).UTC(),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Reclaim(conn, key)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Reclaim(conn, key)
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+
+		It("returns a NotFoundError when the reservation has a response", func() {
+			key, err := repo.Create(conn, models.IdempotencyKey{
+				ClientID:    "some-client",
+				Key:         "some-key",
+				RequestHash: "some-hash",
+				Response:    "[]",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Reclaim(conn, key)
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("removes the key", func() {
+			key, err := repo.Create(conn, models.IdempotencyKey{ClientID: "some-client", Key: "some-key"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = repo.Delete(conn, key)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Find(conn, "some-client", "some-key")
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+	})
+
+	Describe("DeleteBefore", func() {
+		It("deletes keys created before the threshold", func() {
+			_, err := repo.Create(conn, models.IdempotencyKey{ClientID: "some-client", Key: "old-key", CreatedAt: time.Now().Add(-48 * time.Hour)})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Create(conn, models.IdempotencyKey{ClientID: "some-client", Key: "new-key"})
+			Expect(err).NotTo(HaveOccurred())
+
+			count, err := repo.DeleteBefore(conn, time.Now().Add(-24*time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(1))
+
+			_, err = repo.Find(conn, "some-client", "old-key")
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+
+			_, err = repo.Find(conn, "some-client", "new-key")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
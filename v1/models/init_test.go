@@ -23,7 +23,7 @@ var _ = BeforeEach(func() {
 	env, err := application.NewEnvironment()
 	Expect(err).NotTo(HaveOccurred())
 
-	sqlDB, err = sql.Open("mysql", env.DatabaseURL)
+	sqlDB, err = sql.Open(env.DatabaseDriver, env.DatabaseURL)
 	Expect(err).NotTo(HaveOccurred())
 })
 
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+// CanonicalLocale returns the locale as a canonical BCP 47 language tag, so
+// that "en_us" and "en-US" are the same locale, or an error when it is not a
+// language tag.
+func CanonicalLocale(locale string) (string, error) {
+	tag, err := language.Parse(locale)
+	if err != nil || tag.IsRoot() {
+		return "", fmt.Errorf("The locale '%s' is not a BCP 47 language tag", locale)
+	}
+
+	return tag.String(), nil
+}
+
+// LocaleFallbacks returns the locale followed by the less specific locales
+// that content for it falls back to, such as "pt-BR" and then "pt". A locale
+// that is not a language tag has no fallbacks.
+func LocaleFallbacks(locale string) []string {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return nil
+	}
+
+	var fallbacks []string
+	for ; !tag.IsRoot(); tag = tag.Parent() {
+		fallbacks = append(fallbacks, tag.String())
+	}
+
+	return fallbacks
+}
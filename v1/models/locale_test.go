@@ -0,0 +1,39 @@
+package models_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Locales", func() {
+	Describe("CanonicalLocale", func() {
+		It("returns the canonical form of the language tag", func() {
+			locale, err := models.CanonicalLocale("pt_br")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(locale).To(Equal("pt-BR"))
+		})
+
+		It("returns an error when the locale is not a language tag", func() {
+			_, err := models.CanonicalLocale("not a locale")
+			Expect(err).To(MatchError("The locale 'not a locale' is not a BCP 47 language tag"))
+
+			_, err = models.CanonicalLocale("und")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("LocaleFallbacks", func() {
+		It("falls back from the locale to the less specific ones", func() {
+			Expect(models.LocaleFallbacks("pt-BR")).To(Equal([]string{"pt-BR", "pt"}))
+			Expect(models.LocaleFallbacks("sr-Latn-RS")).To(Equal([]string{"sr-Latn-RS", "sr-Latn"}))
+			Expect(models.LocaleFallbacks("fr")).To(Equal([]string{"fr"}))
+		})
+
+		It("has no fallbacks for a locale that is not a language tag", func() {
+			Expect(models.LocaleFallbacks("")).To(BeEmpty())
+			Expect(models.LocaleFallbacks("not a locale")).To(BeEmpty())
+		})
+	})
+})
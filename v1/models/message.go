@@ -7,9 +7,26 @@ import (
 )
 
 type Message struct {
-	ID         string    `db:"id"`
-	Status     string    `db:"status"`
-	UpdatedAt  time.Time `db:"updated_at"`
+	ID        string    `db:"id"`
+	Status    string    `db:"status"`
+	ClientID  string    `db:"client_id"`
+	KindID    string    `db:"kind_id"`
+	UserGUID  string    `db:"user_guid"`
+	Email     string    `db:"email"`
+	JobID     int       `db:"job_id"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type MessagesFilter struct {
+	ClientID      string
+	KindID        string
+	UserGUID      string
+	Email         string
+	Status        string
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+	Limit         int
+	Offset        int
 }
 
 func (m *Message) PreInsert(s gorp.SqlExecutor) error {
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/gorp.v1"
+)
+
+type MessageEvent struct {
+	Primary        int       `db:"primary"`
+	MessageID      string    `db:"message_id"`
+	PreviousStatus string    `db:"previous_status"`
+	Status         string    `db:"status"`
+	RetryCount     int       `db:"retry_count"`
+	SMTPCode       int       `db:"smtp_code"`
+	SMTPText       string    `db:"smtp_text"`
+	Recipient      string    `db:"recipient"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+func (e *MessageEvent) PreInsert(s gorp.SqlExecutor) error {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now().Truncate(1 * time.Second).UTC()
+	}
+
+	return nil
+}
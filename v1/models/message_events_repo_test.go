@@ -0,0 +1,86 @@
+package models_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MessageEventsRepo", func() {
+	var (
+		repo models.MessageEventsRepo
+		conn db.ConnectionInterface
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+
+		repo = models.NewMessageEventsRepo()
+	})
+
+	Describe("Create", func() {
+		It("inserts an event into the database", func() {
+			event, err := repo.Create(conn, models.MessageEvent{
+				MessageID:      "message-id",
+				PreviousStatus: "queued",
+				Status:         "failed",
+				RetryCount:     2,
+				SMTPCode:       451,
+				SMTPText:       "try again later",
+				Recipient:      "user@example.com",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(event.Primary).NotTo(BeZero())
+			Expect(event.CreatedAt).To(BeTemporally("~", time.Now(), 2*time.Second))
+		})
+	})
+
+	Describe("ListByMessageID", func() {
+		It("returns the events for the message in the order they happened", func() {
+			now := time.Now().Truncate(1 * time.Second).UTC()
+
+			_, err := repo.Create(conn, models.MessageEvent{
+				MessageID: "message-id",
+				Status:    "delivered",
+				CreatedAt: now,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Create(conn, models.MessageEvent{
+				MessageID: "message-id",
+				Status:    "queued",
+				CreatedAt: now.Add(-1 * time.Minute),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Create(conn, models.MessageEvent{
+				MessageID: "other-message-id",
+				Status:    "queued",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := repo.ListByMessageID(conn, "message-id")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].Status).To(Equal("queued"))
+			Expect(events[0].CreatedAt).To(Equal(now.Add(-1 * time.Minute)))
+			Expect(events[1].Status).To(Equal("delivered"))
+			Expect(events[1].CreatedAt).To(Equal(now))
+		})
+
+		It("returns an empty list when the message has no events", func() {
+			events, err := repo.ListByMessageID(conn, "missing-message-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(BeEmpty())
+		})
+	})
+})
@@ -3,6 +3,7 @@ package models
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -68,8 +69,85 @@ func (repo MessagesRepo) Upsert(conn ConnectionInterface, message Message) (Mess
 	}
 }
 
+func (repo MessagesRepo) List(conn ConnectionInterface, filter MessagesFilter) ([]Message, error) {
+	where, args := filter.whereClause()
+	query := "SELECT * FROM `messages`" + where + " ORDER BY `updated_at` DESC, `id`"
+
+	if filter.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, filter.Limit, filter.Offset)
+	}
+
+	messages := []Message{}
+	_, err := conn.Select(&messages, query, args...)
+	if err != nil {
+		return []Message{}, err
+	}
+
+	return messages, nil
+}
+
+func (repo MessagesRepo) Count(conn ConnectionInterface, filter MessagesFilter) (int, error) {
+	where, args := filter.whereClause()
+
+	var count int
+	err := conn.SelectOne(&count, "SELECT COUNT(*) FROM `messages`"+where, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (filter MessagesFilter) whereClause() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	columns := []struct {
+		name  string
+		value string
+	}{
+		{"client_id", filter.ClientID},
+		{"kind_id", filter.KindID},
+		{"user_guid", filter.UserGUID},
+		{"email", filter.Email},
+		{"status", filter.Status},
+	}
+
+	for _, column := range columns {
+		if column.value != "" {
+			conditions = append(conditions, "`"+column.name+"` = ?")
+			args = append(args, column.value)
+		}
+	}
+
+	if !filter.UpdatedAfter.IsZero() {
+		conditions = append(conditions, "`updated_at` >= ?")
+		args = append(args, filter.UpdatedAfter.UTC())
+	}
+
+	if !filter.UpdatedBefore.IsZero() {
+		conditions = append(conditions, "`updated_at` < ?")
+		args = append(args, filter.UpdatedBefore.UTC())
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
 func (repo MessagesRepo) DeleteBefore(conn ConnectionInterface, threshold time.Time) (int, error) {
-	result, err := conn.Exec("DELETE FROM `messages` WHERE `updated_at` < ?", threshold.UTC())
+	// Scheduled messages are kept until they are sent or canceled, however
+	// far in the future that is. Digested messages are likewise kept until
+	// their digest is sent, which can be a week later.
+	_, err := conn.Exec("DELETE FROM `message_events` WHERE `message_id` IN (SELECT `id` FROM `messages` WHERE `updated_at` < ? AND `status` NOT IN ('scheduled', 'digested'))", threshold.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := conn.Exec("DELETE FROM `messages` WHERE `updated_at` < ? AND `status` NOT IN ('scheduled', 'digested')", threshold.UTC())
 	if err != nil {
 		return 0, err
 	}
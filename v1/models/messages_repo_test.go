@@ -112,6 +112,76 @@ var _ = Describe("MessagesRepo", func() {
 		})
 	})
 
+	Describe("List and Count", func() {
+		BeforeEach(func() {
+			guidGenerator.GenerateCall.Returns.IDs = []string{
+				"first-random-guid",
+				"second-random-guid",
+				"third-random-guid",
+			}
+
+			for _, m := range []models.Message{
+				{Status: common.StatusDelivered, ClientID: "client-a", KindID: "kind-a", UserGUID: "user-1"},
+				{Status: common.StatusFailed, ClientID: "client-a", KindID: "kind-b", Email: "user@example.com"},
+				{Status: common.StatusFailed, ClientID: "client-b", KindID: "kind-a", UserGUID: "user-2"},
+			} {
+				_, err := repo.Create(conn, m)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("returns every message when the filter is empty", func() {
+			messages, err := repo.List(conn, models.MessagesFilter{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(messages).To(HaveLen(3))
+
+			count, err := repo.Count(conn, models.MessagesFilter{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(3))
+		})
+
+		It("filters by client, kind, recipient and status", func() {
+			messages, err := repo.List(conn, models.MessagesFilter{ClientID: "client-a", Status: common.StatusFailed})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(messages).To(HaveLen(1))
+			Expect(messages[0].ID).To(Equal("second-random-guid"))
+			Expect(messages[0].Email).To(Equal("user@example.com"))
+
+			messages, err = repo.List(conn, models.MessagesFilter{KindID: "kind-a", UserGUID: "user-2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(messages).To(HaveLen(1))
+			Expect(messages[0].ID).To(Equal("third-random-guid"))
+
+			count, err := repo.Count(conn, models.MessagesFilter{ClientID: "client-a"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(2))
+		})
+
+		It("filters by the updated_at window", func() {
+			messages, err := repo.List(conn, models.MessagesFilter{UpdatedAfter: time.Now().Add(1 * time.Hour)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(messages).To(BeEmpty())
+
+			count, err := repo.Count(conn, models.MessagesFilter{
+				UpdatedAfter:  time.Now().Add(-1 * time.Hour),
+				UpdatedBefore: time.Now().Add(1 * time.Hour),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(3))
+		})
+
+		It("pages through the results", func() {
+			firstPage, err := repo.List(conn, models.MessagesFilter{Limit: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(firstPage).To(HaveLen(2))
+
+			secondPage, err := repo.List(conn, models.MessagesFilter{Limit: 2, Offset: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secondPage).To(HaveLen(1))
+			Expect(firstPage).NotTo(ContainElement(secondPage[0]))
+		})
+	})
+
 	Describe("DeleteBefore", func() {
 		It("Deletes messages older than the input time", func() {
 			message, err := repo.Create(conn, message)
@@ -126,6 +196,51 @@ var _ = Describe("MessagesRepo", func() {
 
 		})
 
+		It("Deletes the events of the deleted messages", func() {
+			message, err := repo.Create(conn, message)
+			Expect(err).NotTo(HaveOccurred())
+
+			eventsRepo := models.NewMessageEventsRepo()
+			_, err = eventsRepo.Create(conn, models.MessageEvent{
+				MessageID: message.ID,
+				Status:    common.StatusDelivered,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.DeleteBefore(conn, time.Now().Add(1*time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+
+			events, err := eventsRepo.ListByMessageID(conn, message.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(BeEmpty())
+		})
+
+		It("Does not delete scheduled messages", func() {
+			message.Status = "scheduled"
+			message, err := repo.Create(conn, message)
+			Expect(err).NotTo(HaveOccurred())
+
+			itemsDeleted, err := repo.DeleteBefore(conn, time.Now().Add(1*time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(itemsDeleted).To(Equal(0))
+
+			_, err = repo.FindByID(conn, message.ID)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Does not delete digested messages", func() {
+			message.Status = common.StatusDigested
+			message, err := repo.Create(conn, message)
+			Expect(err).NotTo(HaveOccurred())
+
+			itemsDeleted, err := repo.DeleteBefore(conn, time.Now().Add(1*time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(itemsDeleted).To(Equal(0))
+
+			_, err = repo.FindByID(conn, message.ID)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		It("Does not delete messages younger than the input time", func() {
 			message, err := repo.Create(conn, message)
 			Expect(err).NotTo(HaveOccurred())
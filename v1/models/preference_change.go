@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/gorp.v1"
+)
+
+// The sources a preference change can come from.
+const (
+	PreferenceChangeSourceAPI             = "api"
+	PreferenceChangeSourceUnsubscribeLink = "unsubscribe_link"
+)
+
+// The fields of the preferences of a user whose changes are recorded. The
+// email field is per kind, the frequency field per kind when the change has
+// a client and kind and for the user as a whole otherwise, and the others
+// are for the user as a whole.
+const (
+	PreferenceFieldGlobalUnsubscribe = "global_unsubscribe"
+	PreferenceFieldEmail             = "email"
+	PreferenceFieldFrequency         = "frequency"
+	PreferenceFieldTimeZone          = "time_zone"
+	PreferenceFieldQuietHours        = "quiet_hours"
+	PreferenceFieldLocale            = "locale"
+)
+
+// PreferenceChange records a change to one field of the preferences of a
+// user, along with who made it and through what. Actor is the ID of the user
+// for changes users make to their own preferences, and the ID of the client
+// for changes made on their behalf.
+type PreferenceChange struct {
+	Primary   int       `db:"primary"`
+	UserID    string    `db:"user_id"`
+	Actor     string    `db:"actor"`
+	Source    string    `db:"source"`
+	ClientID  string    `db:"client_id"`
+	KindID    string    `db:"kind_id"`
+	Field     string    `db:"field"`
+	OldValue  string    `db:"old_value"`
+	NewValue  string    `db:"new_value"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (c *PreferenceChange) PreInsert(s gorp.SqlExecutor) error {
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now().Truncate(1 * time.Second).UTC()
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package models
+
+type PreferenceChangesRepo struct{}
+
+func NewPreferenceChangesRepo() PreferenceChangesRepo {
+	return PreferenceChangesRepo{}
+}
+
+func (repo PreferenceChangesRepo) Create(conn ConnectionInterface, change PreferenceChange) (PreferenceChange, error) {
+	err := conn.Insert(&change)
+	if err != nil {
+		return PreferenceChange{}, err
+	}
+
+	return change, nil
+}
+
+func (repo PreferenceChangesRepo) ListByUserID(conn ConnectionInterface, userID string) ([]PreferenceChange, error) {
+	changes := []PreferenceChange{}
+	_, err := conn.Select(&changes, "SELECT * FROM `preference_changes` WHERE `user_id` = ? ORDER BY `created_at`, `primary`", userID)
+	if err != nil {
+		return []PreferenceChange{}, err
+	}
+
+	return changes, nil
+}
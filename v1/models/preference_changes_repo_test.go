@@ -0,0 +1,87 @@
+package models_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PreferenceChangesRepo", func() {
+	var (
+		repo models.PreferenceChangesRepo
+		conn db.ConnectionInterface
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+
+		repo = models.NewPreferenceChangesRepo()
+	})
+
+	Describe("Create", func() {
+		It("inserts a change into the database", func() {
+			change, err := repo.Create(conn, models.PreferenceChange{
+				UserID:   "user-id",
+				Actor:    "user-id",
+				Source:   models.PreferenceChangeSourceAPI,
+				ClientID: "dogs",
+				KindID:   "barking",
+				Field:    models.PreferenceFieldEmail,
+				OldValue: "true",
+				NewValue: "false",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(change.Primary).NotTo(BeZero())
+			Expect(change.CreatedAt).To(BeTemporally("~", time.Now(), 2*time.Second))
+		})
+	})
+
+	Describe("ListByUserID", func() {
+		It("returns the changes to the preferences of the user in the order they happened", func() {
+			now := time.Now().Truncate(1 * time.Second).UTC()
+
+			_, err := repo.Create(conn, models.PreferenceChange{
+				UserID:    "user-id",
+				Source:    models.PreferenceChangeSourceUnsubscribeLink,
+				Field:     models.PreferenceFieldEmail,
+				CreatedAt: now,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Create(conn, models.PreferenceChange{
+				UserID:    "user-id",
+				Source:    models.PreferenceChangeSourceAPI,
+				Field:     models.PreferenceFieldGlobalUnsubscribe,
+				CreatedAt: now.Add(-1 * time.Minute),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Create(conn, models.PreferenceChange{
+				UserID: "other-user-id",
+				Source: models.PreferenceChangeSourceAPI,
+				Field:  models.PreferenceFieldFrequency,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			changes, err := repo.ListByUserID(conn, "user-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(HaveLen(2))
+			Expect(changes[0].Field).To(Equal(models.PreferenceFieldGlobalUnsubscribe))
+			Expect(changes[1].Field).To(Equal(models.PreferenceFieldEmail))
+		})
+
+		It("returns an empty list for users without changes", func() {
+			changes, err := repo.ListByUserID(conn, "user-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/gorp.v1"
+)
+
+const (
+	PreferenceJobStatusQueued    = "queued"
+	PreferenceJobStatusRunning   = "running"
+	PreferenceJobStatusCompleted = "completed"
+	PreferenceJobStatusFailed    = "failed"
+)
+
+// PreferenceJob tracks the progress of a preference change applied to many
+// users at once. Processed counts the users the change was applied to so
+// far, including the Failed ones it could not be applied to.
+type PreferenceJob struct {
+	ID        string    `db:"id"`
+	Status    string    `db:"status"`
+	Total     int       `db:"total"`
+	Processed int       `db:"processed"`
+	Failed    int       `db:"failed"`
+	LastError string    `db:"last_error"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (job *PreferenceJob) PreInsert(s gorp.SqlExecutor) error {
+	job.CreatedAt = time.Now().Truncate(1 * time.Second).UTC()
+	job.UpdatedAt = job.CreatedAt
+
+	return nil
+}
+
+func (job *PreferenceJob) PreUpdate(s gorp.SqlExecutor) error {
+	job.UpdatedAt = time.Now().Truncate(1 * time.Second).UTC()
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+type PreferenceJobsRepo struct {
+	generateID IDGeneratorFunc
+}
+
+func NewPreferenceJobsRepo(guidGenerator IDGeneratorFunc) PreferenceJobsRepo {
+	return PreferenceJobsRepo{
+		generateID: guidGenerator,
+	}
+}
+
+func (repo PreferenceJobsRepo) Create(conn ConnectionInterface, job PreferenceJob) (PreferenceJob, error) {
+	var err error
+	job.ID, err = repo.generateID()
+	if err != nil {
+		return PreferenceJob{}, err
+	}
+
+	err = conn.Insert(&job)
+	if err != nil {
+		return PreferenceJob{}, err
+	}
+
+	return job, nil
+}
+
+func (repo PreferenceJobsRepo) Find(conn ConnectionInterface, jobID string) (PreferenceJob, error) {
+	job := PreferenceJob{}
+	err := conn.SelectOne(&job, "SELECT * FROM `preference_jobs` WHERE `id` = ?", jobID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return PreferenceJob{}, NotFoundError{fmt.Errorf("Preference job with ID %q could not be found", jobID)}
+		}
+
+		return PreferenceJob{}, err
+	}
+
+	return job, nil
+}
+
+func (repo PreferenceJobsRepo) Update(conn ConnectionInterface, job PreferenceJob) (PreferenceJob, error) {
+	_, err := conn.Update(&job)
+	if err != nil {
+		return PreferenceJob{}, err
+	}
+
+	return job, nil
+}
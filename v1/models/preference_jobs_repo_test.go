@@ -0,0 +1,72 @@
+package models_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PreferenceJobsRepo", func() {
+	var (
+		repo          models.PreferenceJobsRepo
+		conn          db.ConnectionInterface
+		guidGenerator *mocks.IDGenerator
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+
+		guidGenerator = mocks.NewIDGenerator()
+		guidGenerator.GenerateCall.Returns.IDs = []string{"some-job-id"}
+
+		repo = models.NewPreferenceJobsRepo(guidGenerator.Generate)
+	})
+
+	It("creates, finds and updates jobs", func() {
+		job, err := repo.Create(conn, models.PreferenceJob{
+			Status: models.PreferenceJobStatusQueued,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.ID).To(Equal("some-job-id"))
+		Expect(job.CreatedAt).NotTo(BeZero())
+
+		job.Status = models.PreferenceJobStatusRunning
+		job.Total = 10
+		job.Processed = 4
+		job.Failed = 1
+		job.LastError = "database is down"
+
+		_, err = repo.Update(conn, job)
+		Expect(err).NotTo(HaveOccurred())
+
+		found, err := repo.Find(conn, "some-job-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found.Status).To(Equal(models.PreferenceJobStatusRunning))
+		Expect(found.Total).To(Equal(10))
+		Expect(found.Processed).To(Equal(4))
+		Expect(found.Failed).To(Equal(1))
+		Expect(found.LastError).To(Equal("database is down"))
+	})
+
+	It("returns a NotFoundError for unknown jobs", func() {
+		_, err := repo.Find(conn, "missing-job-id")
+		Expect(err).To(MatchError(models.NotFoundError{errors.New(`Preference job with ID "missing-job-id" could not be found`)}))
+	})
+
+	Context("when an ID cannot be generated", func() {
+		It("returns the error", func() {
+			guidGenerator.GenerateCall.Returns.Error = errors.New("out of entropy")
+
+			_, err := repo.Create(conn, models.PreferenceJob{})
+			Expect(err).To(MatchError("out of entropy"))
+		})
+	})
+})
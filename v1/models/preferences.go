@@ -6,4 +6,17 @@ type Preference struct {
 	KindDescription   string `db:"kind_description"`
 	SourceDescription string `db:"source_description"`
 	Email             bool
+	Frequency         string
+}
+
+// KindPreference is the preference of a user for notifications of a kind.
+// Email is false when the user unsubscribed from the kind, regardless of
+// whether they also unsubscribed from every notification, which is reported
+// by GlobalUnsubscribe. Frequency is the one the notifications of the kind
+// are delivered at.
+type KindPreference struct {
+	UserID            string
+	Email             bool
+	GlobalUnsubscribe bool
+	Frequency         string
 }
@@ -1,7 +1,8 @@
 package models
 
 type PreferencesRepo struct {
-	unsubscribesRepo UnsubscribesRepo
+	unsubscribesRepo        UnsubscribesRepo
+	deliveryFrequenciesRepo DeliveryFrequenciesRepo
 }
 
 func NewPreferencesRepo() PreferencesRepo {
@@ -33,10 +34,90 @@ func (repo PreferencesRepo) FindNonCriticalPreferences(conn ConnectionInterface,
 		return preferences, err
 	}
 
+	frequencies, err := repo.deliveryFrequenciesRepo.FindAllByUserID(conn, userGUID)
+	if err != nil {
+		return preferences, err
+	}
+
 	unsubscribes := Unsubscribes(unsubs)
 	for index, preference := range preferences {
 		preferences[index].Email = !unsubscribes.Contains(preference.ClientID, preference.KindID)
+		preferences[index].Frequency = frequencies.For(preference.ClientID, preference.KindID)
 	}
 
 	return preferences, nil
 }
+
+// FindAllByKind returns the preferences for the kind of every user who
+// received notifications of the kind or set a preference for it, ordered by
+// user ID.
+func (repo PreferencesRepo) FindAllByKind(conn ConnectionInterface, clientID, kindID string) ([]KindPreference, error) {
+	var userIDs []string
+	_, err := conn.Select(&userIDs, `SELECT user_guid FROM receipts WHERE client_id = ? AND kind_id = ?
+			UNION SELECT user_id FROM unsubscribes WHERE client_id = ? AND kind_id = ?
+			UNION SELECT user_id FROM delivery_frequencies WHERE client_id = ? AND kind_id = ?
+			ORDER BY 1`, clientID, kindID, clientID, kindID, clientID, kindID)
+	if err != nil {
+		return nil, err
+	}
+
+	var unsubscribed []string
+	_, err = conn.Select(&unsubscribed, "SELECT `user_id` FROM `unsubscribes` WHERE `client_id` = ? AND `kind_id` = ?", clientID, kindID)
+	if err != nil {
+		return nil, err
+	}
+
+	var globallyUnsubscribed []string
+	_, err = conn.Select(&globallyUnsubscribed, "SELECT `user_id` FROM `global_unsubscribes`")
+	if err != nil {
+		return nil, err
+	}
+
+	frequencies := []DeliveryFrequency{}
+	_, err = conn.Select(&frequencies, "SELECT * FROM `delivery_frequencies` WHERE (`client_id` = ? AND `kind_id` = ?) OR (`client_id` = '' AND `kind_id` = '')", clientID, kindID)
+	if err != nil {
+		return nil, err
+	}
+
+	unsubscribedSet := stringSet(unsubscribed)
+	globallyUnsubscribedSet := stringSet(globallyUnsubscribed)
+	userFrequencies := map[string]string{}
+	kindFrequencies := map[string]string{}
+	for _, frequency := range frequencies {
+		if frequency.ClientID == "" {
+			userFrequencies[frequency.UserID] = frequency.Frequency
+		} else {
+			kindFrequencies[frequency.UserID] = frequency.Frequency
+		}
+	}
+
+	preferences := []KindPreference{}
+	for _, userID := range userIDs {
+		frequency := kindFrequencies[userID]
+		if frequency == "" {
+			frequency = userFrequencies[userID]
+		}
+
+		if frequency == "" {
+			frequency = FrequencyImmediate
+		}
+
+		preferences = append(preferences, KindPreference{
+			UserID:            userID,
+			Email:             !unsubscribedSet[userID],
+			GlobalUnsubscribe: globallyUnsubscribedSet[userID],
+			Frequency:         frequency,
+		})
+	}
+
+	return preferences, nil
+}
+
+func stringSet(values []string) map[string]bool {
+	set := map[string]bool{}
+	for _, value := range values {
+		set[value] = true
+	}
+
+	return set
+}
@@ -157,6 +157,70 @@ var _ = Describe("PreferencesRepo", func() {
 					SourceDescription: "raptors description",
 				}))
 			})
+
+			It("includes the delivery frequency the user set for a kind", func() {
+				err := models.NewDeliveryFrequenciesRepo().Set(conn, "correct-user", "raptors", "dead", models.FrequencyWeekly)
+				Expect(err).NotTo(HaveOccurred())
+
+				results, err := repo.FindNonCriticalPreferences(conn, "correct-user")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(results).To(ContainElement(models.Preference{
+					ClientID:          "raptors",
+					KindID:            "dead",
+					Email:             true,
+					Frequency:         models.FrequencyWeekly,
+					KindDescription:   "dead description",
+					SourceDescription: "raptors description",
+				}))
+			})
+		})
+	})
+
+	Describe("FindAllByKind", func() {
+		BeforeEach(func() {
+			err := models.NewReceiptsRepo().CreateReceipts(conn, []string{"user-b", "user-a", "user-c"}, "raptors", "sleepy")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = models.NewReceiptsRepo().CreateReceipts(conn, []string{"user-other"}, "raptors", "dead")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = unsubscribeRepo.Set(conn, "user-b", "raptors", "sleepy", true)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = models.NewGlobalUnsubscribesRepo().Set(conn, "user-c", true)
+			Expect(err).NotTo(HaveOccurred())
+
+			frequencies := models.NewDeliveryFrequenciesRepo()
+			err = frequencies.Set(conn, "user-a", "", "", models.FrequencyDaily)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = frequencies.Set(conn, "user-c", "", "", models.FrequencyDaily)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = frequencies.Set(conn, "user-c", "raptors", "sleepy", models.FrequencyWeekly)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = frequencies.Set(conn, "user-d", "raptors", "sleepy", models.FrequencyWeekly)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the preference of every user who received the kind or set a preference for it", func() {
+			results, err := repo.FindAllByKind(conn, "raptors", "sleepy")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(results).To(Equal([]models.KindPreference{
+				{UserID: "user-a", Email: true, Frequency: models.FrequencyDaily},
+				{UserID: "user-b", Email: false, Frequency: models.FrequencyImmediate},
+				{UserID: "user-c", Email: true, GlobalUnsubscribe: true, Frequency: models.FrequencyWeekly},
+				{UserID: "user-d", Email: true, Frequency: models.FrequencyWeekly},
+			}))
+		})
+
+		It("returns nothing for a kind nobody received", func() {
+			results, err := repo.FindAllByKind(conn, "raptors", "orange")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(BeEmpty())
 		})
 	})
 })
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// QuietHours are the time zone of a user and the daily window, in that time
+// zone, during which they do not want to receive non-critical notifications.
+// Start and End are times of day formatted as "15:04"; a window whose end is
+// before its start spans midnight. Users without a time zone are in UTC.
+type QuietHours struct {
+	Primary  int    `db:"primary"`
+	UserID   string `db:"user_id"`
+	TimeZone string `db:"time_zone"`
+	Start    string `db:"start_time"`
+	End      string `db:"end_time"`
+}
+
+func (q QuietHours) HasWindow() bool {
+	return q.Start != "" && q.End != "" && q.Start != q.End
+}
+
+// Validate reports whether the time zone is known and the window is made of
+// two valid times of day, or of none.
+func (q QuietHours) Validate() error {
+	if q.TimeZone != "" {
+		_, err := time.LoadLocation(q.TimeZone)
+		if err != nil {
+			return fmt.Errorf("The time zone '%s' is not known", q.TimeZone)
+		}
+	}
+
+	if q.Start == "" && q.End == "" {
+		return nil
+	}
+
+	for _, value := range []string{q.Start, q.End} {
+		_, err := time.Parse("15:04", value)
+		if err != nil {
+			return fmt.Errorf("The quiet hours time '%s' is not formatted as HH:MM", value)
+		}
+	}
+
+	return nil
+}
+
+// Until returns when the window that the given time falls in ends, and
+// whether it falls in one at all.
+func (q QuietHours) Until(now time.Time) (time.Time, bool) {
+	if !q.HasWindow() {
+		return time.Time{}, false
+	}
+
+	location, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	start, err := time.Parse("15:04", q.Start)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	end, err := time.Parse("15:04", q.End)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	local := now.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	var inside bool
+	if startMinute < endMinute {
+		inside = minute >= startMinute && minute < endMinute
+	} else {
+		inside = minute >= startMinute || minute < endMinute
+	}
+
+	if !inside {
+		return time.Time{}, false
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end.Hour(), end.Minute(), 0, 0, location)
+	if !until.After(local) {
+		until = time.Date(local.Year(), local.Month(), local.Day()+1, end.Hour(), end.Minute(), 0, 0, location)
+	}
+
+	return until, true
+}
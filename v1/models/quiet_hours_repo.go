@@ -0,0 +1,47 @@
+package models
+
+import "database/sql"
+
+type QuietHoursRepo struct{}
+
+func NewQuietHoursRepo() QuietHoursRepo {
+	return QuietHoursRepo{}
+}
+
+// Get returns the quiet hours of the user, which are empty when the user has
+// not set any.
+func (repo QuietHoursRepo) Get(conn ConnectionInterface, userID string) (QuietHours, error) {
+	quietHours := QuietHours{}
+	err := conn.SelectOne(&quietHours, "SELECT * FROM `quiet_hours` WHERE `user_id` = ?", userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return QuietHours{UserID: userID}, nil
+		}
+
+		return QuietHours{}, err
+	}
+
+	return quietHours, nil
+}
+
+// Set stores the quiet hours of the user, removing them when they are empty.
+func (repo QuietHoursRepo) Set(conn ConnectionInterface, quietHours QuietHours) error {
+	existing, err := repo.Get(conn, quietHours.UserID)
+	if err != nil {
+		return err
+	}
+
+	quietHours.Primary = existing.Primary
+	empty := quietHours.TimeZone == "" && quietHours.Start == "" && quietHours.End == ""
+
+	switch {
+	case empty && existing.Primary != 0:
+		_, err = conn.Delete(&quietHours)
+	case !empty && existing.Primary == 0:
+		err = conn.Insert(&quietHours)
+	case !empty:
+		_, err = conn.Update(&quietHours)
+	}
+
+	return err
+}
@@ -0,0 +1,73 @@
+package models_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("QuietHoursRepo", func() {
+	var (
+		repo models.QuietHoursRepo
+		conn db.ConnectionInterface
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+		repo = models.NewQuietHoursRepo()
+	})
+
+	It("returns empty quiet hours when the user has not set any", func() {
+		quietHours, err := repo.Get(conn, "some-user")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quietHours).To(Equal(models.QuietHours{UserID: "some-user"}))
+	})
+
+	It("stores and updates the quiet hours of the user", func() {
+		err := repo.Set(conn, models.QuietHours{
+			UserID:   "some-user",
+			TimeZone: "Asia/Tokyo",
+			Start:    "22:00",
+			End:      "07:00",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = repo.Set(conn, models.QuietHours{
+			UserID:   "some-user",
+			TimeZone: "Asia/Tokyo",
+			Start:    "23:00",
+			End:      "06:30",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		quietHours, err := repo.Get(conn, "some-user")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(quietHours.Primary).NotTo(BeZero())
+		Expect(quietHours.TimeZone).To(Equal("Asia/Tokyo"))
+		Expect(quietHours.Start).To(Equal("23:00"))
+		Expect(quietHours.End).To(Equal("06:30"))
+
+		var rows int
+		err = conn.SelectOne(&rows, "SELECT COUNT(*) FROM `quiet_hours`")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rows).To(Equal(1))
+	})
+
+	It("removes quiet hours that are set to nothing", func() {
+		err := repo.Set(conn, models.QuietHours{UserID: "some-user", TimeZone: "Asia/Tokyo"})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = repo.Set(conn, models.QuietHours{UserID: "some-user"})
+		Expect(err).NotTo(HaveOccurred())
+
+		var rows int
+		err = conn.SelectOne(&rows, "SELECT COUNT(*) FROM `quiet_hours`")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rows).To(Equal(0))
+	})
+})
@@ -0,0 +1,107 @@
+package models_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("QuietHours", func() {
+	var (
+		quietHours models.QuietHours
+		sydney     *time.Location
+	)
+
+	BeforeEach(func() {
+		var err error
+		sydney, err = time.LoadLocation("Australia/Sydney")
+		Expect(err).NotTo(HaveOccurred())
+
+		quietHours = models.QuietHours{
+			TimeZone: "Australia/Sydney",
+			Start:    "22:00",
+			End:      "07:00",
+		}
+	})
+
+	Describe("Until", func() {
+		It("returns the end of a window that spans midnight, late in the evening", func() {
+			until, ok := quietHours.Until(time.Date(2015, time.June, 1, 23, 30, 0, 0, sydney))
+			Expect(ok).To(BeTrue())
+			Expect(until).To(BeTemporally("==", time.Date(2015, time.June, 2, 7, 0, 0, 0, sydney)))
+		})
+
+		It("returns the end of a window that spans midnight, early in the morning", func() {
+			until, ok := quietHours.Until(time.Date(2015, time.June, 1, 3, 0, 0, 0, sydney).UTC())
+			Expect(ok).To(BeTrue())
+			Expect(until).To(BeTemporally("==", time.Date(2015, time.June, 1, 7, 0, 0, 0, sydney)))
+		})
+
+		It("returns the end of a window within a day", func() {
+			quietHours.Start = "12:00"
+			quietHours.End = "13:30"
+
+			until, ok := quietHours.Until(time.Date(2015, time.June, 1, 12, 15, 0, 0, sydney))
+			Expect(ok).To(BeTrue())
+			Expect(until).To(BeTemporally("==", time.Date(2015, time.June, 1, 13, 30, 0, 0, sydney)))
+		})
+
+		It("does not defer outside of the window", func() {
+			_, ok := quietHours.Until(time.Date(2015, time.June, 1, 7, 0, 0, 0, sydney))
+			Expect(ok).To(BeFalse())
+
+			_, ok = quietHours.Until(time.Date(2015, time.June, 1, 21, 59, 0, 0, sydney))
+			Expect(ok).To(BeFalse())
+		})
+
+		It("uses UTC when the user has not set a time zone", func() {
+			quietHours.TimeZone = ""
+
+			until, ok := quietHours.Until(time.Date(2015, time.June, 1, 23, 0, 0, 0, time.UTC))
+			Expect(ok).To(BeTrue())
+			Expect(until).To(BeTemporally("==", time.Date(2015, time.June, 2, 7, 0, 0, 0, time.UTC)))
+		})
+
+		It("does not defer when the user has no window", func() {
+			quietHours.Start = ""
+			quietHours.End = ""
+
+			_, ok := quietHours.Until(time.Date(2015, time.June, 1, 23, 0, 0, 0, sydney))
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("Validate", func() {
+		It("accepts a known time zone and a window of two times of day", func() {
+			Expect(quietHours.Validate()).To(Succeed())
+		})
+
+		It("accepts a time zone without a window", func() {
+			quietHours.Start = ""
+			quietHours.End = ""
+
+			Expect(quietHours.Validate()).To(Succeed())
+		})
+
+		It("rejects unknown time zones", func() {
+			quietHours.TimeZone = "Middle/Earth"
+
+			Expect(quietHours.Validate()).To(MatchError("The time zone 'Middle/Earth' is not known"))
+		})
+
+		It("rejects malformed times of day", func() {
+			quietHours.End = "7am"
+
+			Expect(quietHours.Validate()).To(MatchError("The quiet hours time '7am' is not formatted as HH:MM"))
+		})
+
+		It("rejects windows missing one of their ends", func() {
+			quietHours.End = ""
+
+			Expect(quietHours.Validate()).To(MatchError("The quiet hours time '' is not formatted as HH:MM"))
+		})
+	})
+})
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/gorp.v1"
+)
+
+const (
+	QuotaMeasureRequests   = "requests"
+	QuotaMeasureRecipients = "recipients"
+)
+
+// Quota limits how much a client may send. A quota without a kind applies to
+// everything the client sends, while a quota for a kind only counts the
+// notifications of that kind. A limit of zero means there is no limit.
+type Quota struct {
+	Primary           int       `db:"primary"`
+	ClientID          string    `db:"client_id"`
+	KindID            string    `db:"kind_id"`
+	RequestsPerMinute int       `db:"requests_per_minute"`
+	RecipientsPerHour int       `db:"recipients_per_hour"`
+	CreatedAt         time.Time `db:"created_at"`
+	UpdatedAt         time.Time `db:"updated_at"`
+}
+
+func (q *Quota) PreInsert(e gorp.SqlExecutor) error {
+	q.CreatedAt = time.Now().Truncate(1 * time.Second).UTC()
+	q.UpdatedAt = q.CreatedAt
+
+	return nil
+}
+
+func (q *Quota) PreUpdate(e gorp.SqlExecutor) error {
+	q.UpdatedAt = time.Now().Truncate(1 * time.Second).UTC()
+
+	return nil
+}
+
+// QuotaUsage counts what a client has sent against one of its quotas during
+// a fixed window of time.
+type QuotaUsage struct {
+	Primary     int       `db:"primary"`
+	ClientID    string    `db:"client_id"`
+	KindID      string    `db:"kind_id"`
+	Measure     string    `db:"measure"`
+	WindowStart time.Time `db:"window_start"`
+	Count       int       `db:"count"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "github.com/cloudfoundry-incubator/notifications/db"
+
+type QuotaUsagesRepo struct{}
+
+func NewQuotaUsagesRepo() QuotaUsagesRepo {
+	return QuotaUsagesRepo{}
+}
+
+// Increment adds to the usage of a quota during the window and returns the
+// new total. Usage from earlier windows is no longer needed and is removed
+// along the way. Inside a transaction the usage row stays locked until the
+// transaction ends, so that concurrent requests are counted one at a time.
+func (repo QuotaUsagesRepo) Increment(conn ConnectionInterface, usage QuotaUsage) (int, error) {
+	_, err := conn.Exec("DELETE FROM `quota_usages` WHERE `client_id` = ? AND `kind_id` = ? AND `measure` = ? AND `window_start` < ?",
+		usage.ClientID, usage.KindID, usage.Measure, usage.WindowStart)
+	if err != nil {
+		return 0, err
+	}
+
+	query := "INSERT INTO `quota_usages` (`client_id`, `kind_id`, `measure`, `window_start`, `count`) VALUES (?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE `count`=`count`+VALUES(`count`)"
+	if db.DialectOfMap(conn.GetDbMap()).Name == db.DialectPostgres {
+		query = "INSERT INTO `quota_usages` (`client_id`, `kind_id`, `measure`, `window_start`, `count`) VALUES (?, ?, ?, ?, ?) ON CONFLICT (`client_id`, `kind_id`, `measure`, `window_start`) DO UPDATE SET `count`=`quota_usages`.`count`+EXCLUDED.`count`"
+	}
+
+	_, err = conn.Exec(query, usage.ClientID, usage.KindID, usage.Measure, usage.WindowStart, usage.Count)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = conn.SelectOne(&count, "SELECT `count` FROM `quota_usages` WHERE `client_id` = ? AND `kind_id` = ? AND `measure` = ? AND `window_start` = ?",
+		usage.ClientID, usage.KindID, usage.Measure, usage.WindowStart)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,74 @@
+package models_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("QuotaUsagesRepo", func() {
+	var (
+		repo        models.QuotaUsagesRepo
+		conn        db.ConnectionInterface
+		windowStart time.Time
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+		repo = models.NewQuotaUsagesRepo()
+		windowStart = time.Now().UTC().Truncate(time.Hour)
+	})
+
+	usage := func(windowStart time.Time, count int) models.QuotaUsage {
+		return models.QuotaUsage{
+			ClientID:    "some-client",
+			KindID:      "some-kind",
+			Measure:     models.QuotaMeasureRecipients,
+			WindowStart: windowStart,
+			Count:       count,
+		}
+	}
+
+	It("adds up the usage during a window", func() {
+		count, err := repo.Increment(conn, usage(windowStart, 5))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(5))
+
+		count, err = repo.Increment(conn, usage(windowStart, 3))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(8))
+	})
+
+	It("starts counting again in a new window and forgets the old one", func() {
+		_, err := repo.Increment(conn, usage(windowStart, 5))
+		Expect(err).NotTo(HaveOccurred())
+
+		count, err := repo.Increment(conn, usage(windowStart.Add(time.Hour), 2))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(2))
+
+		var rows int
+		err = conn.SelectOne(&rows, "SELECT COUNT(*) FROM `quota_usages`")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rows).To(Equal(1))
+	})
+
+	It("counts each measure separately", func() {
+		_, err := repo.Increment(conn, usage(windowStart, 5))
+		Expect(err).NotTo(HaveOccurred())
+
+		requests := usage(windowStart, 1)
+		requests.Measure = models.QuotaMeasureRequests
+
+		count, err := repo.Increment(conn, requests)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+	})
+})
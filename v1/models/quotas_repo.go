@@ -0,0 +1,92 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+type QuotasRepo struct{}
+
+func NewQuotasRepo() QuotasRepo {
+	return QuotasRepo{}
+}
+
+// Upsert sets the limits of the quota, creating it when the client does not
+// have a quota for the kind yet.
+func (repo QuotasRepo) Upsert(conn ConnectionInterface, quota Quota) (Quota, error) {
+	existing, err := repo.Find(conn, quota.ClientID, quota.KindID)
+	switch err.(type) {
+	case NotFoundError:
+		err = conn.Insert(&quota)
+		if err != nil {
+			return Quota{}, err
+		}
+
+		return quota, nil
+	case nil:
+		existing.RequestsPerMinute = quota.RequestsPerMinute
+		existing.RecipientsPerHour = quota.RecipientsPerHour
+
+		_, err = conn.Update(&existing)
+		if err != nil {
+			return Quota{}, err
+		}
+
+		return existing, nil
+	default:
+		return Quota{}, err
+	}
+}
+
+func (repo QuotasRepo) Find(conn ConnectionInterface, clientID, kindID string) (Quota, error) {
+	quota := Quota{}
+	err := conn.SelectOne(&quota, "SELECT * FROM `quotas` WHERE `client_id` = ? AND `kind_id` = ?", clientID, kindID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = NotFoundError{fmt.Errorf("Quota for client %q and kind %q could not be found", clientID, kindID)}
+		}
+		return Quota{}, err
+	}
+
+	return quota, nil
+}
+
+// FindApplicable returns the quota of the client as a whole along with the
+// quota for the kind, whichever of them exist.
+func (repo QuotasRepo) FindApplicable(conn ConnectionInterface, clientID, kindID string) ([]Quota, error) {
+	quotas := []Quota{}
+	_, err := conn.Select(&quotas, "SELECT * FROM `quotas` WHERE `client_id` = ? AND ( `kind_id` = '' OR `kind_id` = ? ) ORDER BY `kind_id`", clientID, kindID)
+	if err != nil {
+		return []Quota{}, err
+	}
+
+	return quotas, nil
+}
+
+func (repo QuotasRepo) List(conn ConnectionInterface) ([]Quota, error) {
+	quotas := []Quota{}
+	_, err := conn.Select(&quotas, "SELECT * FROM `quotas` ORDER BY `client_id`, `kind_id`")
+	if err != nil {
+		return []Quota{}, err
+	}
+
+	return quotas, nil
+}
+
+func (repo QuotasRepo) Delete(conn ConnectionInterface, clientID, kindID string) error {
+	result, err := conn.Exec("DELETE FROM `quotas` WHERE `client_id` = ? AND `kind_id` = ?", clientID, kindID)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return NotFoundError{fmt.Errorf("Quota for client %q and kind %q could not be found", clientID, kindID)}
+	}
+
+	return nil
+}
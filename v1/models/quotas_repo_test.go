@@ -0,0 +1,100 @@
+package models_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("QuotasRepo", func() {
+	var (
+		repo models.QuotasRepo
+		conn db.ConnectionInterface
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+		repo = models.NewQuotasRepo()
+	})
+
+	Describe("Upsert", func() {
+		It("creates a quota", func() {
+			quota, err := repo.Upsert(conn, models.Quota{
+				ClientID:          "some-client",
+				RequestsPerMinute: 100,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quota.CreatedAt).NotTo(BeZero())
+
+			found, err := repo.Find(conn, "some-client", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.RequestsPerMinute).To(Equal(100))
+		})
+
+		It("replaces the limits of an existing quota", func() {
+			_, err := repo.Upsert(conn, models.Quota{ClientID: "some-client", KindID: "some-kind", RequestsPerMinute: 100})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Upsert(conn, models.Quota{ClientID: "some-client", KindID: "some-kind", RecipientsPerHour: 1000})
+			Expect(err).NotTo(HaveOccurred())
+
+			quotas, err := repo.List(conn)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quotas).To(HaveLen(1))
+			Expect(quotas[0].RequestsPerMinute).To(Equal(0))
+			Expect(quotas[0].RecipientsPerHour).To(Equal(1000))
+		})
+	})
+
+	Describe("Find", func() {
+		It("returns a not found error when there is no quota", func() {
+			_, err := repo.Find(conn, "some-client", "some-kind")
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New(`Quota for client "some-client" and kind "some-kind" could not be found`)}))
+		})
+	})
+
+	Describe("FindApplicable", func() {
+		It("returns the quota of the client along with the quota of the kind", func() {
+			for _, quota := range []models.Quota{
+				{ClientID: "some-client", KindID: "some-kind", RequestsPerMinute: 10},
+				{ClientID: "some-client", RequestsPerMinute: 100},
+				{ClientID: "some-client", KindID: "other-kind", RequestsPerMinute: 20},
+				{ClientID: "other-client", RequestsPerMinute: 30},
+			} {
+				_, err := repo.Upsert(conn, quota)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			quotas, err := repo.FindApplicable(conn, "some-client", "some-kind")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quotas).To(HaveLen(2))
+			Expect(quotas[0].KindID).To(Equal(""))
+			Expect(quotas[1].KindID).To(Equal("some-kind"))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("deletes the quota", func() {
+			_, err := repo.Upsert(conn, models.Quota{ClientID: "some-client", RequestsPerMinute: 100})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = repo.Delete(conn, "some-client", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Find(conn, "some-client", "")
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+
+		It("returns a not found error when there is no quota", func() {
+			err := repo.Delete(conn, "some-client", "")
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+	})
+})
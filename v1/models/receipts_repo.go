@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+)
 
 type ReceiptsRepo struct{}
 
@@ -10,6 +14,10 @@ func NewReceiptsRepo() ReceiptsRepo {
 
 func (repo ReceiptsRepo) upsert(conn ConnectionInterface, receipt Receipt) error {
 	query := "INSERT INTO `receipts` (`user_guid`, `client_id`, `kind_id`, `count`, `created_at`) VALUES (?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE `count`=`count`+1"
+	if db.DialectOfMap(conn.GetDbMap()).Name == db.DialectPostgres {
+		query = "INSERT INTO `receipts` (`user_guid`, `client_id`, `kind_id`, `count`, `created_at`) VALUES (?, ?, ?, ?, ?) ON CONFLICT (`user_guid`, `client_id`, `kind_id`) DO UPDATE SET `count`=`receipts`.`count`+1"
+	}
+
 	_, err := conn.Exec(query, receipt.UserGUID, receipt.ClientID, receipt.KindID, 1, time.Now().Truncate(1*time.Second).UTC())
 	if err != nil {
 		return err
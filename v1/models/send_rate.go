@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+const SendRateScopeGlobal = "global"
+
+// SendRate counts the messages handed to the mail server during one second,
+// either by every instance together or for a single recipient domain.
+type SendRate struct {
+	Primary     int       `db:"primary"`
+	Scope       string    `db:"scope"`
+	WindowStart time.Time `db:"window_start"`
+	Count       int       `db:"count"`
+}
+
+// SendRateScopeForDomain is the scope that counts the messages sent to
+// recipients at the domain.
+func SendRateScopeForDomain(domain string) string {
+	return "domain:" + domain
+}
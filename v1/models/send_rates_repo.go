@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+)
+
+type SendRatesRepo struct{}
+
+func NewSendRatesRepo() SendRatesRepo {
+	return SendRatesRepo{}
+}
+
+// Increment counts one more message sent in the scope during the window and
+// returns the new total, in a single statement so that concurrent senders
+// each see their own count.
+func (repo SendRatesRepo) Increment(conn ConnectionInterface, scope string, windowStart time.Time) (int, error) {
+	if db.DialectOfMap(conn.GetDbMap()).Name == db.DialectPostgres {
+		var count int
+		err := conn.SelectOne(&count, "INSERT INTO `send_rates` (`scope`, `window_start`, `count`) VALUES (?, ?, 1) ON CONFLICT (`scope`, `window_start`) DO UPDATE SET `count`=`send_rates`.`count`+1 RETURNING `count`", scope, windowStart)
+		if err != nil {
+			return 0, err
+		}
+
+		return count, nil
+	}
+
+	// MySQL reports the value given to LAST_INSERT_ID as the insert ID of an
+	// update. An insert reports the new primary key instead, but then the
+	// count is always 1, and only an insert affects a single row.
+	result, err := conn.Exec("INSERT INTO `send_rates` (`scope`, `window_start`, `count`) VALUES (?, ?, 1) ON DUPLICATE KEY UPDATE `count`=LAST_INSERT_ID(`count`+1)", scope, windowStart)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if rowsAffected == 1 {
+		return 1, nil
+	}
+
+	count, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// DeleteBefore removes the counts of windows that started before the
+// threshold, which no send is counted in anymore.
+func (repo SendRatesRepo) DeleteBefore(conn ConnectionInterface, threshold time.Time) (int, error) {
+	result, err := conn.Exec("DELETE FROM `send_rates` WHERE `window_start` < ?", threshold.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
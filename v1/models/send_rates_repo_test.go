@@ -0,0 +1,72 @@
+package models_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SendRatesRepo", func() {
+	var (
+		repo        models.SendRatesRepo
+		conn        db.ConnectionInterface
+		windowStart time.Time
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+		repo = models.NewSendRatesRepo()
+		windowStart = time.Now().UTC().Truncate(time.Second)
+	})
+
+	It("counts the messages sent during a window", func() {
+		count, err := repo.Increment(conn, models.SendRateScopeGlobal, windowStart)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+
+		count, err = repo.Increment(conn, models.SendRateScopeGlobal, windowStart)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(2))
+	})
+
+	It("starts counting again in a new window", func() {
+		_, err := repo.Increment(conn, models.SendRateScopeGlobal, windowStart)
+		Expect(err).NotTo(HaveOccurred())
+
+		count, err := repo.Increment(conn, models.SendRateScopeGlobal, windowStart.Add(time.Second))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+	})
+
+	It("deletes the counts of windows that started before the threshold", func() {
+		_, err := repo.Increment(conn, models.SendRateScopeGlobal, windowStart.Add(-time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = repo.Increment(conn, models.SendRateScopeGlobal, windowStart)
+		Expect(err).NotTo(HaveOccurred())
+
+		deleted, err := repo.DeleteBefore(conn, windowStart)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(Equal(1))
+
+		count, err := repo.Increment(conn, models.SendRateScopeGlobal, windowStart)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(2))
+	})
+
+	It("counts each scope separately", func() {
+		_, err := repo.Increment(conn, models.SendRateScopeGlobal, windowStart)
+		Expect(err).NotTo(HaveOccurred())
+
+		count, err := repo.Increment(conn, models.SendRateScopeForDomain("example.com"), windowStart)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+	})
+})
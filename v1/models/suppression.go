@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/gorp.v1"
+)
+
+const (
+	SuppressionReasonBounce    = "bounce"
+	SuppressionReasonComplaint = "complaint"
+	SuppressionReasonManual    = "manual"
+)
+
+// Suppression stops any further mail from being sent to an address, whatever
+// the preferences of the user it belongs to.
+type Suppression struct {
+	Primary   int       `db:"primary"`
+	Email     string    `db:"email"`
+	Reason    string    `db:"reason"`
+	Detail    string    `db:"detail"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (s *Suppression) PreInsert(e gorp.SqlExecutor) error {
+	s.CreatedAt = time.Now().Truncate(1 * time.Second).UTC()
+	s.UpdatedAt = s.CreatedAt
+
+	return nil
+}
+
+func (s *Suppression) PreUpdate(e gorp.SqlExecutor) error {
+	s.UpdatedAt = time.Now().Truncate(1 * time.Second).UTC()
+
+	return nil
+}
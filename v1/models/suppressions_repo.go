@@ -0,0 +1,109 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type SuppressionsRepo struct{}
+
+func NewSuppressionsRepo() SuppressionsRepo {
+	return SuppressionsRepo{}
+}
+
+// Upsert suppresses the address, replacing the reason and detail when it is
+// already suppressed. Addresses are compared case-insensitively.
+func (repo SuppressionsRepo) Upsert(conn ConnectionInterface, suppression Suppression) (Suppression, error) {
+	suppression.Email = normalizeEmail(suppression.Email)
+
+	existing, err := repo.Find(conn, suppression.Email)
+	switch err.(type) {
+	case NotFoundError:
+		err = conn.Insert(&suppression)
+		if err != nil {
+			return Suppression{}, err
+		}
+
+		return suppression, nil
+	case nil:
+		existing.Reason = suppression.Reason
+		existing.Detail = suppression.Detail
+
+		_, err = conn.Update(&existing)
+		if err != nil {
+			return Suppression{}, err
+		}
+
+		return existing, nil
+	default:
+		return Suppression{}, err
+	}
+}
+
+func (repo SuppressionsRepo) Find(conn ConnectionInterface, email string) (Suppression, error) {
+	suppression := Suppression{}
+	err := conn.SelectOne(&suppression, "SELECT * FROM `suppressions` WHERE `email` = ?", normalizeEmail(email))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = NotFoundError{fmt.Errorf("Suppression for %q could not be found", email)}
+		}
+		return Suppression{}, err
+	}
+
+	return suppression, nil
+}
+
+func (repo SuppressionsRepo) IsSuppressed(conn ConnectionInterface, email string) (bool, error) {
+	_, err := repo.Find(conn, email)
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case NotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (repo SuppressionsRepo) List(conn ConnectionInterface, limit, offset int) ([]Suppression, error) {
+	suppressions := []Suppression{}
+	_, err := conn.Select(&suppressions, "SELECT * FROM `suppressions` ORDER BY `updated_at` DESC, `email` LIMIT ? OFFSET ?", limit, offset)
+	if err != nil {
+		return []Suppression{}, err
+	}
+
+	return suppressions, nil
+}
+
+func (repo SuppressionsRepo) Count(conn ConnectionInterface) (int, error) {
+	var count int
+	err := conn.SelectOne(&count, "SELECT COUNT(*) FROM `suppressions`")
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (repo SuppressionsRepo) Delete(conn ConnectionInterface, email string) error {
+	result, err := conn.Exec("DELETE FROM `suppressions` WHERE `email` = ?", normalizeEmail(email))
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return NotFoundError{fmt.Errorf("Suppression for %q could not be found", email)}
+	}
+
+	return nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,117 @@
+package models_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SuppressionsRepo", func() {
+	var (
+		repo models.SuppressionsRepo
+		conn db.ConnectionInterface
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+		repo = models.NewSuppressionsRepo()
+	})
+
+	Describe("Upsert", func() {
+		It("suppresses the address in lower case", func() {
+			suppression, err := repo.Upsert(conn, models.Suppression{
+				Email:  " User@Example.com",
+				Reason: models.SuppressionReasonBounce,
+				Detail: "550 5.1.1 mailbox unavailable",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppression.Email).To(Equal("user@example.com"))
+			Expect(suppression.CreatedAt).NotTo(BeZero())
+
+			found, err := repo.Find(conn, "USER@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Reason).To(Equal(models.SuppressionReasonBounce))
+			Expect(found.Detail).To(Equal("550 5.1.1 mailbox unavailable"))
+		})
+
+		It("replaces the reason of an address that is already suppressed", func() {
+			_, err := repo.Upsert(conn, models.Suppression{Email: "user@example.com", Reason: models.SuppressionReasonBounce})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Upsert(conn, models.Suppression{Email: "user@example.com", Reason: models.SuppressionReasonComplaint, Detail: "abuse report"})
+			Expect(err).NotTo(HaveOccurred())
+
+			count, err := repo.Count(conn)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(1))
+
+			found, err := repo.Find(conn, "user@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found.Reason).To(Equal(models.SuppressionReasonComplaint))
+			Expect(found.Detail).To(Equal("abuse report"))
+		})
+	})
+
+	Describe("Find", func() {
+		It("returns a not found error when the address is not suppressed", func() {
+			_, err := repo.Find(conn, "user@example.com")
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New(`Suppression for "user@example.com" could not be found`)}))
+		})
+	})
+
+	Describe("IsSuppressed", func() {
+		It("reports whether the address is suppressed", func() {
+			suppressed, err := repo.IsSuppressed(conn, "user@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppressed).To(BeFalse())
+
+			_, err = repo.Upsert(conn, models.Suppression{Email: "user@example.com", Reason: models.SuppressionReasonBounce})
+			Expect(err).NotTo(HaveOccurred())
+
+			suppressed, err = repo.IsSuppressed(conn, "User@Example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppressed).To(BeTrue())
+		})
+	})
+
+	Describe("List", func() {
+		It("returns a page of suppressions", func() {
+			for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+				_, err := repo.Upsert(conn, models.Suppression{Email: email, Reason: models.SuppressionReasonBounce})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			suppressions, err := repo.List(conn, 2, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppressions).To(HaveLen(2))
+			Expect(suppressions[0].Email).To(Equal("b@example.com"))
+			Expect(suppressions[1].Email).To(Equal("c@example.com"))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("lifts the suppression", func() {
+			_, err := repo.Upsert(conn, models.Suppression{Email: "user@example.com", Reason: models.SuppressionReasonBounce})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = repo.Delete(conn, "User@Example.com")
+			Expect(err).NotTo(HaveOccurred())
+
+			suppressed, err := repo.IsSuppressed(conn, "user@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppressed).To(BeFalse())
+		})
+
+		It("returns a not found error when the address is not suppressed", func() {
+			err := repo.Delete(conn, "user@example.com")
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New(`Suppression for "user@example.com" could not be found`)}))
+		})
+	})
+})
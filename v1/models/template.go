@@ -2,6 +2,7 @@ package models
 
 import (
 	"crypto/rand"
+	"encoding/json"
 	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/util"
@@ -14,16 +15,42 @@ const (
 )
 
 type Template struct {
-	Primary    int       `db:"primary"`
-	ID         string    `db:"id"`
-	Name       string    `db:"name"`
-	Subject    string    `db:"subject"`
-	Text       string    `db:"text"`
-	HTML       string    `db:"html"`
-	Metadata   string    `db:"metadata"`
-	CreatedAt  time.Time `db:"created_at"`
-	UpdatedAt  time.Time `db:"updated_at"`
-	Overridden bool      `db:"overridden"`
+	Primary       int       `db:"primary"`
+	ID            string    `db:"id"`
+	Name          string    `db:"name"`
+	Subject       string    `db:"subject"`
+	Text          string    `db:"text"`
+	HTML          string    `db:"html"`
+	Metadata      string    `db:"metadata"`
+	Localizations string    `db:"localizations"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+	Overridden    bool      `db:"overridden"`
+}
+
+// TemplateLocalization is the content of a template in one locale. The
+// subject, text and HTML of the template itself are its content in the
+// default locale.
+type TemplateLocalization struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	HTML    string `json:"html"`
+}
+
+// ParseLocalizations decodes the localizations of a template, as stored
+// alongside it, into its content keyed by locale.
+func ParseLocalizations(localizations string) (map[string]TemplateLocalization, error) {
+	parsed := map[string]TemplateLocalization{}
+	if localizations == "" {
+		return parsed, nil
+	}
+
+	err := json.Unmarshal([]byte(localizations), &parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
 }
 
 func (t *Template) PreInsert(s gorp.SqlExecutor) error {
@@ -0,0 +1,33 @@
+package models_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseLocalizations", func() {
+	It("decodes the content of the template keyed by locale", func() {
+		localizations, err := models.ParseLocalizations(`{"fr": {"subject": "Bonjour", "text": "salut", "html": "<p>salut</p>"}}`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(localizations).To(Equal(map[string]models.TemplateLocalization{
+			"fr": {
+				Subject: "Bonjour",
+				Text:    "salut",
+				HTML:    "<p>salut</p>",
+			},
+		}))
+	})
+
+	It("has no localizations for a template stored without any", func() {
+		localizations, err := models.ParseLocalizations("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(localizations).To(BeEmpty())
+	})
+
+	It("returns an error when the localizations are malformed", func() {
+		_, err := models.ParseLocalizations("{")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/gorp.v1"
+)
+
+// TemplateVersion is the content a template had after one of its changes.
+// Versions are numbered from 1 for each template and are never changed once
+// stored. ClientID is the client that made the change.
+type TemplateVersion struct {
+	Primary       int       `db:"primary"`
+	TemplateID    string    `db:"template_id"`
+	Version       int       `db:"version"`
+	Name          string    `db:"name"`
+	Subject       string    `db:"subject"`
+	Text          string    `db:"text"`
+	HTML          string    `db:"html"`
+	Metadata      string    `db:"metadata"`
+	Localizations string    `db:"localizations"`
+	ClientID      string    `db:"client_id"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+// NewTemplateVersion returns a version holding the content of the template.
+func NewTemplateVersion(template Template, clientID string) TemplateVersion {
+	return TemplateVersion{
+		TemplateID:    template.ID,
+		Name:          template.Name,
+		Subject:       template.Subject,
+		Text:          template.Text,
+		HTML:          template.HTML,
+		Metadata:      template.Metadata,
+		Localizations: template.Localizations,
+		ClientID:      clientID,
+	}
+}
+
+func (v *TemplateVersion) PreInsert(s gorp.SqlExecutor) error {
+	if v.CreatedAt.IsZero() {
+		v.CreatedAt = time.Now().Truncate(1 * time.Second).UTC()
+	}
+
+	return nil
+}
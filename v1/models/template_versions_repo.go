@@ -0,0 +1,61 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+type TemplateVersionsRepo struct{}
+
+func NewTemplateVersionsRepo() TemplateVersionsRepo {
+	return TemplateVersionsRepo{}
+}
+
+// Create stores the version as the one following the latest version of its
+// template.
+func (repo TemplateVersionsRepo) Create(conn ConnectionInterface, version TemplateVersion) (TemplateVersion, error) {
+	var latest int
+	err := conn.SelectOne(&latest, "SELECT COALESCE(MAX(`version`), 0) FROM `template_versions` WHERE `template_id` = ?", version.TemplateID)
+	if err != nil {
+		return TemplateVersion{}, err
+	}
+
+	version.Version = latest + 1
+
+	err = conn.Insert(&version)
+	if err != nil {
+		return TemplateVersion{}, err
+	}
+
+	return version, nil
+}
+
+func (repo TemplateVersionsRepo) Find(conn ConnectionInterface, templateID string, version int) (TemplateVersion, error) {
+	templateVersion := TemplateVersion{}
+	err := conn.SelectOne(&templateVersion, "SELECT * FROM `template_versions` WHERE `template_id` = ? AND `version` = ?", templateID, version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return templateVersion, NotFoundError{fmt.Errorf("Version %d of template with ID %q could not be found", version, templateID)}
+		}
+		return templateVersion, err
+	}
+
+	return templateVersion, nil
+}
+
+// DestroyAll deletes every version of the template.
+func (repo TemplateVersionsRepo) DestroyAll(conn ConnectionInterface, templateID string) error {
+	_, err := conn.Exec("DELETE FROM `template_versions` WHERE `template_id` = ?", templateID)
+	return err
+}
+
+// List returns the versions of the template, latest first.
+func (repo TemplateVersionsRepo) List(conn ConnectionInterface, templateID string) ([]TemplateVersion, error) {
+	versions := []TemplateVersion{}
+	_, err := conn.Select(&versions, "SELECT * FROM `template_versions` WHERE `template_id` = ? ORDER BY `version` DESC", templateID)
+	if err != nil {
+		return []TemplateVersion{}, err
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,120 @@
+package models_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TemplateVersionsRepo", func() {
+	var (
+		repo models.TemplateVersionsRepo
+		conn db.ConnectionInterface
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+
+		repo = models.NewTemplateVersionsRepo()
+	})
+
+	Describe("Create", func() {
+		It("numbers the versions of each template from 1", func() {
+			first, err := repo.Create(conn, models.TemplateVersion{
+				TemplateID: "template-id",
+				Name:       "some-name",
+				Subject:    "{{.Subject}}",
+				ClientID:   "some-client",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first.Version).To(Equal(1))
+			Expect(first.Primary).NotTo(BeZero())
+			Expect(first.CreatedAt).To(BeTemporally("~", time.Now(), 2*time.Second))
+
+			second, err := repo.Create(conn, models.TemplateVersion{
+				TemplateID: "template-id",
+				Name:       "some-name",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second.Version).To(Equal(2))
+
+			other, err := repo.Create(conn, models.TemplateVersion{
+				TemplateID: "other-template-id",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(other.Version).To(Equal(1))
+		})
+	})
+
+	Describe("Find", func() {
+		It("finds the version of the template", func() {
+			created, err := repo.Create(conn, models.TemplateVersion{
+				TemplateID: "template-id",
+				Name:       "some-name",
+				Subject:    "{{.Subject}}",
+				Text:       "some text",
+				HTML:       "<p>some html</p>",
+				Metadata:   "{}",
+				ClientID:   "some-client",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			version, err := repo.Find(conn, "template-id", 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(created))
+		})
+
+		It("returns a NotFoundError when there is no such version", func() {
+			_, err := repo.Find(conn, "template-id", 3)
+			Expect(err).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		})
+	})
+
+	Describe("List", func() {
+		It("lists the versions of the template, latest first", func() {
+			for i := 0; i < 3; i++ {
+				_, err := repo.Create(conn, models.TemplateVersion{TemplateID: "template-id"})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, err := repo.Create(conn, models.TemplateVersion{TemplateID: "other-template-id"})
+			Expect(err).NotTo(HaveOccurred())
+
+			versions, err := repo.List(conn, "template-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(3))
+			Expect(versions[0].Version).To(Equal(3))
+			Expect(versions[2].Version).To(Equal(1))
+		})
+	})
+
+	Describe("DestroyAll", func() {
+		It("deletes the versions of the template only", func() {
+			for i := 0; i < 2; i++ {
+				_, err := repo.Create(conn, models.TemplateVersion{TemplateID: "template-id"})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			_, err := repo.Create(conn, models.TemplateVersion{TemplateID: "other-template-id"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = repo.DestroyAll(conn, "template-id")
+			Expect(err).NotTo(HaveOccurred())
+
+			versions, err := repo.List(conn, "template-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(BeEmpty())
+
+			versions, err = repo.List(conn, "other-template-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(1))
+		})
+	})
+})
@@ -24,6 +24,21 @@ func (repo TemplatesRepo) FindByID(conn ConnectionInterface, templateID string)
 	return template, nil
 }
 
+// FindByIDForUpdate finds the template and locks its row until the end of the
+// transaction of the connection, so that concurrent updates of the template
+// are made one after the other.
+func (repo TemplatesRepo) FindByIDForUpdate(conn ConnectionInterface, templateID string) (Template, error) {
+	template := Template{}
+	err := conn.SelectOne(&template, "SELECT * FROM `templates` WHERE `id`=? FOR UPDATE", templateID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return template, NotFoundError{fmt.Errorf("Template with ID %q could not be found", templateID)}
+		}
+		return template, err
+	}
+	return template, nil
+}
+
 func (repo TemplatesRepo) Update(conn ConnectionInterface, templateID string, template Template) (Template, error) {
 	existingTemplate, err := repo.FindByID(conn, templateID)
 	if err != nil {
@@ -60,6 +60,28 @@ var _ = Describe("TemplatesRepo", func() {
 		})
 	})
 
+	Context("#FindByIDForUpdate", func() {
+		It("returns the template when it is found", func() {
+			transaction := conn.Transaction()
+			Expect(transaction.Begin()).To(Succeed())
+			defer transaction.Rollback()
+
+			raptorTemplate, err := repo.FindByIDForUpdate(transaction, "raptor_template")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(raptorTemplate.ID).To(Equal("raptor_template"))
+			Expect(raptorTemplate.Name).To(Equal("Raptors On The Run"))
+		})
+
+		It("returns a record not found error when the template is not in the database", func() {
+			transaction := conn.Transaction()
+			Expect(transaction.Begin()).To(Succeed())
+			defer transaction.Rollback()
+
+			_, err := repo.FindByIDForUpdate(transaction, "silly_template")
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("Template with ID \"silly_template\" could not be found")}))
+		})
+	})
+
 	Describe("#Create", func() {
 		It("inserts a template into the database", func() {
 			newTemplate := models.Template{
@@ -3,7 +3,8 @@ package models
 import (
 	"database/sql"
 	"errors"
-	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/db"
 )
 
 type UnsubscribesRepo struct{}
@@ -60,7 +61,7 @@ func (repo UnsubscribesRepo) Set(conn ConnectionInterface, userID, clientID, kin
 func (repo UnsubscribesRepo) create(conn ConnectionInterface, unsubscribe Unsubscribe) (Unsubscribe, error) {
 	err := conn.Insert(&unsubscribe)
 	if err != nil {
-		if strings.Contains(err.Error(), "Duplicate entry") {
+		if db.IsDuplicateError(err) {
 			err = DuplicateError{errors.New("duplicate record")}
 		}
 		return unsubscribe, err
@@ -0,0 +1,9 @@
+package models
+
+// UserLocale is the locale, as a BCP 47 language tag, that a user wants to
+// receive notifications in.
+type UserLocale struct {
+	Primary int    `db:"primary"`
+	UserID  string `db:"user_id"`
+	Locale  string `db:"locale"`
+}
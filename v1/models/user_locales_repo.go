@@ -0,0 +1,59 @@
+package models
+
+import "database/sql"
+
+type UserLocalesRepo struct{}
+
+func NewUserLocalesRepo() UserLocalesRepo {
+	return UserLocalesRepo{}
+}
+
+// Get returns the locale of the user, which is empty when the user has not
+// set one.
+func (repo UserLocalesRepo) Get(conn ConnectionInterface, userID string) (string, error) {
+	userLocale, err := repo.find(conn, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return userLocale.Locale, nil
+}
+
+// Set stores the locale of the user, removing it when it is empty.
+func (repo UserLocalesRepo) Set(conn ConnectionInterface, userID string, locale string) error {
+	userLocale, err := repo.find(conn, userID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return err
+		}
+
+		userLocale = UserLocale{UserID: userID}
+	}
+
+	userLocale.Locale = locale
+
+	switch {
+	case locale == "" && userLocale.Primary != 0:
+		_, err = conn.Delete(&userLocale)
+	case locale != "" && userLocale.Primary == 0:
+		err = conn.Insert(&userLocale)
+	case locale != "":
+		_, err = conn.Update(&userLocale)
+	}
+
+	return err
+}
+
+func (repo UserLocalesRepo) find(conn ConnectionInterface, userID string) (UserLocale, error) {
+	userLocale := UserLocale{}
+	err := conn.SelectOne(&userLocale, "SELECT * FROM `user_locales` WHERE `user_id` = ?", userID)
+	if err != nil {
+		return UserLocale{}, err
+	}
+
+	return userLocale, nil
+}
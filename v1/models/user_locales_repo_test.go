@@ -0,0 +1,60 @@
+package models_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/db"
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UserLocalesRepo", func() {
+	var (
+		repo models.UserLocalesRepo
+		conn db.ConnectionInterface
+	)
+
+	BeforeEach(func() {
+		database := db.NewDatabase(sqlDB, db.Config{})
+		helpers.TruncateTables(database)
+		conn = database.Connection()
+		repo = models.NewUserLocalesRepo()
+	})
+
+	It("returns no locale when the user has not set one", func() {
+		locale, err := repo.Get(conn, "some-user")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(locale).To(BeEmpty())
+	})
+
+	It("stores and updates the locale of the user", func() {
+		err := repo.Set(conn, "some-user", "fr")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = repo.Set(conn, "some-user", "pt-BR")
+		Expect(err).NotTo(HaveOccurred())
+
+		locale, err := repo.Get(conn, "some-user")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(locale).To(Equal("pt-BR"))
+
+		var rows int
+		err = conn.SelectOne(&rows, "SELECT COUNT(*) FROM `user_locales`")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rows).To(Equal(1))
+	})
+
+	It("removes a locale that is set to nothing", func() {
+		err := repo.Set(conn, "some-user", "fr")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = repo.Set(conn, "some-user", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		var rows int
+		err = conn.SelectOne(&rows, "SELECT COUNT(*) FROM `user_locales`")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rows).To(Equal(0))
+	})
+})
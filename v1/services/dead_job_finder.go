@@ -0,0 +1,74 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+type DeadJobList struct {
+	Total    int
+	DeadJobs []gobble.DeadJob
+}
+
+type deadJobsLister interface {
+	DeadJobs(connection gobble.DeadJobsConnectionInterface, limit, offset int) ([]gobble.DeadJob, error)
+	CountDeadJobs(connection gobble.DeadJobsConnectionInterface) (int, error)
+	FindDeadJob(connection gobble.DeadJobsConnectionInterface, id int) (gobble.DeadJob, error)
+}
+
+type DeadJobFinder struct {
+	queue deadJobsLister
+}
+
+func NewDeadJobFinder(queue deadJobsLister) DeadJobFinder {
+	return DeadJobFinder{
+		queue: queue,
+	}
+}
+
+func (finder DeadJobFinder) List(database DatabaseInterface, page, perPage int) (DeadJobList, error) {
+	conn := database.Connection()
+
+	total, err := finder.queue.CountDeadJobs(conn)
+	if err != nil {
+		return DeadJobList{}, err
+	}
+
+	offset := 0
+	if page > 1 {
+		offset = (page - 1) * perPage
+	}
+
+	deadJobs, err := finder.queue.DeadJobs(conn, perPage, offset)
+	if err != nil {
+		return DeadJobList{}, err
+	}
+
+	if deadJobs == nil {
+		deadJobs = []gobble.DeadJob{}
+	}
+
+	return DeadJobList{
+		Total:    total,
+		DeadJobs: deadJobs,
+	}, nil
+}
+
+func (finder DeadJobFinder) Find(database DatabaseInterface, id int) (gobble.DeadJob, error) {
+	deadJob, err := finder.queue.FindDeadJob(database.Connection(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return gobble.DeadJob{}, deadJobNotFound(id)
+		}
+		return gobble.DeadJob{}, err
+	}
+
+	return deadJob, nil
+}
+
+func deadJobNotFound(id int) error {
+	return models.NotFoundError{Err: fmt.Errorf("Dead job with ID %d could not be found", id)}
+}
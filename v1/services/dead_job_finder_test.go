@@ -0,0 +1,112 @@
+package services_test
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeadJobFinder", func() {
+	var (
+		finder   services.DeadJobFinder
+		queue    *mocks.Queue
+		database *mocks.Database
+		conn     *mocks.Connection
+		failedAt time.Time
+	)
+
+	BeforeEach(func() {
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+		failedAt = time.Now().UTC().Truncate(time.Second)
+
+		queue = mocks.NewQueue()
+		finder = services.NewDeadJobFinder(queue)
+	})
+
+	Describe("List", func() {
+		BeforeEach(func() {
+			queue.CountDeadJobsCall.Returns.Count = 12
+			queue.DeadJobsCall.Returns.DeadJobs = []gobble.DeadJob{
+				{ID: 7, JobID: 42, RetryCount: 10, LastError: "server timeout", FailedAt: failedAt},
+			}
+		})
+
+		It("returns a page of dead jobs along with the total count", func() {
+			list, err := finder.List(database, 3, 5)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(list).To(Equal(services.DeadJobList{
+				Total: 12,
+				DeadJobs: []gobble.DeadJob{
+					{ID: 7, JobID: 42, RetryCount: 10, LastError: "server timeout", FailedAt: failedAt},
+				},
+			}))
+
+			Expect(queue.CountDeadJobsCall.Receives.Connection).To(Equal(conn))
+			Expect(queue.DeadJobsCall.Receives.Connection).To(Equal(conn))
+			Expect(queue.DeadJobsCall.Receives.Limit).To(Equal(5))
+			Expect(queue.DeadJobsCall.Receives.Offset).To(Equal(10))
+		})
+
+		It("returns an empty list when there are no dead jobs", func() {
+			queue.CountDeadJobsCall.Returns.Count = 0
+			queue.DeadJobsCall.Returns.DeadJobs = nil
+
+			list, err := finder.List(database, 1, 5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.DeadJobs).To(Equal([]gobble.DeadJob{}))
+			Expect(queue.DeadJobsCall.Receives.Offset).To(Equal(0))
+		})
+
+		It("returns the error when the dead jobs cannot be counted", func() {
+			queue.CountDeadJobsCall.Returns.Error = errors.New("BOOM!")
+
+			_, err := finder.List(database, 1, 5)
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+		})
+
+		It("returns the error when the dead jobs cannot be listed", func() {
+			queue.DeadJobsCall.Returns.Error = errors.New("BOOM!")
+
+			_, err := finder.List(database, 1, 5)
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+		})
+	})
+
+	Describe("Find", func() {
+		It("returns the dead job", func() {
+			queue.FindDeadJobCall.Returns.DeadJob = gobble.DeadJob{ID: 7, JobID: 42, Payload: "{}"}
+
+			deadJob, err := finder.Find(database, 7)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deadJob).To(Equal(gobble.DeadJob{ID: 7, JobID: 42, Payload: "{}"}))
+
+			Expect(queue.FindDeadJobCall.Receives.Connection).To(Equal(conn))
+			Expect(queue.FindDeadJobCall.Receives.ID).To(Equal(7))
+		})
+
+		It("returns a not found error when the dead job does not exist", func() {
+			queue.FindDeadJobCall.Returns.Error = sql.ErrNoRows
+
+			_, err := finder.Find(database, 7)
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("Dead job with ID 7 could not be found")}))
+		})
+
+		It("returns any other error", func() {
+			queue.FindDeadJobCall.Returns.Error = errors.New("BOOM!")
+
+			_, err := finder.Find(database, 7)
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+		})
+	})
+})
@@ -0,0 +1,35 @@
+package services
+
+import "github.com/cloudfoundry-incubator/notifications/gobble"
+
+type deadJobDeleter interface {
+	DeleteDeadJob(connection gobble.DeadJobsConnectionInterface, id int) (bool, error)
+	DeleteDeadJobs(connection gobble.DeadJobsConnectionInterface) (int, error)
+}
+
+type DeadJobPurger struct {
+	queue deadJobDeleter
+}
+
+func NewDeadJobPurger(queue deadJobDeleter) DeadJobPurger {
+	return DeadJobPurger{
+		queue: queue,
+	}
+}
+
+func (purger DeadJobPurger) Purge(database DatabaseInterface, id int) error {
+	deleted, err := purger.queue.DeleteDeadJob(database.Connection(), id)
+	if err != nil {
+		return err
+	}
+
+	if !deleted {
+		return deadJobNotFound(id)
+	}
+
+	return nil
+}
+
+func (purger DeadJobPurger) PurgeAll(database DatabaseInterface) (int, error) {
+	return purger.queue.DeleteDeadJobs(database.Connection())
+}
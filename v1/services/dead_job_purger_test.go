@@ -0,0 +1,73 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeadJobPurger", func() {
+	var (
+		purger   services.DeadJobPurger
+		queue    *mocks.Queue
+		database *mocks.Database
+		conn     *mocks.Connection
+	)
+
+	BeforeEach(func() {
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		queue = mocks.NewQueue()
+		purger = services.NewDeadJobPurger(queue)
+	})
+
+	Describe("Purge", func() {
+		It("deletes the dead job", func() {
+			queue.DeleteDeadJobCall.Returns.Deleted = true
+
+			err := purger.Purge(database, 7)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(queue.DeleteDeadJobCall.Receives.Connection).To(Equal(conn))
+			Expect(queue.DeleteDeadJobCall.Receives.ID).To(Equal(7))
+		})
+
+		It("returns a not found error when nothing was deleted", func() {
+			err := purger.Purge(database, 7)
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("Dead job with ID 7 could not be found")}))
+		})
+
+		It("returns the error when the dead job cannot be deleted", func() {
+			queue.DeleteDeadJobCall.Returns.Error = errors.New("BOOM!")
+
+			err := purger.Purge(database, 7)
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+		})
+	})
+
+	Describe("PurgeAll", func() {
+		It("deletes every dead job and returns how many were removed", func() {
+			queue.DeleteDeadJobsCall.Returns.Count = 4
+
+			count, err := purger.PurgeAll(database)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(4))
+
+			Expect(queue.DeleteDeadJobsCall.Receives.Connection).To(Equal(conn))
+		})
+
+		It("returns the error when the dead jobs cannot be deleted", func() {
+			queue.DeleteDeadJobsCall.Returns.Error = errors.New("BOOM!")
+
+			_, err := purger.PurgeAll(database)
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+		})
+	})
+})
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"database/sql"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+)
+
+type deadJobReplayer interface {
+	ReplayDeadJob(connection gobble.DeadJobsConnectionInterface, id int) (int, error)
+}
+
+type DeadJobReplayer struct {
+	queue deadJobReplayer
+}
+
+func NewDeadJobReplayer(queue deadJobReplayer) DeadJobReplayer {
+	return DeadJobReplayer{
+		queue: queue,
+	}
+}
+
+// Replay puts the dead job back onto the queue as a fresh job with no retries
+// and removes it from the dead jobs table. It returns the ID of the new job.
+func (replayer DeadJobReplayer) Replay(database DatabaseInterface, id int) (int, error) {
+	transaction := database.Connection().Transaction()
+	if err := transaction.Begin(); err != nil {
+		return 0, err
+	}
+
+	jobID, err := replayer.queue.ReplayDeadJob(transaction, id)
+	if err != nil {
+		transaction.Rollback()
+		if err == sql.ErrNoRows {
+			return 0, deadJobNotFound(id)
+		}
+		return 0, err
+	}
+
+	return jobID, transaction.Commit()
+}
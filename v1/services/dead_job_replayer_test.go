@@ -0,0 +1,76 @@
+package services_test
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeadJobReplayer.Replay", func() {
+	var (
+		replayer    services.DeadJobReplayer
+		queue       *mocks.Queue
+		database    *mocks.Database
+		conn        *mocks.Connection
+		transaction *mocks.Transaction
+	)
+
+	BeforeEach(func() {
+		transaction = mocks.NewTransaction()
+		conn = mocks.NewConnection()
+		conn.TransactionCall.Returns.Transaction = transaction
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		queue = mocks.NewQueue()
+		queue.ReplayDeadJobCall.Returns.JobID = 99
+
+		replayer = services.NewDeadJobReplayer(queue)
+	})
+
+	It("requeues the dead job inside a transaction", func() {
+		jobID, err := replayer.Replay(database, 7)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(jobID).To(Equal(99))
+
+		Expect(queue.ReplayDeadJobCall.Receives.Connection).To(Equal(transaction))
+		Expect(queue.ReplayDeadJobCall.Receives.ID).To(Equal(7))
+
+		Expect(transaction.BeginCall.WasCalled).To(BeTrue())
+		Expect(transaction.CommitCall.WasCalled).To(BeTrue())
+		Expect(transaction.RollbackCall.WasCalled).To(BeFalse())
+	})
+
+	Context("failure cases", func() {
+		It("returns a not found error when the dead job does not exist", func() {
+			queue.ReplayDeadJobCall.Returns.Error = sql.ErrNoRows
+
+			_, err := replayer.Replay(database, 7)
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("Dead job with ID 7 could not be found")}))
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+		})
+
+		It("rolls back when the dead job cannot be replayed", func() {
+			queue.ReplayDeadJobCall.Returns.Error = errors.New("BOOM!")
+
+			_, err := replayer.Replay(database, 7)
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+		})
+
+		It("returns the error when the transaction cannot be started", func() {
+			transaction.BeginCall.Returns.Error = errors.New("BOOM!")
+
+			_, err := replayer.Replay(database, 7)
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+			Expect(queue.ReplayDeadJobCall.Receives.ID).To(Equal(0))
+		})
+	})
+})
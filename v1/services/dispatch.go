@@ -5,6 +5,7 @@ import "time"
 type Dispatch struct {
 	JobType    string
 	GUID       string
+	Users      []User
 	Role       string
 	Connection ConnectionInterface
 	UAAHost    string
@@ -30,11 +31,14 @@ type DispatchVCAPRequest struct {
 }
 
 type DispatchMessage struct {
-	To      string
-	ReplyTo string
-	Subject string
-	Text    string
-	HTML    HTML
+	To       string
+	ReplyTo  string
+	Subject  string
+	Text     string
+	HTML     HTML
+	SendAt   time.Time
+	Priority string
+	Locale   string
 }
 
 type DispatchClient struct {
@@ -45,4 +49,5 @@ type DispatchClient struct {
 type DispatchKind struct {
 	ID          string
 	Description string
+	Critical    bool
 }
@@ -43,6 +43,10 @@ func (strategy EmailStrategy) Dispatch(dispatch Dispatch) ([]Response, error) {
 		Endorsement:       EmailEndorsement,
 		Text:              dispatch.Message.Text,
 		TemplateID:        dispatch.TemplateID,
+		Locale:            dispatch.Message.Locale,
+		SendAt:            dispatch.Message.SendAt,
+		Priority:          dispatch.Message.Priority,
+		Critical:          dispatch.Kind.Critical,
 		HTML: HTML{
 			BodyContent:    dispatch.Message.HTML.BodyContent,
 			BodyAttributes: dispatch.Message.HTML.BodyAttributes,
@@ -10,7 +10,25 @@ import (
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 )
 
-const StatusQueued = "queued"
+const (
+	StatusQueued    = "queued"
+	StatusScheduled = "scheduled"
+	StatusCanceled  = "canceled"
+)
+
+const (
+	PriorityHigh   = "high"
+	PriorityNormal = "normal"
+	PriorityLow    = "low"
+)
+
+var Priorities = []string{PriorityHigh, PriorityNormal, PriorityLow}
+
+var jobPriorities = map[string]int{
+	PriorityHigh:   gobble.PriorityHigh,
+	PriorityNormal: gobble.PriorityNormal,
+	PriorityLow:    gobble.PriorityLow,
+}
 
 type Options struct {
 	ReplyTo           string
@@ -24,6 +42,10 @@ type Options struct {
 	Role              string
 	Endorsement       string
 	TemplateID        string
+	Locale            string
+	SendAt            time.Time
+	Priority          string
+	Critical          bool
 }
 
 type Delivery struct {
@@ -44,6 +66,10 @@ type messagesRepoUpserter interface {
 	Upsert(models.ConnectionInterface, models.Message) (models.Message, error)
 }
 
+type messageEventsRepoCreator interface {
+	Create(models.ConnectionInterface, models.MessageEvent) (models.MessageEvent, error)
+}
+
 type queueInterface interface {
 	Enqueue(job *gobble.Job, transaction gobble.ConnectionInterface) (*gobble.Job, error)
 }
@@ -52,17 +78,25 @@ type gobbleInitializer interface {
 	InitializeDBMap(*gorp.DbMap)
 }
 
+type recipientsLimiter interface {
+	LimitRecipients(conn ConnectionInterface, clientID, kindID string, count int) error
+}
+
 type Enqueuer struct {
 	queue             queueInterface
 	messagesRepo      messagesRepoUpserter
+	messageEventsRepo messageEventsRepoCreator
 	gobbleInitializer gobbleInitializer
+	rateLimiter       recipientsLimiter
 }
 
-func NewEnqueuer(queue queueInterface, messagesRepo messagesRepoUpserter, gobbleInitializer gobbleInitializer) Enqueuer {
+func NewEnqueuer(queue queueInterface, messagesRepo messagesRepoUpserter, messageEventsRepo messageEventsRepoCreator, gobbleInitializer gobbleInitializer, rateLimiter recipientsLimiter) Enqueuer {
 	return Enqueuer{
 		queue:             queue,
 		messagesRepo:      messagesRepo,
+		messageEventsRepo: messageEventsRepo,
 		gobbleInitializer: gobbleInitializer,
+		rateLimiter:       rateLimiter,
 	}
 }
 
@@ -87,9 +121,23 @@ func (enqueuer Enqueuer) Enqueue(
 		return []Response{}, err
 	}
 
+	if err := enqueuer.rateLimiter.LimitRecipients(transaction, clientID, options.KindID, len(users)); err != nil {
+		transaction.Rollback()
+		return []Response{}, err
+	}
+
+	status := StatusQueued
+	if !options.SendAt.IsZero() {
+		status = StatusScheduled
+	}
+
 	for _, user := range users {
 		message, err := enqueuer.messagesRepo.Upsert(transaction, models.Message{
-			Status: StatusQueued,
+			Status:   status,
+			ClientID: clientID,
+			KindID:   options.KindID,
+			UserGUID: user.GUID,
+			Email:    user.Email,
 		})
 		if err != nil {
 			transaction.Rollback()
@@ -109,6 +157,8 @@ func (enqueuer Enqueuer) Enqueue(
 			VCAPRequestID:   vcapRequestID,
 			RequestReceived: reqReceived,
 		})
+		job.ActiveAt = options.SendAt
+		job.Priority = jobPriority(options)
 
 		_, err = enqueuer.queue.Enqueue(job, transaction)
 		if err != nil {
@@ -116,11 +166,30 @@ func (enqueuer Enqueuer) Enqueue(
 			return []Response{}, err
 		}
 
+		if status == StatusScheduled {
+			message.JobID = job.ID
+			message, err = enqueuer.messagesRepo.Upsert(transaction, message)
+			if err != nil {
+				transaction.Rollback()
+				return []Response{}, err
+			}
+		}
+
 		recipient := user.Email
 		if recipient == "" {
 			recipient = user.GUID
 		}
 
+		_, err = enqueuer.messageEventsRepo.Create(transaction, models.MessageEvent{
+			MessageID: message.ID,
+			Status:    message.Status,
+			Recipient: recipient,
+		})
+		if err != nil {
+			transaction.Rollback()
+			return []Response{}, err
+		}
+
 		responses = append(responses, Response{
 			Status:         message.Status,
 			NotificationID: message.ID,
@@ -135,3 +204,17 @@ func (enqueuer Enqueuer) Enqueue(
 
 	return responses, nil
 }
+
+// jobPriority prefers the priority requested for the notification, and
+// otherwise puts notifications of critical kinds ahead of everything else.
+func jobPriority(options Options) int {
+	if priority, ok := jobPriorities[options.Priority]; ok {
+		return priority
+	}
+
+	if options.Critical {
+		return gobble.PriorityHigh
+	}
+
+	return gobble.PriorityNormal
+}
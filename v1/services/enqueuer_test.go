@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/cf"
+	"github.com/cloudfoundry-incubator/notifications/gobble"
 	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/cloudfoundry-incubator/notifications/v1/services"
@@ -24,6 +25,8 @@ var _ = Describe("Enqueuer", func() {
 		org               cf.CloudControllerOrganization
 		reqReceived       time.Time
 		messagesRepo      *mocks.MessagesRepo
+		messageEventsRepo *mocks.MessageEventsRepo
+		rateLimiter       *mocks.RateLimiter
 	)
 
 	BeforeEach(func() {
@@ -61,7 +64,11 @@ var _ = Describe("Enqueuer", func() {
 			},
 		}
 
-		enqueuer = services.NewEnqueuer(queue, messagesRepo, gobbleInitializer)
+		messageEventsRepo = mocks.NewMessageEventsRepo()
+
+		rateLimiter = mocks.NewRateLimiter()
+
+		enqueuer = services.NewEnqueuer(queue, messagesRepo, messageEventsRepo, gobbleInitializer, rateLimiter)
 	})
 
 	Describe("Enqueue", func() {
@@ -172,19 +179,153 @@ var _ = Describe("Enqueuer", func() {
 		})
 
 		It("upserts a StatusQueued for each of the jobs", func() {
-			users := []services.User{{GUID: "user-1"}, {GUID: "user-2"}, {GUID: "user-3"}, {GUID: "user-4"}}
-			enqueuer.Enqueue(conn, users, services.Options{}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+			users := []services.User{{GUID: "user-1"}, {GUID: "user-2"}, {GUID: "user-3"}, {Email: "user-4@example.com"}}
+			enqueuer.Enqueue(conn, users, services.Options{KindID: "the-kind"}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
 
 			messages := messagesRepo.UpsertCall.Receives.Messages
 			Expect(messages).To(HaveLen(4))
 			Expect(messages).To(Equal([]models.Message{
-				{Status: services.StatusQueued},
-				{Status: services.StatusQueued},
-				{Status: services.StatusQueued},
-				{Status: services.StatusQueued},
+				{Status: services.StatusQueued, ClientID: "the-client", KindID: "the-kind", UserGUID: "user-1"},
+				{Status: services.StatusQueued, ClientID: "the-client", KindID: "the-kind", UserGUID: "user-2"},
+				{Status: services.StatusQueued, ClientID: "the-client", KindID: "the-kind", UserGUID: "user-3"},
+				{Status: services.StatusQueued, ClientID: "the-client", KindID: "the-kind", Email: "user-4@example.com"},
 			}))
 		})
 
+		It("records a queued event for each of the messages", func() {
+			users := []services.User{{GUID: "user-1"}, {Email: "user-2@example.com"}}
+			enqueuer.Enqueue(conn, users, services.Options{}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+
+			Expect(messageEventsRepo.CreateCall.Receives.Events).To(Equal([]models.MessageEvent{
+				{
+					MessageID: "first-random-guid",
+					Status:    services.StatusQueued,
+					Recipient: "user-1",
+				},
+				{
+					MessageID: "second-random-guid",
+					Status:    services.StatusQueued,
+					Recipient: "user-2@example.com",
+				},
+			}))
+		})
+
+		Describe("job priority", func() {
+			enqueue := func(options services.Options) int {
+				options.KindID = "the-kind"
+				_, err := enqueuer.Enqueue(conn, []services.User{{GUID: "user-1"}}, options, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(queue.EnqueueCall.Receives.Jobs).To(HaveLen(1))
+
+				return queue.EnqueueCall.Receives.Jobs[0].Priority
+			}
+
+			It("queues routine notifications with normal priority", func() {
+				Expect(enqueue(services.Options{})).To(Equal(gobble.PriorityNormal))
+			})
+
+			It("queues notifications of critical kinds with high priority", func() {
+				Expect(enqueue(services.Options{Critical: true})).To(Equal(gobble.PriorityHigh))
+			})
+
+			It("prefers an explicitly requested priority", func() {
+				Expect(enqueue(services.Options{Critical: true, Priority: services.PriorityLow})).To(Equal(gobble.PriorityLow))
+			})
+		})
+
+		Context("when the options include a send time", func() {
+			var sendAt time.Time
+
+			BeforeEach(func() {
+				sendAt = time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+				messagesRepo.UpsertCall.Returns.Messages = []models.Message{
+					{ID: "first-random-guid", Status: services.StatusScheduled},
+					{ID: "first-random-guid", Status: services.StatusScheduled},
+				}
+			})
+
+			It("schedules the job for the send time", func() {
+				responses, err := enqueuer.Enqueue(conn, []services.User{{GUID: "user-1"}}, services.Options{KindID: "the-kind", SendAt: sendAt}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(queue.EnqueueCall.Receives.Jobs).To(HaveLen(1))
+				Expect(queue.EnqueueCall.Receives.Jobs[0].ActiveAt).To(Equal(sendAt))
+
+				Expect(responses).To(Equal([]services.Response{
+					{
+						Status:         services.StatusScheduled,
+						NotificationID: "first-random-guid",
+						Recipient:      "user-1",
+						VCAPRequestID:  "some-request-id",
+					},
+				}))
+			})
+
+			It("records the message as scheduled along with its job", func() {
+				queue.EnqueueCall.Hook = func() {
+					queue.EnqueueCall.Receives.Jobs[0].ID = 42
+				}
+
+				_, err := enqueuer.Enqueue(conn, []services.User{{GUID: "user-1"}}, services.Options{KindID: "the-kind", SendAt: sendAt}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(messagesRepo.UpsertCall.Receives.Messages).To(Equal([]models.Message{
+					{Status: services.StatusScheduled, ClientID: "the-client", KindID: "the-kind", UserGUID: "user-1"},
+					{ID: "first-random-guid", Status: services.StatusScheduled, JobID: 42},
+				}))
+
+				Expect(messageEventsRepo.CreateCall.Receives.Events).To(Equal([]models.MessageEvent{
+					{
+						MessageID: "first-random-guid",
+						Status:    services.StatusScheduled,
+						Recipient: "user-1",
+					},
+				}))
+			})
+
+			It("rolls back the transaction when the job cannot be recorded on the message", func() {
+				queue.EnqueueCall.Hook = func() {
+					messagesRepo.UpsertCall.Returns.Error = errors.New("BOOM!")
+				}
+
+				_, err := enqueuer.Enqueue(conn, []services.User{{GUID: "user-1"}}, services.Options{SendAt: sendAt}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+				Expect(err).To(MatchError(errors.New("BOOM!")))
+				Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+				Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+			})
+		})
+
+		Describe("recipient quotas", func() {
+			var users []services.User
+
+			BeforeEach(func() {
+				users = []services.User{{GUID: "user-1"}, {GUID: "user-2"}}
+			})
+
+			It("counts the recipients against the quota of the client inside the transaction", func() {
+				_, err := enqueuer.Enqueue(conn, users, services.Options{KindID: "the-kind"}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(rateLimiter.LimitRecipientsCall.Receives.Connection).To(Equal(transaction))
+				Expect(rateLimiter.LimitRecipientsCall.Receives.ClientID).To(Equal("the-client"))
+				Expect(rateLimiter.LimitRecipientsCall.Receives.KindID).To(Equal("the-kind"))
+				Expect(rateLimiter.LimitRecipientsCall.Receives.Count).To(Equal(2))
+			})
+
+			It("enqueues nothing when the recipients exceed the quota", func() {
+				quotaError := services.QuotaExceededError{Err: errors.New("too many"), RetryAfter: time.Minute}
+				rateLimiter.LimitRecipientsCall.Returns.Error = quotaError
+
+				responses, err := enqueuer.Enqueue(conn, users, services.Options{KindID: "the-kind"}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+				Expect(err).To(MatchError(quotaError))
+				Expect(responses).To(BeEmpty())
+
+				Expect(queue.EnqueueCall.Receives.Jobs).To(BeEmpty())
+				Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+				Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+			})
+		})
+
 		Context("using a transaction", func() {
 			var users []services.User
 
@@ -237,10 +378,21 @@ var _ = Describe("Enqueuer", func() {
 				Expect(err).To(HaveOccurred())
 			})
 
+			It("rolls back the transaction when there is an error recording the queued event", func() {
+				messageEventsRepo.CreateCall.Returns.Error = errors.New("BOOM!")
+				_, err := enqueuer.Enqueue(conn, users, services.Options{}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
+
+				Expect(transaction.BeginCall.WasCalled).To(BeTrue())
+				Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+				Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+				Expect(err).To(HaveOccurred())
+			})
+
 			It("uses the same transaction for the queue as it did for the messages repo", func() {
 				enqueuer.Enqueue(conn, users, services.Options{}, space, org, "the-client", "my-uaa-host", "my.scope", "some-request-id", reqReceived)
 
 				Expect(messagesRepo.UpsertCall.Receives.Connection).To(Equal(transaction))
+				Expect(messageEventsRepo.CreateCall.Receives.Connection).To(Equal(transaction))
 				Expect(queue.EnqueueCall.Receives.Connection).To(Equal(transaction))
 			})
 
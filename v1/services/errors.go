@@ -2,6 +2,7 @@ package services
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/cf"
 )
@@ -14,6 +15,30 @@ func (e MissingKindOrClientError) Error() string {
 	return e.Err.Error()
 }
 
+type InvalidFrequencyError struct {
+	Err error
+}
+
+func (e InvalidFrequencyError) Error() string {
+	return e.Err.Error()
+}
+
+type InvalidQuietHoursError struct {
+	Err error
+}
+
+func (e InvalidQuietHoursError) Error() string {
+	return e.Err.Error()
+}
+
+type InvalidLocaleError struct {
+	Err error
+}
+
+func (e InvalidLocaleError) Error() string {
+	return e.Err.Error()
+}
+
 type CriticalKindError struct {
 	Err error
 }
@@ -69,3 +94,32 @@ type DefaultScopeError struct{}
 func (d DefaultScopeError) Error() string {
 	return "You cannot send a notification to a default scope"
 }
+
+type MessageNotCancelableError struct {
+	Err error
+}
+
+func (e MessageNotCancelableError) Error() string {
+	return e.Err.Error()
+}
+
+// QuotaExceededError is returned when a client sends more than its quota
+// allows. RetryAfter is how long it takes for the quota to be replenished.
+type QuotaExceededError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e QuotaExceededError) Error() string {
+	return e.Err.Error()
+}
+
+// InvalidUnsubscribeTokenError is returned when the token of an unsubscribe
+// link cannot be decrypted, or was not made for a user and a kind.
+type InvalidUnsubscribeTokenError struct {
+	Err error
+}
+
+func (e InvalidUnsubscribeTokenError) Error() string {
+	return e.Err.Error()
+}
@@ -39,6 +39,10 @@ func (strategy EveryoneStrategy) Dispatch(dispatch Dispatch) ([]Response, error)
 		SourceDescription: dispatch.Client.Description,
 		Text:              dispatch.Message.Text,
 		TemplateID:        dispatch.TemplateID,
+		Locale:            dispatch.Message.Locale,
+		SendAt:            dispatch.Message.SendAt,
+		Priority:          dispatch.Message.Priority,
+		Critical:          dispatch.Kind.Critical,
 		HTML: HTML{
 			BodyContent:    dispatch.Message.HTML.BodyContent,
 			BodyAttributes: dispatch.Message.HTML.BodyAttributes,
@@ -0,0 +1,41 @@
+package services
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type kindPreferencesRepo interface {
+	FindAllByKind(conn models.ConnectionInterface, clientID, kindID string) ([]models.KindPreference, error)
+}
+
+type KindPreferencesFinder struct {
+	kindsRepo       KindsRepo
+	preferencesRepo kindPreferencesRepo
+}
+
+func NewKindPreferencesFinder(kindsRepo KindsRepo, preferencesRepo kindPreferencesRepo) KindPreferencesFinder {
+	return KindPreferencesFinder{
+		kindsRepo:       kindsRepo,
+		preferencesRepo: preferencesRepo,
+	}
+}
+
+// Find returns the preferences of every user known to have received, or to
+// have set a preference for, notifications of the kind.
+func (finder KindPreferencesFinder) Find(database DatabaseInterface, clientID, kindID string) ([]models.KindPreference, error) {
+	conn := database.Connection()
+
+	_, err := finder.kindsRepo.Find(conn, kindID, clientID)
+	if err != nil {
+		return []models.KindPreference{}, err
+	}
+
+	preferences, err := finder.preferencesRepo.FindAllByKind(conn, clientID, kindID)
+	if err != nil {
+		return []models.KindPreference{}, err
+	}
+
+	if preferences == nil {
+		preferences = []models.KindPreference{}
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,79 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KindPreferencesFinder", func() {
+	var (
+		finder          services.KindPreferencesFinder
+		kindsRepo       *mocks.KindsRepo
+		preferencesRepo *mocks.PreferencesRepo
+		database        *mocks.Database
+		conn            *mocks.Connection
+	)
+
+	BeforeEach(func() {
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		kindsRepo = mocks.NewKindsRepo()
+		kindsRepo.FindCall.Returns.Kinds = []models.Kind{
+			{ID: "barking", ClientID: "dogs"},
+		}
+
+		preferencesRepo = mocks.NewPreferencesRepo()
+		preferencesRepo.FindAllByKindCall.Returns.Preferences = []models.KindPreference{
+			{UserID: "user-1", Email: true, Frequency: models.FrequencyImmediate},
+			{UserID: "user-2", Email: false, GlobalUnsubscribe: true, Frequency: models.FrequencyDaily},
+		}
+
+		finder = services.NewKindPreferencesFinder(kindsRepo, preferencesRepo)
+	})
+
+	It("returns the preferences of the users for the kind", func() {
+		preferences, err := finder.Find(database, "dogs", "barking")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(preferences).To(Equal(preferencesRepo.FindAllByKindCall.Returns.Preferences))
+
+		Expect(kindsRepo.FindCall.Receives.Connection).To(Equal(conn))
+		Expect(kindsRepo.FindCall.Receives.ClientID).To(Equal("dogs"))
+		Expect(kindsRepo.FindCall.Receives.KindID).To(Equal("barking"))
+
+		Expect(preferencesRepo.FindAllByKindCall.Receives.Connection).To(Equal(conn))
+		Expect(preferencesRepo.FindAllByKindCall.Receives.ClientID).To(Equal("dogs"))
+		Expect(preferencesRepo.FindAllByKindCall.Receives.KindID).To(Equal("barking"))
+	})
+
+	It("returns an empty list when no user has preferences for the kind", func() {
+		preferencesRepo.FindAllByKindCall.Returns.Preferences = nil
+
+		preferences, err := finder.Find(database, "dogs", "barking")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(preferences).To(Equal([]models.KindPreference{}))
+	})
+
+	Context("failure cases", func() {
+		It("returns the error when the kind cannot be found", func() {
+			kindsRepo.FindCall.Returns.Error = models.NotFoundError{Err: errors.New("kind not found")}
+
+			_, err := finder.Find(database, "dogs", "barking")
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("kind not found")}))
+		})
+
+		It("returns the error when the preferences cannot be found", func() {
+			preferencesRepo.FindAllByKindCall.Returns.Error = errors.New("database is down")
+
+			_, err := finder.Find(database, "dogs", "barking")
+			Expect(err).To(MatchError(errors.New("database is down")))
+		})
+	})
+})
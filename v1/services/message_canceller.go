@@ -0,0 +1,86 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+type messagesRepoFindUpserter interface {
+	FindByID(models.ConnectionInterface, string) (models.Message, error)
+	Upsert(models.ConnectionInterface, models.Message) (models.Message, error)
+}
+
+type jobCanceller interface {
+	Cancel(jobID int, connection gobble.ExecConnectionInterface) (bool, error)
+}
+
+type MessageCanceller struct {
+	messagesRepo      messagesRepoFindUpserter
+	messageEventsRepo messageEventsRepoCreator
+	queue             jobCanceller
+}
+
+func NewMessageCanceller(messagesRepo messagesRepoFindUpserter, messageEventsRepo messageEventsRepoCreator, queue jobCanceller) MessageCanceller {
+	return MessageCanceller{
+		messagesRepo:      messagesRepo,
+		messageEventsRepo: messageEventsRepo,
+		queue:             queue,
+	}
+}
+
+// Cancel cancels a scheduled message of the client. Messages of other
+// clients are reported as not found.
+func (canceller MessageCanceller) Cancel(database DatabaseInterface, messageID, clientID string) error {
+	conn := database.Connection()
+
+	message, err := canceller.messagesRepo.FindByID(conn, messageID)
+	if err != nil {
+		return err
+	}
+
+	if message.ClientID != clientID {
+		return models.NotFoundError{Err: fmt.Errorf("Message with ID %q could not be found", messageID)}
+	}
+
+	notCancelable := MessageNotCancelableError{fmt.Errorf("Message with ID %q is %s and cannot be canceled", messageID, message.Status)}
+	if message.Status != StatusScheduled || message.JobID == 0 {
+		return notCancelable
+	}
+
+	transaction := conn.Transaction()
+	if err := transaction.Begin(); err != nil {
+		return err
+	}
+
+	canceled, err := canceller.queue.Cancel(message.JobID, transaction)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	if !canceled {
+		transaction.Rollback()
+		return MessageNotCancelableError{fmt.Errorf("Message with ID %q is already being delivered and cannot be canceled", messageID)}
+	}
+
+	message.Status = StatusCanceled
+	_, err = canceller.messagesRepo.Upsert(transaction, message)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	_, err = canceller.messageEventsRepo.Create(transaction, models.MessageEvent{
+		MessageID:      messageID,
+		PreviousStatus: StatusScheduled,
+		Status:         StatusCanceled,
+	})
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	return transaction.Commit()
+}
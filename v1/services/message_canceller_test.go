@@ -0,0 +1,140 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MessageCanceller.Cancel", func() {
+	var (
+		canceller         services.MessageCanceller
+		messagesRepo      *mocks.MessagesRepo
+		messageEventsRepo *mocks.MessageEventsRepo
+		queue             *mocks.Queue
+		database          *mocks.Database
+		conn              *mocks.Connection
+		transaction       *mocks.Transaction
+	)
+
+	BeforeEach(func() {
+		transaction = mocks.NewTransaction()
+		conn = mocks.NewConnection()
+		conn.TransactionCall.Returns.Transaction = transaction
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		messagesRepo = mocks.NewMessagesRepo()
+		messagesRepo.FindByIDCall.Returns.Message = models.Message{
+			ID:       "some-message-id",
+			Status:   services.StatusScheduled,
+			ClientID: "some-client",
+			JobID:    42,
+		}
+		messagesRepo.UpsertCall.Returns.Messages = []models.Message{{}}
+		messageEventsRepo = mocks.NewMessageEventsRepo()
+
+		queue = mocks.NewQueue()
+		queue.CancelCall.Returns.Canceled = true
+
+		canceller = services.NewMessageCanceller(messagesRepo, messageEventsRepo, queue)
+	})
+
+	It("deletes the job and marks the message as canceled", func() {
+		err := canceller.Cancel(database, "some-message-id", "some-client")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(messagesRepo.FindByIDCall.Receives.Connection).To(Equal(conn))
+		Expect(messagesRepo.FindByIDCall.Receives.MessageID).To(Equal("some-message-id"))
+
+		Expect(queue.CancelCall.Receives.JobID).To(Equal(42))
+		Expect(queue.CancelCall.Receives.Connection).To(Equal(transaction))
+
+		Expect(messagesRepo.UpsertCall.Receives.Connection).To(Equal(transaction))
+		Expect(messagesRepo.UpsertCall.Receives.Messages).To(Equal([]models.Message{
+			{
+				ID:       "some-message-id",
+				Status:   services.StatusCanceled,
+				ClientID: "some-client",
+				JobID:    42,
+			},
+		}))
+
+		Expect(messageEventsRepo.CreateCall.Receives.Connection).To(Equal(transaction))
+		Expect(messageEventsRepo.CreateCall.Receives.Events).To(Equal([]models.MessageEvent{
+			{
+				MessageID:      "some-message-id",
+				PreviousStatus: services.StatusScheduled,
+				Status:         services.StatusCanceled,
+			},
+		}))
+
+		Expect(transaction.CommitCall.WasCalled).To(BeTrue())
+		Expect(transaction.RollbackCall.WasCalled).To(BeFalse())
+	})
+
+	Context("failure cases", func() {
+		It("returns the error when the message cannot be found", func() {
+			messagesRepo.FindByIDCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+			err := canceller.Cancel(database, "some-message-id", "some-client")
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+			Expect(transaction.BeginCall.WasCalled).To(BeFalse())
+		})
+
+		It("reports the message of another client as not found", func() {
+			err := canceller.Cancel(database, "some-message-id", "another-client")
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New(`Message with ID "some-message-id" could not be found`)}))
+			Expect(transaction.BeginCall.WasCalled).To(BeFalse())
+			Expect(queue.CancelCall.Receives.JobID).To(BeZero())
+		})
+
+		It("refuses to cancel a message that is not scheduled", func() {
+			messagesRepo.FindByIDCall.Returns.Message.Status = "delivered"
+
+			err := canceller.Cancel(database, "some-message-id", "some-client")
+			Expect(err).To(MatchError(services.MessageNotCancelableError{Err: errors.New(`Message with ID "some-message-id" is delivered and cannot be canceled`)}))
+			Expect(transaction.BeginCall.WasCalled).To(BeFalse())
+		})
+
+		It("refuses to cancel a message whose job has been reserved", func() {
+			queue.CancelCall.Returns.Canceled = false
+
+			err := canceller.Cancel(database, "some-message-id", "some-client")
+			Expect(err).To(MatchError(services.MessageNotCancelableError{Err: errors.New(`Message with ID "some-message-id" is already being delivered and cannot be canceled`)}))
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(messagesRepo.UpsertCall.Receives.Messages).To(BeEmpty())
+		})
+
+		It("rolls back when the job cannot be deleted", func() {
+			queue.CancelCall.Returns.Error = errors.New("BOOM!")
+
+			err := canceller.Cancel(database, "some-message-id", "some-client")
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+		})
+
+		It("rolls back when the message cannot be updated", func() {
+			messagesRepo.UpsertCall.Returns.Error = errors.New("BOOM!")
+
+			err := canceller.Cancel(database, "some-message-id", "some-client")
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+		})
+
+		It("rolls back when the event cannot be recorded", func() {
+			messageEventsRepo.CreateCall.Returns.Error = errors.New("BOOM!")
+
+			err := canceller.Cancel(database, "some-message-id", "some-client")
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+		})
+	})
+})
@@ -1,30 +1,82 @@
 package services
 
-import "github.com/cloudfoundry-incubator/notifications/v1/models"
+import (
+	"fmt"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
 
 type Message struct {
 	Status string
+	Events []MessageEvent
+}
+
+type MessageEvent struct {
+	PreviousStatus string
+	Status         string
+	RetryCount     int
+	SMTPCode       int
+	SMTPText       string
+	Recipient      string
+	CreatedAt      time.Time
 }
 
 type messagesRepoFinder interface {
 	FindByID(models.ConnectionInterface, string) (models.Message, error)
 }
 
+type messageEventsRepoLister interface {
+	ListByMessageID(models.ConnectionInterface, string) ([]models.MessageEvent, error)
+}
+
 type MessageFinder struct {
-	repo messagesRepoFinder
+	repo       messagesRepoFinder
+	eventsRepo messageEventsRepoLister
 }
 
-func NewMessageFinder(repo messagesRepoFinder) MessageFinder {
+func NewMessageFinder(repo messagesRepoFinder, eventsRepo messageEventsRepoLister) MessageFinder {
 	return MessageFinder{
-		repo: repo,
+		repo:       repo,
+		eventsRepo: eventsRepo,
 	}
 }
 
-func (finder MessageFinder) Find(database DatabaseInterface, messageID string) (Message, error) {
-	message, err := finder.repo.FindByID(database.Connection(), messageID)
+// Find returns the message along with its delivery history. The messages of
+// other clients are reported as not found.
+func (finder MessageFinder) Find(database DatabaseInterface, messageID, clientID string) (Message, error) {
+	conn := database.Connection()
+
+	message, err := finder.repo.FindByID(conn, messageID)
+	if err != nil {
+		return Message{}, err
+	}
+
+	if message.ClientID != clientID {
+		return Message{}, models.NotFoundError{Err: fmt.Errorf("Message with ID %q could not be found", messageID)}
+	}
+
+	events, err := finder.eventsRepo.ListByMessageID(conn, messageID)
 	if err != nil {
 		return Message{}, err
 	}
 
-	return Message{Status: message.Status}, nil
+	result := Message{
+		Status: message.Status,
+		Events: []MessageEvent{},
+	}
+
+	for _, event := range events {
+		result.Events = append(result.Events, MessageEvent{
+			PreviousStatus: event.PreviousStatus,
+			Status:         event.Status,
+			RetryCount:     event.RetryCount,
+			SMTPCode:       event.SMTPCode,
+			SMTPText:       event.SMTPText,
+			Recipient:      event.Recipient,
+			CreatedAt:      event.CreatedAt,
+		})
+	}
+
+	return result, nil
 }
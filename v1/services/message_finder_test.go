@@ -2,6 +2,7 @@ package services_test
 
 import (
 	"errors"
+	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/postal/common"
 	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
@@ -14,10 +15,11 @@ import (
 
 var _ = Describe("MessageFinder.Find", func() {
 	var (
-		finder       services.MessageFinder
-		messagesRepo *mocks.MessagesRepo
-		database     *mocks.Database
-		conn         *mocks.Connection
+		finder            services.MessageFinder
+		messagesRepo      *mocks.MessagesRepo
+		messageEventsRepo *mocks.MessageEventsRepo
+		database          *mocks.Database
+		conn              *mocks.Connection
 	)
 
 	BeforeEach(func() {
@@ -26,14 +28,16 @@ var _ = Describe("MessageFinder.Find", func() {
 		database = mocks.NewDatabase()
 		database.ConnectionCall.Returns.Connection = conn
 
-		finder = services.NewMessageFinder(messagesRepo)
+		messageEventsRepo = mocks.NewMessageEventsRepo()
+
+		finder = services.NewMessageFinder(messagesRepo, messageEventsRepo)
 	})
 
 	Context("when a message exists with the given id", func() {
 		It("returns the right Message struct", func() {
-			messagesRepo.FindByIDCall.Returns.Message = models.Message{Status: common.StatusDelivered}
+			messagesRepo.FindByIDCall.Returns.Message = models.Message{Status: common.StatusDelivered, ClientID: "some-client-id"}
 
-			message, err := finder.Find(database, "a-message-id")
+			message, err := finder.Find(database, "a-message-id", "some-client-id")
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(message.Status).To(Equal(common.StatusDelivered))
@@ -41,14 +45,83 @@ var _ = Describe("MessageFinder.Find", func() {
 			Expect(messagesRepo.FindByIDCall.Receives.Connection).To(Equal(conn))
 			Expect(messagesRepo.FindByIDCall.Receives.MessageID).To(Equal("a-message-id"))
 		})
+
+		It("includes the delivery history of the message", func() {
+			queuedAt := time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC)
+			failedAt := queuedAt.Add(1 * time.Minute)
+
+			messagesRepo.FindByIDCall.Returns.Message = models.Message{Status: common.StatusFailed, ClientID: "some-client-id"}
+			messageEventsRepo.ListByMessageIDCall.Returns.Events = []models.MessageEvent{
+				{
+					Primary:   1,
+					MessageID: "a-message-id",
+					Status:    common.StatusQueued,
+					Recipient: "user-123",
+					CreatedAt: queuedAt,
+				},
+				{
+					Primary:        2,
+					MessageID:      "a-message-id",
+					PreviousStatus: common.StatusQueued,
+					Status:         common.StatusFailed,
+					RetryCount:     1,
+					SMTPCode:       451,
+					SMTPText:       "try again later",
+					Recipient:      "user-123@example.com",
+					CreatedAt:      failedAt,
+				},
+			}
+
+			message, err := finder.Find(database, "a-message-id", "some-client-id")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(message.Events).To(Equal([]services.MessageEvent{
+				{
+					Status:    common.StatusQueued,
+					Recipient: "user-123",
+					CreatedAt: queuedAt,
+				},
+				{
+					PreviousStatus: common.StatusQueued,
+					Status:         common.StatusFailed,
+					RetryCount:     1,
+					SMTPCode:       451,
+					SMTPText:       "try again later",
+					Recipient:      "user-123@example.com",
+					CreatedAt:      failedAt,
+				},
+			}))
+
+			Expect(messageEventsRepo.ListByMessageIDCall.Receives.Connection).To(Equal(conn))
+			Expect(messageEventsRepo.ListByMessageIDCall.Receives.MessageID).To(Equal("a-message-id"))
+		})
+	})
+
+	Context("when the message belongs to another client", func() {
+		It("reports the message as not found", func() {
+			messagesRepo.FindByIDCall.Returns.Message = models.Message{Status: common.StatusDelivered, ClientID: "another-client-id"}
+
+			_, err := finder.Find(database, "a-message-id", "some-client-id")
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New(`Message with ID "a-message-id" could not be found`)}))
+
+			Expect(messageEventsRepo.ListByMessageIDCall.Receives.MessageID).To(BeEmpty())
+		})
 	})
 
 	Context("when the underlying repo returns an error", func() {
 		It("bubbles up the error", func() {
 			messagesRepo.FindByIDCall.Returns.Error = errors.New("some error")
 
-			_, err := finder.Find(database, "a-message-id")
+			_, err := finder.Find(database, "a-message-id", "some-client-id")
 			Expect(err).To(MatchError(errors.New("some error")))
 		})
+
+		It("bubbles up errors from the events repo", func() {
+			messagesRepo.FindByIDCall.Returns.Message = models.Message{ClientID: "some-client-id"}
+			messageEventsRepo.ListByMessageIDCall.Returns.Error = errors.New("some events error")
+
+			_, err := finder.Find(database, "a-message-id", "some-client-id")
+			Expect(err).To(MatchError(errors.New("some events error")))
+		})
 	})
 })
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+type MessageFilter struct {
+	ClientID      string
+	KindID        string
+	UserGUID      string
+	Email         string
+	Status        string
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+	Page          int
+	PerPage       int
+}
+
+type MessageSummary struct {
+	ID        string
+	Status    string
+	ClientID  string
+	KindID    string
+	UserGUID  string
+	Email     string
+	UpdatedAt time.Time
+}
+
+type MessageList struct {
+	Total    int
+	Messages []MessageSummary
+}
+
+type messagesRepoLister interface {
+	List(models.ConnectionInterface, models.MessagesFilter) ([]models.Message, error)
+	Count(models.ConnectionInterface, models.MessagesFilter) (int, error)
+}
+
+type MessageLister struct {
+	repo messagesRepoLister
+}
+
+func NewMessageLister(repo messagesRepoLister) MessageLister {
+	return MessageLister{
+		repo: repo,
+	}
+}
+
+func (lister MessageLister) List(database DatabaseInterface, filter MessageFilter) (MessageList, error) {
+	conn := database.Connection()
+
+	modelFilter := models.MessagesFilter{
+		ClientID:      filter.ClientID,
+		KindID:        filter.KindID,
+		UserGUID:      filter.UserGUID,
+		Email:         filter.Email,
+		Status:        filter.Status,
+		UpdatedAfter:  filter.UpdatedAfter,
+		UpdatedBefore: filter.UpdatedBefore,
+	}
+
+	total, err := lister.repo.Count(conn, modelFilter)
+	if err != nil {
+		return MessageList{}, err
+	}
+
+	if filter.PerPage > 0 {
+		modelFilter.Limit = filter.PerPage
+		if filter.Page > 1 {
+			modelFilter.Offset = (filter.Page - 1) * filter.PerPage
+		}
+	}
+
+	messages, err := lister.repo.List(conn, modelFilter)
+	if err != nil {
+		return MessageList{}, err
+	}
+
+	list := MessageList{
+		Total:    total,
+		Messages: []MessageSummary{},
+	}
+
+	for _, message := range messages {
+		list.Messages = append(list.Messages, MessageSummary{
+			ID:        message.ID,
+			Status:    message.Status,
+			ClientID:  message.ClientID,
+			KindID:    message.KindID,
+			UserGUID:  message.UserGUID,
+			Email:     message.Email,
+			UpdatedAt: message.UpdatedAt,
+		})
+	}
+
+	return list, nil
+}
@@ -0,0 +1,121 @@
+package services_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MessageLister.List", func() {
+	var (
+		lister       services.MessageLister
+		messagesRepo *mocks.MessagesRepo
+		database     *mocks.Database
+		conn         *mocks.Connection
+		updatedAt    time.Time
+	)
+
+	BeforeEach(func() {
+		messagesRepo = mocks.NewMessagesRepo()
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		updatedAt = time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC)
+
+		lister = services.NewMessageLister(messagesRepo)
+	})
+
+	It("returns the matching page of messages and the total count", func() {
+		messagesRepo.CountCall.Returns.Count = 12
+		messagesRepo.ListCall.Returns.Messages = []models.Message{
+			{
+				ID:        "message-1",
+				Status:    common.StatusFailed,
+				ClientID:  "some-client",
+				KindID:    "some-kind",
+				UserGUID:  "user-123",
+				Email:     "user@example.com",
+				UpdatedAt: updatedAt,
+			},
+		}
+
+		list, err := lister.List(database, services.MessageFilter{
+			ClientID:      "some-client",
+			KindID:        "some-kind",
+			UserGUID:      "user-123",
+			Email:         "user@example.com",
+			Status:        common.StatusFailed,
+			UpdatedAfter:  updatedAt.Add(-1 * time.Hour),
+			UpdatedBefore: updatedAt.Add(1 * time.Hour),
+			Page:          3,
+			PerPage:       5,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list).To(Equal(services.MessageList{
+			Total: 12,
+			Messages: []services.MessageSummary{
+				{
+					ID:        "message-1",
+					Status:    common.StatusFailed,
+					ClientID:  "some-client",
+					KindID:    "some-kind",
+					UserGUID:  "user-123",
+					Email:     "user@example.com",
+					UpdatedAt: updatedAt,
+				},
+			},
+		}))
+
+		expectedFilter := models.MessagesFilter{
+			ClientID:      "some-client",
+			KindID:        "some-kind",
+			UserGUID:      "user-123",
+			Email:         "user@example.com",
+			Status:        common.StatusFailed,
+			UpdatedAfter:  updatedAt.Add(-1 * time.Hour),
+			UpdatedBefore: updatedAt.Add(1 * time.Hour),
+		}
+
+		Expect(messagesRepo.CountCall.Receives.Connection).To(Equal(conn))
+		Expect(messagesRepo.CountCall.Receives.Filter).To(Equal(expectedFilter))
+
+		expectedFilter.Limit = 5
+		expectedFilter.Offset = 10
+		Expect(messagesRepo.ListCall.Receives.Connection).To(Equal(conn))
+		Expect(messagesRepo.ListCall.Receives.Filter).To(Equal(expectedFilter))
+	})
+
+	It("returns an empty list when nothing matches", func() {
+		list, err := lister.List(database, services.MessageFilter{Page: 1, PerPage: 50})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Total).To(Equal(0))
+		Expect(list.Messages).To(BeEmpty())
+		Expect(list.Messages).NotTo(BeNil())
+
+		Expect(messagesRepo.ListCall.Receives.Filter.Offset).To(Equal(0))
+	})
+
+	Context("when the repo errors", func() {
+		It("returns the count error", func() {
+			messagesRepo.CountCall.Returns.Error = errors.New("count failed")
+
+			_, err := lister.List(database, services.MessageFilter{})
+			Expect(err).To(MatchError(errors.New("count failed")))
+		})
+
+		It("returns the list error", func() {
+			messagesRepo.ListCall.Returns.Error = errors.New("list failed")
+
+			_, err := lister.List(database, services.MessageFilter{})
+			Expect(err).To(MatchError(errors.New("list failed")))
+		})
+	})
+})
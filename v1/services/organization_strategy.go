@@ -43,6 +43,10 @@ func (strategy OrganizationStrategy) Dispatch(dispatch Dispatch) ([]Response, er
 		Endorsement:       OrganizationEndorsement,
 		Text:              dispatch.Message.Text,
 		TemplateID:        dispatch.TemplateID,
+		Locale:            dispatch.Message.Locale,
+		SendAt:            dispatch.Message.SendAt,
+		Priority:          dispatch.Message.Priority,
+		Critical:          dispatch.Kind.Critical,
 		Role:              dispatch.Role,
 		HTML: HTML{
 			BodyContent:    dispatch.Message.HTML.BodyContent,
@@ -0,0 +1,23 @@
+package services
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type preferenceChangesLister interface {
+	ListByUserID(conn models.ConnectionInterface, userID string) ([]models.PreferenceChange, error)
+}
+
+type PreferenceChangesFinder struct {
+	repo preferenceChangesLister
+}
+
+func NewPreferenceChangesFinder(repo preferenceChangesLister) PreferenceChangesFinder {
+	return PreferenceChangesFinder{
+		repo: repo,
+	}
+}
+
+// List returns the changes made to the preferences of the user, oldest
+// first.
+func (finder PreferenceChangesFinder) List(database DatabaseInterface, userID string) ([]models.PreferenceChange, error) {
+	return finder.repo.ListByUserID(database.Connection(), userID)
+}
@@ -0,0 +1,40 @@
+package services_test
+
+import (
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PreferenceChangesFinder", func() {
+	It("lists the changes made to the preferences of the user", func() {
+		conn := mocks.NewConnection()
+		database := mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		repo := mocks.NewPreferenceChangesRepo()
+		repo.ListByUserIDCall.Returns.Changes = []models.PreferenceChange{
+			{
+				UserID:    "some-user-id",
+				Actor:     "some-user-id",
+				Source:    models.PreferenceChangeSourceAPI,
+				Field:     models.PreferenceFieldGlobalUnsubscribe,
+				OldValue:  "false",
+				NewValue:  "true",
+				CreatedAt: time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+			},
+		}
+
+		changes, err := services.NewPreferenceChangesFinder(repo).List(database, "some-user-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changes).To(Equal(repo.ListByUserIDCall.Returns.Changes))
+
+		Expect(repo.ListByUserIDCall.Receives.Connection).To(Equal(conn))
+		Expect(repo.ListByUserIDCall.Receives.UserID).To(Equal("some-user-id"))
+	})
+})
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+// PreferencesJobType is the type of the jobs that apply a preference change
+// to many users at once.
+const PreferencesJobType = "preferences"
+
+// PreferencesJob is the payload of a job that applies the preferences to
+// many users. The users are either listed, or are the members of the
+// organization, with the given role, or of the space, in which case they are
+// looked up when the job is processed. The changes are recorded as made by
+// the actor.
+type PreferencesJob struct {
+	JobType          string
+	ID               string
+	UserIDs          []string
+	OrganizationGUID string
+	OrganizationRole string
+	SpaceGUID        string
+	Preferences      []models.Preference
+	Actor            Actor
+}
+
+type preferencesValidator interface {
+	Validate(conn ConnectionInterface, preferences []models.Preference) error
+}
+
+type preferenceJobsRepoCreator interface {
+	Create(conn models.ConnectionInterface, job models.PreferenceJob) (models.PreferenceJob, error)
+}
+
+type PreferenceJobCreator struct {
+	queue              queueInterface
+	gobbleInitializer  gobbleInitializer
+	preferenceJobsRepo preferenceJobsRepoCreator
+	validator          preferencesValidator
+}
+
+func NewPreferenceJobCreator(queue queueInterface, gobbleInitializer gobbleInitializer, preferenceJobsRepo preferenceJobsRepoCreator, validator preferencesValidator) PreferenceJobCreator {
+	return PreferenceJobCreator{
+		queue:              queue,
+		gobbleInitializer:  gobbleInitializer,
+		preferenceJobsRepo: preferenceJobsRepo,
+		validator:          validator,
+	}
+}
+
+// Create checks the preferences of the job and enqueues it, returning the
+// record through which its progress is reported.
+func (creator PreferenceJobCreator) Create(conn ConnectionInterface, payload PreferencesJob) (models.PreferenceJob, error) {
+	err := creator.validator.Validate(conn, payload.Preferences)
+	if err != nil {
+		return models.PreferenceJob{}, err
+	}
+
+	transaction := conn.Transaction()
+	creator.gobbleInitializer.InitializeDBMap(transaction.GetDbMap())
+
+	if err := transaction.Begin(); err != nil {
+		return models.PreferenceJob{}, err
+	}
+
+	preferenceJob, err := creator.preferenceJobsRepo.Create(transaction, models.PreferenceJob{
+		Status: models.PreferenceJobStatusQueued,
+		Total:  len(payload.UserIDs),
+	})
+	if err != nil {
+		transaction.Rollback()
+		return models.PreferenceJob{}, err
+	}
+
+	payload.JobType = PreferencesJobType
+	payload.ID = preferenceJob.ID
+
+	_, err = creator.queue.Enqueue(gobble.NewJob(payload), transaction)
+	if err != nil {
+		transaction.Rollback()
+		return models.PreferenceJob{}, err
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return models.PreferenceJob{}, err
+	}
+
+	return preferenceJob, nil
+}
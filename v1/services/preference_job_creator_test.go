@@ -0,0 +1,135 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PreferenceJobCreator", func() {
+	var (
+		creator            services.PreferenceJobCreator
+		queue              *mocks.Queue
+		gobbleInitializer  *mocks.GobbleInitializer
+		preferenceJobsRepo *mocks.PreferenceJobsRepo
+		validator          *mocks.PreferenceUpdater
+		conn               *mocks.Connection
+		transaction        *mocks.Transaction
+		preferences        []models.Preference
+	)
+
+	BeforeEach(func() {
+		transaction = mocks.NewTransaction()
+		conn = mocks.NewConnection()
+		conn.TransactionCall.Returns.Transaction = transaction
+		transaction.Connection = conn
+
+		queue = mocks.NewQueue()
+		gobbleInitializer = mocks.NewGobbleInitializer()
+		validator = mocks.NewPreferenceUpdater()
+
+		preferenceJobsRepo = mocks.NewPreferenceJobsRepo()
+		preferenceJobsRepo.CreateCall.Returns.Job = models.PreferenceJob{
+			ID:     "some-job-id",
+			Status: models.PreferenceJobStatusQueued,
+			Total:  2,
+		}
+
+		preferences = []models.Preference{
+			{
+				ClientID:  "dogs",
+				KindID:    "barking",
+				Email:     false,
+				Frequency: models.FrequencyWeekly,
+			},
+		}
+
+		creator = services.NewPreferenceJobCreator(queue, gobbleInitializer, preferenceJobsRepo, validator)
+	})
+
+	It("records the job and enqueues it inside a transaction", func() {
+		job, err := creator.Create(conn, services.PreferencesJob{
+			UserIDs:     []string{"user-1", "user-2"},
+			Preferences: preferences,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job).To(Equal(models.PreferenceJob{
+			ID:     "some-job-id",
+			Status: models.PreferenceJobStatusQueued,
+			Total:  2,
+		}))
+
+		Expect(validator.ValidateCall.Receives.Connection).To(Equal(conn))
+		Expect(validator.ValidateCall.Receives.Preferences).To(Equal(preferences))
+
+		Expect(preferenceJobsRepo.CreateCall.Receives.Connection).To(Equal(transaction))
+		Expect(preferenceJobsRepo.CreateCall.Receives.Job).To(Equal(models.PreferenceJob{
+			Status: models.PreferenceJobStatusQueued,
+			Total:  2,
+		}))
+
+		Expect(gobbleInitializer.InitializeDBMapCall.Receives.DbMap).To(BeNil())
+		Expect(conn.GetDbMapCall.WasCalled).To(BeTrue())
+
+		Expect(queue.EnqueueCall.Receives.Connection).To(Equal(transaction))
+		Expect(queue.EnqueueCall.Receives.Jobs).To(HaveLen(1))
+
+		var payload services.PreferencesJob
+		err = queue.EnqueueCall.Receives.Jobs[0].Unmarshal(&payload)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(payload).To(Equal(services.PreferencesJob{
+			JobType:     services.PreferencesJobType,
+			ID:          "some-job-id",
+			UserIDs:     []string{"user-1", "user-2"},
+			Preferences: preferences,
+		}))
+
+		Expect(transaction.BeginCall.WasCalled).To(BeTrue())
+		Expect(transaction.CommitCall.WasCalled).To(BeTrue())
+		Expect(transaction.RollbackCall.WasCalled).To(BeFalse())
+	})
+
+	Context("failure cases", func() {
+		It("returns the validation error without enqueuing anything", func() {
+			validator.ValidateCall.Returns.Error = services.CriticalKindError{Err: errors.New("critical")}
+
+			_, err := creator.Create(conn, services.PreferencesJob{Preferences: preferences})
+			Expect(err).To(MatchError(services.CriticalKindError{Err: errors.New("critical")}))
+
+			Expect(transaction.BeginCall.WasCalled).To(BeFalse())
+			Expect(queue.EnqueueCall.Receives.Jobs).To(BeEmpty())
+		})
+
+		It("rolls back when the job cannot be recorded", func() {
+			preferenceJobsRepo.CreateCall.Returns.Error = errors.New("database is down")
+
+			_, err := creator.Create(conn, services.PreferencesJob{Preferences: preferences})
+			Expect(err).To(MatchError(errors.New("database is down")))
+
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(queue.EnqueueCall.Receives.Jobs).To(BeEmpty())
+		})
+
+		It("rolls back when the job cannot be enqueued", func() {
+			queue.EnqueueCall.Returns.Error = errors.New("queue is full")
+
+			_, err := creator.Create(conn, services.PreferencesJob{Preferences: preferences})
+			Expect(err).To(MatchError(errors.New("queue is full")))
+
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+		})
+
+		It("returns the error when the transaction cannot be committed", func() {
+			transaction.CommitCall.Returns.Error = errors.New("commit failed")
+
+			_, err := creator.Create(conn, services.PreferencesJob{Preferences: preferences})
+			Expect(err).To(MatchError(errors.New("commit failed")))
+		})
+	})
+})
@@ -0,0 +1,21 @@
+package services
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type preferenceJobsRepoFinder interface {
+	Find(conn models.ConnectionInterface, jobID string) (models.PreferenceJob, error)
+}
+
+type PreferenceJobFinder struct {
+	repo preferenceJobsRepoFinder
+}
+
+func NewPreferenceJobFinder(repo preferenceJobsRepoFinder) PreferenceJobFinder {
+	return PreferenceJobFinder{
+		repo: repo,
+	}
+}
+
+func (finder PreferenceJobFinder) Find(database DatabaseInterface, jobID string) (models.PreferenceJob, error) {
+	return finder.repo.Find(database.Connection(), jobID)
+}
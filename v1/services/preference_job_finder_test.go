@@ -0,0 +1,32 @@
+package services_test
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PreferenceJobFinder", func() {
+	It("finds the job in the repo", func() {
+		conn := mocks.NewConnection()
+		database := mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		repo := mocks.NewPreferenceJobsRepo()
+		repo.FindCall.Returns.Job = models.PreferenceJob{
+			ID:        "some-job-id",
+			Status:    models.PreferenceJobStatusRunning,
+			Processed: 3,
+		}
+
+		job, err := services.NewPreferenceJobFinder(repo).Find(database, "some-job-id")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job).To(Equal(repo.FindCall.Returns.Job))
+
+		Expect(repo.FindCall.Receives.Connection).To(Equal(conn))
+		Expect(repo.FindCall.Receives.JobID).To(Equal("some-job-id"))
+	})
+})
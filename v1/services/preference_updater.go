@@ -2,30 +2,106 @@ package services
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 )
 
+// FrequencyDefault can be given as the frequency of a kind to have its
+// notifications follow the frequency the user set for all notifications.
+const FrequencyDefault = "default"
+
+// Actor is who changes the preferences of a user, and through what, as kept
+// in the history of changes to the preferences of the user.
+type Actor struct {
+	ID     string
+	Source string
+}
+
 type PreferenceUpdater struct {
-	globalUnsubscribesRepo GlobalUnsubscribesRepo
-	unsubscribesRepo       UnsubscribesRepo
-	kindsRepo              KindsRepo
+	globalUnsubscribesRepo  GlobalUnsubscribesRepo
+	unsubscribesRepo        UnsubscribesRepo
+	kindsRepo               KindsRepo
+	deliveryFrequenciesRepo DeliveryFrequenciesRepo
+	quietHoursRepo          QuietHoursRepo
+	userLocalesRepo         UserLocalesRepo
+	preferenceChangesRepo   PreferenceChangesRepo
 }
 
-func NewPreferenceUpdater(globalUnsubscribesRepo GlobalUnsubscribesRepo, unsubscribesRepo UnsubscribesRepo, kindsRepo KindsRepo) PreferenceUpdater {
+func NewPreferenceUpdater(globalUnsubscribesRepo GlobalUnsubscribesRepo, unsubscribesRepo UnsubscribesRepo, kindsRepo KindsRepo, deliveryFrequenciesRepo DeliveryFrequenciesRepo, quietHoursRepo QuietHoursRepo, userLocalesRepo UserLocalesRepo, preferenceChangesRepo PreferenceChangesRepo) PreferenceUpdater {
 	return PreferenceUpdater{
-		globalUnsubscribesRepo: globalUnsubscribesRepo,
-		unsubscribesRepo:       unsubscribesRepo,
-		kindsRepo:              kindsRepo,
+		globalUnsubscribesRepo:  globalUnsubscribesRepo,
+		unsubscribesRepo:        unsubscribesRepo,
+		kindsRepo:               kindsRepo,
+		deliveryFrequenciesRepo: deliveryFrequenciesRepo,
+		quietHoursRepo:          quietHoursRepo,
+		userLocalesRepo:         userLocalesRepo,
+		preferenceChangesRepo:   preferenceChangesRepo,
 	}
 }
 
-func (updater PreferenceUpdater) Update(conn ConnectionInterface, preferences []models.Preference, globalUnsubscribe bool, userID string) error {
-	err := updater.globalUnsubscribesRepo.Set(conn, userID, globalUnsubscribe)
+// Update stores the preferences of the user. The frequency applies to every
+// notification the user receives and is left as it is when empty, as is the
+// frequency of a preference. Every value that changes is recorded as made by
+// the actor.
+func (updater PreferenceUpdater) Update(conn ConnectionInterface, preferences []models.Preference, globalUnsubscribe bool, frequency string, userID string, actor Actor) error {
+	wasUnsubscribed, err := updater.globalUnsubscribesRepo.Get(conn, userID)
+	if err != nil {
+		return err
+	}
+
+	err = updater.globalUnsubscribesRepo.Set(conn, userID, globalUnsubscribe)
 	if err != nil {
 		return err
 	}
 
+	err = updater.record(conn, actor, models.PreferenceChange{
+		UserID:   userID,
+		Field:    models.PreferenceFieldGlobalUnsubscribe,
+		OldValue: strconv.FormatBool(wasUnsubscribed),
+		NewValue: strconv.FormatBool(globalUnsubscribe),
+	})
+	if err != nil {
+		return err
+	}
+
+	if frequency != "" {
+		if !models.IsValidFrequency(frequency) {
+			return InvalidFrequencyError{fmt.Errorf("The frequency '%s' is not one of immediate, daily or weekly", frequency)}
+		}
+
+		oldFrequency, err := updater.deliveryFrequenciesRepo.Get(conn, userID, "", "")
+		if err != nil {
+			return err
+		}
+
+		err = updater.deliveryFrequenciesRepo.Set(conn, userID, "", "", frequency)
+		if err != nil {
+			return err
+		}
+
+		err = updater.record(conn, actor, models.PreferenceChange{
+			UserID:   userID,
+			Field:    models.PreferenceFieldFrequency,
+			OldValue: oldFrequency,
+			NewValue: frequency,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = updater.Validate(conn, preferences)
+	if err != nil {
+		return err
+	}
+
+	return updater.UpdateKinds(conn, userID, preferences, actor)
+}
+
+// Validate checks that each of the preferences is for a kind that exists and
+// can be unsubscribed from, and that its frequency is known.
+func (updater PreferenceUpdater) Validate(conn ConnectionInterface, preferences []models.Preference) error {
 	for _, preference := range preferences {
 		kind, err := updater.kindsRepo.Find(conn, preference.KindID, preference.ClientID)
 		if err != nil {
@@ -36,10 +112,184 @@ func (updater PreferenceUpdater) Update(conn ConnectionInterface, preferences []
 			return CriticalKindError{fmt.Errorf("The kind '%s' for the '%s' client is critical and cannot be unsubscribed from", preference.KindID, preference.ClientID)}
 		}
 
+		if preference.Frequency != "" && preference.Frequency != FrequencyDefault && !models.IsValidFrequency(preference.Frequency) {
+			return InvalidFrequencyError{fmt.Errorf("The frequency '%s' of the kind '%s' for the '%s' client is not one of immediate, daily, weekly or default", preference.Frequency, preference.KindID, preference.ClientID)}
+		}
+	}
+
+	return nil
+}
+
+// UpdateKinds stores the per-kind preferences of the user, leaving the
+// global unsubscribe and frequency of the user as they are. The preferences
+// are expected to have been checked with Validate.
+func (updater PreferenceUpdater) UpdateKinds(conn ConnectionInterface, userID string, preferences []models.Preference, actor Actor) error {
+	for _, preference := range preferences {
+		wasUnsubscribed, err := updater.unsubscribesRepo.Get(conn, userID, preference.ClientID, preference.KindID)
+		if err != nil {
+			return err
+		}
+
 		err = updater.unsubscribesRepo.Set(conn, userID, preference.ClientID, preference.KindID, !preference.Email)
 		if err != nil {
 			return err
 		}
+
+		err = updater.record(conn, actor, models.PreferenceChange{
+			UserID:   userID,
+			ClientID: preference.ClientID,
+			KindID:   preference.KindID,
+			Field:    models.PreferenceFieldEmail,
+			OldValue: strconv.FormatBool(!wasUnsubscribed),
+			NewValue: strconv.FormatBool(preference.Email),
+		})
+		if err != nil {
+			return err
+		}
+
+		if preference.Frequency == "" {
+			continue
+		}
+
+		oldFrequency, err := updater.deliveryFrequenciesRepo.Get(conn, userID, preference.ClientID, preference.KindID)
+		if err != nil {
+			return err
+		}
+
+		err = updater.updateFrequency(conn, userID, preference)
+		if err != nil {
+			return err
+		}
+
+		err = updater.record(conn, actor, models.PreferenceChange{
+			UserID:   userID,
+			ClientID: preference.ClientID,
+			KindID:   preference.KindID,
+			Field:    models.PreferenceFieldFrequency,
+			OldValue: oldFrequency,
+			NewValue: preference.Frequency,
+		})
+		if err != nil {
+			return err
+		}
 	}
+
 	return nil
 }
+
+func (updater PreferenceUpdater) updateFrequency(conn ConnectionInterface, userID string, preference models.Preference) error {
+	switch {
+	case preference.Frequency == "":
+		return nil
+	case preference.Frequency == FrequencyDefault:
+		return updater.deliveryFrequenciesRepo.Set(conn, userID, preference.ClientID, preference.KindID, "")
+	case !models.IsValidFrequency(preference.Frequency):
+		return InvalidFrequencyError{fmt.Errorf("The frequency '%s' of the kind '%s' for the '%s' client is not one of immediate, daily, weekly or default", preference.Frequency, preference.KindID, preference.ClientID)}
+	}
+
+	return updater.deliveryFrequenciesRepo.Set(conn, userID, preference.ClientID, preference.KindID, preference.Frequency)
+}
+
+// UpdateQuietHours stores the time zone and quiet hours of the user. An empty
+// time zone leaves the time zone as it is, as do nil quiet hours the window,
+// while quiet hours with an empty start and end remove the window.
+func (updater PreferenceUpdater) UpdateQuietHours(conn ConnectionInterface, userID string, timeZone string, window *QuietHours, actor Actor) error {
+	if timeZone == "" && window == nil {
+		return nil
+	}
+
+	quietHours, err := updater.quietHoursRepo.Get(conn, userID)
+	if err != nil {
+		return err
+	}
+	old := quietHours
+
+	quietHours.UserID = userID
+	if timeZone != "" {
+		quietHours.TimeZone = timeZone
+	}
+
+	if window != nil {
+		quietHours.Start = window.Start
+		quietHours.End = window.End
+	}
+
+	err = quietHours.Validate()
+	if err != nil {
+		return InvalidQuietHoursError{err}
+	}
+
+	err = updater.quietHoursRepo.Set(conn, quietHours)
+	if err != nil {
+		return err
+	}
+
+	err = updater.record(conn, actor, models.PreferenceChange{
+		UserID:   userID,
+		Field:    models.PreferenceFieldTimeZone,
+		OldValue: old.TimeZone,
+		NewValue: quietHours.TimeZone,
+	})
+	if err != nil {
+		return err
+	}
+
+	return updater.record(conn, actor, models.PreferenceChange{
+		UserID:   userID,
+		Field:    models.PreferenceFieldQuietHours,
+		OldValue: quietHoursWindow(old),
+		NewValue: quietHoursWindow(quietHours),
+	})
+}
+
+// UpdateLocale stores the locale that the user wants to receive
+// notifications in. An empty locale leaves the locale as it is.
+func (updater PreferenceUpdater) UpdateLocale(conn ConnectionInterface, userID string, locale string, actor Actor) error {
+	if locale == "" {
+		return nil
+	}
+
+	locale, err := models.CanonicalLocale(locale)
+	if err != nil {
+		return InvalidLocaleError{err}
+	}
+
+	oldLocale, err := updater.userLocalesRepo.Get(conn, userID)
+	if err != nil {
+		return err
+	}
+
+	err = updater.userLocalesRepo.Set(conn, userID, locale)
+	if err != nil {
+		return err
+	}
+
+	return updater.record(conn, actor, models.PreferenceChange{
+		UserID:   userID,
+		Field:    models.PreferenceFieldLocale,
+		OldValue: oldLocale,
+		NewValue: locale,
+	})
+}
+
+// record keeps the change in the history of the preferences of the user,
+// unless the value did not change.
+func (updater PreferenceUpdater) record(conn ConnectionInterface, actor Actor, change models.PreferenceChange) error {
+	if change.OldValue == change.NewValue {
+		return nil
+	}
+
+	change.Actor = actor.ID
+	change.Source = actor.Source
+
+	_, err := updater.preferenceChangesRepo.Create(conn, change)
+	return err
+}
+
+func quietHoursWindow(quietHours models.QuietHours) string {
+	if quietHours.Start == "" && quietHours.End == "" {
+		return ""
+	}
+
+	return quietHours.Start + "-" + quietHours.End
+}
@@ -12,11 +12,19 @@ import (
 )
 
 var _ = Describe("PreferenceUpdater", func() {
+	actor := services.Actor{
+		ID:     "some-client",
+		Source: models.PreferenceChangeSourceAPI,
+	}
+
 	Describe("Update", func() {
 		var (
 			unsubscribesRepo           *mocks.UnsubscribesRepo
 			kindsRepo                  *mocks.KindsRepo
 			fakeGlobalUnsubscribesRepo *mocks.GlobalUnsubscribesRepo
+			deliveryFrequenciesRepo    *mocks.DeliveryFrequenciesRepo
+			quietHoursRepo             *mocks.QuietHoursRepo
+			preferenceChangesRepo      *mocks.PreferenceChangesRepo
 			conn                       *mocks.Connection
 			updater                    services.PreferenceUpdater
 		)
@@ -26,15 +34,18 @@ var _ = Describe("PreferenceUpdater", func() {
 			unsubscribesRepo = mocks.NewUnsubscribesRepo()
 			kindsRepo = mocks.NewKindsRepo()
 			fakeGlobalUnsubscribesRepo = mocks.NewGlobalUnsubscribesRepo()
-			updater = services.NewPreferenceUpdater(fakeGlobalUnsubscribesRepo, unsubscribesRepo, kindsRepo)
+			deliveryFrequenciesRepo = mocks.NewDeliveryFrequenciesRepo()
+			quietHoursRepo = mocks.NewQuietHoursRepo()
+			preferenceChangesRepo = mocks.NewPreferenceChangesRepo()
+			updater = services.NewPreferenceUpdater(fakeGlobalUnsubscribesRepo, unsubscribesRepo, kindsRepo, deliveryFrequenciesRepo, quietHoursRepo, mocks.NewUserLocalesRepo(), preferenceChangesRepo)
 		})
 
 		Context("when globally unsubscribing", func() {
 			It("inserts a record into the global unsubscribes repo", func() {
-				updater.Update(conn, []models.Preference{}, true, "user-guid")
+				updater.Update(conn, []models.Preference{}, true, "", "user-guid", actor)
 				Expect(fakeGlobalUnsubscribesRepo.SetCall.Receives.Unsubscribed).To(BeTrue())
 
-				updater.Update(conn, []models.Preference{}, false, "user-guid")
+				updater.Update(conn, []models.Preference{}, false, "", "user-guid", actor)
 				Expect(fakeGlobalUnsubscribesRepo.SetCall.Receives.Unsubscribed).To(BeFalse())
 			})
 
@@ -42,7 +53,7 @@ var _ = Describe("PreferenceUpdater", func() {
 				It("returns the error", func() {
 					fakeGlobalUnsubscribesRepo.SetCall.Returns.Error = errors.New("global unsubscribe db error")
 
-					err := updater.Update(conn, []models.Preference{}, true, "user-guid")
+					err := updater.Update(conn, []models.Preference{}, true, "", "user-guid", actor)
 					Expect(err).To(MatchError(errors.New("global unsubscribe db error")))
 				})
 			})
@@ -70,7 +81,7 @@ var _ = Describe("PreferenceUpdater", func() {
 						KindID:   "door-open",
 						Email:    false,
 					},
-				}, false, "the-user")
+				}, false, "", "the-user", actor)
 
 				Expect(unsubscribesRepo.SetCall.Receives.Connection).To(Equal(conn))
 				Expect(unsubscribesRepo.SetCall.Receives.UserID).To(Equal("the-user"))
@@ -86,7 +97,7 @@ var _ = Describe("PreferenceUpdater", func() {
 						KindID:   "barking",
 						Email:    true,
 					},
-				}, false, "the-user")
+				}, false, "", "the-user", actor)
 
 				unsubscribed, err := unsubscribesRepo.Get(conn, "the-user", "dogs", "barking")
 				Expect(err).NotTo(HaveOccurred())
@@ -103,7 +114,7 @@ var _ = Describe("PreferenceUpdater", func() {
 						KindID:   "door-open",
 						Email:    true,
 					},
-				}, false, "my-user")
+				}, false, "", "my-user", actor)
 				Expect(err).NotTo(HaveOccurred())
 
 				unsubscribed, err := unsubscribesRepo.Get(conn, "my-user", "raptors", "door-open")
@@ -140,7 +151,7 @@ var _ = Describe("PreferenceUpdater", func() {
 				}
 				kindsRepo.FindCall.Returns.Error = errors.New("something bad happened")
 
-				err := updater.Update(conn, preferences, false, "the-user")
+				err := updater.Update(conn, preferences, false, "", "the-user", actor)
 				Expect(err).To(MatchError(services.MissingKindOrClientError{Err: errors.New("The kind 'boo' cannot be found for client 'ghosts'")}))
 			})
 		})
@@ -168,7 +179,7 @@ var _ = Describe("PreferenceUpdater", func() {
 				}
 				kindsRepo.FindCall.Returns.Error = errors.New("something bad happened")
 
-				err := updater.Update(conn, preferences, false, "the-user")
+				err := updater.Update(conn, preferences, false, "", "the-user", actor)
 				Expect(err).To(Equal(services.MissingKindOrClientError{Err: errors.New("The kind 'dead' cannot be found for client 'raptors'")}))
 			})
 		})
@@ -200,9 +211,469 @@ var _ = Describe("PreferenceUpdater", func() {
 					},
 				}
 
-				err := updater.Update(conn, preferences, false, "the-user")
+				err := updater.Update(conn, preferences, false, "", "the-user", actor)
 				Expect(err).To(Equal(services.CriticalKindError{Err: errors.New("The kind 'hungry' for the 'raptors' client is critical and cannot be unsubscribed from")}))
 			})
 		})
+
+		Context("when recording the changes", func() {
+			It("records the values that changed as made by the actor", func() {
+				fakeGlobalUnsubscribesRepo.GetCall.Returns.Unsubscribed = false
+				deliveryFrequenciesRepo.GetCall.Returns.Frequency = models.FrequencyImmediate
+
+				err := updater.Update(conn, []models.Preference{}, true, models.FrequencyWeekly, "the-user", actor)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeGlobalUnsubscribesRepo.GetCall.Receives.UserID).To(Equal("the-user"))
+				Expect(preferenceChangesRepo.CreateCall.Receives.Connection).To(Equal(conn))
+				Expect(preferenceChangesRepo.CreateCall.Receives.Changes).To(Equal([]models.PreferenceChange{
+					{
+						UserID:   "the-user",
+						Actor:    "some-client",
+						Source:   models.PreferenceChangeSourceAPI,
+						Field:    models.PreferenceFieldGlobalUnsubscribe,
+						OldValue: "false",
+						NewValue: "true",
+					},
+					{
+						UserID:   "the-user",
+						Actor:    "some-client",
+						Source:   models.PreferenceChangeSourceAPI,
+						Field:    models.PreferenceFieldFrequency,
+						OldValue: models.FrequencyImmediate,
+						NewValue: models.FrequencyWeekly,
+					},
+				}))
+			})
+
+			It("does not record values that stay the same", func() {
+				fakeGlobalUnsubscribesRepo.GetCall.Returns.Unsubscribed = true
+				deliveryFrequenciesRepo.GetCall.Returns.Frequency = models.FrequencyDaily
+
+				err := updater.Update(conn, []models.Preference{}, true, models.FrequencyDaily, "the-user", actor)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(preferenceChangesRepo.CreateCall.Receives.Changes).To(BeEmpty())
+			})
+
+			It("returns the error when the global unsubscribe cannot be read", func() {
+				fakeGlobalUnsubscribesRepo.GetCall.Returns.Error = errors.New("database is down")
+
+				err := updater.Update(conn, []models.Preference{}, true, "", "the-user", actor)
+				Expect(err).To(MatchError(errors.New("database is down")))
+				Expect(fakeGlobalUnsubscribesRepo.SetCall.Receives.Connection).To(BeNil())
+			})
+
+			It("returns the error when the change cannot be recorded", func() {
+				preferenceChangesRepo.CreateCall.Returns.Error = errors.New("database is down")
+
+				err := updater.Update(conn, []models.Preference{}, true, "", "the-user", actor)
+				Expect(err).To(MatchError(errors.New("database is down")))
+			})
+		})
+
+		Context("when setting delivery frequencies", func() {
+			BeforeEach(func() {
+				kindsRepo.FindCall.Returns.Kinds = []models.Kind{
+					{
+						ID:       "door-open",
+						ClientID: "raptors",
+					},
+					{
+						ID:       "barking",
+						ClientID: "dogs",
+					},
+					{
+						ID:       "door-open",
+						ClientID: "raptors",
+					},
+				}
+			})
+
+			It("sets the frequency for all of the user's notifications", func() {
+				err := updater.Update(conn, []models.Preference{}, false, models.FrequencyDaily, "the-user", actor)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(deliveryFrequenciesRepo.SetCall.Receives.Connection).To(Equal(conn))
+				Expect(deliveryFrequenciesRepo.SetCall.Receives.Frequencies).To(Equal([]models.DeliveryFrequency{
+					{UserID: "the-user", Frequency: models.FrequencyDaily},
+				}))
+			})
+
+			It("sets, clears or leaves the frequency of each kind", func() {
+				err := updater.Update(conn, []models.Preference{
+					{
+						ClientID:  "raptors",
+						KindID:    "door-open",
+						Email:     true,
+						Frequency: models.FrequencyWeekly,
+					},
+					{
+						ClientID:  "dogs",
+						KindID:    "barking",
+						Email:     true,
+						Frequency: services.FrequencyDefault,
+					},
+					{
+						ClientID: "raptors",
+						KindID:   "door-open",
+						Email:    true,
+					},
+				}, false, "", "the-user", actor)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(deliveryFrequenciesRepo.SetCall.Receives.Frequencies).To(Equal([]models.DeliveryFrequency{
+					{UserID: "the-user", ClientID: "raptors", KindID: "door-open", Frequency: models.FrequencyWeekly},
+					{UserID: "the-user", ClientID: "dogs", KindID: "barking", Frequency: ""},
+				}))
+			})
+
+			It("returns an InvalidFrequencyError for an unknown frequency", func() {
+				err := updater.Update(conn, []models.Preference{}, false, "hourly", "the-user", actor)
+				Expect(err).To(Equal(services.InvalidFrequencyError{Err: errors.New("The frequency 'hourly' is not one of immediate, daily or weekly")}))
+
+				err = updater.Update(conn, []models.Preference{
+					{
+						ClientID:  "dogs",
+						KindID:    "barking",
+						Email:     true,
+						Frequency: "hourly",
+					},
+				}, false, "", "the-user", actor)
+				Expect(err).To(Equal(services.InvalidFrequencyError{Err: errors.New("The frequency 'hourly' of the kind 'barking' for the 'dogs' client is not one of immediate, daily, weekly or default")}))
+				Expect(deliveryFrequenciesRepo.SetCall.Receives.Frequencies).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Validate", func() {
+		var (
+			kindsRepo *mocks.KindsRepo
+			conn      *mocks.Connection
+			updater   services.PreferenceUpdater
+		)
+
+		BeforeEach(func() {
+			conn = mocks.NewConnection()
+			kindsRepo = mocks.NewKindsRepo()
+			kindsRepo.FindCall.Returns.Kinds = []models.Kind{
+				{
+					ID:       "barking",
+					ClientID: "dogs",
+				},
+			}
+
+			updater = services.NewPreferenceUpdater(mocks.NewGlobalUnsubscribesRepo(), mocks.NewUnsubscribesRepo(), kindsRepo, mocks.NewDeliveryFrequenciesRepo(), mocks.NewQuietHoursRepo(), mocks.NewUserLocalesRepo(), mocks.NewPreferenceChangesRepo())
+		})
+
+		It("accepts preferences for kinds that can be unsubscribed from", func() {
+			err := updater.Validate(conn, []models.Preference{
+				{
+					ClientID:  "dogs",
+					KindID:    "barking",
+					Frequency: services.FrequencyDefault,
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(kindsRepo.FindCall.Receives.Connection).To(Equal(conn))
+			Expect(kindsRepo.FindCall.Receives.ClientID).To(Equal("dogs"))
+			Expect(kindsRepo.FindCall.Receives.KindID).To(Equal("barking"))
+		})
+
+		It("returns a MissingKindOrClientError for a missing kind", func() {
+			kindsRepo.FindCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+			err := updater.Validate(conn, []models.Preference{{ClientID: "dogs", KindID: "barking"}})
+			Expect(err).To(Equal(services.MissingKindOrClientError{Err: errors.New("The kind 'barking' cannot be found for client 'dogs'")}))
+		})
+
+		It("returns a CriticalKindError for a critical kind", func() {
+			kindsRepo.FindCall.Returns.Kinds[0].Critical = true
+
+			err := updater.Validate(conn, []models.Preference{{ClientID: "dogs", KindID: "barking"}})
+			Expect(err).To(Equal(services.CriticalKindError{Err: errors.New("The kind 'barking' for the 'dogs' client is critical and cannot be unsubscribed from")}))
+		})
+
+		It("returns an InvalidFrequencyError for an unknown frequency", func() {
+			err := updater.Validate(conn, []models.Preference{{ClientID: "dogs", KindID: "barking", Frequency: "hourly"}})
+			Expect(err).To(Equal(services.InvalidFrequencyError{Err: errors.New("The frequency 'hourly' of the kind 'barking' for the 'dogs' client is not one of immediate, daily, weekly or default")}))
+		})
+	})
+
+	Describe("UpdateKinds", func() {
+		var (
+			unsubscribesRepo        *mocks.UnsubscribesRepo
+			globalUnsubscribesRepo  *mocks.GlobalUnsubscribesRepo
+			deliveryFrequenciesRepo *mocks.DeliveryFrequenciesRepo
+			preferenceChangesRepo   *mocks.PreferenceChangesRepo
+			conn                    *mocks.Connection
+			updater                 services.PreferenceUpdater
+		)
+
+		BeforeEach(func() {
+			conn = mocks.NewConnection()
+			unsubscribesRepo = mocks.NewUnsubscribesRepo()
+			globalUnsubscribesRepo = mocks.NewGlobalUnsubscribesRepo()
+			deliveryFrequenciesRepo = mocks.NewDeliveryFrequenciesRepo()
+			preferenceChangesRepo = mocks.NewPreferenceChangesRepo()
+
+			updater = services.NewPreferenceUpdater(globalUnsubscribesRepo, unsubscribesRepo, mocks.NewKindsRepo(), deliveryFrequenciesRepo, mocks.NewQuietHoursRepo(), mocks.NewUserLocalesRepo(), preferenceChangesRepo)
+		})
+
+		It("sets the unsubscribe and frequency of each kind, leaving the rest as it is", func() {
+			err := updater.UpdateKinds(conn, "the-user", []models.Preference{
+				{
+					ClientID:  "dogs",
+					KindID:    "barking",
+					Email:     false,
+					Frequency: models.FrequencyDaily,
+				},
+			}, actor)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(unsubscribesRepo.SetCall.Receives.Connection).To(Equal(conn))
+			Expect(unsubscribesRepo.SetCall.Receives.UserID).To(Equal("the-user"))
+			Expect(unsubscribesRepo.SetCall.Receives.ClientID).To(Equal("dogs"))
+			Expect(unsubscribesRepo.SetCall.Receives.KindID).To(Equal("barking"))
+			Expect(unsubscribesRepo.SetCall.Receives.Unsubscribe).To(BeTrue())
+
+			Expect(deliveryFrequenciesRepo.SetCall.Receives.Frequencies).To(Equal([]models.DeliveryFrequency{
+				{UserID: "the-user", ClientID: "dogs", KindID: "barking", Frequency: models.FrequencyDaily},
+			}))
+
+			Expect(globalUnsubscribesRepo.SetCall.Receives.Connection).To(BeNil())
+		})
+
+		It("records the email and frequency of each kind that changed", func() {
+			unsubscribesRepo.GetCall.Returns.Unsubscribed = false
+			deliveryFrequenciesRepo.GetCall.Returns.Frequency = models.FrequencyImmediate
+
+			err := updater.UpdateKinds(conn, "the-user", []models.Preference{
+				{
+					ClientID:  "dogs",
+					KindID:    "barking",
+					Email:     false,
+					Frequency: models.FrequencyWeekly,
+				},
+				{
+					ClientID: "raptors",
+					KindID:   "door-open",
+					Email:    true,
+				},
+			}, actor)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(unsubscribesRepo.GetCall.Receives.UserID).To(Equal("the-user"))
+			Expect(deliveryFrequenciesRepo.GetCall.Receives.ClientID).To(Equal("dogs"))
+			Expect(deliveryFrequenciesRepo.GetCall.Receives.KindID).To(Equal("barking"))
+
+			Expect(preferenceChangesRepo.CreateCall.Receives.Changes).To(Equal([]models.PreferenceChange{
+				{
+					UserID:   "the-user",
+					Actor:    "some-client",
+					Source:   models.PreferenceChangeSourceAPI,
+					ClientID: "dogs",
+					KindID:   "barking",
+					Field:    models.PreferenceFieldEmail,
+					OldValue: "true",
+					NewValue: "false",
+				},
+				{
+					UserID:   "the-user",
+					Actor:    "some-client",
+					Source:   models.PreferenceChangeSourceAPI,
+					ClientID: "dogs",
+					KindID:   "barking",
+					Field:    models.PreferenceFieldFrequency,
+					OldValue: models.FrequencyImmediate,
+					NewValue: models.FrequencyWeekly,
+				},
+			}))
+		})
+
+		It("returns the error when an unsubscribe cannot be set", func() {
+			unsubscribesRepo.SetCall.Returns.Error = errors.New("database is down")
+
+			err := updater.UpdateKinds(conn, "the-user", []models.Preference{{ClientID: "dogs", KindID: "barking"}}, actor)
+			Expect(err).To(MatchError(errors.New("database is down")))
+		})
+	})
+
+	Describe("UpdateQuietHours", func() {
+		var (
+			quietHoursRepo        *mocks.QuietHoursRepo
+			preferenceChangesRepo *mocks.PreferenceChangesRepo
+			conn                  *mocks.Connection
+			updater               services.PreferenceUpdater
+		)
+
+		BeforeEach(func() {
+			conn = mocks.NewConnection()
+			quietHoursRepo = mocks.NewQuietHoursRepo()
+			quietHoursRepo.GetCall.Returns.QuietHours = models.QuietHours{
+				Primary:  42,
+				UserID:   "the-user",
+				TimeZone: "Asia/Tokyo",
+				Start:    "22:00",
+				End:      "07:00",
+			}
+
+			preferenceChangesRepo = mocks.NewPreferenceChangesRepo()
+
+			updater = services.NewPreferenceUpdater(mocks.NewGlobalUnsubscribesRepo(), mocks.NewUnsubscribesRepo(), mocks.NewKindsRepo(), mocks.NewDeliveryFrequenciesRepo(), quietHoursRepo, mocks.NewUserLocalesRepo(), preferenceChangesRepo)
+		})
+
+		It("sets the time zone, leaving the window as it is", func() {
+			err := updater.UpdateQuietHours(conn, "the-user", "Europe/Paris", nil, actor)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(quietHoursRepo.GetCall.Receives.Connection).To(Equal(conn))
+			Expect(quietHoursRepo.GetCall.Receives.UserID).To(Equal("the-user"))
+			Expect(quietHoursRepo.SetCall.Receives.Connection).To(Equal(conn))
+			Expect(quietHoursRepo.SetCall.Receives.QuietHours).To(Equal(models.QuietHours{
+				Primary:  42,
+				UserID:   "the-user",
+				TimeZone: "Europe/Paris",
+				Start:    "22:00",
+				End:      "07:00",
+			}))
+		})
+
+		It("sets the window, leaving the time zone as it is", func() {
+			err := updater.UpdateQuietHours(conn, "the-user", "", &services.QuietHours{Start: "21:30", End: "06:00"}, actor)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(quietHoursRepo.SetCall.Receives.QuietHours.TimeZone).To(Equal("Asia/Tokyo"))
+			Expect(quietHoursRepo.SetCall.Receives.QuietHours.Start).To(Equal("21:30"))
+			Expect(quietHoursRepo.SetCall.Receives.QuietHours.End).To(Equal("06:00"))
+		})
+
+		It("removes the window when it is set to nothing", func() {
+			err := updater.UpdateQuietHours(conn, "the-user", "", &services.QuietHours{}, actor)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(quietHoursRepo.SetCall.Receives.QuietHours.Start).To(BeEmpty())
+			Expect(quietHoursRepo.SetCall.Receives.QuietHours.End).To(BeEmpty())
+		})
+
+		It("records the time zone and window that changed", func() {
+			err := updater.UpdateQuietHours(conn, "the-user", "Europe/Paris", &services.QuietHours{}, actor)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(preferenceChangesRepo.CreateCall.Receives.Changes).To(Equal([]models.PreferenceChange{
+				{
+					UserID:   "the-user",
+					Actor:    "some-client",
+					Source:   models.PreferenceChangeSourceAPI,
+					Field:    models.PreferenceFieldTimeZone,
+					OldValue: "Asia/Tokyo",
+					NewValue: "Europe/Paris",
+				},
+				{
+					UserID:   "the-user",
+					Actor:    "some-client",
+					Source:   models.PreferenceChangeSourceAPI,
+					Field:    models.PreferenceFieldQuietHours,
+					OldValue: "22:00-07:00",
+					NewValue: "",
+				},
+			}))
+		})
+
+		It("does nothing when neither is given", func() {
+			err := updater.UpdateQuietHours(conn, "the-user", "", nil, actor)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(quietHoursRepo.GetCall.WasCalled).To(BeFalse())
+			Expect(quietHoursRepo.SetCall.WasCalled).To(BeFalse())
+		})
+
+		It("returns an InvalidQuietHoursError for an unknown time zone", func() {
+			err := updater.UpdateQuietHours(conn, "the-user", "Middle/Earth", nil, actor)
+			Expect(err).To(Equal(services.InvalidQuietHoursError{Err: errors.New("The time zone 'Middle/Earth' is not known")}))
+			Expect(quietHoursRepo.SetCall.WasCalled).To(BeFalse())
+		})
+
+		It("returns an InvalidQuietHoursError for a malformed window", func() {
+			err := updater.UpdateQuietHours(conn, "the-user", "", &services.QuietHours{Start: "10pm", End: "07:00"}, actor)
+			Expect(err).To(Equal(services.InvalidQuietHoursError{Err: errors.New("The quiet hours time '10pm' is not formatted as HH:MM")}))
+			Expect(quietHoursRepo.SetCall.WasCalled).To(BeFalse())
+		})
+
+		Context("when the quiet hours cannot be loaded", func() {
+			It("returns the error", func() {
+				quietHoursRepo.GetCall.Returns.Error = errors.New("database is down")
+
+				err := updater.UpdateQuietHours(conn, "the-user", "Europe/Paris", nil, actor)
+				Expect(err).To(MatchError("database is down"))
+			})
+		})
+	})
+
+	Describe("UpdateLocale", func() {
+		var (
+			localesRepo           *mocks.UserLocalesRepo
+			preferenceChangesRepo *mocks.PreferenceChangesRepo
+			conn                  *mocks.Connection
+			updater               services.PreferenceUpdater
+		)
+
+		BeforeEach(func() {
+			conn = mocks.NewConnection()
+			localesRepo = mocks.NewUserLocalesRepo()
+			localesRepo.GetCall.Returns.Locale = "fr"
+			preferenceChangesRepo = mocks.NewPreferenceChangesRepo()
+
+			updater = services.NewPreferenceUpdater(mocks.NewGlobalUnsubscribesRepo(), mocks.NewUnsubscribesRepo(), mocks.NewKindsRepo(), mocks.NewDeliveryFrequenciesRepo(), mocks.NewQuietHoursRepo(), localesRepo, preferenceChangesRepo)
+		})
+
+		It("sets the locale in its canonical form", func() {
+			err := updater.UpdateLocale(conn, "the-user", "pt_br", actor)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(localesRepo.SetCall.Receives.Connection).To(Equal(conn))
+			Expect(localesRepo.SetCall.Receives.UserID).To(Equal("the-user"))
+			Expect(localesRepo.SetCall.Receives.Locale).To(Equal("pt-BR"))
+		})
+
+		It("records the locale that changed", func() {
+			err := updater.UpdateLocale(conn, "the-user", "pt-BR", actor)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(preferenceChangesRepo.CreateCall.Receives.Changes).To(Equal([]models.PreferenceChange{
+				{
+					UserID:   "the-user",
+					Actor:    "some-client",
+					Source:   models.PreferenceChangeSourceAPI,
+					Field:    models.PreferenceFieldLocale,
+					OldValue: "fr",
+					NewValue: "pt-BR",
+				},
+			}))
+		})
+
+		It("does nothing when no locale is given", func() {
+			err := updater.UpdateLocale(conn, "the-user", "", actor)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(localesRepo.GetCall.WasCalled).To(BeFalse())
+			Expect(localesRepo.SetCall.WasCalled).To(BeFalse())
+		})
+
+		It("returns an InvalidLocaleError for a locale that is not a language tag", func() {
+			err := updater.UpdateLocale(conn, "the-user", "Middle Earth", actor)
+			Expect(err).To(Equal(services.InvalidLocaleError{Err: errors.New("The locale 'Middle Earth' is not a BCP 47 language tag")}))
+			Expect(localesRepo.SetCall.WasCalled).To(BeFalse())
+		})
+
+		Context("when the locale cannot be loaded", func() {
+			It("returns the error", func() {
+				localesRepo.GetCall.Returns.Error = errors.New("database is down")
+
+				err := updater.UpdateLocale(conn, "the-user", "fr", actor)
+				Expect(err).To(MatchError("database is down"))
+			})
+		})
 	})
 })
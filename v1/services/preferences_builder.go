@@ -8,16 +8,28 @@ import (
 
 type Kind struct {
 	Email             *bool  `json:"email"`
+	Frequency         string `json:"frequency,omitempty"`
 	KindDescription   string `json:"kind_description"`
 	SourceDescription string `json:"source_description"`
 }
 
+// QuietHours is the daily window, in the time zone of the user, during which
+// they do not receive non-critical notifications.
+type QuietHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
 type ClientMap map[string]Kind
 type ClientsMap map[string]ClientMap
 
 type PreferencesBuilder struct {
-	GlobalUnsubscribe bool       `json:"global_unsubscribe"`
-	Clients           ClientsMap `json:"clients"`
+	GlobalUnsubscribe bool        `json:"global_unsubscribe"`
+	Frequency         string      `json:"frequency,omitempty"`
+	TimeZone          string      `json:"time_zone,omitempty"`
+	QuietHours        *QuietHours `json:"quiet_hours,omitempty"`
+	Locale            string      `json:"locale,omitempty"`
+	Clients           ClientsMap  `json:"clients"`
 }
 
 func NewPreferencesBuilder() PreferencesBuilder {
@@ -37,6 +49,7 @@ func (pref PreferencesBuilder) Add(preference models.Preference) {
 
 	data := Kind{
 		Email:             &preference.Email,
+		Frequency:         preference.Frequency,
 		KindDescription:   preference.KindDescription,
 		SourceDescription: preference.SourceDescription,
 	}
@@ -64,9 +77,10 @@ func (pref PreferencesBuilder) ToPreferences() ([]models.Preference, error) {
 			}
 
 			preferences = append(preferences, models.Preference{
-				ClientID: clientID,
-				KindID:   kindID,
-				Email:    *kind.Email,
+				ClientID:  clientID,
+				KindID:    kindID,
+				Email:     *kind.Email,
+				Frequency: kind.Frequency,
 			})
 		}
 	}
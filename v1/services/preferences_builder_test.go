@@ -128,6 +128,26 @@ var _ = Describe("NotificationsPreferences", func() {
 				SourceDescription: "raptors",
 			}))
 		})
+
+		It("includes the delivery frequency set for the kind", func() {
+			builder.Add(models.Preference{
+				ClientID:  "raptors",
+				KindID:    "hungry",
+				Email:     true,
+				Frequency: models.FrequencyDaily,
+			})
+
+			Expect(builder.Clients["raptors"]["hungry"].Frequency).To(Equal(models.FrequencyDaily))
+
+			preferences, err := builder.ToPreferences()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(preferences).To(ConsistOf(models.Preference{
+				ClientID:  "raptors",
+				KindID:    "hungry",
+				Email:     true,
+				Frequency: models.FrequencyDaily,
+			}))
+		})
 	})
 
 	Describe("ToPreferences", func() {
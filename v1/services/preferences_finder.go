@@ -1,14 +1,20 @@
 package services
 
 type PreferencesFinder struct {
-	preferencesRepo        PreferencesRepo
-	globalUnsubscribesRepo GlobalUnsubscribesRepo
+	preferencesRepo         PreferencesRepo
+	globalUnsubscribesRepo  GlobalUnsubscribesRepo
+	deliveryFrequenciesRepo DeliveryFrequenciesRepo
+	quietHoursRepo          QuietHoursRepo
+	userLocalesRepo         UserLocalesRepo
 }
 
-func NewPreferencesFinder(preferencesRepo PreferencesRepo, globalUnsubscribesRepo GlobalUnsubscribesRepo) *PreferencesFinder {
+func NewPreferencesFinder(preferencesRepo PreferencesRepo, globalUnsubscribesRepo GlobalUnsubscribesRepo, deliveryFrequenciesRepo DeliveryFrequenciesRepo, quietHoursRepo QuietHoursRepo, userLocalesRepo UserLocalesRepo) *PreferencesFinder {
 	return &PreferencesFinder{
-		preferencesRepo:        preferencesRepo,
-		globalUnsubscribesRepo: globalUnsubscribesRepo,
+		preferencesRepo:         preferencesRepo,
+		globalUnsubscribesRepo:  globalUnsubscribesRepo,
+		deliveryFrequenciesRepo: deliveryFrequenciesRepo,
+		quietHoursRepo:          quietHoursRepo,
+		userLocalesRepo:         userLocalesRepo,
 	}
 }
 
@@ -21,12 +27,40 @@ func (finder PreferencesFinder) Find(database DatabaseInterface, userGUID string
 		return builder, err
 	}
 
+	frequency, err := finder.deliveryFrequenciesRepo.Get(conn, userGUID, "", "")
+	if err != nil {
+		return builder, err
+	}
+
+	quietHours, err := finder.quietHoursRepo.Get(conn, userGUID)
+	if err != nil {
+		return builder, err
+	}
+
+	locale, err := finder.userLocalesRepo.Get(conn, userGUID)
+	if err != nil {
+		return builder, err
+	}
+
 	preferences, err := finder.preferencesRepo.FindNonCriticalPreferences(conn, userGUID)
 	if err != nil {
 		return builder, err
 	}
 
 	builder.GlobalUnsubscribe = globallyUnsubscribed
+	builder.Frequency = frequency
+	builder.Locale = locale
+	builder.TimeZone = quietHours.TimeZone
+	if builder.TimeZone == "" {
+		builder.TimeZone = "UTC"
+	}
+
+	if quietHours.Start != "" || quietHours.End != "" {
+		builder.QuietHours = &QuietHours{
+			Start: quietHours.Start,
+			End:   quietHours.End,
+		}
+	}
 	for _, preference := range preferences {
 		builder.Add(preference)
 	}
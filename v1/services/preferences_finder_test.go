@@ -15,6 +15,9 @@ var _ = Describe("PreferencesFinder", func() {
 	var (
 		finder          *services.PreferencesFinder
 		preferencesRepo *mocks.PreferencesRepo
+		frequenciesRepo *mocks.DeliveryFrequenciesRepo
+		quietHoursRepo  *mocks.QuietHoursRepo
+		localesRepo     *mocks.UserLocalesRepo
 		preferences     []models.Preference
 		database        *mocks.Database
 		conn            *mocks.Connection
@@ -48,7 +51,21 @@ var _ = Describe("PreferencesFinder", func() {
 		database = mocks.NewDatabase()
 		database.ConnectionCall.Returns.Connection = conn
 
-		finder = services.NewPreferencesFinder(preferencesRepo, fakeGlobalUnsubscribesRepo)
+		frequenciesRepo = mocks.NewDeliveryFrequenciesRepo()
+		frequenciesRepo.GetCall.Returns.Frequency = models.FrequencyDaily
+
+		quietHoursRepo = mocks.NewQuietHoursRepo()
+		quietHoursRepo.GetCall.Returns.QuietHours = models.QuietHours{
+			UserID:   "correct-user",
+			TimeZone: "Asia/Tokyo",
+			Start:    "22:00",
+			End:      "07:00",
+		}
+
+		localesRepo = mocks.NewUserLocalesRepo()
+		localesRepo.GetCall.Returns.Locale = "pt-BR"
+
+		finder = services.NewPreferencesFinder(preferencesRepo, fakeGlobalUnsubscribesRepo, frequenciesRepo, quietHoursRepo, localesRepo)
 	})
 
 	Describe("Find", func() {
@@ -57,6 +74,13 @@ var _ = Describe("PreferencesFinder", func() {
 			expectedResult.Add(preferences[0])
 			expectedResult.Add(preferences[1])
 			expectedResult.GlobalUnsubscribe = true
+			expectedResult.Frequency = models.FrequencyDaily
+			expectedResult.TimeZone = "Asia/Tokyo"
+			expectedResult.QuietHours = &services.QuietHours{
+				Start: "22:00",
+				End:   "07:00",
+			}
+			expectedResult.Locale = "pt-BR"
 
 			resultPreferences, err := finder.Find(database, "correct-user")
 			Expect(err).NotTo(HaveOccurred())
@@ -64,6 +88,47 @@ var _ = Describe("PreferencesFinder", func() {
 
 			Expect(preferencesRepo.FindNonCriticalPreferencesCall.Receives.Connection).To(Equal(conn))
 			Expect(preferencesRepo.FindNonCriticalPreferencesCall.Receives.UserGUID).To(Equal("correct-user"))
+			Expect(frequenciesRepo.GetCall.Receives.UserID).To(Equal("correct-user"))
+			Expect(frequenciesRepo.GetCall.Receives.ClientID).To(BeEmpty())
+			Expect(frequenciesRepo.GetCall.Receives.KindID).To(BeEmpty())
+			Expect(quietHoursRepo.GetCall.Receives.UserID).To(Equal("correct-user"))
+			Expect(localesRepo.GetCall.Receives.UserID).To(Equal("correct-user"))
+		})
+
+		It("reports users without a time zone or quiet hours as being in UTC without quiet hours", func() {
+			quietHoursRepo.GetCall.Returns.QuietHours = models.QuietHours{UserID: "correct-user"}
+
+			resultPreferences, err := finder.Find(database, "correct-user")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resultPreferences.TimeZone).To(Equal("UTC"))
+			Expect(resultPreferences.QuietHours).To(BeNil())
+		})
+
+		Context("when the delivery frequencies repo returns an error", func() {
+			It("should propagate the error", func() {
+				frequenciesRepo.GetCall.Returns.Error = errors.New("BOOM!")
+
+				_, err := finder.Find(database, "correct-user")
+				Expect(err).To(MatchError("BOOM!"))
+			})
+		})
+
+		Context("when the quiet hours repo returns an error", func() {
+			It("should propagate the error", func() {
+				quietHoursRepo.GetCall.Returns.Error = errors.New("BOOM!")
+
+				_, err := finder.Find(database, "correct-user")
+				Expect(err).To(MatchError("BOOM!"))
+			})
+		})
+
+		Context("when the user locales repo returns an error", func() {
+			It("should propagate the error", func() {
+				localesRepo.GetCall.Returns.Error = errors.New("BOOM!")
+
+				_, err := finder.Find(database, "correct-user")
+				Expect(err).To(MatchError("BOOM!"))
+			})
 		})
 
 		Context("when the preferences repo returns an error", func() {
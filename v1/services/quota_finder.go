@@ -0,0 +1,35 @@
+package services
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type quotasRepoLister interface {
+	Find(models.ConnectionInterface, string, string) (models.Quota, error)
+	List(models.ConnectionInterface) ([]models.Quota, error)
+}
+
+type QuotaFinder struct {
+	repo quotasRepoLister
+}
+
+func NewQuotaFinder(repo quotasRepoLister) QuotaFinder {
+	return QuotaFinder{
+		repo: repo,
+	}
+}
+
+func (finder QuotaFinder) List(database DatabaseInterface) ([]models.Quota, error) {
+	quotas, err := finder.repo.List(database.Connection())
+	if err != nil {
+		return []models.Quota{}, err
+	}
+
+	if quotas == nil {
+		quotas = []models.Quota{}
+	}
+
+	return quotas, nil
+}
+
+func (finder QuotaFinder) Find(database DatabaseInterface, clientID, kindID string) (models.Quota, error) {
+	return finder.repo.Find(database.Connection(), clientID, kindID)
+}
@@ -0,0 +1,72 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("QuotaFinder", func() {
+	var (
+		finder     services.QuotaFinder
+		quotasRepo *mocks.QuotasRepo
+		database   *mocks.Database
+		conn       *mocks.Connection
+	)
+
+	BeforeEach(func() {
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		quotasRepo = mocks.NewQuotasRepo()
+		finder = services.NewQuotaFinder(quotasRepo)
+	})
+
+	Describe("List", func() {
+		It("returns every quota", func() {
+			quotasRepo.ListCall.Returns.Quotas = []models.Quota{
+				{ClientID: "some-client", RequestsPerMinute: 10},
+			}
+
+			quotas, err := finder.List(database)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quotas).To(Equal([]models.Quota{
+				{ClientID: "some-client", RequestsPerMinute: 10},
+			}))
+			Expect(quotasRepo.ListCall.Receives.Connection).To(Equal(conn))
+		})
+
+		It("returns an empty list when there are no quotas", func() {
+			quotas, err := finder.List(database)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quotas).To(Equal([]models.Quota{}))
+		})
+
+		It("returns the error when the quotas cannot be listed", func() {
+			quotasRepo.ListCall.Returns.Error = errors.New("BOOM!")
+
+			_, err := finder.List(database)
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+		})
+	})
+
+	Describe("Find", func() {
+		It("finds the quota of the client and kind", func() {
+			quotasRepo.FindCall.Returns.Quota = models.Quota{ClientID: "some-client", KindID: "some-kind"}
+
+			quota, err := finder.Find(database, "some-client", "some-kind")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quota).To(Equal(models.Quota{ClientID: "some-client", KindID: "some-kind"}))
+
+			Expect(quotasRepo.FindCall.Receives.Connection).To(Equal(conn))
+			Expect(quotasRepo.FindCall.Receives.ClientID).To(Equal("some-client"))
+			Expect(quotasRepo.FindCall.Receives.KindID).To(Equal("some-kind"))
+		})
+	})
+})
@@ -0,0 +1,28 @@
+package services
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type quotasRepoWriter interface {
+	Upsert(models.ConnectionInterface, models.Quota) (models.Quota, error)
+	Delete(models.ConnectionInterface, string, string) error
+}
+
+type QuotaUpdater struct {
+	repo quotasRepoWriter
+}
+
+func NewQuotaUpdater(repo quotasRepoWriter) QuotaUpdater {
+	return QuotaUpdater{
+		repo: repo,
+	}
+}
+
+// Update sets the limits of the quota for the client and kind. An empty kind
+// sets the quota of the client as a whole.
+func (updater QuotaUpdater) Update(database DatabaseInterface, quota models.Quota) (models.Quota, error) {
+	return updater.repo.Upsert(database.Connection(), quota)
+}
+
+func (updater QuotaUpdater) Delete(database DatabaseInterface, clientID, kindID string) error {
+	return updater.repo.Delete(database.Connection(), clientID, kindID)
+}
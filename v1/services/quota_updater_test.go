@@ -0,0 +1,61 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("QuotaUpdater", func() {
+	var (
+		updater    services.QuotaUpdater
+		quotasRepo *mocks.QuotasRepo
+		database   *mocks.Database
+		conn       *mocks.Connection
+	)
+
+	BeforeEach(func() {
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		quotasRepo = mocks.NewQuotasRepo()
+		updater = services.NewQuotaUpdater(quotasRepo)
+	})
+
+	Describe("Update", func() {
+		It("upserts the quota", func() {
+			quotasRepo.UpsertCall.Returns.Quota = models.Quota{Primary: 4, ClientID: "some-client", RecipientsPerHour: 1000}
+
+			quota, err := updater.Update(database, models.Quota{ClientID: "some-client", RecipientsPerHour: 1000})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quota.Primary).To(Equal(4))
+
+			Expect(quotasRepo.UpsertCall.Receives.Connection).To(Equal(conn))
+			Expect(quotasRepo.UpsertCall.Receives.Quota).To(Equal(models.Quota{ClientID: "some-client", RecipientsPerHour: 1000}))
+		})
+
+		It("returns the error when the quota cannot be saved", func() {
+			quotasRepo.UpsertCall.Returns.Error = errors.New("BOOM!")
+
+			_, err := updater.Update(database, models.Quota{ClientID: "some-client"})
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("deletes the quota", func() {
+			err := updater.Delete(database, "some-client", "some-kind")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(quotasRepo.DeleteCall.Receives.Connection).To(Equal(conn))
+			Expect(quotasRepo.DeleteCall.Receives.ClientID).To(Equal("some-client"))
+			Expect(quotasRepo.DeleteCall.Receives.KindID).To(Equal("some-kind"))
+		})
+	})
+})
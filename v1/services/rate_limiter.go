@@ -0,0 +1,109 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+type quotasRepoFinder interface {
+	FindApplicable(models.ConnectionInterface, string, string) ([]models.Quota, error)
+}
+
+type quotaUsagesRepo interface {
+	Increment(models.ConnectionInterface, models.QuotaUsage) (int, error)
+}
+
+type clock interface {
+	Now() time.Time
+}
+
+// RateLimiter counts what clients send against their quotas. The counts are
+// kept in the database so that every instance enforces the same quota.
+type RateLimiter struct {
+	quotasRepo      quotasRepoFinder
+	quotaUsagesRepo quotaUsagesRepo
+	clock           clock
+}
+
+func NewRateLimiter(quotasRepo quotasRepoFinder, quotaUsagesRepo quotaUsagesRepo, clock clock) RateLimiter {
+	return RateLimiter{
+		quotasRepo:      quotasRepo,
+		quotaUsagesRepo: quotaUsagesRepo,
+		clock:           clock,
+	}
+}
+
+// LimitRequests counts a request to send a notification of the kind, and
+// returns a QuotaExceededError when the client has run out of requests for
+// the current minute.
+func (limiter RateLimiter) LimitRequests(conn ConnectionInterface, clientID, kindID string) error {
+	transaction := conn.Transaction()
+	err := transaction.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = limiter.consume(transaction, clientID, kindID, models.QuotaMeasureRequests, 1)
+	if err != nil {
+		transaction.Rollback()
+		return err
+	}
+
+	return transaction.Commit()
+}
+
+// LimitRecipients counts the recipients of a notification of the kind, and
+// returns a QuotaExceededError when they do not fit in what is left of the
+// client's quota for the current hour. The connection should be the
+// transaction the recipients are enqueued in, so that they only count once
+// the transaction commits.
+func (limiter RateLimiter) LimitRecipients(conn ConnectionInterface, clientID, kindID string, count int) error {
+	return limiter.consume(conn, clientID, kindID, models.QuotaMeasureRecipients, count)
+}
+
+func (limiter RateLimiter) consume(conn ConnectionInterface, clientID, kindID, measure string, count int) error {
+	quotas, err := limiter.quotasRepo.FindApplicable(conn, clientID, kindID)
+	if err != nil {
+		return err
+	}
+
+	now := limiter.clock.Now().UTC()
+	for _, quota := range quotas {
+		limit, window, unit := quota.RequestsPerMinute, time.Minute, "minute"
+		if measure == models.QuotaMeasureRecipients {
+			limit, window, unit = quota.RecipientsPerHour, time.Hour, "hour"
+		}
+
+		if limit <= 0 {
+			continue
+		}
+
+		windowStart := now.Truncate(window)
+		used, err := limiter.quotaUsagesRepo.Increment(conn, models.QuotaUsage{
+			ClientID:    quota.ClientID,
+			KindID:      quota.KindID,
+			Measure:     measure,
+			WindowStart: windowStart,
+			Count:       count,
+		})
+		if err != nil {
+			return err
+		}
+
+		if used > limit {
+			owner := fmt.Sprintf("client %q", quota.ClientID)
+			if quota.KindID != "" {
+				owner += fmt.Sprintf(" and kind %q", quota.KindID)
+			}
+
+			return QuotaExceededError{
+				Err:        fmt.Errorf("Quota of %d %s per %s for %s has been exceeded", limit, measure, unit, owner),
+				RetryAfter: windowStart.Add(window).Sub(now),
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package services_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RateLimiter", func() {
+	var (
+		rateLimiter     services.RateLimiter
+		quotasRepo      *mocks.QuotasRepo
+		quotaUsagesRepo *mocks.QuotaUsagesRepo
+		clock           *mocks.Clock
+		conn            *mocks.Connection
+		transaction     *mocks.Transaction
+	)
+
+	BeforeEach(func() {
+		transaction = mocks.NewTransaction()
+		conn = mocks.NewConnection()
+		conn.TransactionCall.Returns.Transaction = transaction
+		transaction.Connection = conn
+
+		quotasRepo = mocks.NewQuotasRepo()
+		quotaUsagesRepo = mocks.NewQuotaUsagesRepo()
+		clock = mocks.NewClock()
+		clock.NowCall.Returns.Time = time.Date(2015, 6, 8, 14, 20, 15, 0, time.UTC)
+
+		rateLimiter = services.NewRateLimiter(quotasRepo, quotaUsagesRepo, clock)
+	})
+
+	Describe("LimitRequests", func() {
+		BeforeEach(func() {
+			quotasRepo.FindApplicableCall.Returns.Quotas = []models.Quota{
+				{ClientID: "some-client", RequestsPerMinute: 100},
+				{ClientID: "some-client", KindID: "some-kind", RequestsPerMinute: 10},
+			}
+			quotaUsagesRepo.IncrementCall.Returns.Counts = []int{50, 5}
+		})
+
+		It("counts the request against every quota of the client and kind in a transaction", func() {
+			err := rateLimiter.LimitRequests(conn, "some-client", "some-kind")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(quotasRepo.FindApplicableCall.Receives.Connection).To(Equal(transaction))
+			Expect(quotasRepo.FindApplicableCall.Receives.ClientID).To(Equal("some-client"))
+			Expect(quotasRepo.FindApplicableCall.Receives.KindID).To(Equal("some-kind"))
+
+			Expect(quotaUsagesRepo.IncrementCall.Receives.Connection).To(Equal(transaction))
+			Expect(quotaUsagesRepo.IncrementCall.Receives.Usages).To(Equal([]models.QuotaUsage{
+				{
+					ClientID:    "some-client",
+					Measure:     models.QuotaMeasureRequests,
+					WindowStart: time.Date(2015, 6, 8, 14, 20, 0, 0, time.UTC),
+					Count:       1,
+				},
+				{
+					ClientID:    "some-client",
+					KindID:      "some-kind",
+					Measure:     models.QuotaMeasureRequests,
+					WindowStart: time.Date(2015, 6, 8, 14, 20, 0, 0, time.UTC),
+					Count:       1,
+				},
+			}))
+
+			Expect(transaction.CommitCall.WasCalled).To(BeTrue())
+			Expect(transaction.RollbackCall.WasCalled).To(BeFalse())
+		})
+
+		It("ignores quotas without a limit on requests", func() {
+			quotasRepo.FindApplicableCall.Returns.Quotas = []models.Quota{
+				{ClientID: "some-client", RecipientsPerHour: 1000},
+			}
+
+			err := rateLimiter.LimitRequests(conn, "some-client", "some-kind")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quotaUsagesRepo.IncrementCall.CallCount).To(Equal(0))
+		})
+
+		It("rejects the request and rolls back the count when a quota is exceeded", func() {
+			quotaUsagesRepo.IncrementCall.Returns.Counts = []int{50, 11}
+
+			err := rateLimiter.LimitRequests(conn, "some-client", "some-kind")
+			Expect(err).To(MatchError(services.QuotaExceededError{
+				Err:        errors.New(`Quota of 10 requests per minute for client "some-client" and kind "some-kind" has been exceeded`),
+				RetryAfter: 45 * time.Second,
+			}))
+
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+		})
+
+		It("returns the error when the usage cannot be counted", func() {
+			quotaUsagesRepo.IncrementCall.Returns.Error = errors.New("BOOM!")
+
+			err := rateLimiter.LimitRequests(conn, "some-client", "some-kind")
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+		})
+
+		It("returns the error when the quotas cannot be found", func() {
+			quotasRepo.FindApplicableCall.Returns.Error = errors.New("BOOM!")
+
+			err := rateLimiter.LimitRequests(conn, "some-client", "some-kind")
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+		})
+	})
+
+	Describe("LimitRecipients", func() {
+		BeforeEach(func() {
+			quotasRepo.FindApplicableCall.Returns.Quotas = []models.Quota{
+				{ClientID: "some-client", RequestsPerMinute: 100, RecipientsPerHour: 1000},
+			}
+			quotaUsagesRepo.IncrementCall.Returns.Counts = []int{600}
+		})
+
+		It("counts the recipients in the hour on the connection it is given", func() {
+			err := rateLimiter.LimitRecipients(conn, "some-client", "some-kind", 200)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(quotaUsagesRepo.IncrementCall.Receives.Connection).To(Equal(conn))
+			Expect(quotaUsagesRepo.IncrementCall.Receives.Usages).To(Equal([]models.QuotaUsage{
+				{
+					ClientID:    "some-client",
+					Measure:     models.QuotaMeasureRecipients,
+					WindowStart: time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+					Count:       200,
+				},
+			}))
+			Expect(transaction.BeginCall.WasCalled).To(BeFalse())
+		})
+
+		It("rejects recipients that do not fit in the quota", func() {
+			quotaUsagesRepo.IncrementCall.Returns.Counts = []int{1200}
+
+			err := rateLimiter.LimitRecipients(conn, "some-client", "some-kind", 200)
+			Expect(err).To(MatchError(services.QuotaExceededError{
+				Err:        errors.New(`Quota of 1000 recipients per hour for client "some-client" has been exceeded`),
+				RetryAfter: 39*time.Minute + 45*time.Second,
+			}))
+		})
+	})
+})
@@ -27,15 +27,42 @@ type TemplatesRepo interface {
 	Create(connection models.ConnectionInterface, template models.Template) (models.Template, error)
 	Destroy(connection models.ConnectionInterface, templateID string) error
 	FindByID(connection models.ConnectionInterface, templateID string) (models.Template, error)
+	FindByIDForUpdate(connection models.ConnectionInterface, templateID string) (models.Template, error)
 	ListIDsAndNames(connection models.ConnectionInterface) ([]models.Template, error)
 	Update(connection models.ConnectionInterface, templateID string, template models.Template) (models.Template, error)
 }
 
+type TemplateVersionsRepo interface {
+	Create(connection models.ConnectionInterface, version models.TemplateVersion) (models.TemplateVersion, error)
+	Find(connection models.ConnectionInterface, templateID string, version int) (models.TemplateVersion, error)
+	List(connection models.ConnectionInterface, templateID string) ([]models.TemplateVersion, error)
+}
+
 type UnsubscribesRepo interface {
+	Get(connection models.ConnectionInterface, userID string, clientID string, kindID string) (bool, error)
 	Set(connection models.ConnectionInterface, userID string, clientID string, kindID string, unsubscribe bool) error
 }
 
+type DeliveryFrequenciesRepo interface {
+	Get(connection models.ConnectionInterface, userID string, clientID string, kindID string) (string, error)
+	Set(connection models.ConnectionInterface, userID string, clientID string, kindID string, frequency string) error
+}
+
+type QuietHoursRepo interface {
+	Get(connection models.ConnectionInterface, userID string) (models.QuietHours, error)
+	Set(connection models.ConnectionInterface, quietHours models.QuietHours) error
+}
+
+type UserLocalesRepo interface {
+	Get(connection models.ConnectionInterface, userID string) (string, error)
+	Set(connection models.ConnectionInterface, userID string, locale string) error
+}
+
 type GlobalUnsubscribesRepo interface {
 	Get(connection models.ConnectionInterface, userGUID string) (bool, error)
 	Set(connection models.ConnectionInterface, userGUID string, unsubscribe bool) error
 }
+
+type PreferenceChangesRepo interface {
+	Create(connection models.ConnectionInterface, change models.PreferenceChange) (models.PreferenceChange, error)
+}
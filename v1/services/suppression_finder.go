@@ -0,0 +1,56 @@
+package services
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type SuppressionList struct {
+	Total        int
+	Suppressions []models.Suppression
+}
+
+type suppressionsRepoFinder interface {
+	Find(models.ConnectionInterface, string) (models.Suppression, error)
+	List(models.ConnectionInterface, int, int) ([]models.Suppression, error)
+	Count(models.ConnectionInterface) (int, error)
+}
+
+type SuppressionFinder struct {
+	repo suppressionsRepoFinder
+}
+
+func NewSuppressionFinder(repo suppressionsRepoFinder) SuppressionFinder {
+	return SuppressionFinder{
+		repo: repo,
+	}
+}
+
+func (finder SuppressionFinder) List(database DatabaseInterface, page, perPage int) (SuppressionList, error) {
+	conn := database.Connection()
+
+	total, err := finder.repo.Count(conn)
+	if err != nil {
+		return SuppressionList{}, err
+	}
+
+	offset := 0
+	if page > 1 {
+		offset = (page - 1) * perPage
+	}
+
+	suppressions, err := finder.repo.List(conn, perPage, offset)
+	if err != nil {
+		return SuppressionList{}, err
+	}
+
+	if suppressions == nil {
+		suppressions = []models.Suppression{}
+	}
+
+	return SuppressionList{
+		Total:        total,
+		Suppressions: suppressions,
+	}, nil
+}
+
+func (finder SuppressionFinder) Find(database DatabaseInterface, email string) (models.Suppression, error) {
+	return finder.repo.Find(database.Connection(), email)
+}
@@ -0,0 +1,87 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SuppressionFinder", func() {
+	var (
+		finder           services.SuppressionFinder
+		suppressionsRepo *mocks.SuppressionsRepo
+		database         *mocks.Database
+		conn             *mocks.Connection
+	)
+
+	BeforeEach(func() {
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		suppressionsRepo = mocks.NewSuppressionsRepo()
+		finder = services.NewSuppressionFinder(suppressionsRepo)
+	})
+
+	Describe("List", func() {
+		It("returns a page of suppressions along with the total count", func() {
+			suppressionsRepo.CountCall.Returns.Count = 8
+			suppressionsRepo.ListCall.Returns.Suppressions = []models.Suppression{
+				{Email: "user@example.com", Reason: models.SuppressionReasonBounce},
+			}
+
+			list, err := finder.List(database, 2, 5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list).To(Equal(services.SuppressionList{
+				Total: 8,
+				Suppressions: []models.Suppression{
+					{Email: "user@example.com", Reason: models.SuppressionReasonBounce},
+				},
+			}))
+
+			Expect(suppressionsRepo.CountCall.Receives.Connection).To(Equal(conn))
+			Expect(suppressionsRepo.ListCall.Receives.Connection).To(Equal(conn))
+			Expect(suppressionsRepo.ListCall.Receives.Limit).To(Equal(5))
+			Expect(suppressionsRepo.ListCall.Receives.Offset).To(Equal(5))
+		})
+
+		It("returns the error when the suppressions cannot be counted", func() {
+			suppressionsRepo.CountCall.Returns.Error = errors.New("BOOM!")
+
+			_, err := finder.List(database, 1, 5)
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+		})
+
+		It("returns the error when the suppressions cannot be listed", func() {
+			suppressionsRepo.ListCall.Returns.Error = errors.New("BOOM!")
+
+			_, err := finder.List(database, 1, 5)
+			Expect(err).To(MatchError(errors.New("BOOM!")))
+		})
+	})
+
+	Describe("Find", func() {
+		It("returns the suppression for the address", func() {
+			suppressionsRepo.FindCall.Returns.Suppression = models.Suppression{Email: "user@example.com", Reason: models.SuppressionReasonBounce}
+
+			suppression, err := finder.Find(database, "user@example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suppression).To(Equal(models.Suppression{Email: "user@example.com", Reason: models.SuppressionReasonBounce}))
+
+			Expect(suppressionsRepo.FindCall.Receives.Connection).To(Equal(conn))
+			Expect(suppressionsRepo.FindCall.Receives.Email).To(Equal("user@example.com"))
+		})
+
+		It("returns the error when the suppression cannot be found", func() {
+			suppressionsRepo.FindCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+			_, err := finder.Find(database, "user@example.com")
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+		})
+	})
+})
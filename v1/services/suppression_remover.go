@@ -0,0 +1,21 @@
+package services
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type suppressionsRepoDeleter interface {
+	Delete(models.ConnectionInterface, string) error
+}
+
+type SuppressionRemover struct {
+	repo suppressionsRepoDeleter
+}
+
+func NewSuppressionRemover(repo suppressionsRepoDeleter) SuppressionRemover {
+	return SuppressionRemover{
+		repo: repo,
+	}
+}
+
+func (remover SuppressionRemover) Remove(database DatabaseInterface, email string) error {
+	return remover.repo.Delete(database.Connection(), email)
+}
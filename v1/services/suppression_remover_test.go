@@ -0,0 +1,44 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SuppressionRemover.Remove", func() {
+	var (
+		remover          services.SuppressionRemover
+		suppressionsRepo *mocks.SuppressionsRepo
+		database         *mocks.Database
+		conn             *mocks.Connection
+	)
+
+	BeforeEach(func() {
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		suppressionsRepo = mocks.NewSuppressionsRepo()
+		remover = services.NewSuppressionRemover(suppressionsRepo)
+	})
+
+	It("lifts the suppression of the address", func() {
+		err := remover.Remove(database, "user@example.com")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(suppressionsRepo.DeleteCall.Receives.Connection).To(Equal(conn))
+		Expect(suppressionsRepo.DeleteCall.Receives.Email).To(Equal("user@example.com"))
+	})
+
+	It("returns the error when the suppression cannot be lifted", func() {
+		suppressionsRepo.DeleteCall.Returns.Error = errors.New("BOOM!")
+
+		err := remover.Remove(database, "user@example.com")
+		Expect(err).To(MatchError(errors.New("BOOM!")))
+	})
+})
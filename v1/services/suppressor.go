@@ -0,0 +1,25 @@
+package services
+
+import "github.com/cloudfoundry-incubator/notifications/v1/models"
+
+type suppressionsRepoUpserter interface {
+	Upsert(models.ConnectionInterface, models.Suppression) (models.Suppression, error)
+}
+
+type Suppressor struct {
+	repo suppressionsRepoUpserter
+}
+
+func NewSuppressor(repo suppressionsRepoUpserter) Suppressor {
+	return Suppressor{
+		repo: repo,
+	}
+}
+
+func (suppressor Suppressor) Suppress(database DatabaseInterface, email, reason, detail string) (models.Suppression, error) {
+	return suppressor.repo.Upsert(database.Connection(), models.Suppression{
+		Email:  email,
+		Reason: reason,
+		Detail: detail,
+	})
+}
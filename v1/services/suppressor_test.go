@@ -0,0 +1,56 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Suppressor.Suppress", func() {
+	var (
+		suppressor       services.Suppressor
+		suppressionsRepo *mocks.SuppressionsRepo
+		database         *mocks.Database
+		conn             *mocks.Connection
+	)
+
+	BeforeEach(func() {
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		suppressionsRepo = mocks.NewSuppressionsRepo()
+		suppressor = services.NewSuppressor(suppressionsRepo)
+	})
+
+	It("suppresses the address", func() {
+		suppressionsRepo.UpsertCall.Returns.Suppression = models.Suppression{
+			Primary: 3,
+			Email:   "user@example.com",
+			Reason:  models.SuppressionReasonComplaint,
+		}
+
+		suppression, err := suppressor.Suppress(database, "user@example.com", models.SuppressionReasonComplaint, "abuse report")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(suppression.Primary).To(Equal(3))
+
+		Expect(suppressionsRepo.UpsertCall.Receives.Connection).To(Equal(conn))
+		Expect(suppressionsRepo.UpsertCall.Receives.Suppression).To(Equal(models.Suppression{
+			Email:  "user@example.com",
+			Reason: models.SuppressionReasonComplaint,
+			Detail: "abuse report",
+		}))
+	})
+
+	It("returns the error when the address cannot be suppressed", func() {
+		suppressionsRepo.UpsertCall.Returns.Error = errors.New("BOOM!")
+
+		_, err := suppressor.Suppress(database, "user@example.com", models.SuppressionReasonBounce, "")
+		Expect(err).To(MatchError(errors.New("BOOM!")))
+	})
+})
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+type templateRenderPackager interface {
+	Render(context common.MessageContext) (mail.Message, error)
+}
+
+// TemplateRendering is what a template renders to for a notification: its
+// subject, its text and HTML parts, and the whole MIME message.
+type TemplateRendering struct {
+	Subject string
+	Text    string
+	HTML    string
+	MIME    string
+}
+
+type TemplateRenderer struct {
+	templatesRepo TemplatesRepo
+	packager      templateRenderPackager
+}
+
+func NewTemplateRenderer(templatesRepo TemplatesRepo, packager templateRenderPackager) TemplateRenderer {
+	return TemplateRenderer{
+		templatesRepo: templatesRepo,
+		packager:      packager,
+	}
+}
+
+// Render renders the stored template against the sample context.
+func (renderer TemplateRenderer) Render(database DatabaseInterface, templateID string, context common.MessageContext) (TemplateRendering, error) {
+	template, err := renderer.templatesRepo.FindByID(database.Connection(), templateID)
+	if err != nil {
+		return TemplateRendering{}, err
+	}
+
+	return renderer.RenderTemplate(template, context)
+}
+
+// RenderTemplate renders the given template, which need not be stored,
+// against the sample context.
+func (renderer TemplateRenderer) RenderTemplate(template models.Template, context common.MessageContext) (TemplateRendering, error) {
+	context.SubjectTemplate = template.Subject
+	context.TextTemplate = template.Text
+	context.HTMLTemplate = template.HTML
+
+	message, err := renderer.packager.Render(context)
+	if err != nil {
+		return TemplateRendering{}, err
+	}
+
+	rendering := TemplateRendering{
+		Subject: message.Subject,
+	}
+	for _, part := range message.Body {
+		switch part.ContentType {
+		case "text/plain":
+			rendering.Text = part.Content
+		case "text/html":
+			rendering.HTML = part.Content
+		}
+	}
+	rendering.MIME = message.Data()
+
+	return rendering, nil
+}
@@ -0,0 +1,131 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/mail"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TemplateRenderer", func() {
+	var (
+		conn          *mocks.Connection
+		database      *mocks.Database
+		templatesRepo *mocks.TemplatesRepo
+		packager      *mocks.Packager
+		renderer      services.TemplateRenderer
+		context       common.MessageContext
+	)
+
+	BeforeEach(func() {
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+		templatesRepo = mocks.NewTemplatesRepo()
+		templatesRepo.FindByIDCall.Returns.Template = models.Template{
+			ID:      "some-template-id",
+			Subject: "Subject: {{.Subject}}",
+			Text:    "Text: {{.Text}}",
+			HTML:    "<p>{{.HTML}}</p>",
+		}
+
+		packager = mocks.NewPackager()
+		packager.RenderCall.Returns.Message = mail.Message{
+			From:    "no-reply@example.com",
+			To:      "user@example.com",
+			Subject: "Subject: hello",
+			Body: []mail.Part{
+				{ContentType: "text/plain", Content: "Text: some text"},
+				{ContentType: "text/html", Content: "<html><p>some html</p></html>"},
+			},
+		}
+
+		context = common.MessageContext{
+			From:    "no-reply@example.com",
+			To:      "user@example.com",
+			Subject: "hello",
+			Text:    "some text",
+			HTML:    "some html",
+		}
+
+		renderer = services.NewTemplateRenderer(templatesRepo, packager)
+	})
+
+	Describe("Render", func() {
+		It("renders the stored template against the context", func() {
+			rendering, err := renderer.Render(database, "some-template-id", context)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(templatesRepo.FindByIDCall.Receives.Connection).To(Equal(conn))
+			Expect(templatesRepo.FindByIDCall.Receives.TemplateID).To(Equal("some-template-id"))
+
+			Expect(packager.RenderCall.Receives.MessageContext).To(Equal(common.MessageContext{
+				From:            "no-reply@example.com",
+				To:              "user@example.com",
+				Subject:         "hello",
+				Text:            "some text",
+				HTML:            "some html",
+				SubjectTemplate: "Subject: {{.Subject}}",
+				TextTemplate:    "Text: {{.Text}}",
+				HTMLTemplate:    "<p>{{.HTML}}</p>",
+			}))
+
+			Expect(rendering.Subject).To(Equal("Subject: hello"))
+			Expect(rendering.Text).To(Equal("Text: some text"))
+			Expect(rendering.HTML).To(Equal("<html><p>some html</p></html>"))
+			Expect(rendering.MIME).To(ContainSubstring("Subject: Subject: hello\n"))
+			Expect(rendering.MIME).To(ContainSubstring("To: user@example.com\n"))
+			Expect(rendering.MIME).To(ContainSubstring("Text: some text"))
+		})
+
+		It("returns an error when the template cannot be found", func() {
+			templatesRepo.FindByIDCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+			_, err := renderer.Render(database, "missing-template-id", context)
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+		})
+	})
+
+	Describe("RenderTemplate", func() {
+		It("renders the given template against the context", func() {
+			rendering, err := renderer.RenderTemplate(models.Template{
+				Subject: "Ad hoc: {{.Subject}}",
+				Text:    "{{.Text}}",
+			}, context)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(packager.RenderCall.Receives.MessageContext.SubjectTemplate).To(Equal("Ad hoc: {{.Subject}}"))
+			Expect(packager.RenderCall.Receives.MessageContext.TextTemplate).To(Equal("{{.Text}}"))
+			Expect(packager.RenderCall.Receives.MessageContext.HTMLTemplate).To(BeEmpty())
+			Expect(rendering.Subject).To(Equal("Subject: hello"))
+		})
+
+		It("leaves out the parts the message does not have", func() {
+			packager.RenderCall.Returns.Message.Body = []mail.Part{
+				{ContentType: "text/html", Content: "<html><p>some html</p></html>"},
+			}
+
+			rendering, err := renderer.RenderTemplate(models.Template{}, context)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rendering.Text).To(BeEmpty())
+			Expect(rendering.HTML).To(Equal("<html><p>some html</p></html>"))
+		})
+
+		It("propagates errors from rendering", func() {
+			packager.RenderCall.Returns.Error = common.TemplateErrors{
+				{Template: "text", Line: 1, Column: 3, Message: "function \"shout\" not defined"},
+			}
+
+			_, err := renderer.RenderTemplate(models.Template{}, context)
+			Expect(err).To(Equal(common.TemplateErrors{
+				{Template: "text", Line: 1, Column: 3, Message: "function \"shout\" not defined"},
+			}))
+		})
+	})
+})
@@ -3,19 +3,94 @@ package services
 import "github.com/cloudfoundry-incubator/notifications/v1/models"
 
 type TemplateUpdater struct {
-	templatesRepo TemplatesRepo
+	templatesRepo        TemplatesRepo
+	templateVersionsRepo TemplateVersionsRepo
 }
 
-func NewTemplateUpdater(templatesRepo TemplatesRepo) TemplateUpdater {
+func NewTemplateUpdater(templatesRepo TemplatesRepo, templateVersionsRepo TemplateVersionsRepo) TemplateUpdater {
 	return TemplateUpdater{
-		templatesRepo: templatesRepo,
+		templatesRepo:        templatesRepo,
+		templateVersionsRepo: templateVersionsRepo,
 	}
 }
 
-func (updater TemplateUpdater) Update(database DatabaseInterface, templateID string, template models.Template) error {
-	_, err := updater.templatesRepo.Update(database.Connection(), templateID, template)
+// Update stores the template and keeps its new content as a version made by
+// the client.
+func (updater TemplateUpdater) Update(database DatabaseInterface, templateID string, template models.Template, clientID string) error {
+	_, err := updater.update(database.Connection(), templateID, template, clientID)
+	return err
+}
+
+// Rollback sets the content of the template back to that of one of its
+// versions. The rollback is itself kept as a new version made by the client,
+// which is returned.
+func (updater TemplateUpdater) Rollback(database DatabaseInterface, templateID string, version int, clientID string) (models.TemplateVersion, error) {
+	conn := database.Connection()
+
+	templateVersion, err := updater.templateVersionsRepo.Find(conn, templateID, version)
+	if err != nil {
+		return models.TemplateVersion{}, err
+	}
+
+	return updater.update(conn, templateID, models.Template{
+		Name:          templateVersion.Name,
+		Subject:       templateVersion.Subject,
+		Text:          templateVersion.Text,
+		HTML:          templateVersion.HTML,
+		Metadata:      templateVersion.Metadata,
+		Localizations: templateVersion.Localizations,
+	}, clientID)
+}
+
+// update stores the template along with its new version. A template that has
+// no versions yet, as is the case for the default template and for templates
+// that predate versioning, first has its current content kept as a version,
+// so that it can be rolled back to. The template row stays locked until the
+// transaction ends, so that concurrent updates do not number their versions
+// the same.
+func (updater TemplateUpdater) update(conn ConnectionInterface, templateID string, template models.Template, clientID string) (models.TemplateVersion, error) {
+	transaction := conn.Transaction()
+	err := transaction.Begin()
+	if err != nil {
+		return models.TemplateVersion{}, err
+	}
+
+	existing, err := updater.templatesRepo.FindByIDForUpdate(transaction, templateID)
+	if err != nil {
+		transaction.Rollback()
+		return models.TemplateVersion{}, err
+	}
+
+	versions, err := updater.templateVersionsRepo.List(transaction, templateID)
 	if err != nil {
-		return err
+		transaction.Rollback()
+		return models.TemplateVersion{}, err
+	}
+
+	if len(versions) == 0 {
+		_, err = updater.templateVersionsRepo.Create(transaction, models.NewTemplateVersion(existing, ""))
+		if err != nil {
+			transaction.Rollback()
+			return models.TemplateVersion{}, err
+		}
 	}
-	return nil
+
+	updated, err := updater.templatesRepo.Update(transaction, templateID, template)
+	if err != nil {
+		transaction.Rollback()
+		return models.TemplateVersion{}, err
+	}
+
+	version, err := updater.templateVersionsRepo.Create(transaction, models.NewTemplateVersion(updated, clientID))
+	if err != nil {
+		transaction.Rollback()
+		return models.TemplateVersion{}, err
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		return models.TemplateVersion{}, err
+	}
+
+	return version, nil
 }
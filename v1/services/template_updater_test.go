@@ -12,45 +12,268 @@ import (
 )
 
 var _ = Describe("Updater", func() {
-	Describe("Update", func() {
-		var (
-			conn          *mocks.Connection
-			database      *mocks.Database
-			templatesRepo *mocks.TemplatesRepo
-			updater       services.TemplateUpdater
-		)
+	var (
+		conn          *mocks.Connection
+		transaction   *mocks.Transaction
+		database      *mocks.Database
+		templatesRepo *mocks.TemplatesRepo
+		versionsRepo  *mocks.TemplateVersionsRepo
+		updater       services.TemplateUpdater
+	)
 
-		BeforeEach(func() {
-			conn = mocks.NewConnection()
-			database = mocks.NewDatabase()
-			database.ConnectionCall.Returns.Connection = conn
-			templatesRepo = mocks.NewTemplatesRepo()
+	BeforeEach(func() {
+		transaction = mocks.NewTransaction()
+		conn = mocks.NewConnection()
+		conn.TransactionCall.Returns.Transaction = transaction
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+		templatesRepo = mocks.NewTemplatesRepo()
+		versionsRepo = mocks.NewTemplateVersionsRepo()
+		versionsRepo.ListCall.Returns.Versions = []models.TemplateVersion{
+			{TemplateID: "my-awesome-id", Version: 1},
+		}
 
-			updater = services.NewTemplateUpdater(templatesRepo)
-		})
+		updater = services.NewTemplateUpdater(templatesRepo, versionsRepo)
+	})
 
+	Describe("Update", func() {
 		It("Inserts templates into the templates repo", func() {
 			err := updater.Update(database, "my-awesome-id", models.Template{
 				Name: "gobble template",
 				Text: "gobble",
 				HTML: "<p>gobble</p>",
-			})
+			}, "some-client-id")
 			Expect(err).ToNot(HaveOccurred())
 
-			Expect(templatesRepo.UpdateCall.Receives.Connection).To(Equal(conn))
+			Expect(templatesRepo.UpdateCall.Receives.Connection).To(Equal(transaction))
 			Expect(templatesRepo.UpdateCall.Receives.TemplateID).To(Equal("my-awesome-id"))
 			Expect(templatesRepo.UpdateCall.Receives.Template).To(Equal(models.Template{
 				Name: "gobble template",
 				Text: "gobble",
 				HTML: "<p>gobble</p>",
 			}))
+
+			Expect(transaction.BeginCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeTrue())
+			Expect(transaction.RollbackCall.WasCalled).To(BeFalse())
+		})
+
+		It("records the updated template as a new version made by the client", func() {
+			templatesRepo.UpdateCall.Returns.Template = models.Template{
+				ID:       "my-awesome-id",
+				Name:     "gobble template",
+				Subject:  "gobble subject",
+				Text:     "gobble",
+				HTML:     "<p>gobble</p>",
+				Metadata: "{}",
+			}
+
+			err := updater.Update(database, "my-awesome-id", models.Template{}, "some-client-id")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(versionsRepo.ListCall.Receives.Connection).To(Equal(transaction))
+			Expect(versionsRepo.ListCall.Receives.TemplateID).To(Equal("my-awesome-id"))
+			Expect(versionsRepo.CreateCall.Receives.Connection).To(Equal(transaction))
+			Expect(versionsRepo.CreateCall.Receives.Versions).To(Equal([]models.TemplateVersion{
+				{
+					TemplateID: "my-awesome-id",
+					Name:       "gobble template",
+					Subject:    "gobble subject",
+					Text:       "gobble",
+					HTML:       "<p>gobble</p>",
+					Metadata:   "{}",
+					ClientID:   "some-client-id",
+				},
+			}))
+		})
+
+		Context("when the template has no versions yet", func() {
+			BeforeEach(func() {
+				versionsRepo.ListCall.Returns.Versions = []models.TemplateVersion{}
+				templatesRepo.FindByIDForUpdateCall.Returns.Template = models.Template{
+					ID:       "my-awesome-id",
+					Name:     "original template",
+					Subject:  "original subject",
+					Text:     "original",
+					HTML:     "<p>original</p>",
+					Metadata: "{}",
+				}
+				templatesRepo.UpdateCall.Returns.Template = models.Template{
+					ID:       "my-awesome-id",
+					Name:     "gobble template",
+					Subject:  "gobble subject",
+					Text:     "gobble",
+					HTML:     "<p>gobble</p>",
+					Metadata: "{}",
+				}
+			})
+
+			It("first records the current content of the template as a version", func() {
+				err := updater.Update(database, "my-awesome-id", models.Template{}, "some-client-id")
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(versionsRepo.CreateCall.Receives.Versions).To(Equal([]models.TemplateVersion{
+					{
+						TemplateID: "my-awesome-id",
+						Name:       "original template",
+						Subject:    "original subject",
+						Text:       "original",
+						HTML:       "<p>original</p>",
+						Metadata:   "{}",
+					},
+					{
+						TemplateID: "my-awesome-id",
+						Name:       "gobble template",
+						Subject:    "gobble subject",
+						Text:       "gobble",
+						HTML:       "<p>gobble</p>",
+						Metadata:   "{}",
+						ClientID:   "some-client-id",
+					},
+				}))
+			})
+
+		})
+
+		It("locks the template for the duration of the transaction", func() {
+			err := updater.Update(database, "my-awesome-id", models.Template{}, "some-client-id")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(templatesRepo.FindByIDForUpdateCall.Receives.Connection).To(Equal(transaction))
+			Expect(templatesRepo.FindByIDForUpdateCall.Receives.TemplateID).To(Equal("my-awesome-id"))
+		})
+
+		It("propagates errors from finding the template", func() {
+			templatesRepo.FindByIDForUpdateCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+			err := updater.Update(database, "my-awesome-id", models.Template{}, "some-client-id")
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+
+			Expect(versionsRepo.ListCall.Receives.TemplateID).To(BeEmpty())
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeFalse())
 		})
 
 		It("propagates errors from repo", func() {
 			templatesRepo.UpdateCall.Returns.Error = errors.New("Boom!")
 
-			err := updater.Update(database, "unimportant", models.Template{})
+			err := updater.Update(database, "unimportant", models.Template{}, "some-client-id")
+			Expect(err).To(MatchError(errors.New("Boom!")))
+
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+		})
+
+		It("propagates errors from listing the versions", func() {
+			versionsRepo.ListCall.Returns.Error = errors.New("Boom!")
+
+			err := updater.Update(database, "unimportant", models.Template{}, "some-client-id")
+			Expect(err).To(MatchError(errors.New("Boom!")))
+
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+		})
+
+		It("propagates errors from recording the version", func() {
+			versionsRepo.CreateCall.Returns.Error = errors.New("Boom!")
+
+			err := updater.Update(database, "unimportant", models.Template{}, "some-client-id")
 			Expect(err).To(MatchError(errors.New("Boom!")))
+
+			Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+			Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+		})
+
+		It("propagates errors from committing the transaction", func() {
+			transaction.CommitCall.Returns.Error = errors.New("commit failed")
+
+			err := updater.Update(database, "unimportant", models.Template{}, "some-client-id")
+			Expect(err).To(MatchError(errors.New("commit failed")))
+		})
+	})
+
+	Describe("Rollback", func() {
+		BeforeEach(func() {
+			versionsRepo.FindCall.Returns.Versions = map[int]models.TemplateVersion{
+				2: {
+					TemplateID:    "my-awesome-id",
+					Version:       2,
+					Name:          "old template",
+					Subject:       "old subject",
+					Text:          "old",
+					HTML:          "<p>old</p>",
+					Metadata:      `{"old":true}`,
+					Localizations: `{"fr":{"subject":"","text":"","html":"<p>vieux</p>"}}`,
+					ClientID:      "another-client-id",
+				},
+			}
+			templatesRepo.UpdateCall.Returns.Template = models.Template{
+				ID:       "my-awesome-id",
+				Name:     "old template",
+				Subject:  "old subject",
+				Text:     "old",
+				HTML:     "<p>old</p>",
+				Metadata: `{"old":true}`,
+			}
+			versionsRepo.CreateCall.Returns.Version = models.TemplateVersion{
+				TemplateID: "my-awesome-id",
+				Version:    5,
+				Name:       "old template",
+				ClientID:   "some-client-id",
+			}
+		})
+
+		It("sets the template back to the content of the version", func() {
+			_, err := updater.Rollback(database, "my-awesome-id", 2, "some-client-id")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(versionsRepo.FindCall.Receives.Connection).To(Equal(conn))
+			Expect(versionsRepo.FindCall.Receives.TemplateID).To(Equal("my-awesome-id"))
+			Expect(versionsRepo.FindCall.Receives.Versions).To(Equal([]int{2}))
+
+			Expect(templatesRepo.UpdateCall.Receives.Connection).To(Equal(transaction))
+			Expect(templatesRepo.UpdateCall.Receives.TemplateID).To(Equal("my-awesome-id"))
+			Expect(templatesRepo.UpdateCall.Receives.Template).To(Equal(models.Template{
+				Name:          "old template",
+				Subject:       "old subject",
+				Text:          "old",
+				HTML:          "<p>old</p>",
+				Metadata:      `{"old":true}`,
+				Localizations: `{"fr":{"subject":"","text":"","html":"<p>vieux</p>"}}`,
+			}))
+		})
+
+		It("records the rollback as a new version made by the client", func() {
+			version, err := updater.Rollback(database, "my-awesome-id", 2, "some-client-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(models.TemplateVersion{
+				TemplateID: "my-awesome-id",
+				Version:    5,
+				Name:       "old template",
+				ClientID:   "some-client-id",
+			}))
+
+			Expect(versionsRepo.CreateCall.Receives.Versions).To(Equal([]models.TemplateVersion{
+				{
+					TemplateID: "my-awesome-id",
+					Name:       "old template",
+					Subject:    "old subject",
+					Text:       "old",
+					HTML:       "<p>old</p>",
+					Metadata:   `{"old":true}`,
+					ClientID:   "some-client-id",
+				},
+			}))
+			Expect(transaction.CommitCall.WasCalled).To(BeTrue())
+		})
+
+		It("propagates errors from finding the version", func() {
+			versionsRepo.FindCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+			_, err := updater.Rollback(database, "my-awesome-id", 7, "some-client-id")
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+
+			Expect(templatesRepo.UpdateCall.Receives.TemplateID).To(BeEmpty())
+			Expect(transaction.BeginCall.WasCalled).To(BeFalse())
 		})
 	})
 })
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+)
+
+// TemplateDiff is the line by line difference between two versions of a
+// template, for each of the fields that differ. Lines are prefixed with a
+// space when they are in both versions, with "-" when they are only in the
+// version diffed from and with "+" when they are only in the other.
+type TemplateDiff struct {
+	From   int
+	To     int
+	Fields map[string][]string
+}
+
+type TemplateVersionsFinder struct {
+	templatesRepo        TemplatesRepo
+	templateVersionsRepo TemplateVersionsRepo
+}
+
+func NewTemplateVersionsFinder(templatesRepo TemplatesRepo, templateVersionsRepo TemplateVersionsRepo) TemplateVersionsFinder {
+	return TemplateVersionsFinder{
+		templatesRepo:        templatesRepo,
+		templateVersionsRepo: templateVersionsRepo,
+	}
+}
+
+// List returns the versions of the template, latest first.
+func (finder TemplateVersionsFinder) List(database DatabaseInterface, templateID string) ([]models.TemplateVersion, error) {
+	conn := database.Connection()
+
+	_, err := finder.templatesRepo.FindByID(conn, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	return finder.templateVersionsRepo.List(conn, templateID)
+}
+
+func (finder TemplateVersionsFinder) Find(database DatabaseInterface, templateID string, version int) (models.TemplateVersion, error) {
+	return finder.templateVersionsRepo.Find(database.Connection(), templateID, version)
+}
+
+// Diff returns the difference between a version of the template and an
+// earlier one. When from is 0, the version is diffed from the one before it,
+// and the first version from an empty template.
+func (finder TemplateVersionsFinder) Diff(database DatabaseInterface, templateID string, version, from int) (TemplateDiff, error) {
+	conn := database.Connection()
+
+	to, err := finder.templateVersionsRepo.Find(conn, templateID, version)
+	if err != nil {
+		return TemplateDiff{}, err
+	}
+
+	if from == 0 {
+		from = version - 1
+	}
+
+	var previous models.TemplateVersion
+	if from > 0 {
+		previous, err = finder.templateVersionsRepo.Find(conn, templateID, from)
+		if err != nil {
+			return TemplateDiff{}, err
+		}
+	}
+
+	diff := TemplateDiff{
+		From:   from,
+		To:     version,
+		Fields: map[string][]string{},
+	}
+
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"name", previous.Name, to.Name},
+		{"subject", previous.Subject, to.Subject},
+		{"text", previous.Text, to.Text},
+		{"html", previous.HTML, to.HTML},
+		{"metadata", previous.Metadata, to.Metadata},
+		{"localizations", previous.Localizations, to.Localizations},
+	}
+	for _, field := range fields {
+		if field.from != field.to {
+			diff.Fields[field.name] = diffLines(field.from, field.to)
+		}
+	}
+
+	return diff, nil
+}
+
+// diffLines compares the lines of the two texts through their longest
+// common subsequence.
+func diffLines(from, to string) []string {
+	a := splitLines(from)
+	b := splitLines(to)
+
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				common[i][j] = common[i+1][j+1] + 1
+			case common[i+1][j] >= common[i][j+1]:
+				common[i][j] = common[i+1][j]
+			default:
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	lines := []string{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, " "+a[i])
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			lines = append(lines, "-"+a[i])
+			i++
+		default:
+			lines = append(lines, "+"+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, "-"+a[i])
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, "+"+b[j])
+	}
+
+	return lines
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(text, "\n")
+}
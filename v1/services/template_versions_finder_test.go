@@ -0,0 +1,189 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TemplateVersionsFinder", func() {
+	var (
+		conn          *mocks.Connection
+		database      *mocks.Database
+		templatesRepo *mocks.TemplatesRepo
+		versionsRepo  *mocks.TemplateVersionsRepo
+		finder        services.TemplateVersionsFinder
+	)
+
+	BeforeEach(func() {
+		conn = mocks.NewConnection()
+		database = mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+		templatesRepo = mocks.NewTemplatesRepo()
+		versionsRepo = mocks.NewTemplateVersionsRepo()
+
+		finder = services.NewTemplateVersionsFinder(templatesRepo, versionsRepo)
+	})
+
+	Describe("List", func() {
+		It("returns the versions of the template", func() {
+			versionsRepo.ListCall.Returns.Versions = []models.TemplateVersion{
+				{TemplateID: "some-template-id", Version: 2},
+				{TemplateID: "some-template-id", Version: 1},
+			}
+
+			versions, err := finder.List(database, "some-template-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(Equal([]models.TemplateVersion{
+				{TemplateID: "some-template-id", Version: 2},
+				{TemplateID: "some-template-id", Version: 1},
+			}))
+
+			Expect(templatesRepo.FindByIDCall.Receives.Connection).To(Equal(conn))
+			Expect(templatesRepo.FindByIDCall.Receives.TemplateID).To(Equal("some-template-id"))
+			Expect(versionsRepo.ListCall.Receives.Connection).To(Equal(conn))
+			Expect(versionsRepo.ListCall.Receives.TemplateID).To(Equal("some-template-id"))
+		})
+
+		It("returns an error when the template does not exist", func() {
+			templatesRepo.FindByIDCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+			_, err := finder.List(database, "missing-template-id")
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+		})
+
+		It("propagates errors from the versions repo", func() {
+			versionsRepo.ListCall.Returns.Error = errors.New("Boom!")
+
+			_, err := finder.List(database, "some-template-id")
+			Expect(err).To(MatchError(errors.New("Boom!")))
+		})
+	})
+
+	Describe("Find", func() {
+		It("returns the version of the template", func() {
+			versionsRepo.FindCall.Returns.Versions = map[int]models.TemplateVersion{
+				3: {TemplateID: "some-template-id", Version: 3, Name: "some-name"},
+			}
+
+			version, err := finder.Find(database, "some-template-id", 3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(models.TemplateVersion{TemplateID: "some-template-id", Version: 3, Name: "some-name"}))
+
+			Expect(versionsRepo.FindCall.Receives.Connection).To(Equal(conn))
+			Expect(versionsRepo.FindCall.Receives.TemplateID).To(Equal("some-template-id"))
+			Expect(versionsRepo.FindCall.Receives.Versions).To(Equal([]int{3}))
+		})
+
+		It("propagates errors from the versions repo", func() {
+			versionsRepo.FindCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+			_, err := finder.Find(database, "some-template-id", 3)
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+		})
+	})
+
+	Describe("Diff", func() {
+		BeforeEach(func() {
+			versionsRepo.FindCall.Returns.Versions = map[int]models.TemplateVersion{
+				1: {
+					Version:       1,
+					Name:          "some-name",
+					Subject:       "some-subject",
+					Text:          "first line\nsecond line\nthird line",
+					HTML:          "<p>hello</p>",
+					Metadata:      "{}",
+					Localizations: "{}",
+				},
+				2: {
+					Version:       2,
+					Name:          "some-name",
+					Subject:       "another-subject",
+					Text:          "first line\nnew second line\nthird line\nfourth line",
+					HTML:          "<p>hello</p>",
+					Metadata:      "{}",
+					Localizations: "{}",
+				},
+				3: {
+					Version:       3,
+					Name:          "some-name",
+					Subject:       "some-subject",
+					Text:          "first line\nsecond line\nthird line",
+					HTML:          "<p>hello</p>",
+					Metadata:      `{"key":"value"}`,
+					Localizations: `{"fr":{"subject":"","text":"","html":"<p>bonjour</p>"}}`,
+				},
+			}
+		})
+
+		It("diffs the version from the one before it", func() {
+			diff, err := finder.Diff(database, "some-template-id", 2, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff).To(Equal(services.TemplateDiff{
+				From: 1,
+				To:   2,
+				Fields: map[string][]string{
+					"subject": {"-some-subject", "+another-subject"},
+					"text": {
+						" first line",
+						"-second line",
+						"+new second line",
+						" third line",
+						"+fourth line",
+					},
+				},
+			}))
+
+			Expect(versionsRepo.FindCall.Receives.Connection).To(Equal(conn))
+			Expect(versionsRepo.FindCall.Receives.TemplateID).To(Equal("some-template-id"))
+			Expect(versionsRepo.FindCall.Receives.Versions).To(Equal([]int{2, 1}))
+		})
+
+		It("diffs the version from the given version", func() {
+			diff, err := finder.Diff(database, "some-template-id", 3, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff).To(Equal(services.TemplateDiff{
+				From: 1,
+				To:   3,
+				Fields: map[string][]string{
+					"metadata": {"-{}", `+{"key":"value"}`},
+					"localizations": {
+						"-{}",
+						`+{"fr":{"subject":"","text":"","html":"<p>bonjour</p>"}}`,
+					},
+				},
+			}))
+		})
+
+		It("diffs the first version from an empty template", func() {
+			diff, err := finder.Diff(database, "some-template-id", 1, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff).To(Equal(services.TemplateDiff{
+				From: 0,
+				To:   1,
+				Fields: map[string][]string{
+					"name":          {"+some-name"},
+					"subject":       {"+some-subject"},
+					"text":          {"+first line", "+second line", "+third line"},
+					"html":          {"+<p>hello</p>"},
+					"metadata":      {"+{}"},
+					"localizations": {"+{}"},
+				},
+			}))
+
+			Expect(versionsRepo.FindCall.Receives.Versions).To(Equal([]int{1}))
+		})
+
+		It("propagates errors from the versions repo", func() {
+			versionsRepo.FindCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+			_, err := finder.Diff(database, "some-template-id", 9, 0)
+			Expect(err).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+		})
+	})
+})
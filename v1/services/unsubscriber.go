@@ -0,0 +1,61 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/pivotal-golang/conceal"
+)
+
+type kindsPreferenceUpdater interface {
+	Validate(conn ConnectionInterface, preferences []models.Preference) error
+	UpdateKinds(conn ConnectionInterface, userID string, preferences []models.Preference, actor Actor) error
+}
+
+type Unsubscriber struct {
+	cloak   conceal.CloakInterface
+	updater kindsPreferenceUpdater
+}
+
+func NewUnsubscriber(cloak conceal.CloakInterface, updater kindsPreferenceUpdater) Unsubscriber {
+	return Unsubscriber{
+		cloak:   cloak,
+		updater: updater,
+	}
+}
+
+// Unsubscribe unsubscribes the user from the kind of notification the token
+// of an unsubscribe link was made for. The token is the unsubscribe ID of the
+// notification, which veils "user|client|kind". Critical kinds cannot be
+// unsubscribed from.
+func (unsubscriber Unsubscriber) Unsubscribe(conn ConnectionInterface, token string) error {
+	plaintext, err := unsubscriber.cloak.Unveil([]byte(token))
+	if err != nil {
+		return InvalidUnsubscribeTokenError{errors.New("The unsubscribe token is not valid")}
+	}
+
+	parts := strings.Split(string(plaintext), "|")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return InvalidUnsubscribeTokenError{errors.New("The unsubscribe token is not valid")}
+	}
+
+	userID := parts[0]
+	preferences := []models.Preference{
+		{
+			ClientID: parts[1],
+			KindID:   parts[2],
+			Email:    false,
+		},
+	}
+
+	err = unsubscriber.updater.Validate(conn, preferences)
+	if err != nil {
+		return err
+	}
+
+	return unsubscriber.updater.UpdateKinds(conn, userID, preferences, Actor{
+		ID:     userID,
+		Source: models.PreferenceChangeSourceUnsubscribeLink,
+	})
+}
@@ -0,0 +1,88 @@
+package services_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Unsubscriber", func() {
+	var (
+		unsubscriber services.Unsubscriber
+		cloak        *mocks.Cloak
+		updater      *mocks.PreferenceUpdater
+		conn         *mocks.Connection
+	)
+
+	BeforeEach(func() {
+		conn = mocks.NewConnection()
+		cloak = mocks.NewCloak()
+		cloak.UnveilCall.Returns.PlainText = []byte("some-user|some-client|some-kind")
+		updater = mocks.NewPreferenceUpdater()
+
+		unsubscriber = services.NewUnsubscriber(cloak, updater)
+	})
+
+	It("unsubscribes the user from the kind the token was made for", func() {
+		err := unsubscriber.Unsubscribe(conn, "some-token")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(cloak.UnveilCall.Receives.CipherText).To(Equal([]byte("some-token")))
+
+		preferences := []models.Preference{
+			{
+				ClientID: "some-client",
+				KindID:   "some-kind",
+				Email:    false,
+			},
+		}
+		Expect(updater.ValidateCall.Receives.Connection).To(Equal(conn))
+		Expect(updater.ValidateCall.Receives.Preferences).To(Equal(preferences))
+
+		Expect(updater.UpdateKindsCall.Receives.Connection).To(Equal(conn))
+		Expect(updater.UpdateKindsCall.Receives.UserIDs).To(Equal([]string{"some-user"}))
+		Expect(updater.UpdateKindsCall.Receives.Preferences).To(Equal(preferences))
+		Expect(updater.UpdateKindsCall.Receives.Actor).To(Equal(services.Actor{
+			ID:     "some-user",
+			Source: models.PreferenceChangeSourceUnsubscribeLink,
+		}))
+	})
+
+	It("refuses a token that cannot be decrypted", func() {
+		cloak.UnveilCall.Returns.Error = errors.New("Data length should be at least 16 bytes")
+
+		err := unsubscriber.Unsubscribe(conn, "some-token")
+		Expect(err).To(BeAssignableToTypeOf(services.InvalidUnsubscribeTokenError{}))
+		Expect(updater.UpdateKindsCall.Receives.UserIDs).To(BeEmpty())
+	})
+
+	It("refuses a token that was not made for a user and a kind", func() {
+		cloak.UnveilCall.Returns.PlainText = []byte("|some-client|")
+
+		err := unsubscriber.Unsubscribe(conn, "some-token")
+		Expect(err).To(BeAssignableToTypeOf(services.InvalidUnsubscribeTokenError{}))
+		Expect(updater.UpdateKindsCall.Receives.UserIDs).To(BeEmpty())
+	})
+
+	It("refuses to unsubscribe from a critical kind", func() {
+		updater.ValidateCall.Returns.Error = services.CriticalKindError{Err: errors.New("critical")}
+
+		err := unsubscriber.Unsubscribe(conn, "some-token")
+		Expect(err).To(MatchError(services.CriticalKindError{Err: errors.New("critical")}))
+		Expect(updater.UpdateKindsCall.Receives.UserIDs).To(BeEmpty())
+	})
+
+	It("returns the error when the preference cannot be stored", func() {
+		updater.UpdateKindsCall.Returns.Errors = map[string]error{
+			"some-user": errors.New("database is down"),
+		}
+
+		err := unsubscriber.Unsubscribe(conn, "some-token")
+		Expect(err).To(MatchError(errors.New("database is down")))
+	})
+})
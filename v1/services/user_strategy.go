@@ -25,6 +25,10 @@ func (strategy UserStrategy) Dispatch(dispatch Dispatch) ([]Response, error) {
 		SourceDescription: dispatch.Client.Description,
 		Text:              dispatch.Message.Text,
 		TemplateID:        dispatch.TemplateID,
+		Locale:            dispatch.Message.Locale,
+		SendAt:            dispatch.Message.SendAt,
+		Priority:          dispatch.Message.Priority,
+		Critical:          dispatch.Kind.Critical,
 		HTML: HTML{
 			BodyContent:    dispatch.Message.HTML.BodyContent,
 			BodyAttributes: dispatch.Message.HTML.BodyAttributes,
@@ -33,7 +37,12 @@ func (strategy UserStrategy) Dispatch(dispatch Dispatch) ([]Response, error) {
 		},
 	}
 
-	users := []User{{GUID: dispatch.GUID}}
+	// A batch of users is enqueued as a whole, so that every recipient is
+	// enqueued in the same transaction.
+	users := dispatch.Users
+	if len(users) == 0 {
+		users = []User{{GUID: dispatch.GUID}}
+	}
 
 	return strategy.enqueuer.Enqueue(
 		dispatch.Connection,
@@ -28,6 +28,22 @@ var _ = Describe("UserStrategy", func() {
 	})
 
 	Describe("Dispatch", func() {
+		It("passes the send time along to the enqueuer", func() {
+			sendAt := time.Date(2015, 6, 9, 9, 0, 0, 0, time.UTC)
+
+			_, err := strategy.Dispatch(services.Dispatch{
+				GUID:       "user-123",
+				Connection: conn,
+				Message: services.DispatchMessage{
+					Text:   "The maintenance window starts now",
+					SendAt: sendAt,
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(enqueuer.EnqueueCall.Receives.Options.SendAt).To(Equal(sendAt))
+		})
+
 		It("calls enqueuer.Enqueue with the correct arguments for a user", func() {
 			_, err := strategy.Dispatch(services.Dispatch{
 				GUID:       "user-123",
@@ -37,6 +53,7 @@ var _ = Describe("UserStrategy", func() {
 					ReplyTo: "reply-to@example.com",
 					Subject: "this is the subject",
 					Text:    "Please make sure to leave your bottle in a place that is safe and dry",
+					Locale:  "pt-BR",
 					HTML: services.HTML{
 						BodyContent:    "<p>The water bottle needs to be safe and dry</p>",
 						BodyAttributes: "some-html-body-attributes",
@@ -74,6 +91,7 @@ var _ = Describe("UserStrategy", func() {
 				SourceDescription: "The Water Bottle System",
 				Text:              "Please make sure to leave your bottle in a place that is safe and dry",
 				TemplateID:        "some-template-id",
+				Locale:            "pt-BR",
 				HTML: services.HTML{
 					BodyContent:    "<p>The water bottle needs to be safe and dry</p>",
 					BodyAttributes: "some-html-body-attributes",
@@ -90,5 +108,23 @@ var _ = Describe("UserStrategy", func() {
 			Expect(enqueuer.EnqueueCall.Receives.VCAPRequestID).To(Equal("some-vcap-request-id"))
 			Expect(enqueuer.EnqueueCall.Receives.RequestReceived).To(Equal(requestReceived))
 		})
+
+		It("enqueues the users of a batch together instead of the single user", func() {
+			users := []services.User{
+				{GUID: "user-123"},
+				{Email: "someone@example.com"},
+			}
+
+			_, err := strategy.Dispatch(services.Dispatch{
+				Users:      users,
+				Connection: conn,
+				Message: services.DispatchMessage{
+					Text: "The maintenance window starts now",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(enqueuer.EnqueueCall.Receives.Users).To(Equal(users))
+		})
 	})
 })
@@ -0,0 +1,17 @@
+package services
+
+// UsersStrategy sends a notification to each user of a batch in the same way
+// UserStrategy sends it to a single user.
+type UsersStrategy struct {
+	userStrategy UserStrategy
+}
+
+func NewUsersStrategy(enqueuer enqueuer) UsersStrategy {
+	return UsersStrategy{
+		userStrategy: NewUserStrategy(enqueuer),
+	}
+}
+
+func (strategy UsersStrategy) Dispatch(dispatch Dispatch) ([]Response, error) {
+	return strategy.userStrategy.Dispatch(dispatch)
+}
@@ -0,0 +1,41 @@
+package deadjobs
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/ryanmoran/stack"
+)
+
+type deadJobPurger interface {
+	Purge(services.DatabaseInterface, int) error
+	PurgeAll(services.DatabaseInterface) (int, error)
+}
+
+type DeleteHandler struct {
+	purger      deadJobPurger
+	errorWriter errorWriter
+}
+
+func NewDeleteHandler(purger deadJobPurger, errWriter errorWriter) DeleteHandler {
+	return DeleteHandler{
+		purger:      purger,
+		errorWriter: errWriter,
+	}
+}
+
+func (h DeleteHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	id, err := parseDeadJobID(req.URL.Path)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	err = h.purger.Purge(context.Get("database").(DatabaseInterface), id)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
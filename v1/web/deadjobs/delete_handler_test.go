@@ -0,0 +1,71 @@
+package deadjobs_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/deadjobs"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeleteHandler", func() {
+	var (
+		handler       deadjobs.DeleteHandler
+		errorWriter   *mocks.ErrorWriter
+		writer        *httptest.ResponseRecorder
+		deadJobPurger *mocks.DeadJobPurger
+		database      *mocks.Database
+		context       stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		deadJobPurger = mocks.NewDeadJobPurger()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = deadjobs.NewDeleteHandler(deadJobPurger, errorWriter)
+	})
+
+	It("deletes the dead job", func() {
+		request, err := http.NewRequest("DELETE", "/dead_jobs/7", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+		Expect(writer.Body.String()).To(BeEmpty())
+
+		Expect(deadJobPurger.PurgeCall.Receives.Database).To(Equal(database))
+		Expect(deadJobPurger.PurgeCall.Receives.ID).To(Equal(7))
+	})
+
+	It("rejects an ID that is not an integer", func() {
+		request, err := http.NewRequest("DELETE", "/dead_jobs/banana", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New(`Dead job ID "banana" must be an integer`)}))
+	})
+
+	It("delegates errors to the error writer", func() {
+		deadJobPurger.PurgeCall.Returns.Error = errors.New("BOOM!")
+
+		request, err := http.NewRequest("DELETE", "/dead_jobs/7", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Writer).To(Equal(writer))
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
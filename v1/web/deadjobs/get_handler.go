@@ -0,0 +1,97 @@
+package deadjobs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+type errorWriter interface {
+	Write(writer http.ResponseWriter, err error)
+}
+
+type deadJobFinder interface {
+	List(services.DatabaseInterface, int, int) (services.DeadJobList, error)
+	Find(services.DatabaseInterface, int) (gobble.DeadJob, error)
+}
+
+type GetHandler struct {
+	finder      deadJobFinder
+	errorWriter errorWriter
+}
+
+func NewGetHandler(finder deadJobFinder, errWriter errorWriter) GetHandler {
+	return GetHandler{
+		finder:      finder,
+		errorWriter: errWriter,
+	}
+}
+
+func (h GetHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	id, err := parseDeadJobID(req.URL.Path)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	deadJob, err := h.finder.Find(context.Get("database").(DatabaseInterface), id)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	document := newDeadJobDocument(deadJob)
+	document.Payload = deadJob.Payload
+
+	writeJSON(w, http.StatusOK, document)
+}
+
+type deadJobDocument struct {
+	ID         int    `json:"id"`
+	JobID      int    `json:"job_id"`
+	RetryCount int    `json:"retry_count"`
+	LastError  string `json:"last_error"`
+	ActiveAt   string `json:"active_at"`
+	FailedAt   string `json:"failed_at"`
+	Payload    string `json:"payload,omitempty"`
+}
+
+func newDeadJobDocument(deadJob gobble.DeadJob) deadJobDocument {
+	return deadJobDocument{
+		ID:         deadJob.ID,
+		JobID:      deadJob.JobID,
+		RetryCount: deadJob.RetryCount,
+		LastError:  deadJob.LastError,
+		ActiveAt:   deadJob.ActiveAt.UTC().Format(time.RFC3339),
+		FailedAt:   deadJob.FailedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func parseDeadJobID(path string) (int, error) {
+	segment := strings.Split(strings.Split(path, "/dead_jobs/")[1], "/")[0]
+
+	id, err := strconv.Atoi(segment)
+	if err != nil {
+		return 0, webutil.ValidationError{Err: fmt.Errorf("Dead job ID %q must be an integer", segment)}
+	}
+
+	return id, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, object interface{}) {
+	output, err := json.Marshal(object)
+	if err != nil {
+		panic(err) // No JSON we write into a response should ever panic
+	}
+
+	w.WriteHeader(status)
+	w.Write(output)
+}
@@ -0,0 +1,92 @@
+package deadjobs_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/deadjobs"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetHandler", func() {
+	var (
+		handler       deadjobs.GetHandler
+		errorWriter   *mocks.ErrorWriter
+		writer        *httptest.ResponseRecorder
+		deadJobFinder *mocks.DeadJobFinder
+		database      *mocks.Database
+		context       stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		deadJobFinder = mocks.NewDeadJobFinder()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = deadjobs.NewGetHandler(deadJobFinder, errorWriter)
+	})
+
+	It("returns the dead job including its payload", func() {
+		deadJobFinder.FindCall.Returns.DeadJob = gobble.DeadJob{
+			ID:         7,
+			JobID:      42,
+			Payload:    `{"some":"payload"}`,
+			RetryCount: 10,
+			LastError:  "server timeout",
+			ActiveAt:   time.Date(2015, 6, 8, 13, 0, 0, 0, time.UTC),
+			FailedAt:   time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+		}
+
+		request, err := http.NewRequest("GET", "/dead_jobs/7", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"id": 7,
+			"job_id": 42,
+			"retry_count": 10,
+			"last_error": "server timeout",
+			"active_at": "2015-06-08T13:00:00Z",
+			"failed_at": "2015-06-08T14:00:00Z",
+			"payload": "{\"some\":\"payload\"}"
+		}`))
+
+		Expect(deadJobFinder.FindCall.Receives.Database).To(Equal(database))
+		Expect(deadJobFinder.FindCall.Receives.ID).To(Equal(7))
+	})
+
+	It("rejects an ID that is not an integer", func() {
+		request, err := http.NewRequest("GET", "/dead_jobs/banana", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New(`Dead job ID "banana" must be an integer`)}))
+		Expect(deadJobFinder.FindCall.Receives.ID).To(Equal(0))
+	})
+
+	It("delegates errors to the error writer", func() {
+		deadJobFinder.FindCall.Returns.Error = errors.New("BOOM!")
+
+		request, err := http.NewRequest("GET", "/dead_jobs/7", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Writer).To(Equal(writer))
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
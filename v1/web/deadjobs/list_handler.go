@@ -0,0 +1,56 @@
+package deadjobs
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+const (
+	DefaultDeadJobsPerPage = 50
+	MaxDeadJobsPerPage     = 500
+)
+
+type ListHandler struct {
+	finder      deadJobFinder
+	errorWriter errorWriter
+}
+
+func NewListHandler(finder deadJobFinder, errWriter errorWriter) ListHandler {
+	return ListHandler{
+		finder:      finder,
+		errorWriter: errWriter,
+	}
+}
+
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	page, perPage, err := webutil.ParsePagination(req, DefaultDeadJobsPerPage, MaxDeadJobsPerPage)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	list, err := h.finder.List(context.Get("database").(DatabaseInterface), page, perPage)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	var document struct {
+		Total    int               `json:"total"`
+		Page     int               `json:"page"`
+		PerPage  int               `json:"per_page"`
+		DeadJobs []deadJobDocument `json:"dead_jobs"`
+	}
+	document.Total = list.Total
+	document.Page = page
+	document.PerPage = perPage
+	document.DeadJobs = []deadJobDocument{}
+
+	for _, deadJob := range list.DeadJobs {
+		document.DeadJobs = append(document.DeadJobs, newDeadJobDocument(deadJob))
+	}
+
+	writeJSON(w, http.StatusOK, document)
+}
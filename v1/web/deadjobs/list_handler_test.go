@@ -0,0 +1,128 @@
+package deadjobs_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/deadjobs"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ListHandler", func() {
+	var (
+		handler       deadjobs.ListHandler
+		errorWriter   *mocks.ErrorWriter
+		writer        *httptest.ResponseRecorder
+		deadJobFinder *mocks.DeadJobFinder
+		database      *mocks.Database
+		context       stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		deadJobFinder = mocks.NewDeadJobFinder()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = deadjobs.NewListHandler(deadJobFinder, errorWriter)
+	})
+
+	It("returns the page of dead jobs without their payloads", func() {
+		deadJobFinder.ListCall.Returns.DeadJobList = services.DeadJobList{
+			Total: 11,
+			DeadJobs: []gobble.DeadJob{
+				{
+					ID:         7,
+					JobID:      42,
+					Payload:    `{"some":"payload"}`,
+					RetryCount: 10,
+					LastError:  "server timeout",
+					ActiveAt:   time.Date(2015, 6, 8, 13, 0, 0, 0, time.UTC),
+					FailedAt:   time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+				},
+			},
+		}
+
+		request, err := http.NewRequest("GET", "/dead_jobs?page=2&per_page=10", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"total": 11,
+			"page": 2,
+			"per_page": 10,
+			"dead_jobs": [
+				{
+					"id": 7,
+					"job_id": 42,
+					"retry_count": 10,
+					"last_error": "server timeout",
+					"active_at": "2015-06-08T13:00:00Z",
+					"failed_at": "2015-06-08T14:00:00Z"
+				}
+			]
+		}`))
+
+		Expect(deadJobFinder.ListCall.Receives.Database).To(Equal(database))
+		Expect(deadJobFinder.ListCall.Receives.Page).To(Equal(2))
+		Expect(deadJobFinder.ListCall.Receives.PerPage).To(Equal(10))
+	})
+
+	It("defaults the page and page size", func() {
+		request, err := http.NewRequest("GET", "/dead_jobs", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"total": 0,
+			"page": 1,
+			"per_page": 50,
+			"dead_jobs": []
+		}`))
+	})
+
+	It("rejects an invalid page", func() {
+		request, err := http.NewRequest("GET", "/dead_jobs?page=0", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New(`"page" must be a positive integer`)}))
+	})
+
+	It("rejects a page size that is too large", func() {
+		request, err := http.NewRequest("GET", "/dead_jobs?per_page=501", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New(`"per_page" must be an integer between 1 and 500`)}))
+	})
+
+	It("delegates errors to the error writer", func() {
+		deadJobFinder.ListCall.Returns.Error = errors.New("BOOM!")
+
+		request, err := http.NewRequest("GET", "/dead_jobs", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Writer).To(Equal(writer))
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
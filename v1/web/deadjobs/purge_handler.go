@@ -0,0 +1,31 @@
+package deadjobs
+
+import (
+	"net/http"
+
+	"github.com/ryanmoran/stack"
+)
+
+type PurgeHandler struct {
+	purger      deadJobPurger
+	errorWriter errorWriter
+}
+
+func NewPurgeHandler(purger deadJobPurger, errWriter errorWriter) PurgeHandler {
+	return PurgeHandler{
+		purger:      purger,
+		errorWriter: errWriter,
+	}
+}
+
+func (h PurgeHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	count, err := h.purger.PurgeAll(context.Get("database").(DatabaseInterface))
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{
+		"purged": count,
+	})
+}
@@ -0,0 +1,60 @@
+package deadjobs_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/deadjobs"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PurgeHandler", func() {
+	var (
+		handler       deadjobs.PurgeHandler
+		errorWriter   *mocks.ErrorWriter
+		writer        *httptest.ResponseRecorder
+		request       *http.Request
+		deadJobPurger *mocks.DeadJobPurger
+		database      *mocks.Database
+		context       stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		deadJobPurger = mocks.NewDeadJobPurger()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		var err error
+		request, err = http.NewRequest("DELETE", "/dead_jobs", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = deadjobs.NewPurgeHandler(deadJobPurger, errorWriter)
+	})
+
+	It("deletes every dead job and reports how many were removed", func() {
+		deadJobPurger.PurgeAllCall.Returns.Count = 4
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{"purged": 4}`))
+		Expect(deadJobPurger.PurgeAllCall.Receives.Database).To(Equal(database))
+	})
+
+	It("delegates errors to the error writer", func() {
+		deadJobPurger.PurgeAllCall.Returns.Error = errors.New("BOOM!")
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Writer).To(Equal(writer))
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
@@ -0,0 +1,42 @@
+package deadjobs
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/ryanmoran/stack"
+)
+
+type deadJobReplayer interface {
+	Replay(services.DatabaseInterface, int) (int, error)
+}
+
+type ReplayHandler struct {
+	replayer    deadJobReplayer
+	errorWriter errorWriter
+}
+
+func NewReplayHandler(replayer deadJobReplayer, errWriter errorWriter) ReplayHandler {
+	return ReplayHandler{
+		replayer:    replayer,
+		errorWriter: errWriter,
+	}
+}
+
+func (h ReplayHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	id, err := parseDeadJobID(req.URL.Path)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	jobID, err := h.replayer.Replay(context.Get("database").(DatabaseInterface), id)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{
+		"job_id": jobID,
+	})
+}
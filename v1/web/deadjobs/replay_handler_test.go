@@ -0,0 +1,73 @@
+package deadjobs_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/deadjobs"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReplayHandler", func() {
+	var (
+		handler         deadjobs.ReplayHandler
+		errorWriter     *mocks.ErrorWriter
+		writer          *httptest.ResponseRecorder
+		deadJobReplayer *mocks.DeadJobReplayer
+		database        *mocks.Database
+		context         stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		deadJobReplayer = mocks.NewDeadJobReplayer()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = deadjobs.NewReplayHandler(deadJobReplayer, errorWriter)
+	})
+
+	It("replays the dead job and returns the new job ID", func() {
+		deadJobReplayer.ReplayCall.Returns.JobID = 99
+
+		request, err := http.NewRequest("POST", "/dead_jobs/7/replay", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{"job_id": 99}`))
+
+		Expect(deadJobReplayer.ReplayCall.Receives.Database).To(Equal(database))
+		Expect(deadJobReplayer.ReplayCall.Receives.ID).To(Equal(7))
+	})
+
+	It("rejects an ID that is not an integer", func() {
+		request, err := http.NewRequest("POST", "/dead_jobs/banana/replay", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New(`Dead job ID "banana" must be an integer`)}))
+	})
+
+	It("delegates errors to the error writer", func() {
+		deadJobReplayer.ReplayCall.Returns.Error = errors.New("BOOM!")
+
+		request, err := http.NewRequest("POST", "/dead_jobs/7/replay", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Writer).To(Equal(writer))
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
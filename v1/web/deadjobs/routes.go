@@ -0,0 +1,27 @@
+package deadjobs
+
+import "github.com/ryanmoran/stack"
+
+type muxer interface {
+	Handle(method, path string, handler stack.Handler, middleware ...stack.Middleware)
+}
+
+type Routes struct {
+	RequestCounter                  stack.Middleware
+	RequestLogging                  stack.Middleware
+	NotificationsAdminAuthenticator stack.Middleware
+	DatabaseAllocator               stack.Middleware
+
+	DeadJobFinder   deadJobFinder
+	DeadJobReplayer deadJobReplayer
+	DeadJobPurger   deadJobPurger
+	ErrorWriter     errorWriter
+}
+
+func (r Routes) Register(m muxer) {
+	m.Handle("GET", "/dead_jobs", NewListHandler(r.DeadJobFinder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsAdminAuthenticator, r.DatabaseAllocator)
+	m.Handle("DELETE", "/dead_jobs", NewPurgeHandler(r.DeadJobPurger, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsAdminAuthenticator, r.DatabaseAllocator)
+	m.Handle("GET", "/dead_jobs/{dead_job_id}", NewGetHandler(r.DeadJobFinder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsAdminAuthenticator, r.DatabaseAllocator)
+	m.Handle("DELETE", "/dead_jobs/{dead_job_id}", NewDeleteHandler(r.DeadJobPurger, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsAdminAuthenticator, r.DatabaseAllocator)
+	m.Handle("POST", "/dead_jobs/{dead_job_id}/replay", NewReplayHandler(r.DeadJobReplayer, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsAdminAuthenticator, r.DatabaseAllocator)
+}
@@ -0,0 +1,66 @@
+package deadjobs_test
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/deadjobs"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/middleware"
+	"github.com/cloudfoundry-incubator/notifications/web"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Routes", func() {
+	var muxer web.Muxer
+
+	BeforeEach(func() {
+		muxer = web.NewMuxer()
+		deadjobs.Routes{
+			RequestCounter:                  middleware.RequestCounter{},
+			RequestLogging:                  middleware.RequestLogging{},
+			DatabaseAllocator:               middleware.DatabaseAllocator{},
+			NotificationsAdminAuthenticator: middleware.Authenticator{Scopes: []string{"notifications.admin"}},
+
+			ErrorWriter:     mocks.NewErrorWriter(),
+			DeadJobFinder:   mocks.NewDeadJobFinder(),
+			DeadJobReplayer: mocks.NewDeadJobReplayer(),
+			DeadJobPurger:   mocks.NewDeadJobPurger(),
+		}.Register(muxer)
+	})
+
+	expectRoute := func(method, path string, handler interface{}) {
+		request, err := http.NewRequest(method, path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		s := muxer.Match(request).(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handler))
+		ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.Authenticator{}, middleware.DatabaseAllocator{})
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(ConsistOf([]string{"notifications.admin"}))
+	}
+
+	It("routes GET /dead_jobs", func() {
+		expectRoute("GET", "/dead_jobs", deadjobs.ListHandler{})
+	})
+
+	It("routes DELETE /dead_jobs", func() {
+		expectRoute("DELETE", "/dead_jobs", deadjobs.PurgeHandler{})
+	})
+
+	It("routes GET /dead_jobs/{dead_job_id}", func() {
+		expectRoute("GET", "/dead_jobs/7", deadjobs.GetHandler{})
+	})
+
+	It("routes DELETE /dead_jobs/{dead_job_id}", func() {
+		expectRoute("DELETE", "/dead_jobs/7", deadjobs.DeleteHandler{})
+	})
+
+	It("routes POST /dead_jobs/{dead_job_id}/replay", func() {
+		expectRoute("POST", "/dead_jobs/7/replay", deadjobs.ReplayHandler{})
+	})
+})
@@ -0,0 +1,50 @@
+package messages
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ryanmoran/stack"
+)
+
+type messageCanceller interface {
+	Cancel(database services.DatabaseInterface, messageID, clientID string) error
+}
+
+type DeleteHandler struct {
+	canceller   messageCanceller
+	errorWriter errorWriter
+}
+
+func NewDeleteHandler(canceller messageCanceller, errWriter errorWriter) DeleteHandler {
+	return DeleteHandler{
+		canceller:   canceller,
+		errorWriter: errWriter,
+	}
+}
+
+func (h DeleteHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	messageID := strings.Split(req.URL.Path, "/messages/")[1]
+
+	err := h.canceller.Cancel(context.Get("database").(DatabaseInterface), messageID, clientIDFromToken(context))
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// clientIDFromToken returns the ID of the client whose token authorized the
+// request, or nothing when there is no token.
+func clientIDFromToken(context stack.Context) string {
+	token, ok := context.Get("token").(*jwt.Token)
+	if !ok {
+		return ""
+	}
+
+	clientID, _ := token.Claims.(jwt.MapClaims)["client_id"].(string)
+	return clientID
+}
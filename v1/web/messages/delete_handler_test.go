@@ -0,0 +1,92 @@
+package messages_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/messages"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeleteHandler", func() {
+	var (
+		handler          messages.DeleteHandler
+		errorWriter      *mocks.ErrorWriter
+		writer           *httptest.ResponseRecorder
+		request          *http.Request
+		messageCanceller *mocks.MessageCanceller
+		database         *mocks.Database
+		context          stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		messageCanceller = mocks.NewMessageCanceller()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+		context.Set("token", buildClientToken("some-client"))
+
+		var err error
+		request, err = http.NewRequest("DELETE", "/messages/message-123", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = messages.NewDeleteHandler(messageCanceller, errorWriter)
+	})
+
+	It("cancels the message", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+		Expect(writer.Body.String()).To(BeEmpty())
+
+		Expect(messageCanceller.CancelCall.Receives.Database).To(Equal(database))
+		Expect(messageCanceller.CancelCall.Receives.MessageID).To(Equal("message-123"))
+		Expect(messageCanceller.CancelCall.Receives.ClientID).To(Equal("some-client"))
+	})
+
+	It("cancels only messages of the client of the token", func() {
+		context.Set("token", buildClientToken("another-client"))
+		messageCanceller.CancelCall.Returns.Error = models.NotFoundError{Err: errors.New(`Message with ID "message-123" could not be found`)}
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(messageCanceller.CancelCall.Receives.ClientID).To(Equal("another-client"))
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(models.NotFoundError{Err: errors.New(`Message with ID "message-123" could not be found`)}))
+		Expect(writer.Body.String()).To(BeEmpty())
+	})
+
+	It("delegates errors to the error writer", func() {
+		messageCanceller.CancelCall.Returns.Error = errors.New("BOOM!")
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Writer).To(Equal(writer))
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
+
+func buildClientToken(clientID string) *jwt.Token {
+	tokenHeader := map[string]interface{}{
+		"alg": "RS256",
+	}
+	claims := jwt.MapClaims{
+		"client_id": clientID,
+		"exp":       int64(3404281214),
+	}
+	token, err := jwt.Parse(helpers.BuildToken(tokenHeader, claims), func(*jwt.Token) (interface{}, error) {
+		return helpers.UAAPublicKeyRSA, nil
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	return token
+}
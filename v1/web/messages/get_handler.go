@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/v1/services"
 	"github.com/ryanmoran/stack"
@@ -19,7 +20,7 @@ type errorWriter interface {
 }
 
 type messageFinder interface {
-	Find(services.DatabaseInterface, string) (services.Message, error)
+	Find(database services.DatabaseInterface, messageID, clientID string) (services.Message, error)
 }
 
 func NewGetHandler(finder messageFinder, errWriter errorWriter) GetHandler {
@@ -32,16 +33,40 @@ func NewGetHandler(finder messageFinder, errWriter errorWriter) GetHandler {
 func (h GetHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
 	messageID := strings.Split(req.URL.Path, "/messages/")[1]
 
-	message, err := h.finder.Find(context.Get("database").(DatabaseInterface), messageID)
+	message, err := h.finder.Find(context.Get("database").(DatabaseInterface), messageID, clientIDFromToken(context))
 	if err != nil {
 		h.errorWriter.Write(w, err)
 		return
 	}
 
+	type event struct {
+		PreviousStatus string `json:"previous_status"`
+		Status         string `json:"status"`
+		RetryCount     int    `json:"retry_count"`
+		SMTPCode       int    `json:"smtp_code"`
+		SMTPText       string `json:"smtp_text"`
+		Recipient      string `json:"recipient"`
+		Timestamp      string `json:"timestamp"`
+	}
+
 	var document struct {
-		Status string `json:"status"`
+		Status  string  `json:"status"`
+		History []event `json:"history"`
 	}
 	document.Status = message.Status
+	document.History = []event{}
+
+	for _, e := range message.Events {
+		document.History = append(document.History, event{
+			PreviousStatus: e.PreviousStatus,
+			Status:         e.Status,
+			RetryCount:     e.RetryCount,
+			SMTPCode:       e.SMTPCode,
+			SMTPText:       e.SMTPText,
+			Recipient:      e.Recipient,
+			Timestamp:      e.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
 
 	writeJSON(w, http.StatusOK, document)
 }
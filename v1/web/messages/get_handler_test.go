@@ -4,8 +4,10 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/cloudfoundry-incubator/notifications/v1/services"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/messages"
 	"github.com/ryanmoran/stack"
@@ -35,6 +37,7 @@ var _ = Describe("GetHandler", func() {
 		database = mocks.NewDatabase()
 		context = stack.NewContext()
 		context.Set("database", database)
+		context.Set("token", buildClientToken("some-client"))
 
 		request, err = http.NewRequest("GET", "/messages/"+messageID, nil)
 		if err != nil {
@@ -54,11 +57,75 @@ var _ = Describe("GetHandler", func() {
 
 			Expect(writer.Code).To(Equal(http.StatusOK))
 			Expect(writer.Body.Bytes()).To(MatchJSON(`{
-				"status": "The generic status returned"
+				"status": "The generic status returned",
+				"history": []
 			}`))
 
 			Expect(messageFinder.FindCall.Receives.Database).To(Equal(database))
 			Expect(messageFinder.FindCall.Receives.MessageID).To(Equal(messageID))
+			Expect(messageFinder.FindCall.Receives.ClientID).To(Equal("some-client"))
+		})
+
+		It("reports the messages of another client as not found", func() {
+			context.Set("token", buildClientToken("another-client"))
+			messageFinder.FindCall.Returns.Error = models.NotFoundError{Err: errors.New(`Message with ID "message-123" could not be found`)}
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(messageFinder.FindCall.Receives.ClientID).To(Equal("another-client"))
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(models.NotFoundError{Err: errors.New(`Message with ID "message-123" could not be found`)}))
+			Expect(writer.Body.String()).To(BeEmpty())
+		})
+
+		It("returns the delivery history of the message", func() {
+			queuedAt := time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC)
+
+			messageFinder.FindCall.Returns.Message = services.Message{
+				Status: "failed",
+				Events: []services.MessageEvent{
+					{
+						Status:    "queued",
+						Recipient: "user-123",
+						CreatedAt: queuedAt,
+					},
+					{
+						PreviousStatus: "queued",
+						Status:         "failed",
+						RetryCount:     2,
+						SMTPCode:       451,
+						SMTPText:       "try again later",
+						Recipient:      "user-123@example.com",
+						CreatedAt:      queuedAt.Add(90 * time.Second),
+					},
+				},
+			}
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(writer.Code).To(Equal(http.StatusOK))
+			Expect(writer.Body.Bytes()).To(MatchJSON(`{
+				"status": "failed",
+				"history": [
+					{
+						"previous_status": "",
+						"status": "queued",
+						"retry_count": 0,
+						"smtp_code": 0,
+						"smtp_text": "",
+						"recipient": "user-123",
+						"timestamp": "2015-06-08T14:00:00Z"
+					},
+					{
+						"previous_status": "queued",
+						"status": "failed",
+						"retry_count": 2,
+						"smtp_code": 451,
+						"smtp_text": "try again later",
+						"recipient": "user-123@example.com",
+						"timestamp": "2015-06-08T14:01:30Z"
+					}
+				]
+			}`))
 		})
 
 		Context("When the finder errors", func() {
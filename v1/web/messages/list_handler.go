@@ -0,0 +1,122 @@
+package messages
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+const (
+	DefaultMessagesPerPage = 50
+	MaxMessagesPerPage     = 500
+)
+
+type messageLister interface {
+	List(services.DatabaseInterface, services.MessageFilter) (services.MessageList, error)
+}
+
+type ListHandler struct {
+	lister      messageLister
+	errorWriter errorWriter
+}
+
+func NewListHandler(lister messageLister, errWriter errorWriter) ListHandler {
+	return ListHandler{
+		lister:      lister,
+		errorWriter: errWriter,
+	}
+}
+
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	filter, err := parseMessageFilter(req)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	list, err := h.lister.List(context.Get("database").(DatabaseInterface), filter)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	type message struct {
+		ID        string `json:"id"`
+		Status    string `json:"status"`
+		ClientID  string `json:"client_id"`
+		KindID    string `json:"kind_id"`
+		UserGUID  string `json:"user_guid"`
+		Email     string `json:"email"`
+		UpdatedAt string `json:"updated_at"`
+	}
+
+	var document struct {
+		Total    int       `json:"total"`
+		Page     int       `json:"page"`
+		PerPage  int       `json:"per_page"`
+		Messages []message `json:"messages"`
+	}
+	document.Total = list.Total
+	document.Page = filter.Page
+	document.PerPage = filter.PerPage
+	document.Messages = []message{}
+
+	for _, m := range list.Messages {
+		document.Messages = append(document.Messages, message{
+			ID:        m.ID,
+			Status:    m.Status,
+			ClientID:  m.ClientID,
+			KindID:    m.KindID,
+			UserGUID:  m.UserGUID,
+			Email:     m.Email,
+			UpdatedAt: m.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, document)
+}
+
+func parseMessageFilter(req *http.Request) (services.MessageFilter, error) {
+	query := req.URL.Query()
+
+	filter := services.MessageFilter{
+		ClientID: query.Get("client_id"),
+		KindID:   query.Get("kind_id"),
+		UserGUID: query.Get("user_guid"),
+		Email:    query.Get("email"),
+		Status:   query.Get("status"),
+	}
+
+	var err error
+	if filter.UpdatedAfter, err = parseTimeParam(query.Get("updated_after"), "updated_after"); err != nil {
+		return services.MessageFilter{}, err
+	}
+
+	if filter.UpdatedBefore, err = parseTimeParam(query.Get("updated_before"), "updated_before"); err != nil {
+		return services.MessageFilter{}, err
+	}
+
+	filter.Page, filter.PerPage, err = webutil.ParsePagination(req, DefaultMessagesPerPage, MaxMessagesPerPage)
+	if err != nil {
+		return services.MessageFilter{}, err
+	}
+
+	return filter, nil
+}
+
+func parseTimeParam(value, name string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, webutil.ValidationError{Err: fmt.Errorf("%q must be an RFC3339 timestamp", name)}
+	}
+
+	return t, nil
+}
@@ -0,0 +1,148 @@
+package messages_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/messages"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ListHandler", func() {
+	var (
+		handler       messages.ListHandler
+		errorWriter   *mocks.ErrorWriter
+		writer        *httptest.ResponseRecorder
+		messageLister *mocks.MessageLister
+		database      *mocks.Database
+		context       stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		messageLister = mocks.NewMessageLister()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = messages.NewListHandler(messageLister, errorWriter)
+	})
+
+	It("returns the page of messages from the lister", func() {
+		messageLister.ListCall.Returns.MessageList = services.MessageList{
+			Total: 1,
+			Messages: []services.MessageSummary{
+				{
+					ID:        "message-123",
+					Status:    "failed",
+					ClientID:  "some-client",
+					KindID:    "some-kind",
+					UserGUID:  "user-123",
+					Email:     "user@example.com",
+					UpdatedAt: time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+				},
+			},
+		}
+
+		request, err := http.NewRequest("GET", "/messages?client_id=some-client&kind_id=some-kind&user_guid=user-123&email=user@example.com&status=failed&updated_after=2015-06-08T13:00:00Z&updated_before=2015-06-08T15:00:00Z&page=2&per_page=10", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"total": 1,
+			"page": 2,
+			"per_page": 10,
+			"messages": [
+				{
+					"id": "message-123",
+					"status": "failed",
+					"client_id": "some-client",
+					"kind_id": "some-kind",
+					"user_guid": "user-123",
+					"email": "user@example.com",
+					"updated_at": "2015-06-08T14:00:00Z"
+				}
+			]
+		}`))
+
+		Expect(messageLister.ListCall.Receives.Database).To(Equal(database))
+		Expect(messageLister.ListCall.Receives.Filter).To(Equal(services.MessageFilter{
+			ClientID:      "some-client",
+			KindID:        "some-kind",
+			UserGUID:      "user-123",
+			Email:         "user@example.com",
+			Status:        "failed",
+			UpdatedAfter:  time.Date(2015, 6, 8, 13, 0, 0, 0, time.UTC),
+			UpdatedBefore: time.Date(2015, 6, 8, 15, 0, 0, 0, time.UTC),
+			Page:          2,
+			PerPage:       10,
+		}))
+	})
+
+	It("defaults the page and page size", func() {
+		request, err := http.NewRequest("GET", "/messages", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"total": 0,
+			"page": 1,
+			"per_page": 50,
+			"messages": []
+		}`))
+		Expect(messageLister.ListCall.Receives.Filter).To(Equal(services.MessageFilter{
+			Page:    1,
+			PerPage: 50,
+		}))
+	})
+
+	Context("when the query is invalid", func() {
+		expectValidationError := func(query, message string) {
+			request, err := http.NewRequest("GET", "/messages?"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New(message)}))
+		}
+
+		It("rejects a page that is not a positive integer", func() {
+			expectValidationError("page=two", `"page" must be a positive integer`)
+			expectValidationError("page=0", `"page" must be a positive integer`)
+		})
+
+		It("rejects a page size outside of the allowed range", func() {
+			expectValidationError("per_page=501", `"per_page" must be an integer between 1 and 500`)
+			expectValidationError("per_page=0", `"per_page" must be an integer between 1 and 500`)
+		})
+
+		It("rejects malformed timestamps", func() {
+			expectValidationError("updated_after=yesterday", `"updated_after" must be an RFC3339 timestamp`)
+			expectValidationError("updated_before=tomorrow", `"updated_before" must be an RFC3339 timestamp`)
+		})
+	})
+
+	It("writes the lister error", func() {
+		messageLister.ListCall.Returns.Error = errors.New("BOOM!")
+
+		request, err := http.NewRequest("GET", "/messages", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
@@ -10,12 +10,17 @@ type Routes struct {
 	RequestCounter                               stack.Middleware
 	RequestLogging                               stack.Middleware
 	NotificationsWriteOrEmailsWriteAuthenticator stack.Middleware
+	NotificationsManageAuthenticator             stack.Middleware
 	DatabaseAllocator                            stack.Middleware
 
-	MessageFinder messageFinder
-	ErrorWriter   errorWriter
+	MessageFinder    messageFinder
+	MessageLister    messageLister
+	MessageCanceller messageCanceller
+	ErrorWriter      errorWriter
 }
 
 func (r Routes) Register(m muxer) {
+	m.Handle("GET", "/messages", NewListHandler(r.MessageLister, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsManageAuthenticator, r.DatabaseAllocator)
 	m.Handle("GET", "/messages/{message_id}", NewGetHandler(r.MessageFinder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsWriteOrEmailsWriteAuthenticator, r.DatabaseAllocator)
+	m.Handle("DELETE", "/messages/{message_id}", NewDeleteHandler(r.MessageCanceller, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsWriteOrEmailsWriteAuthenticator, r.DatabaseAllocator)
 }
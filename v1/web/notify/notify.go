@@ -1,8 +1,12 @@
 package notify
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -12,6 +16,7 @@ import (
 	"github.com/cloudfoundry-incubator/notifications/v1/services"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/pivotal-golang/lager"
 	"github.com/ryanmoran/stack"
 )
 
@@ -24,15 +29,39 @@ type registrar interface {
 	Prune(services.ConnectionInterface, models.Client, []models.Kind) error
 }
 
+type idempotencyKeysRepo interface {
+	Create(models.ConnectionInterface, models.IdempotencyKey) (models.IdempotencyKey, error)
+	Find(models.ConnectionInterface, string, string) (models.IdempotencyKey, error)
+	Update(models.ConnectionInterface, models.IdempotencyKey) (models.IdempotencyKey, error)
+	Reclaim(models.ConnectionInterface, models.IdempotencyKey) (models.IdempotencyKey, error)
+	Delete(models.ConnectionInterface, models.IdempotencyKey) error
+}
+
+type requestsLimiter interface {
+	LimitRequests(conn services.ConnectionInterface, clientID, kindID string) error
+}
+
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyKeyReservationTimeout is how long a reservation without a
+// response holds off retries. A reservation is only left without a response
+// for longer when the request that made it never finished, so a retry may
+// then take it over.
+const idempotencyKeyReservationTimeout = time.Minute
+
 type Notify struct {
-	finder    clientAndKindFinder
-	registrar registrar
+	finder          clientAndKindFinder
+	registrar       registrar
+	idempotencyKeys idempotencyKeysRepo
+	rateLimiter     requestsLimiter
 }
 
-func NewNotify(finder clientAndKindFinder, registrar registrar) Notify {
+func NewNotify(finder clientAndKindFinder, registrar registrar, idempotencyKeys idempotencyKeysRepo, rateLimiter requestsLimiter) Notify {
 	return Notify{
-		finder:    finder,
-		registrar: registrar,
+		finder:          finder,
+		registrar:       registrar,
+		idempotencyKeys: idempotencyKeys,
+		rateLimiter:     rateLimiter,
 	}
 }
 
@@ -43,7 +72,12 @@ type ValidatorInterface interface {
 func (h Notify) Execute(connection ConnectionInterface, req *http.Request, context stack.Context,
 	guid string, strategy Dispatcher, validator ValidatorInterface, vcapRequestID string) ([]byte, error) {
 
-	parameters, err := NewNotifyParams(req.Body)
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return []byte{}, webutil.ParseError{}
+	}
+
+	parameters, err := NewNotifyParams(io.NopCloser(bytes.NewReader(body)))
 	if err != nil {
 		return []byte{}, err
 	}
@@ -66,6 +100,56 @@ func (h Notify) Execute(connection ConnectionInterface, req *http.Request, conte
 	}
 	uaaHost := tokenIssuerURL.Scheme + "://" + tokenIssuerURL.Host
 
+	var idempotencyKey models.IdempotencyKey
+	if key := req.Header.Get(IdempotencyKeyHeader); key != "" {
+		var replay []byte
+		idempotencyKey, replay, err = h.reserveIdempotencyKey(connection, clientID, key, requestHash(req, body))
+		if err != nil {
+			return []byte{}, err
+		}
+
+		if replay != nil {
+			return replay, nil
+		}
+	}
+
+	logger := context.Get("logger").(lager.Logger)
+
+	output, err := h.dispatch(connection, context, guid, strategy, parameters, claims, clientID, uaaHost, vcapRequestID, requestReceivedTime)
+	if err != nil {
+		if idempotencyKey.Primary != 0 {
+			deleteErr := h.idempotencyKeys.Delete(connection, idempotencyKey)
+			if deleteErr != nil {
+				logger.Error("idempotency-key-release-failed", deleteErr, lager.Data{
+					"client_id":       clientID,
+					"idempotency_key": idempotencyKey.Key,
+				})
+			}
+		}
+		return []byte{}, err
+	}
+
+	if idempotencyKey.Primary != 0 {
+		// The jobs are already enqueued, so failing here would only invite
+		// a retry; the reservation left behind keeps that retry from
+		// sending the notification a second time, answering it with a
+		// conflict until the reservation times out.
+		idempotencyKey.Response = string(output)
+		_, err = h.idempotencyKeys.Update(connection, idempotencyKey)
+		if err != nil {
+			logger.Error("idempotency-key-store-failed", err, lager.Data{
+				"client_id":       clientID,
+				"idempotency_key": idempotencyKey.Key,
+			})
+		}
+	}
+
+	return output, nil
+}
+
+func (h Notify) dispatch(connection ConnectionInterface, context stack.Context, guid string, strategy Dispatcher, parameters NotifyParams,
+	claims jwt.MapClaims, clientID, uaaHost, vcapRequestID string, requestReceivedTime time.Time) ([]byte, error) {
+
 	client, kind, err := h.finder.ClientAndKind(context.Get("database").(DatabaseInterface), clientID, parameters.KindID)
 	if err != nil {
 		return []byte{}, err
@@ -75,15 +159,31 @@ func (h Notify) Execute(connection ConnectionInterface, req *http.Request, conte
 		return []byte{}, webutil.NewCriticalNotificationError(kind.ID)
 	}
 
+	// Only requests that could be sent count against the quota of the
+	// client, so that a rejected request is answered with its real error.
+	err = h.rateLimiter.LimitRequests(connection, clientID, parameters.KindID)
+	if err != nil {
+		return []byte{}, err
+	}
+
 	err = h.registrar.Register(connection, client, []models.Kind{kind})
 	if err != nil {
 		return []byte{}, err
 	}
 
+	var users []services.User
+	for _, user := range parameters.Users {
+		users = append(users, services.User{
+			GUID:  user.GUID,
+			Email: user.Email,
+		})
+	}
+
 	var responses []services.Response
 
 	responses, err = strategy.Dispatch(services.Dispatch{
 		GUID:       guid,
+		Users:      users,
 		Connection: connection,
 		Role:       parameters.Role,
 		Client: services.DispatchClient{
@@ -93,6 +193,7 @@ func (h Notify) Execute(connection ConnectionInterface, req *http.Request, conte
 		Kind: services.DispatchKind{
 			ID:          parameters.KindID,
 			Description: kind.Description,
+			Critical:    kind.Critical,
 		},
 		UAAHost: uaaHost,
 		VCAPRequest: services.DispatchVCAPRequest{
@@ -110,6 +211,9 @@ func (h Notify) Execute(connection ConnectionInterface, req *http.Request, conte
 				Head:           parameters.ParsedHTML.Head,
 				Doctype:        parameters.ParsedHTML.Doctype,
 			},
+			SendAt:   parameters.ParsedSendAt,
+			Priority: parameters.Priority,
+			Locale:   parameters.Locale,
 		},
 	})
 	if err != nil {
@@ -124,6 +228,57 @@ func (h Notify) Execute(connection ConnectionInterface, req *http.Request, conte
 	return output, nil
 }
 
+func (h Notify) reserveIdempotencyKey(connection ConnectionInterface, clientID, key, hash string) (models.IdempotencyKey, []byte, error) {
+	reservation, err := h.idempotencyKeys.Create(connection, models.IdempotencyKey{
+		ClientID:    clientID,
+		Key:         key,
+		RequestHash: hash,
+	})
+	if err == nil {
+		return reservation, nil, nil
+	}
+
+	if _, ok := err.(models.DuplicateError); !ok {
+		return models.IdempotencyKey{}, nil, err
+	}
+
+	existing, err := h.idempotencyKeys.Find(connection, clientID, key)
+	if err != nil {
+		return models.IdempotencyKey{}, nil, err
+	}
+
+	if existing.RequestHash != hash {
+		return models.IdempotencyKey{}, nil, webutil.ValidationError{Err: errors.New(`"Idempotency-Key" has already been used for a different request`)}
+	}
+
+	if existing.Response == "" {
+		conflict := webutil.IdempotencyKeyConflictError{Err: errors.New(`A request with this "Idempotency-Key" is still being processed`)}
+		if time.Since(existing.CreatedAt) < idempotencyKeyReservationTimeout {
+			return models.IdempotencyKey{}, nil, conflict
+		}
+
+		reservation, err = h.idempotencyKeys.Reclaim(connection, existing)
+		if err != nil {
+			if _, ok := err.(models.NotFoundError); ok {
+				return models.IdempotencyKey{}, nil, conflict
+			}
+			return models.IdempotencyKey{}, nil, err
+		}
+
+		return reservation, nil, nil
+	}
+
+	return models.IdempotencyKey{}, []byte(existing.Response), nil
+}
+
+func requestHash(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 func (h Notify) hasCriticalNotificationsWriteScope(elements interface{}) bool {
 	for _, elem := range elements.([]interface{}) {
 		if elem.(string) == "critical_notifications.write" {
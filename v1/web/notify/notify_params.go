@@ -6,6 +6,7 @@ import (
 	"io"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
@@ -19,20 +20,30 @@ var (
 )
 
 type NotifyParams struct {
-	ReplyTo string `json:"reply_to"`
-	Subject string `json:"subject"`
-	Text    string `json:"text"`
-	RawHTML string `json:"html"`
-	KindID  string `json:"kind_id"`
-	To      string `json:"to"`
-	Role    string `json:"role"`
+	ReplyTo  string       `json:"reply_to"`
+	Subject  string       `json:"subject"`
+	Text     string       `json:"text"`
+	RawHTML  string       `json:"html"`
+	KindID   string       `json:"kind_id"`
+	To       string       `json:"to"`
+	Role     string       `json:"role"`
+	Users    []NotifyUser `json:"users"`
+	SendAt   string       `json:"send_at"`
+	Priority string       `json:"priority"`
+	Locale   string       `json:"locale"`
 
 	ParsedHTML        HTML
+	ParsedSendAt      time.Time
 	KindDescription   string
 	SourceDescription string
 	Errors            []string
 }
 
+type NotifyUser struct {
+	GUID  string `json:"guid"`
+	Email string `json:"email"`
+}
+
 type HTML struct {
 	BodyContent    string
 	BodyAttributes string
@@ -53,9 +64,26 @@ func NewNotifyParams(body io.ReadCloser) (NotifyParams, error) {
 		return notify, err
 	}
 
+	notify.parseSendAt()
+
 	return notify, nil
 }
 
+// parseSendAt leaves ParsedSendAt unset when send_at is malformed so that
+// the validators can report it alongside any other errors.
+func (notify *NotifyParams) parseSendAt() {
+	if notify.SendAt == "" {
+		return
+	}
+
+	sendAt, err := time.Parse(time.RFC3339, notify.SendAt)
+	if err != nil {
+		return
+	}
+
+	notify.ParsedSendAt = sendAt.UTC()
+}
+
 func (notify *NotifyParams) parseRequestBody(body io.ReadCloser) error {
 	defer body.Close()
 
@@ -73,6 +101,10 @@ func (notify *NotifyParams) parseRequestBody(body io.ReadCloser) error {
 func (notify *NotifyParams) FormatEmailAndExtractHTML() error {
 	notify.To = EmailFormatter{}.Format(notify.To)
 
+	for i := range notify.Users {
+		notify.Users[i].Email = EmailFormatter{}.Format(notify.Users[i].Email)
+	}
+
 	doctype, head, bodyContent, bodyAttributes, err := HTMLExtractor{}.Extract(notify.RawHTML)
 	if err != nil {
 		return err
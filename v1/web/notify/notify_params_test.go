@@ -4,6 +4,7 @@ import (
 	"io"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/v1/web/notify"
 
@@ -70,6 +71,24 @@ var _ = Describe("NotifyParams", func() {
 			})
 		})
 
+		Describe("users field parsing", func() {
+			It("parses the guids and formats the emails of each user", func() {
+				parameters, err := notify.NewNotifyParams(ioutil.NopCloser(strings.NewReader(`{
+					"users": [
+						{"guid": "user-123"},
+						{"email": "The User <user@example.com>"},
+						{"guid": "user-456", "email": "<The User"}
+					]
+				}`)))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parameters.Users).To(Equal([]notify.NotifyUser{
+					{GUID: "user-123"},
+					{Email: "user@example.com"},
+					{GUID: "user-456", Email: notify.InvalidEmail},
+				}))
+			})
+		})
+
 		Describe("role field parsing", func() {
 			It("sets the role field to empty if it is not specificed", func() {
 				parameters, err := notify.NewNotifyParams(ioutil.NopCloser(strings.NewReader("{}")))
@@ -87,6 +106,33 @@ var _ = Describe("NotifyParams", func() {
 			})
 		})
 
+		Describe("send_at field parsing", func() {
+			It("leaves the send time unset if it is not specified", func() {
+				parameters, err := notify.NewNotifyParams(ioutil.NopCloser(strings.NewReader("{}")))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parameters.SendAt).To(BeEmpty())
+				Expect(parameters.ParsedSendAt.IsZero()).To(BeTrue())
+			})
+
+			It("parses the send time in UTC", func() {
+				parameters, err := notify.NewNotifyParams(ioutil.NopCloser(strings.NewReader(`{
+                    "send_at": "2015-06-08T14:00:00-07:00"
+				}`)))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parameters.SendAt).To(Equal("2015-06-08T14:00:00-07:00"))
+				Expect(parameters.ParsedSendAt).To(Equal(time.Date(2015, 6, 8, 21, 0, 0, 0, time.UTC)))
+			})
+
+			It("leaves a malformed send time for the validator to reject", func() {
+				parameters, err := notify.NewNotifyParams(ioutil.NopCloser(strings.NewReader(`{
+                    "send_at": "tomorrow"
+				}`)))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parameters.SendAt).To(Equal("tomorrow"))
+				Expect(parameters.ParsedSendAt.IsZero()).To(BeTrue())
+			})
+		})
+
 		Describe("html parsing", func() {
 			Context("when a doctype is passed in", func() {
 				It("pulls out the doctype", func() {
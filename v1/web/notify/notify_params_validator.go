@@ -1,6 +1,13 @@
 package notify
 
-import "regexp"
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+)
 
 var kindIDFormat = regexp.MustCompile(`^[0-9a-zA-Z_\-.]+$`)
 
@@ -21,6 +28,11 @@ func (validator EmailValidator) Validate(notify *NotifyParams) bool {
 		notify.Errors = append(notify.Errors, `"text" or "html" fields must be supplied`)
 	}
 
+	checkSendAtField(notify)
+	checkPriorityField(notify)
+	checkLocaleField(notify)
+	checkNoUsersField(notify)
+
 	return len(notify.Errors) == 0
 }
 
@@ -39,6 +51,49 @@ func (validator GUIDValidator) Validate(notify *NotifyParams) bool {
 		notify.Errors = append(notify.Errors, `"role" must be "OrgManager", "OrgAuditor", "BillingManager" or unset`)
 	}
 
+	checkSendAtField(notify)
+	checkPriorityField(notify)
+	checkLocaleField(notify)
+	checkNoUsersField(notify)
+
+	return len(notify.Errors) == 0
+}
+
+type UsersValidator struct {
+	MaxBatchSize int
+}
+
+func (validator UsersValidator) Validate(notify *NotifyParams) bool {
+	notify.Errors = []string{}
+
+	GUIDValidator{}.checkKindIDField(notify)
+
+	if missingTextOrHTMLFields(notify) {
+		notify.Errors = append(notify.Errors, `"text" or "html" fields must be supplied`)
+	}
+
+	if len(notify.Users) == 0 {
+		notify.Errors = append(notify.Errors, `"users" must contain at least one user`)
+	}
+
+	if validator.MaxBatchSize > 0 && len(notify.Users) > validator.MaxBatchSize {
+		notify.Errors = append(notify.Errors, fmt.Sprintf(`"users" must not contain more than %d users`, validator.MaxBatchSize))
+	}
+
+	for i, user := range notify.Users {
+		if user.GUID == "" && user.Email == "" {
+			notify.Errors = append(notify.Errors, fmt.Sprintf(`"users[%d]" must have a "guid" or an "email"`, i))
+		}
+
+		if user.Email == InvalidEmail {
+			notify.Errors = append(notify.Errors, fmt.Sprintf(`"users[%d].email" is improperly formatted`, i))
+		}
+	}
+
+	checkSendAtField(notify)
+	checkPriorityField(notify)
+	checkLocaleField(notify)
+
 	return len(notify.Errors) == 0
 }
 
@@ -46,6 +101,59 @@ func missingTextOrHTMLFields(notify *NotifyParams) bool {
 	return notify.Text == "" && notify.ParsedHTML.BodyContent == ""
 }
 
+// checkNoUsersField rejects a list of users sent to an endpoint with a single
+// recipient, rather than silently ignoring it.
+func checkNoUsersField(notify *NotifyParams) {
+	if len(notify.Users) > 0 {
+		notify.Errors = append(notify.Errors, `"users" is only accepted by POST /users`)
+	}
+}
+
+func checkSendAtField(notify *NotifyParams) {
+	if notify.SendAt == "" {
+		return
+	}
+
+	if notify.ParsedSendAt.IsZero() {
+		notify.Errors = append(notify.Errors, `"send_at" must be an RFC3339 timestamp`)
+		return
+	}
+
+	if notify.ParsedSendAt.Before(time.Now()) {
+		notify.Errors = append(notify.Errors, `"send_at" must be in the future`)
+	}
+}
+
+func checkPriorityField(notify *NotifyParams) {
+	if notify.Priority == "" {
+		return
+	}
+
+	for _, priority := range services.Priorities {
+		if notify.Priority == priority {
+			return
+		}
+	}
+
+	notify.Errors = append(notify.Errors, `"priority" must be "high", "normal", "low" or unset`)
+}
+
+// checkLocaleField leaves a valid locale in its canonical form, so that
+// templates are localized the same way whichever way it was written.
+func checkLocaleField(notify *NotifyParams) {
+	if notify.Locale == "" {
+		return
+	}
+
+	locale, err := models.CanonicalLocale(notify.Locale)
+	if err != nil {
+		notify.Errors = append(notify.Errors, `"locale" must be a BCP 47 language tag, such as "en-US"`)
+		return
+	}
+
+	notify.Locale = locale
+}
+
 func (validator GUIDValidator) invalidRoleField(roleName string) bool {
 	if roleName == "" {
 		return false
@@ -1,6 +1,8 @@
 package notify_test
 
 import (
+	"time"
+
 	"github.com/cloudfoundry-incubator/notifications/v1/web/notify"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -47,6 +49,56 @@ var _ = Describe("Validator", func() {
 				Expect(len(params.Errors)).To(Equal(0))
 			})
 
+			It("validates the send_at field", func() {
+				params.SendAt = "next tuesday"
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(params.Errors).To(ConsistOf(`"send_at" must be an RFC3339 timestamp`))
+
+				params.SendAt = "2015-06-08T14:00:00Z"
+				params.ParsedSendAt = time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC)
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(params.Errors).To(ConsistOf(`"send_at" must be in the future`))
+
+				params.ParsedSendAt = time.Now().Add(time.Hour)
+
+				Expect(validator.Validate(params)).To(BeTrue())
+				Expect(params.Errors).To(BeEmpty())
+			})
+
+			It("validates the priority field", func() {
+				params.Priority = "urgent"
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(params.Errors).To(ConsistOf(`"priority" must be "high", "normal", "low" or unset`))
+
+				params.Priority = "high"
+
+				Expect(validator.Validate(params)).To(BeTrue())
+				Expect(params.Errors).To(BeEmpty())
+			})
+
+			It("validates the locale field", func() {
+				params.Locale = "not a locale"
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(params.Errors).To(ConsistOf(`"locale" must be a BCP 47 language tag, such as "en-US"`))
+
+				params.Locale = "pt_br"
+
+				Expect(validator.Validate(params)).To(BeTrue())
+				Expect(params.Errors).To(BeEmpty())
+				Expect(params.Locale).To(Equal("pt-BR"))
+			})
+
+			It("rejects a list of users", func() {
+				params.Users = []notify.NotifyUser{{GUID: "user-123"}}
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(params.Errors).To(ConsistOf(`"users" is only accepted by POST /users`))
+			})
+
 			Context("When the notify params object finds an invalid email", func() {
 				It("Reports a validation error", func() {
 					params.To = notify.InvalidEmail
@@ -124,6 +176,114 @@ var _ = Describe("Validator", func() {
 				Expect(len(params.Errors)).To(Equal(1))
 				Expect(params.Errors).To(ContainElement(`"role" must be "OrgManager", "OrgAuditor", "BillingManager" or unset`))
 			})
+
+			It("validates the send_at field", func() {
+				params.SendAt = "2015-06-08T14:00:00Z"
+				params.ParsedSendAt = time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC)
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(params.Errors).To(ConsistOf(`"send_at" must be in the future`))
+			})
+
+			It("validates the locale field", func() {
+				params.Locale = "not a locale"
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(params.Errors).To(ConsistOf(`"locale" must be a BCP 47 language tag, such as "en-US"`))
+			})
+
+			It("rejects a list of users", func() {
+				params.Users = []notify.NotifyUser{{GUID: "user-123"}}
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(params.Errors).To(ConsistOf(`"users" is only accepted by POST /users`))
+			})
+		})
+	})
+
+	Describe("UsersValidator", func() {
+		var (
+			params    *notify.NotifyParams
+			validator notify.UsersValidator
+		)
+
+		BeforeEach(func() {
+			params = &notify.NotifyParams{
+				KindID:  "test_email",
+				Subject: "Summary of contents",
+				Text:    "Contents of the email message",
+				Users: []notify.NotifyUser{
+					{GUID: "user-123"},
+					{Email: "user@example.com"},
+				},
+			}
+			validator = notify.UsersValidator{MaxBatchSize: 3}
+		})
+
+		Describe("Validate", func() {
+			It("validates the kind and text fields", func() {
+				Expect(validator.Validate(params)).To(BeTrue())
+				Expect(params.Errors).To(BeEmpty())
+
+				params.KindID = ""
+				params.Text = ""
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(params.Errors).To(ConsistOf(
+					`"kind_id" is a required field`,
+					`"text" or "html" fields must be supplied`,
+				))
+			})
+
+			It("requires at least one user", func() {
+				params.Users = []notify.NotifyUser{}
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(params.Errors).To(ConsistOf(`"users" must contain at least one user`))
+			})
+
+			It("rejects batches larger than the configured maximum", func() {
+				params.Users = append(params.Users, notify.NotifyUser{GUID: "user-456"}, notify.NotifyUser{GUID: "user-789"})
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(params.Errors).To(ConsistOf(`"users" must not contain more than 3 users`))
+			})
+
+			It("does not limit the batch size when the maximum is not set", func() {
+				validator.MaxBatchSize = 0
+				params.Users = append(params.Users, notify.NotifyUser{GUID: "user-456"}, notify.NotifyUser{GUID: "user-789"})
+
+				Expect(validator.Validate(params)).To(BeTrue())
+				Expect(params.Errors).To(BeEmpty())
+			})
+
+			It("validates the send_at field", func() {
+				params.SendAt = "soon"
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(params.Errors).To(ConsistOf(`"send_at" must be an RFC3339 timestamp`))
+			})
+
+			It("validates the locale field", func() {
+				params.Locale = "not a locale"
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(params.Errors).To(ConsistOf(`"locale" must be a BCP 47 language tag, such as "en-US"`))
+			})
+
+			It("names each rejected entry", func() {
+				params.Users = []notify.NotifyUser{
+					{GUID: "user-123"},
+					{},
+					{Email: notify.InvalidEmail},
+				}
+
+				Expect(validator.Validate(params)).To(BeFalse())
+				Expect(params.Errors).To(ConsistOf(
+					`"users[1]" must have a "guid" or an "email"`,
+					`"users[2].email" is improperly formatted`,
+				))
+			})
 		})
 	})
 })
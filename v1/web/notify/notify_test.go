@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/cloudfoundry-incubator/notifications/v1/web/notify"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/pivotal-golang/lager"
 	"github.com/ryanmoran/stack"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -29,6 +31,8 @@ var _ = Describe("Notify", func() {
 				finder          *mocks.NotificationsFinder
 				validator       *mocks.Validator
 				registrar       *mocks.Registrar
+				idempotencyKeys *mocks.IdempotencyKeysRepo
+				rateLimiter     *mocks.RateLimiter
 				request         *http.Request
 				rawToken        string
 				client          models.Client
@@ -41,6 +45,8 @@ var _ = Describe("Notify", func() {
 				vcapRequestID   string
 				database        *mocks.Database
 				reqReceivedTime time.Time
+				requestBody     []byte
+				buffer          *bytes.Buffer
 			)
 
 			BeforeEach(func() {
@@ -60,7 +66,8 @@ var _ = Describe("Notify", func() {
 
 				registrar = mocks.NewRegistrar()
 
-				body, err := json.Marshal(map[string]string{
+				var err error
+				requestBody, err = json.Marshal(map[string]string{
 					"kind_id":  "test_email",
 					"text":     "This is the plain text body of the email",
 					"html":     "<!DOCTYPE html><html><head><script type='javascript'></script></head><body class='hello'><p>This is the HTML Body of the email</p><body></html>",
@@ -82,7 +89,7 @@ var _ = Describe("Notify", func() {
 				}
 				rawToken = helpers.BuildToken(tokenHeader, tokenClaims)
 
-				request, err = http.NewRequest("POST", "/spaces/space-001", bytes.NewBuffer(body))
+				request, err = http.NewRequest("POST", "/spaces/space-001", bytes.NewBuffer(requestBody))
 				if err != nil {
 					panic(err)
 				}
@@ -101,6 +108,11 @@ var _ = Describe("Notify", func() {
 				context.Set("database", database)
 				context.Set(notify.RequestReceivedTime, reqReceivedTime)
 
+				buffer = bytes.NewBuffer([]byte{})
+				logger := lager.NewLogger("notifications")
+				logger.RegisterSink(lager.NewWriterSink(buffer, lager.DEBUG))
+				context.Set("logger", logger)
+
 				vcapRequestID = "some-request-id"
 
 				conn = mocks.NewConnection()
@@ -108,7 +120,11 @@ var _ = Describe("Notify", func() {
 				validator = mocks.NewValidator()
 				validator.ValidateCall.Returns.Valid = true
 
-				handler = notify.NewNotify(finder, registrar)
+				idempotencyKeys = mocks.NewIdempotencyKeysRepo()
+
+				rateLimiter = mocks.NewRateLimiter()
+
+				handler = notify.NewNotify(finder, registrar, idempotencyKeys, rateLimiter)
 			})
 
 			It("delegates to the strategy", func() {
@@ -126,6 +142,7 @@ var _ = Describe("Notify", func() {
 					Kind: services.DispatchKind{
 						ID:          "test_email",
 						Description: "Instance Down",
+						Critical:    true,
 					},
 					UAAHost: "http://zone-uaa-host",
 					VCAPRequest: services.DispatchVCAPRequest{
@@ -146,6 +163,89 @@ var _ = Describe("Notify", func() {
 				}))
 			})
 
+			It("passes the batch of users to the strategy", func() {
+				body, err := json.Marshal(map[string]interface{}{
+					"kind_id": "test_email",
+					"text":    "This is the plain text body of the email",
+					"users": []map[string]string{
+						{"guid": "user-123"},
+						{"email": "The User <user@example.com>"},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				request, err = http.NewRequest("POST", "/users", bytes.NewBuffer(body))
+				Expect(err).NotTo(HaveOccurred())
+				request.Header.Set("Authorization", "Bearer "+rawToken)
+
+				_, err = handler.Execute(conn, request, context, "", strategy, validator, vcapRequestID)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(strategy.DispatchCallsCount).To(Equal(1))
+				Expect(strategy.DispatchCalls[0].Receives.Dispatch.Users).To(Equal([]services.User{
+					{GUID: "user-123"},
+					{Email: "user@example.com"},
+				}))
+			})
+
+			It("passes the send time to the strategy", func() {
+				sendAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+				body, err := json.Marshal(map[string]interface{}{
+					"kind_id": "test_email",
+					"text":    "This is the plain text body of the email",
+					"send_at": sendAt.Format(time.RFC3339),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				request, err = http.NewRequest("POST", "/spaces/space-001", bytes.NewBuffer(body))
+				Expect(err).NotTo(HaveOccurred())
+				request.Header.Set("Authorization", "Bearer "+rawToken)
+
+				_, err = handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(strategy.DispatchCallsCount).To(Equal(1))
+				Expect(strategy.DispatchCalls[0].Receives.Dispatch.Message.SendAt).To(Equal(sendAt))
+			})
+
+			It("passes the requested priority to the strategy", func() {
+				body, err := json.Marshal(map[string]interface{}{
+					"kind_id":  "test_email",
+					"text":     "This is the plain text body of the email",
+					"priority": "low",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				request, err = http.NewRequest("POST", "/spaces/space-001", bytes.NewBuffer(body))
+				Expect(err).NotTo(HaveOccurred())
+				request.Header.Set("Authorization", "Bearer "+rawToken)
+
+				_, err = handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(strategy.DispatchCallsCount).To(Equal(1))
+				Expect(strategy.DispatchCalls[0].Receives.Dispatch.Message.Priority).To(Equal("low"))
+			})
+
+			It("passes the requested locale to the strategy", func() {
+				body, err := json.Marshal(map[string]interface{}{
+					"kind_id": "test_email",
+					"text":    "This is the plain text body of the email",
+					"locale":  "pt-BR",
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				request, err = http.NewRequest("POST", "/spaces/space-001", bytes.NewBuffer(body))
+				Expect(err).NotTo(HaveOccurred())
+				request.Header.Set("Authorization", "Bearer "+rawToken)
+
+				_, err = handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(strategy.DispatchCallsCount).To(Equal(1))
+				Expect(strategy.DispatchCalls[0].Receives.Dispatch.Message.Locale).To(Equal("pt-BR"))
+			})
+
 			It("registers the client and kind", func() {
 				_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
 				Expect(err).NotTo(HaveOccurred())
@@ -159,6 +259,184 @@ var _ = Describe("Notify", func() {
 				Expect(registrar.RegisterCall.Receives.Kinds).To(ConsistOf([]models.Kind{kind}))
 			})
 
+			It("counts the request against the quota of the client", func() {
+				_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(rateLimiter.LimitRequestsCall.Receives.Connection).To(Equal(conn))
+				Expect(rateLimiter.LimitRequestsCall.Receives.ClientID).To(Equal("mister-client"))
+				Expect(rateLimiter.LimitRequestsCall.Receives.KindID).To(Equal("test_email"))
+			})
+
+			It("does not record an idempotency key when the header is missing", func() {
+				_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(idempotencyKeys.CreateCall.CallCount).To(Equal(0))
+			})
+
+			Context("when an Idempotency-Key header is provided", func() {
+				BeforeEach(func() {
+					request.Header.Set("Idempotency-Key", "some-key")
+					idempotencyKeys.CreateCall.Returns.IdempotencyKey = models.IdempotencyKey{
+						Primary:  42,
+						ClientID: "mister-client",
+						Key:      "some-key",
+					}
+					strategy.DispatchCalls = append(strategy.DispatchCalls, mocks.NewStrategyDispatchCall([]services.Response{
+						{Status: "queued", Recipient: "user-123", NotificationID: "notification-123"},
+					}, nil))
+				})
+
+				It("reserves the key for the client and stores the response", func() {
+					output, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(idempotencyKeys.CreateCall.Receives.Connection).To(Equal(conn))
+					Expect(idempotencyKeys.CreateCall.Receives.IdempotencyKey.ClientID).To(Equal("mister-client"))
+					Expect(idempotencyKeys.CreateCall.Receives.IdempotencyKey.Key).To(Equal("some-key"))
+					Expect(idempotencyKeys.CreateCall.Receives.IdempotencyKey.RequestHash).To(HaveLen(64))
+
+					Expect(strategy.DispatchCallsCount).To(Equal(1))
+
+					Expect(idempotencyKeys.UpdateCall.CallCount).To(Equal(1))
+					Expect(idempotencyKeys.UpdateCall.Receives.IdempotencyKey.Primary).To(Equal(42))
+					Expect(idempotencyKeys.UpdateCall.Receives.IdempotencyKey.Response).To(Equal(string(output)))
+				})
+
+				It("replays the stored response without dispatching again", func() {
+					_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).NotTo(HaveOccurred())
+					stored := idempotencyKeys.UpdateCall.Receives.IdempotencyKey
+					stored.RequestHash = idempotencyKeys.CreateCall.Receives.IdempotencyKey.RequestHash
+
+					idempotencyKeys.CreateCall.Returns.Error = models.DuplicateError{Err: errors.New("duplicate record")}
+					idempotencyKeys.FindCall.Returns.IdempotencyKey = stored
+
+					request.Body = io.NopCloser(bytes.NewBuffer(requestBody))
+					output, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(output).To(MatchJSON(stored.Response))
+
+					Expect(idempotencyKeys.FindCall.Receives.ClientID).To(Equal("mister-client"))
+					Expect(idempotencyKeys.FindCall.Receives.Key).To(Equal("some-key"))
+					Expect(strategy.DispatchCallsCount).To(Equal(1))
+				})
+
+				It("returns a conflict when the original request is still being processed", func() {
+					_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).NotTo(HaveOccurred())
+					hash := idempotencyKeys.CreateCall.Receives.IdempotencyKey.RequestHash
+
+					idempotencyKeys.CreateCall.Returns.Error = models.DuplicateError{Err: errors.New("duplicate record")}
+					idempotencyKeys.FindCall.Returns.IdempotencyKey = models.IdempotencyKey{
+						ClientID:    "mister-client",
+						Key:         "some-key",
+						RequestHash: hash,
+						CreatedAt:   time.Now().Add(-10 * time.Second),
+					}
+
+					request.Body = io.NopCloser(bytes.NewBuffer(requestBody))
+					_, err = handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).To(MatchError(webutil.IdempotencyKeyConflictError{Err: errors.New(`A request with this "Idempotency-Key" is still being processed`)}))
+					Expect(idempotencyKeys.ReclaimCall.CallCount).To(Equal(0))
+				})
+
+				Context("when the original request left its reservation without a response", func() {
+					var stale models.IdempotencyKey
+
+					BeforeEach(func() {
+						_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+						Expect(err).NotTo(HaveOccurred())
+
+						stale = models.IdempotencyKey{
+							Primary:     42,
+							ClientID:    "mister-client",
+							Key:         "some-key",
+							RequestHash: idempotencyKeys.CreateCall.Receives.IdempotencyKey.RequestHash,
+							CreatedAt:   time.Now().Add(-2 * time.Minute),
+						}
+						idempotencyKeys.CreateCall.Returns.Error = models.DuplicateError{Err: errors.New("duplicate record")}
+						idempotencyKeys.FindCall.Returns.IdempotencyKey = stale
+						idempotencyKeys.ReclaimCall.Returns.IdempotencyKey = models.IdempotencyKey{Primary: 42, Key: "some-key"}
+						request.Body = io.NopCloser(bytes.NewBuffer(requestBody))
+					})
+
+					It("takes the reservation over and sends the notification", func() {
+						_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(idempotencyKeys.ReclaimCall.Receives.IdempotencyKey).To(Equal(stale))
+						Expect(strategy.DispatchCallsCount).To(Equal(2))
+						Expect(idempotencyKeys.UpdateCall.Receives.IdempotencyKey.Primary).To(Equal(42))
+					})
+
+					It("returns a conflict when another retry took it over first", func() {
+						idempotencyKeys.ReclaimCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+						_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+						Expect(err).To(MatchError(webutil.IdempotencyKeyConflictError{Err: errors.New(`A request with this "Idempotency-Key" is still being processed`)}))
+						Expect(strategy.DispatchCallsCount).To(Equal(1))
+					})
+				})
+
+				It("rejects a key that was used for a different request", func() {
+					idempotencyKeys.CreateCall.Returns.Error = models.DuplicateError{Err: errors.New("duplicate record")}
+					idempotencyKeys.FindCall.Returns.IdempotencyKey = models.IdempotencyKey{
+						ClientID:    "mister-client",
+						Key:         "some-key",
+						RequestHash: "some-other-hash",
+						Response:    "[]",
+					}
+
+					_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).To(MatchError(webutil.ValidationError{Err: errors.New(`"Idempotency-Key" has already been used for a different request`)}))
+					Expect(strategy.DispatchCallsCount).To(Equal(0))
+				})
+
+				It("releases the key when the dispatch fails", func() {
+					strategy.DispatchCalls[0] = mocks.NewStrategyDispatchCall([]services.Response{}, errors.New("BOOM!"))
+
+					_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).To(MatchError(errors.New("BOOM!")))
+
+					Expect(idempotencyKeys.DeleteCall.CallCount).To(Equal(1))
+					Expect(idempotencyKeys.DeleteCall.Receives.IdempotencyKey.Primary).To(Equal(42))
+					Expect(idempotencyKeys.UpdateCall.CallCount).To(Equal(0))
+				})
+
+				It("logs when the key cannot be released", func() {
+					strategy.DispatchCalls[0] = mocks.NewStrategyDispatchCall([]services.Response{}, errors.New("BOOM!"))
+					idempotencyKeys.DeleteCall.Returns.Error = errors.New("database is down")
+
+					_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).To(MatchError(errors.New("BOOM!")))
+
+					Expect(buffer.String()).To(ContainSubstring(`"message":"notifications.idempotency-key-release-failed"`))
+					Expect(buffer.String()).To(ContainSubstring(`"error":"database is down"`))
+					Expect(buffer.String()).To(ContainSubstring(`"idempotency_key":"some-key"`))
+				})
+
+				It("logs when the response cannot be stored, still returning it", func() {
+					idempotencyKeys.UpdateCall.Returns.Error = errors.New("database is down")
+
+					output, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(output).To(MatchJSON(`[{"status": "queued", "recipient": "user-123", "notification_id": "notification-123", "vcap_request_id": ""}]`))
+
+					Expect(buffer.String()).To(ContainSubstring(`"message":"notifications.idempotency-key-store-failed"`))
+					Expect(buffer.String()).To(ContainSubstring(`"error":"database is down"`))
+				})
+
+				It("returns the error when the key cannot be reserved", func() {
+					idempotencyKeys.CreateCall.Returns.Error = errors.New("database is down")
+
+					_, err := handler.Execute(conn, request, context, "space-001", strategy, validator, vcapRequestID)
+					Expect(err).To(MatchError(errors.New("database is down")))
+					Expect(strategy.DispatchCallsCount).To(Equal(0))
+				})
+			})
+
 			Context("failure cases", func() {
 				Context("when validating params", func() {
 					It("returns a error response when params are missing", func() {
@@ -201,6 +479,17 @@ var _ = Describe("Notify", func() {
 					})
 				})
 
+				Context("when the client has exceeded its request quota", func() {
+					It("returns the error without dispatching", func() {
+						quotaError := services.QuotaExceededError{Err: errors.New("too many"), RetryAfter: time.Minute}
+						rateLimiter.LimitRequestsCall.Returns.Error = quotaError
+
+						_, err := handler.Execute(conn, request, context, "user-123", strategy, validator, vcapRequestID)
+						Expect(err).To(Equal(quotaError))
+						Expect(strategy.DispatchCallsCount).To(Equal(0))
+					})
+				})
+
 				Context("when the finder return errors", func() {
 					It("returns the error", func() {
 						finder.ClientAndKindCall.Returns.Error = errors.New("BOOM!")
@@ -208,6 +497,15 @@ var _ = Describe("Notify", func() {
 						_, err := handler.Execute(conn, request, context, "user-123", strategy, validator, vcapRequestID)
 						Expect(err).To(Equal(errors.New("BOOM!")))
 					})
+
+					It("does not count the request against the quota of the client", func() {
+						finder.ClientAndKindCall.Returns.Error = errors.New("BOOM!")
+						rateLimiter.LimitRequestsCall.Returns.Error = services.QuotaExceededError{Err: errors.New("too many"), RetryAfter: time.Minute}
+
+						_, err := handler.Execute(conn, request, context, "user-123", strategy, validator, vcapRequestID)
+						Expect(err).To(Equal(errors.New("BOOM!")))
+						Expect(rateLimiter.LimitRequestsCall.CallCount).To(Equal(0))
+					})
 				})
 
 				Context("when the registrar returns errors", func() {
@@ -16,15 +16,19 @@ type Routes struct {
 	Notify               notifyExecutor
 	ErrorWriter          errorWriter
 	UserStrategy         Dispatcher
+	UsersStrategy        Dispatcher
 	SpaceStrategy        Dispatcher
 	OrganizationStrategy Dispatcher
 	EveryoneStrategy     Dispatcher
 	UAAScopeStrategy     Dispatcher
 	EmailStrategy        Dispatcher
+
+	MaxUsersBatchSize int
 }
 
 func (r Routes) Register(m muxer) {
 	m.Handle("POST", "/users/{user_id}", NewUserHandler(r.Notify, r.ErrorWriter, r.UserStrategy), r.RequestLogging, r.RequestCounter, r.NotificationsWriteAuthenticator, r.DatabaseAllocator)
+	m.Handle("POST", "/users", NewUsersHandler(r.Notify, r.ErrorWriter, r.UsersStrategy, r.MaxUsersBatchSize), r.RequestLogging, r.RequestCounter, r.NotificationsWriteAuthenticator, r.DatabaseAllocator)
 	m.Handle("POST", "/spaces/{space_id}", NewSpaceHandler(r.Notify, r.ErrorWriter, r.SpaceStrategy), r.RequestLogging, r.RequestCounter, r.NotificationsWriteAuthenticator, r.DatabaseAllocator)
 	m.Handle("POST", "/organizations/{org_id}", NewOrganizationHandler(r.Notify, r.ErrorWriter, r.OrganizationStrategy), r.RequestLogging, r.RequestCounter, r.NotificationsWriteAuthenticator, r.DatabaseAllocator)
 	m.Handle("POST", "/everyone", NewEveryoneHandler(r.Notify, r.ErrorWriter, r.EveryoneStrategy), r.RequestLogging, r.RequestCounter, r.NotificationsWriteAuthenticator, r.DatabaseAllocator)
@@ -23,11 +23,13 @@ var _ = Describe("Routes", func() {
 			Notify:               mocks.NewNotify(),
 			ErrorWriter:          mocks.NewErrorWriter(),
 			UserStrategy:         mocks.NewStrategy(),
+			UsersStrategy:        mocks.NewStrategy(),
 			SpaceStrategy:        mocks.NewStrategy(),
 			OrganizationStrategy: mocks.NewStrategy(),
 			EveryoneStrategy:     mocks.NewStrategy(),
 			UAAScopeStrategy:     mocks.NewStrategy(),
 			EmailStrategy:        mocks.NewStrategy(),
+			MaxUsersBatchSize:    100,
 
 			RequestCounter:                  middleware.RequestCounter{},
 			RequestLogging:                  middleware.RequestLogging{},
@@ -49,6 +51,18 @@ var _ = Describe("Routes", func() {
 		Expect(authenticator.Scopes).To(Equal([]string{"notifications.write"}))
 	})
 
+	It("routes POST /users", func() {
+		request, err := http.NewRequest("POST", "/users", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		s := muxer.Match(request).(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(notify.UsersHandler{}))
+		ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.Authenticator{}, middleware.DatabaseAllocator{})
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(Equal([]string{"notifications.write"}))
+	})
+
 	It("routes POST /spaces/{space_id}", func() {
 		request, err := http.NewRequest("POST", "/spaces/{space_id}", nil)
 		Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,37 @@
+package notify
+
+import (
+	"net/http"
+
+	"github.com/ryanmoran/stack"
+)
+
+type UsersHandler struct {
+	errorWriter  errorWriter
+	notify       notifyExecutor
+	strategy     Dispatcher
+	maxBatchSize int
+}
+
+func NewUsersHandler(notify notifyExecutor, errWriter errorWriter, strategy Dispatcher, maxBatchSize int) UsersHandler {
+	return UsersHandler{
+		errorWriter:  errWriter,
+		notify:       notify,
+		strategy:     strategy,
+		maxBatchSize: maxBatchSize,
+	}
+}
+
+func (h UsersHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	conn := context.Get("database").(DatabaseInterface).Connection()
+	vcapRequestID := context.Get(VCAPRequestIDKey).(string)
+
+	output, err := h.notify.Execute(conn, req, context, "", h.strategy, UsersValidator{MaxBatchSize: h.maxBatchSize}, vcapRequestID)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(output)
+}
@@ -0,0 +1,111 @@
+package preferences
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/cloudfoundry-incubator/notifications/valiant"
+	"github.com/ryanmoran/stack"
+)
+
+var validOrganizationRoles = []string{"", "OrgManager", "OrgAuditor", "BillingManager"}
+
+type preferenceJobCreator interface {
+	Create(connection services.ConnectionInterface, payload services.PreferencesJob) (models.PreferenceJob, error)
+}
+
+type createJobRequest struct {
+	UserIDs          []string            `json:"user_ids"`
+	OrganizationGUID string              `json:"organization_guid"`
+	OrganizationRole string              `json:"organization_role"`
+	SpaceGUID        string              `json:"space_guid"`
+	Clients          services.ClientsMap `json:"clients" validate-required:"true"`
+}
+
+// validate checks that the request targets exactly one of a list of users,
+// an organization or a space.
+func (r createJobRequest) validate() error {
+	targets := 0
+	for _, given := range []bool{len(r.UserIDs) > 0, r.OrganizationGUID != "", r.SpaceGUID != ""} {
+		if given {
+			targets++
+		}
+	}
+
+	if targets != 1 {
+		return errors.New(`Exactly one of "user_ids", "organization_guid" or "space_guid" must be given`)
+	}
+
+	if r.OrganizationGUID == "" && r.OrganizationRole != "" {
+		return errors.New(`"organization_role" can only be given with "organization_guid"`)
+	}
+
+	for _, role := range validOrganizationRoles {
+		if r.OrganizationRole == role {
+			return nil
+		}
+	}
+
+	return errors.New(`"organization_role" must be "OrgManager", "OrgAuditor", "BillingManager" or unset`)
+}
+
+type CreateJobHandler struct {
+	creator     preferenceJobCreator
+	errorWriter errorWriter
+}
+
+func NewCreateJobHandler(creator preferenceJobCreator, errWriter errorWriter) CreateJobHandler {
+	return CreateJobHandler{
+		creator:     creator,
+		errorWriter: errWriter,
+	}
+}
+
+func (h CreateJobHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	database := context.Get("database").(DatabaseInterface)
+
+	var request createJobRequest
+	err := valiant.NewValidator(req.Body).Validate(&request)
+	if err != nil {
+		h.errorWriter.Write(w, webutil.ValidationError{Err: err})
+		return
+	}
+
+	err = request.validate()
+	if err != nil {
+		h.errorWriter.Write(w, webutil.ValidationError{Err: err})
+		return
+	}
+
+	preferences, err := services.PreferencesBuilder{Clients: request.Clients}.ToPreferences()
+	if err != nil {
+		h.errorWriter.Write(w, webutil.ValidationError{Err: err})
+		return
+	}
+
+	job, err := h.creator.Create(database.Connection(), services.PreferencesJob{
+		UserIDs:          request.UserIDs,
+		OrganizationGUID: request.OrganizationGUID,
+		OrganizationRole: request.OrganizationRole,
+		SpaceGUID:        request.SpaceGUID,
+		Preferences:      preferences,
+		Actor: services.Actor{
+			ID:     clientIDFromToken(context),
+			Source: models.PreferenceChangeSourceAPI,
+		},
+	})
+	if err != nil {
+		switch err.(type) {
+		case services.MissingKindOrClientError, services.CriticalKindError, services.InvalidFrequencyError:
+			h.errorWriter.Write(w, webutil.ValidationError{Err: err})
+		default:
+			h.errorWriter.Write(w, err)
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, newJobDocument(job))
+}
@@ -0,0 +1,192 @@
+package preferences_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/preferences"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CreateJobHandler", func() {
+	var (
+		handler     preferences.CreateJobHandler
+		errorWriter *mocks.ErrorWriter
+		writer      *httptest.ResponseRecorder
+		creator     *mocks.PreferenceJobCreator
+		conn        *mocks.Connection
+		context     stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		creator = mocks.NewPreferenceJobCreator()
+		creator.CreateCall.Returns.Job = models.PreferenceJob{
+			ID:        "some-job-id",
+			Status:    models.PreferenceJobStatusQueued,
+			Total:     2,
+			CreatedAt: time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+		}
+
+		conn = mocks.NewConnection()
+		database := mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		tokenHeader := map[string]interface{}{
+			"alg": "RS256",
+		}
+		claims := jwt.MapClaims{
+			"client_id": "mister-client",
+			"exp":       int64(3404281214),
+		}
+		token, err := jwt.Parse(helpers.BuildToken(tokenHeader, claims), func(*jwt.Token) (interface{}, error) {
+			return helpers.UAAPublicKeyRSA, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		context.Set("token", token)
+
+		handler = preferences.NewCreateJobHandler(creator, errorWriter)
+	})
+
+	post := func(body string) {
+		request, err := http.NewRequest("POST", "/user_preferences/jobs", bytes.NewBufferString(body))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+	}
+
+	It("creates a job for the listed users", func() {
+		post(`{
+			"user_ids": ["user-1", "user-2"],
+			"clients": {
+				"dogs": {
+					"barking": {"email": false, "frequency": "weekly"}
+				}
+			}
+		}`)
+
+		Expect(writer.Code).To(Equal(http.StatusAccepted))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"id": "some-job-id",
+			"status": "queued",
+			"total": 2,
+			"processed": 0,
+			"failed": 0,
+			"created_at": "2015-06-08T14:00:00Z",
+			"updated_at": "2015-06-08T14:00:00Z"
+		}`))
+
+		Expect(creator.CreateCall.Receives.Connection).To(Equal(conn))
+		Expect(creator.CreateCall.Receives.Payload).To(Equal(services.PreferencesJob{
+			UserIDs: []string{"user-1", "user-2"},
+			Preferences: []models.Preference{
+				{
+					ClientID:  "dogs",
+					KindID:    "barking",
+					Email:     false,
+					Frequency: models.FrequencyWeekly,
+				},
+			},
+			Actor: services.Actor{
+				ID:     "mister-client",
+				Source: models.PreferenceChangeSourceAPI,
+			},
+		}))
+	})
+
+	It("creates a job for the members of an organization with a role", func() {
+		post(`{
+			"organization_guid": "some-org-guid",
+			"organization_role": "OrgManager",
+			"clients": {"dogs": {"barking": {"email": true}}}
+		}`)
+
+		Expect(writer.Code).To(Equal(http.StatusAccepted))
+		Expect(creator.CreateCall.Receives.Payload.OrganizationGUID).To(Equal("some-org-guid"))
+		Expect(creator.CreateCall.Receives.Payload.OrganizationRole).To(Equal("OrgManager"))
+	})
+
+	It("creates a job for the members of a space", func() {
+		post(`{
+			"space_guid": "some-space-guid",
+			"clients": {"dogs": {"barking": {"email": true}}}
+		}`)
+
+		Expect(writer.Code).To(Equal(http.StatusAccepted))
+		Expect(creator.CreateCall.Receives.Payload.SpaceGUID).To(Equal("some-space-guid"))
+	})
+
+	Context("failure cases", func() {
+		It("rejects requests without any users", func() {
+			post(`{"clients": {"dogs": {"barking": {"email": true}}}}`)
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(Equal(webutil.ValidationError{Err: errors.New(`Exactly one of "user_ids", "organization_guid" or "space_guid" must be given`)}))
+		})
+
+		It("rejects requests with more than one kind of users", func() {
+			post(`{
+				"user_ids": ["user-1"],
+				"space_guid": "some-space-guid",
+				"clients": {"dogs": {"barking": {"email": true}}}
+			}`)
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(BeAssignableToTypeOf(webutil.ValidationError{}))
+		})
+
+		It("rejects unknown organization roles", func() {
+			post(`{
+				"organization_guid": "some-org-guid",
+				"organization_role": "SpaceDeveloper",
+				"clients": {"dogs": {"barking": {"email": true}}}
+			}`)
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(Equal(webutil.ValidationError{Err: errors.New(`"organization_role" must be "OrgManager", "OrgAuditor", "BillingManager" or unset`)}))
+		})
+
+		It("rejects requests without clients", func() {
+			post(`{"user_ids": ["user-1"]}`)
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(BeAssignableToTypeOf(webutil.ValidationError{}))
+			Expect(creator.CreateCall.Receives.Connection).To(BeNil())
+		})
+
+		It("rejects preferences without the email field", func() {
+			post(`{"user_ids": ["user-1"], "clients": {"dogs": {"barking": {}}}}`)
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(Equal(webutil.ValidationError{Err: errors.New("Missing the email field")}))
+		})
+
+		It("returns a validation error when the preferences are invalid", func() {
+			creator.CreateCall.Returns.Error = services.CriticalKindError{Err: errors.New("critical")}
+
+			post(`{"user_ids": ["user-1"], "clients": {"dogs": {"barking": {"email": false}}}}`)
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(Equal(webutil.ValidationError{Err: services.CriticalKindError{Err: errors.New("critical")}}))
+		})
+
+		It("returns other errors as they are", func() {
+			creator.CreateCall.Returns.Error = errors.New("database is down")
+
+			post(`{"user_ids": ["user-1"], "clients": {"dogs": {"barking": {"email": false}}}}`)
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("database is down")))
+		})
+	})
+})
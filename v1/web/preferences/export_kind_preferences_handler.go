@@ -0,0 +1,102 @@
+package preferences
+
+import (
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+var kindPreferencesPath = regexp.MustCompile("/clients/([^/]+)/notifications/([^/]+)/user_preferences$")
+
+type kindPreferencesFinder interface {
+	Find(database services.DatabaseInterface, clientID, kindID string) ([]models.KindPreference, error)
+}
+
+type ExportKindPreferencesHandler struct {
+	finder      kindPreferencesFinder
+	errorWriter errorWriter
+}
+
+func NewExportKindPreferencesHandler(finder kindPreferencesFinder, errWriter errorWriter) ExportKindPreferencesHandler {
+	return ExportKindPreferencesHandler{
+		finder:      finder,
+		errorWriter: errWriter,
+	}
+}
+
+func (h ExportKindPreferencesHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	var clientID, kindID string
+	if matches := kindPreferencesPath.FindStringSubmatch(req.URL.Path); matches != nil {
+		clientID, kindID = matches[1], matches[2]
+	}
+
+	format := req.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		h.errorWriter.Write(w, webutil.ValidationError{Err: errors.New(`"format" must be "json", "csv" or unset`)})
+		return
+	}
+
+	preferences, err := h.finder.Find(context.Get("database").(DatabaseInterface), clientID, kindID)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	if format == "csv" {
+		writeKindPreferencesCSV(w, preferences)
+		return
+	}
+
+	document := kindPreferencesDocument{
+		ClientID:        clientID,
+		KindID:          kindID,
+		UserPreferences: []kindPreferenceDocument{},
+	}
+	for _, preference := range preferences {
+		document.UserPreferences = append(document.UserPreferences, kindPreferenceDocument{
+			UserID:            preference.UserID,
+			Email:             preference.Email,
+			GlobalUnsubscribe: preference.GlobalUnsubscribe,
+			Frequency:         preference.Frequency,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, document)
+}
+
+type kindPreferencesDocument struct {
+	ClientID        string                   `json:"client_id"`
+	KindID          string                   `json:"kind_id"`
+	UserPreferences []kindPreferenceDocument `json:"user_preferences"`
+}
+
+type kindPreferenceDocument struct {
+	UserID            string `json:"user_id"`
+	Email             bool   `json:"email"`
+	GlobalUnsubscribe bool   `json:"global_unsubscribe"`
+	Frequency         string `json:"frequency"`
+}
+
+func writeKindPreferencesCSV(w http.ResponseWriter, preferences []models.KindPreference) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"user_id", "email", "global_unsubscribe", "frequency"})
+	for _, preference := range preferences {
+		writer.Write([]string{
+			preference.UserID,
+			strconv.FormatBool(preference.Email),
+			strconv.FormatBool(preference.GlobalUnsubscribe),
+			preference.Frequency,
+		})
+	}
+	writer.Flush()
+}
@@ -0,0 +1,108 @@
+package preferences_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/preferences"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExportKindPreferencesHandler", func() {
+	var (
+		handler     preferences.ExportKindPreferencesHandler
+		errorWriter *mocks.ErrorWriter
+		writer      *httptest.ResponseRecorder
+		finder      *mocks.KindPreferencesFinder
+		database    *mocks.Database
+		context     stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		finder = mocks.NewKindPreferencesFinder()
+		finder.FindCall.Returns.Preferences = []models.KindPreference{
+			{UserID: "user-1", Email: true, Frequency: models.FrequencyImmediate},
+			{UserID: "user-2", Email: false, GlobalUnsubscribe: true, Frequency: models.FrequencyDaily},
+		}
+
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = preferences.NewExportKindPreferencesHandler(finder, errorWriter)
+	})
+
+	get := func(path string) {
+		request, err := http.NewRequest("GET", path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+	}
+
+	It("exports the preferences of the kind as JSON", func() {
+		get("/clients/dogs/notifications/barking/user_preferences")
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"client_id": "dogs",
+			"kind_id": "barking",
+			"user_preferences": [
+				{"user_id": "user-1", "email": true, "global_unsubscribe": false, "frequency": "immediate"},
+				{"user_id": "user-2", "email": false, "global_unsubscribe": true, "frequency": "daily"}
+			]
+		}`))
+
+		Expect(finder.FindCall.Receives.Database).To(Equal(database))
+		Expect(finder.FindCall.Receives.ClientID).To(Equal("dogs"))
+		Expect(finder.FindCall.Receives.KindID).To(Equal("barking"))
+	})
+
+	It("exports the preferences of the kind as CSV", func() {
+		get("/clients/dogs/notifications/barking/user_preferences?format=csv")
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Header().Get("Content-Type")).To(Equal("text/csv"))
+		Expect(writer.Body.String()).To(Equal("user_id,email,global_unsubscribe,frequency\n" +
+			"user-1,true,false,immediate\n" +
+			"user-2,false,true,daily\n"))
+	})
+
+	It("exports an empty list when no user has preferences for the kind", func() {
+		finder.FindCall.Returns.Preferences = []models.KindPreference{}
+
+		get("/clients/dogs/notifications/barking/user_preferences")
+
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"client_id": "dogs",
+			"kind_id": "barking",
+			"user_preferences": []
+		}`))
+	})
+
+	Context("failure cases", func() {
+		It("rejects unknown formats", func() {
+			get("/clients/dogs/notifications/barking/user_preferences?format=xml")
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(Equal(webutil.ValidationError{Err: errors.New(`"format" must be "json", "csv" or unset`)}))
+			Expect(finder.FindCall.Receives.Database).To(BeNil())
+		})
+
+		It("writes the error when the kind cannot be found", func() {
+			finder.FindCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+			get("/clients/dogs/notifications/barking/user_preferences")
+
+			Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+		})
+	})
+})
@@ -0,0 +1,63 @@
+package preferences
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/ryanmoran/stack"
+)
+
+type preferenceJobFinder interface {
+	Find(database services.DatabaseInterface, jobID string) (models.PreferenceJob, error)
+}
+
+type GetJobHandler struct {
+	finder      preferenceJobFinder
+	errorWriter errorWriter
+}
+
+func NewGetJobHandler(finder preferenceJobFinder, errWriter errorWriter) GetJobHandler {
+	return GetJobHandler{
+		finder:      finder,
+		errorWriter: errWriter,
+	}
+}
+
+func (h GetJobHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	jobID := strings.TrimPrefix(req.URL.Path, "/user_preferences/jobs/")
+
+	job, err := h.finder.Find(context.Get("database").(DatabaseInterface), jobID)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newJobDocument(job))
+}
+
+type jobDocument struct {
+	ID        string `json:"id"`
+	Status    string `json:"status"`
+	Total     int    `json:"total"`
+	Processed int    `json:"processed"`
+	Failed    int    `json:"failed"`
+	Error     string `json:"error,omitempty"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func newJobDocument(job models.PreferenceJob) jobDocument {
+	return jobDocument{
+		ID:        job.ID,
+		Status:    job.Status,
+		Total:     job.Total,
+		Processed: job.Processed,
+		Failed:    job.Failed,
+		Error:     job.LastError,
+		CreatedAt: job.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: job.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
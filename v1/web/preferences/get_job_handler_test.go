@@ -0,0 +1,82 @@
+package preferences_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/preferences"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetJobHandler", func() {
+	var (
+		handler     preferences.GetJobHandler
+		errorWriter *mocks.ErrorWriter
+		writer      *httptest.ResponseRecorder
+		finder      *mocks.PreferenceJobFinder
+		database    *mocks.Database
+		context     stack.Context
+		request     *http.Request
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		finder = mocks.NewPreferenceJobFinder()
+		finder.FindCall.Returns.Job = models.PreferenceJob{
+			ID:        "some-job-id",
+			Status:    models.PreferenceJobStatusRunning,
+			Total:     300,
+			Processed: 200,
+			Failed:    1,
+			LastError: "database is down",
+			CreatedAt: time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2015, 6, 8, 14, 5, 0, 0, time.UTC),
+		}
+
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		var err error
+		request, err = http.NewRequest("GET", "/user_preferences/jobs/some-job-id", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = preferences.NewGetJobHandler(finder, errorWriter)
+	})
+
+	It("returns the progress of the job", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"id": "some-job-id",
+			"status": "running",
+			"total": 300,
+			"processed": 200,
+			"failed": 1,
+			"error": "database is down",
+			"created_at": "2015-06-08T14:00:00Z",
+			"updated_at": "2015-06-08T14:05:00Z"
+		}`))
+
+		Expect(finder.FindCall.Receives.Database).To(Equal(database))
+		Expect(finder.FindCall.Receives.JobID).To(Equal("some-job-id"))
+	})
+
+	It("writes the error when the job cannot be found", func() {
+		finder.FindCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+	})
+})
@@ -0,0 +1,75 @@
+package preferences
+
+import (
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/ryanmoran/stack"
+)
+
+var userPreferenceChangesPath = regexp.MustCompile("/user_preferences/([^/]+)/changes$")
+
+type preferenceChangesFinder interface {
+	List(database services.DatabaseInterface, userID string) ([]models.PreferenceChange, error)
+}
+
+type GetUserPreferenceChangesHandler struct {
+	finder      preferenceChangesFinder
+	errorWriter errorWriter
+}
+
+func NewGetUserPreferenceChangesHandler(finder preferenceChangesFinder, errWriter errorWriter) GetUserPreferenceChangesHandler {
+	return GetUserPreferenceChangesHandler{
+		finder:      finder,
+		errorWriter: errWriter,
+	}
+}
+
+func (h GetUserPreferenceChangesHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	var userID string
+	if matches := userPreferenceChangesPath.FindStringSubmatch(req.URL.Path); matches != nil {
+		userID = matches[1]
+	}
+
+	changes, err := h.finder.List(context.Get("database").(DatabaseInterface), userID)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	document := preferenceChangesDocument{
+		Changes: []preferenceChangeDocument{},
+	}
+	for _, change := range changes {
+		document.Changes = append(document.Changes, preferenceChangeDocument{
+			ClientID:  change.ClientID,
+			KindID:    change.KindID,
+			Field:     change.Field,
+			OldValue:  change.OldValue,
+			NewValue:  change.NewValue,
+			Actor:     change.Actor,
+			Source:    change.Source,
+			CreatedAt: change.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, document)
+}
+
+type preferenceChangesDocument struct {
+	Changes []preferenceChangeDocument `json:"changes"`
+}
+
+type preferenceChangeDocument struct {
+	ClientID  string `json:"client_id"`
+	KindID    string `json:"kind_id"`
+	Field     string `json:"field"`
+	OldValue  string `json:"old_value"`
+	NewValue  string `json:"new_value"`
+	Actor     string `json:"actor"`
+	Source    string `json:"source"`
+	CreatedAt string `json:"created_at"`
+}
@@ -0,0 +1,117 @@
+package preferences_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/preferences"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetUserPreferenceChangesHandler", func() {
+	var (
+		handler     preferences.GetUserPreferenceChangesHandler
+		errorWriter *mocks.ErrorWriter
+		writer      *httptest.ResponseRecorder
+		finder      *mocks.PreferenceChangesFinder
+		database    *mocks.Database
+		context     stack.Context
+		request     *http.Request
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		finder = mocks.NewPreferenceChangesFinder()
+		finder.ListCall.Returns.Changes = []models.PreferenceChange{
+			{
+				UserID:    "some-user-id",
+				Actor:     "some-user-id",
+				Source:    models.PreferenceChangeSourceAPI,
+				Field:     models.PreferenceFieldGlobalUnsubscribe,
+				OldValue:  "false",
+				NewValue:  "true",
+				CreatedAt: time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+			},
+			{
+				UserID:    "some-user-id",
+				Actor:     "some-admin-client",
+				Source:    models.PreferenceChangeSourceAPI,
+				ClientID:  "dogs",
+				KindID:    "barking",
+				Field:     models.PreferenceFieldEmail,
+				OldValue:  "true",
+				NewValue:  "false",
+				CreatedAt: time.Date(2015, 6, 9, 8, 30, 0, 0, time.UTC),
+			},
+		}
+
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		var err error
+		request, err = http.NewRequest("GET", "/user_preferences/some-user-id/changes", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = preferences.NewGetUserPreferenceChangesHandler(finder, errorWriter)
+	})
+
+	It("returns the history of the preferences of the user", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"changes": [
+				{
+					"client_id": "",
+					"kind_id": "",
+					"field": "global_unsubscribe",
+					"old_value": "false",
+					"new_value": "true",
+					"actor": "some-user-id",
+					"source": "api",
+					"created_at": "2015-06-08T14:00:00Z"
+				},
+				{
+					"client_id": "dogs",
+					"kind_id": "barking",
+					"field": "email",
+					"old_value": "true",
+					"new_value": "false",
+					"actor": "some-admin-client",
+					"source": "api",
+					"created_at": "2015-06-09T08:30:00Z"
+				}
+			]
+		}`))
+
+		Expect(finder.ListCall.Receives.Database).To(Equal(database))
+		Expect(finder.ListCall.Receives.UserID).To(Equal("some-user-id"))
+	})
+
+	It("returns an empty list when nothing has changed", func() {
+		finder.ListCall.Returns.Changes = nil
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{"changes": []}`))
+	})
+
+	It("writes the error when the changes cannot be listed", func() {
+		finder.ListCall.Returns.Error = errors.New("database is down")
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("database is down")))
+	})
+})
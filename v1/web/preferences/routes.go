@@ -11,7 +11,9 @@ type muxer interface {
 }
 
 type preferenceUpdater interface {
-	Update(connection services.ConnectionInterface, preferences []models.Preference, globallyUnsubscribe bool, userID string) error
+	Update(connection services.ConnectionInterface, preferences []models.Preference, globallyUnsubscribe bool, frequency string, userID string, actor services.Actor) error
+	UpdateQuietHours(connection services.ConnectionInterface, userID string, timeZone string, quietHours *services.QuietHours, actor services.Actor) error
+	UpdateLocale(connection services.ConnectionInterface, userID string, locale string, actor services.Actor) error
 }
 
 type Routes struct {
@@ -23,9 +25,14 @@ type Routes struct {
 	NotificationPreferencesAdminAuthenticator stack.Middleware
 	NotificationPreferencesWriteAuthenticator stack.Middleware
 
-	ErrorWriter       errorWriter
-	PreferencesFinder preferencesFinder
-	PreferenceUpdater preferenceUpdater
+	ErrorWriter             errorWriter
+	PreferencesFinder       preferencesFinder
+	PreferenceUpdater       preferenceUpdater
+	PreferenceJobCreator    preferenceJobCreator
+	PreferenceJobFinder     preferenceJobFinder
+	KindPreferencesFinder   kindPreferencesFinder
+	PreferenceChangesFinder preferenceChangesFinder
+	Unsubscriber            unsubscriber
 }
 
 func (r Routes) Register(m muxer) {
@@ -33,6 +40,12 @@ func (r Routes) Register(m muxer) {
 	m.Handle("OPTIONS", "/user_preferences/{user_id}", NewOptionsHandler(), r.RequestLogging, r.RequestCounter, r.CORS)
 	m.Handle("GET", "/user_preferences", NewGetPreferencesHandler(r.PreferencesFinder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.CORS, r.NotificationPreferencesReadAuthenticator, r.DatabaseAllocator)
 	m.Handle("PATCH", "/user_preferences", NewUpdatePreferencesHandler(r.PreferenceUpdater, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.CORS, r.NotificationPreferencesWriteAuthenticator, r.DatabaseAllocator)
+	m.Handle("POST", "/user_preferences/jobs", NewCreateJobHandler(r.PreferenceJobCreator, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationPreferencesAdminAuthenticator, r.DatabaseAllocator)
+	m.Handle("GET", "/user_preferences/jobs/{job_id}", NewGetJobHandler(r.PreferenceJobFinder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationPreferencesAdminAuthenticator, r.DatabaseAllocator)
+	m.Handle("GET", "/clients/{client_id}/notifications/{kind_id}/user_preferences", NewExportKindPreferencesHandler(r.KindPreferencesFinder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationPreferencesAdminAuthenticator, r.DatabaseAllocator)
+	m.Handle("GET", "/user_preferences/{user_id}/changes", NewGetUserPreferenceChangesHandler(r.PreferenceChangesFinder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationPreferencesAdminAuthenticator, r.DatabaseAllocator)
 	m.Handle("GET", "/user_preferences/{user_id}", NewGetUserPreferencesHandler(r.PreferencesFinder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.CORS, r.NotificationPreferencesAdminAuthenticator, r.DatabaseAllocator)
 	m.Handle("PATCH", "/user_preferences/{user_id}", NewUpdateUserPreferencesHandler(r.PreferenceUpdater, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.CORS, r.NotificationPreferencesAdminAuthenticator, r.DatabaseAllocator)
+	m.Handle("GET", "/unsubscribe/{token}", NewUnsubscribeConfirmationHandler(), r.RequestLogging, r.RequestCounter)
+	m.Handle("POST", "/unsubscribe/{token}", NewUnsubscribeHandler(r.Unsubscriber, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.DatabaseAllocator)
 }
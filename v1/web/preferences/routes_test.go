@@ -24,6 +24,13 @@ var _ = Describe("Routes", func() {
 			PreferencesFinder: mocks.NewPreferencesFinder(),
 			PreferenceUpdater: mocks.NewPreferenceUpdater(),
 
+			PreferenceJobCreator:  mocks.NewPreferenceJobCreator(),
+			PreferenceJobFinder:   mocks.NewPreferenceJobFinder(),
+			KindPreferencesFinder: mocks.NewKindPreferencesFinder(),
+
+			PreferenceChangesFinder: mocks.NewPreferenceChangesFinder(),
+			Unsubscriber:            mocks.NewUnsubscriber(),
+
 			CORS:                                     middleware.CORS{},
 			RequestCounter:                           middleware.RequestCounter{},
 			RequestLogging:                           middleware.RequestLogging{},
@@ -103,4 +110,78 @@ var _ = Describe("Routes", func() {
 			ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.CORS{})
 		})
 	})
+
+	Describe("/user_preferences/jobs", func() {
+		It("routes POST /user_preferences/jobs", func() {
+			request, err := http.NewRequest("POST", "/user_preferences/jobs", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			s := muxer.Match(request).(stack.Stack)
+			Expect(s.Handler).To(BeAssignableToTypeOf(preferences.CreateJobHandler{}))
+			ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.Authenticator{}, middleware.DatabaseAllocator{})
+
+			authenticator := s.Middleware[2].(middleware.Authenticator)
+			Expect(authenticator.Scopes).To(Equal([]string{"notification_preferences.admin"}))
+		})
+
+		It("routes GET /user_preferences/jobs/{job_id}", func() {
+			request, err := http.NewRequest("GET", "/user_preferences/jobs/some-job-id", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			s := muxer.Match(request).(stack.Stack)
+			Expect(s.Handler).To(BeAssignableToTypeOf(preferences.GetJobHandler{}))
+			ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.Authenticator{}, middleware.DatabaseAllocator{})
+
+			authenticator := s.Middleware[2].(middleware.Authenticator)
+			Expect(authenticator.Scopes).To(Equal([]string{"notification_preferences.admin"}))
+		})
+	})
+
+	Describe("/user_preferences/{user_id}/changes", func() {
+		It("routes GET /user_preferences/{user_id}/changes", func() {
+			request, err := http.NewRequest("GET", "/user_preferences/some-user-id/changes", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			s := muxer.Match(request).(stack.Stack)
+			Expect(s.Handler).To(BeAssignableToTypeOf(preferences.GetUserPreferenceChangesHandler{}))
+			ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.Authenticator{}, middleware.DatabaseAllocator{})
+
+			authenticator := s.Middleware[2].(middleware.Authenticator)
+			Expect(authenticator.Scopes).To(Equal([]string{"notification_preferences.admin"}))
+		})
+	})
+
+	Describe("/unsubscribe/{token}", func() {
+		It("routes GET /unsubscribe/{token}", func() {
+			request, err := http.NewRequest("GET", "/unsubscribe/some-token", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			s := muxer.Match(request).(stack.Stack)
+			Expect(s.Handler).To(BeAssignableToTypeOf(preferences.UnsubscribeConfirmationHandler{}))
+			ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{})
+		})
+
+		It("routes POST /unsubscribe/{token}", func() {
+			request, err := http.NewRequest("POST", "/unsubscribe/some-token", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			s := muxer.Match(request).(stack.Stack)
+			Expect(s.Handler).To(BeAssignableToTypeOf(preferences.UnsubscribeHandler{}))
+			ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.DatabaseAllocator{})
+		})
+	})
+
+	Describe("/clients/{client_id}/notifications/{kind_id}/user_preferences", func() {
+		It("routes GET /clients/{client_id}/notifications/{kind_id}/user_preferences", func() {
+			request, err := http.NewRequest("GET", "/clients/some-client-id/notifications/some-kind-id/user_preferences", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			s := muxer.Match(request).(stack.Stack)
+			Expect(s.Handler).To(BeAssignableToTypeOf(preferences.ExportKindPreferencesHandler{}))
+			ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.Authenticator{}, middleware.DatabaseAllocator{})
+
+			authenticator := s.Middleware[2].(middleware.Authenticator)
+			Expect(authenticator.Scopes).To(Equal([]string{"notification_preferences.admin"}))
+		})
+	})
 })
@@ -0,0 +1,37 @@
+package preferences
+
+import (
+	"net/http"
+
+	"github.com/ryanmoran/stack"
+)
+
+// The form has no action so that it posts back to the unsubscribe link it
+// was served from, with the body of a one-click unsubscribe (RFC 8058).
+const unsubscribeConfirmationPage = `<!DOCTYPE html>
+<html>
+	<body>
+		<form method="post">
+			<input type="hidden" name="List-Unsubscribe" value="One-Click">
+			<p>Do you want to unsubscribe from these notifications?</p>
+			<button type="submit">Unsubscribe</button>
+		</form>
+	</body>
+</html>
+`
+
+// UnsubscribeConfirmationHandler serves the unsubscribe links of
+// notifications when they are opened. It changes nothing, so that link
+// scanners and prefetchers cannot unsubscribe users; the page asks the user
+// to confirm by posting back to the link.
+type UnsubscribeConfirmationHandler struct{}
+
+func NewUnsubscribeConfirmationHandler() UnsubscribeConfirmationHandler {
+	return UnsubscribeConfirmationHandler{}
+}
+
+func (h UnsubscribeConfirmationHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(unsubscribeConfirmationPage))
+}
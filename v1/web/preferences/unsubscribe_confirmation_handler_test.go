@@ -0,0 +1,28 @@
+package preferences_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/web/preferences"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UnsubscribeConfirmationHandler", func() {
+	It("asks the user to confirm with a form that posts back to the link", func() {
+		writer := httptest.NewRecorder()
+		request, err := http.NewRequest("GET", "/unsubscribe/c29tZS10b2tlbg==", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		preferences.NewUnsubscribeConfirmationHandler().ServeHTTP(writer, request, stack.NewContext())
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Header().Get("Content-Type")).To(Equal("text/html; charset=utf-8"))
+		Expect(writer.Body.String()).To(ContainSubstring(`<form method="post">`))
+		Expect(writer.Body.String()).To(ContainSubstring(`<input type="hidden" name="List-Unsubscribe" value="One-Click">`))
+		Expect(writer.Body.String()).NotTo(ContainSubstring("You have been unsubscribed"))
+	})
+})
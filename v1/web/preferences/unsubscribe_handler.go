@@ -0,0 +1,71 @@
+package preferences
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+const unsubscribedPage = `<!DOCTYPE html>
+<html>
+	<body>
+		<p>You have been unsubscribed from these notifications.</p>
+	</body>
+</html>
+`
+
+type unsubscriber interface {
+	Unsubscribe(conn services.ConnectionInterface, token string) error
+}
+
+// UnsubscribeHandler unsubscribes with the unsubscribe links of
+// notifications. It is reached without a token, both from the confirmation
+// page of the link in the body of a notification and from the one-click
+// unsubscribe of mail clients (RFC 8058), the token in the path being proof
+// enough of who the user is.
+type UnsubscribeHandler struct {
+	unsubscriber unsubscriber
+	errorWriter  errorWriter
+}
+
+func NewUnsubscribeHandler(unsubscriber unsubscriber, errWriter errorWriter) UnsubscribeHandler {
+	return UnsubscribeHandler{
+		unsubscriber: unsubscriber,
+		errorWriter:  errWriter,
+	}
+}
+
+func (h UnsubscribeHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	token := strings.TrimPrefix(req.URL.Path, "/unsubscribe/")
+
+	database := context.Get("database").(DatabaseInterface)
+	transaction := database.Connection().Transaction()
+	transaction.Begin()
+
+	err := h.unsubscriber.Unsubscribe(transaction, token)
+	if err != nil {
+		transaction.Rollback()
+
+		switch err.(type) {
+		case services.MissingKindOrClientError, services.CriticalKindError:
+			h.errorWriter.Write(w, webutil.ValidationError{Err: err})
+		default:
+			h.errorWriter.Write(w, err)
+		}
+		return
+	}
+
+	err = transaction.Commit()
+	if err != nil {
+		h.errorWriter.Write(w, models.TransactionCommitError{Err: err})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(unsubscribedPage))
+}
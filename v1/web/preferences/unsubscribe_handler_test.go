@@ -0,0 +1,104 @@
+package preferences_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/preferences"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UnsubscribeHandler", func() {
+	var (
+		handler      preferences.UnsubscribeHandler
+		errorWriter  *mocks.ErrorWriter
+		writer       *httptest.ResponseRecorder
+		unsubscriber *mocks.Unsubscriber
+		transaction  *mocks.Transaction
+		context      stack.Context
+		request      *http.Request
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+		unsubscriber = mocks.NewUnsubscriber()
+
+		transaction = mocks.NewTransaction()
+		conn := mocks.NewConnection()
+		conn.TransactionCall.Returns.Transaction = transaction
+		database := mocks.NewDatabase()
+		database.ConnectionCall.Returns.Connection = conn
+
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		var err error
+		request, err = http.NewRequest("POST", "/unsubscribe/c29tZS10b2tlbg==", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = preferences.NewUnsubscribeHandler(unsubscriber, errorWriter)
+	})
+
+	It("unsubscribes with the token in the path", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Header().Get("Content-Type")).To(Equal("text/html; charset=utf-8"))
+		Expect(writer.Body.String()).To(ContainSubstring("You have been unsubscribed"))
+
+		Expect(unsubscriber.UnsubscribeCall.Receives.Connection).To(Equal(transaction))
+		Expect(unsubscriber.UnsubscribeCall.Receives.Token).To(Equal("c29tZS10b2tlbg=="))
+
+		Expect(transaction.BeginCall.WasCalled).To(BeTrue())
+		Expect(transaction.CommitCall.WasCalled).To(BeTrue())
+		Expect(transaction.RollbackCall.WasCalled).To(BeFalse())
+	})
+
+	It("delegates CriticalKindErrors as webutil.ValidationError to the ErrorWriter", func() {
+		unsubscribeError := services.CriticalKindError{Err: errors.New("BOOM!")}
+		unsubscriber.UnsubscribeCall.Returns.Error = unsubscribeError
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: unsubscribeError}))
+		Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+		Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+	})
+
+	It("delegates MissingKindOrClientErrors as webutil.ValidationError to the ErrorWriter", func() {
+		unsubscribeError := services.MissingKindOrClientError{Err: errors.New("BOOM!")}
+		unsubscriber.UnsubscribeCall.Returns.Error = unsubscribeError
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: unsubscribeError}))
+		Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+	})
+
+	It("delegates other errors to the ErrorWriter", func() {
+		unsubscribeError := services.InvalidUnsubscribeTokenError{Err: errors.New("BOOM!")}
+		unsubscriber.UnsubscribeCall.Returns.Error = unsubscribeError
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(unsubscribeError))
+		Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+	})
+
+	It("writes a TransactionCommitError when the transaction cannot be committed", func() {
+		transaction.CommitCall.Returns.Error = errors.New("commit failed")
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(models.TransactionCommitError{Err: errors.New("commit failed")}))
+	})
+})
@@ -54,12 +54,21 @@ func (h UpdatePreferencesHandler) ServeHTTP(w http.ResponseWriter, req *http.Req
 
 	transaction := connection.Transaction()
 	transaction.Begin()
-	err = h.preferences.Update(transaction, preferences, builder.GlobalUnsubscribe, userID)
+	actor := services.Actor{ID: userID, Source: models.PreferenceChangeSourceAPI}
+	err = h.preferences.Update(transaction, preferences, builder.GlobalUnsubscribe, builder.Frequency, userID, actor)
+	if err == nil {
+		err = h.preferences.UpdateQuietHours(transaction, userID, builder.TimeZone, builder.QuietHours, actor)
+	}
+
+	if err == nil {
+		err = h.preferences.UpdateLocale(transaction, userID, builder.Locale, actor)
+	}
+
 	if err != nil {
 		transaction.Rollback()
 
 		switch err.(type) {
-		case services.MissingKindOrClientError, services.CriticalKindError:
+		case services.MissingKindOrClientError, services.CriticalKindError, services.InvalidFrequencyError, services.InvalidQuietHoursError, services.InvalidLocaleError:
 			h.errorWriter.Write(w, webutil.ValidationError{Err: err})
 		default:
 			h.errorWriter.Write(w, err)
@@ -56,11 +56,19 @@ var _ = Describe("UpdatePreferencesHandler", func() {
 				Email:    true,
 			})
 			builder.Add(models.Preference{
-				ClientID: "dogs",
-				KindID:   "barking",
-				Email:    false,
+				ClientID:  "dogs",
+				KindID:    "barking",
+				Email:     false,
+				Frequency: models.FrequencyWeekly,
 			})
 			builder.GlobalUnsubscribe = true
+			builder.Frequency = models.FrequencyDaily
+			builder.TimeZone = "Asia/Tokyo"
+			builder.Locale = "pt-BR"
+			builder.QuietHours = &services.QuietHours{
+				Start: "22:00",
+				End:   "07:00",
+			}
 
 			body, err := json.Marshal(builder)
 			Expect(err).NotTo(HaveOccurred())
@@ -111,13 +119,39 @@ var _ = Describe("UpdatePreferencesHandler", func() {
 				Email:    true,
 			}))
 			Expect(preferencesArguments).To(ContainElement(models.Preference{
-				ClientID: "dogs",
-				KindID:   "barking",
-				Email:    false,
+				ClientID:  "dogs",
+				KindID:    "barking",
+				Email:     false,
+				Frequency: models.FrequencyWeekly,
 			}))
 
 			Expect(updater.UpdateCall.Receives.GlobalUnsubscribe).To(BeTrue())
+			Expect(updater.UpdateCall.Receives.Frequency).To(Equal(models.FrequencyDaily))
 			Expect(updater.UpdateCall.Receives.UserID).To(Equal("correct-user"))
+			Expect(updater.UpdateCall.Receives.Actor).To(Equal(services.Actor{
+				ID:     "correct-user",
+				Source: models.PreferenceChangeSourceAPI,
+			}))
+		})
+
+		It("passes the time zone and quiet hours to the PreferenceUpdater", func() {
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(reflect.ValueOf(updater.UpdateQuietHoursCall.Receives.Connection).Pointer()).To(Equal(reflect.ValueOf(transaction).Pointer()))
+			Expect(updater.UpdateQuietHoursCall.Receives.UserID).To(Equal("correct-user"))
+			Expect(updater.UpdateQuietHoursCall.Receives.TimeZone).To(Equal("Asia/Tokyo"))
+			Expect(updater.UpdateQuietHoursCall.Receives.QuietHours).To(Equal(&services.QuietHours{
+				Start: "22:00",
+				End:   "07:00",
+			}))
+		})
+
+		It("passes the locale to the PreferenceUpdater", func() {
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(reflect.ValueOf(updater.UpdateLocaleCall.Receives.Connection).Pointer()).To(Equal(reflect.ValueOf(transaction).Pointer()))
+			Expect(updater.UpdateLocaleCall.Receives.UserID).To(Equal("correct-user"))
+			Expect(updater.UpdateLocaleCall.Receives.Locale).To(Equal("pt-BR"))
 		})
 
 		It("Returns a 204 status code when the Preference object does not error", func() {
@@ -207,6 +241,45 @@ var _ = Describe("UpdatePreferencesHandler", func() {
 					Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
 				})
 
+				It("delegates InvalidFrequencyErrors as webutil.ValidationError to the ErrorWriter", func() {
+					updateError := services.InvalidFrequencyError{Err: errors.New("BOOM!")}
+					updater.UpdateCall.Returns.Error = updateError
+
+					handler.ServeHTTP(writer, request, context)
+
+					Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: updateError}))
+
+					Expect(transaction.BeginCall.WasCalled).To(BeTrue())
+					Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+					Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+				})
+
+				It("delegates InvalidQuietHoursErrors as webutil.ValidationError to the ErrorWriter", func() {
+					updateError := services.InvalidQuietHoursError{Err: errors.New("BOOM!")}
+					updater.UpdateQuietHoursCall.Returns.Error = updateError
+
+					handler.ServeHTTP(writer, request, context)
+
+					Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: updateError}))
+
+					Expect(transaction.BeginCall.WasCalled).To(BeTrue())
+					Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+					Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+				})
+
+				It("delegates InvalidLocaleErrors as webutil.ValidationError to the ErrorWriter", func() {
+					updateError := services.InvalidLocaleError{Err: errors.New("BOOM!")}
+					updater.UpdateLocaleCall.Returns.Error = updateError
+
+					handler.ServeHTTP(writer, request, context)
+
+					Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: updateError}))
+
+					Expect(transaction.BeginCall.WasCalled).To(BeTrue())
+					Expect(transaction.CommitCall.WasCalled).To(BeFalse())
+					Expect(transaction.RollbackCall.WasCalled).To(BeTrue())
+				})
+
 				It("delegates other errors to the ErrorWriter", func() {
 					updater.UpdateCall.Returns.Error = errors.New("BOOM!")
 
@@ -9,6 +9,7 @@ import (
 	"github.com/cloudfoundry-incubator/notifications/v1/services"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
 	"github.com/cloudfoundry-incubator/notifications/valiant"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/ryanmoran/stack"
 )
 
@@ -46,12 +47,21 @@ func (h UpdateUserPreferencesHandler) ServeHTTP(w http.ResponseWriter, req *http
 
 	transaction := connection.Transaction()
 	transaction.Begin()
-	err = h.preferences.Update(transaction, preferences, builder.GlobalUnsubscribe, userGUID)
+	actor := services.Actor{ID: clientIDFromToken(context), Source: models.PreferenceChangeSourceAPI}
+	err = h.preferences.Update(transaction, preferences, builder.GlobalUnsubscribe, builder.Frequency, userGUID, actor)
+	if err == nil {
+		err = h.preferences.UpdateQuietHours(transaction, userGUID, builder.TimeZone, builder.QuietHours, actor)
+	}
+
+	if err == nil {
+		err = h.preferences.UpdateLocale(transaction, userGUID, builder.Locale, actor)
+	}
+
 	if err != nil {
 		transaction.Rollback()
 
 		switch err.(type) {
-		case services.MissingKindOrClientError, services.CriticalKindError:
+		case services.MissingKindOrClientError, services.CriticalKindError, services.InvalidFrequencyError, services.InvalidQuietHoursError, services.InvalidLocaleError:
 			h.errorWriter.Write(w, webutil.ValidationError{Err: err})
 		default:
 			h.errorWriter.Write(w, err)
@@ -68,6 +78,19 @@ func (h UpdateUserPreferencesHandler) ServeHTTP(w http.ResponseWriter, req *http
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// clientIDFromToken returns the ID of the client whose token authorized the
+// request, which is who admin changes to the preferences of a user are
+// recorded as made by.
+func clientIDFromToken(context stack.Context) string {
+	token, ok := context.Get("token").(*jwt.Token)
+	if !ok {
+		return ""
+	}
+
+	clientID, _ := token.Claims.(jwt.MapClaims)["client_id"].(string)
+	return clientID
+}
+
 func writeJSON(w http.ResponseWriter, status int, object interface{}) {
 	output, err := json.Marshal(object)
 	if err != nil {
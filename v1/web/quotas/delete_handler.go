@@ -0,0 +1,31 @@
+package quotas
+
+import (
+	"net/http"
+
+	"github.com/ryanmoran/stack"
+)
+
+type DeleteHandler struct {
+	updater     quotaUpdater
+	errorWriter errorWriter
+}
+
+func NewDeleteHandler(updater quotaUpdater, errWriter errorWriter) DeleteHandler {
+	return DeleteHandler{
+		updater:     updater,
+		errorWriter: errWriter,
+	}
+}
+
+func (h DeleteHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	clientID, kindID := parseQuotaPath(req.URL.Path)
+
+	err := h.updater.Delete(context.Get("database").(DatabaseInterface), clientID, kindID)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
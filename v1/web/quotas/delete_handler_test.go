@@ -0,0 +1,61 @@
+package quotas_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/quotas"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeleteHandler", func() {
+	var (
+		handler      quotas.DeleteHandler
+		errorWriter  *mocks.ErrorWriter
+		writer       *httptest.ResponseRecorder
+		quotaUpdater *mocks.QuotaUpdater
+		database     *mocks.Database
+		context      stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		quotaUpdater = mocks.NewQuotaUpdater()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = quotas.NewDeleteHandler(quotaUpdater, errorWriter)
+	})
+
+	remove := func(path string) {
+		request, err := http.NewRequest("DELETE", path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+	}
+
+	It("deletes the quota", func() {
+		remove("/quotas/some-client/some-kind")
+
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+		Expect(quotaUpdater.DeleteCall.Receives.Database).To(Equal(database))
+		Expect(quotaUpdater.DeleteCall.Receives.ClientID).To(Equal("some-client"))
+		Expect(quotaUpdater.DeleteCall.Receives.KindID).To(Equal("some-kind"))
+	})
+
+	It("delegates errors to the error writer", func() {
+		quotaUpdater.DeleteCall.Returns.Error = errors.New("BOOM!")
+
+		remove("/quotas/some-client")
+
+		Expect(errorWriter.WriteCall.Receives.Writer).To(Equal(writer))
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
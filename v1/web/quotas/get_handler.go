@@ -0,0 +1,88 @@
+package quotas
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/ryanmoran/stack"
+)
+
+type errorWriter interface {
+	Write(writer http.ResponseWriter, err error)
+}
+
+type quotaFinder interface {
+	List(services.DatabaseInterface) ([]models.Quota, error)
+	Find(services.DatabaseInterface, string, string) (models.Quota, error)
+}
+
+type GetHandler struct {
+	finder      quotaFinder
+	errorWriter errorWriter
+}
+
+func NewGetHandler(finder quotaFinder, errWriter errorWriter) GetHandler {
+	return GetHandler{
+		finder:      finder,
+		errorWriter: errWriter,
+	}
+}
+
+func (h GetHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	clientID, kindID := parseQuotaPath(req.URL.Path)
+
+	quota, err := h.finder.Find(context.Get("database").(DatabaseInterface), clientID, kindID)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newQuotaDocument(quota))
+}
+
+var quotaPath = regexp.MustCompile("/quotas/([^/]+)(?:/([^/]+))?$")
+
+// parseQuotaPath returns the client and kind of a quota path. The kind is
+// empty for the quota of the client as a whole.
+func parseQuotaPath(path string) (string, string) {
+	matches := quotaPath.FindStringSubmatch(path)
+	if matches == nil {
+		return "", ""
+	}
+
+	return matches[1], matches[2]
+}
+
+type quotaDocument struct {
+	ClientID          string `json:"client_id"`
+	KindID            string `json:"kind_id"`
+	RequestsPerMinute int    `json:"requests_per_minute"`
+	RecipientsPerHour int    `json:"recipients_per_hour"`
+	CreatedAt         string `json:"created_at"`
+	UpdatedAt         string `json:"updated_at"`
+}
+
+func newQuotaDocument(quota models.Quota) quotaDocument {
+	return quotaDocument{
+		ClientID:          quota.ClientID,
+		KindID:            quota.KindID,
+		RequestsPerMinute: quota.RequestsPerMinute,
+		RecipientsPerHour: quota.RecipientsPerHour,
+		CreatedAt:         quota.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:         quota.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, object interface{}) {
+	output, err := json.Marshal(object)
+	if err != nil {
+		panic(err) // No JSON we write into a response should ever panic
+	}
+
+	w.WriteHeader(status)
+	w.Write(output)
+}
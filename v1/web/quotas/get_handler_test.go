@@ -0,0 +1,86 @@
+package quotas_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/quotas"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetHandler", func() {
+	var (
+		handler     quotas.GetHandler
+		errorWriter *mocks.ErrorWriter
+		writer      *httptest.ResponseRecorder
+		quotaFinder *mocks.QuotaFinder
+		database    *mocks.Database
+		context     stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		quotaFinder = mocks.NewQuotaFinder()
+		quotaFinder.FindCall.Returns.Quota = models.Quota{
+			ClientID:          "some-client",
+			KindID:            "some-kind",
+			RequestsPerMinute: 10,
+			RecipientsPerHour: 500,
+			CreatedAt:         time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+			UpdatedAt:         time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+		}
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = quotas.NewGetHandler(quotaFinder, errorWriter)
+	})
+
+	get := func(path string) {
+		request, err := http.NewRequest("GET", path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+	}
+
+	It("returns the quota of the client and kind", func() {
+		get("/quotas/some-client/some-kind")
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"client_id": "some-client",
+			"kind_id": "some-kind",
+			"requests_per_minute": 10,
+			"recipients_per_hour": 500,
+			"created_at": "2015-06-08T14:00:00Z",
+			"updated_at": "2015-06-08T14:00:00Z"
+		}`))
+		Expect(quotaFinder.FindCall.Receives.Database).To(Equal(database))
+		Expect(quotaFinder.FindCall.Receives.ClientID).To(Equal("some-client"))
+		Expect(quotaFinder.FindCall.Receives.KindID).To(Equal("some-kind"))
+	})
+
+	It("finds the quota of the client as a whole when no kind is given", func() {
+		get("/quotas/some-client")
+
+		Expect(quotaFinder.FindCall.Receives.ClientID).To(Equal("some-client"))
+		Expect(quotaFinder.FindCall.Receives.KindID).To(Equal(""))
+	})
+
+	It("delegates errors to the error writer", func() {
+		quotaFinder.FindCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+		get("/quotas/some-client")
+
+		Expect(errorWriter.WriteCall.Receives.Writer).To(Equal(writer))
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+	})
+})
@@ -0,0 +1,38 @@
+package quotas
+
+import (
+	"net/http"
+
+	"github.com/ryanmoran/stack"
+)
+
+type ListHandler struct {
+	finder      quotaFinder
+	errorWriter errorWriter
+}
+
+func NewListHandler(finder quotaFinder, errWriter errorWriter) ListHandler {
+	return ListHandler{
+		finder:      finder,
+		errorWriter: errWriter,
+	}
+}
+
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	quotas, err := h.finder.List(context.Get("database").(DatabaseInterface))
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	var document struct {
+		Quotas []quotaDocument `json:"quotas"`
+	}
+	document.Quotas = []quotaDocument{}
+
+	for _, quota := range quotas {
+		document.Quotas = append(document.Quotas, newQuotaDocument(quota))
+	}
+
+	writeJSON(w, http.StatusOK, document)
+}
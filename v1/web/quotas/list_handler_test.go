@@ -0,0 +1,103 @@
+package quotas_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/quotas"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ListHandler", func() {
+	var (
+		handler     quotas.ListHandler
+		errorWriter *mocks.ErrorWriter
+		writer      *httptest.ResponseRecorder
+		request     *http.Request
+		quotaFinder *mocks.QuotaFinder
+		database    *mocks.Database
+		context     stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		quotaFinder = mocks.NewQuotaFinder()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		var err error
+		request, err = http.NewRequest("GET", "/quotas", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = quotas.NewListHandler(quotaFinder, errorWriter)
+	})
+
+	It("lists the quotas", func() {
+		quotaFinder.ListCall.Returns.Quotas = []models.Quota{
+			{
+				ClientID:          "some-client",
+				RequestsPerMinute: 100,
+				RecipientsPerHour: 10000,
+				CreatedAt:         time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+				UpdatedAt:         time.Date(2015, 6, 8, 15, 0, 0, 0, time.UTC),
+			},
+			{
+				ClientID:          "some-client",
+				KindID:            "some-kind",
+				RequestsPerMinute: 10,
+				CreatedAt:         time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+				UpdatedAt:         time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+			},
+		}
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"quotas": [
+				{
+					"client_id": "some-client",
+					"kind_id": "",
+					"requests_per_minute": 100,
+					"recipients_per_hour": 10000,
+					"created_at": "2015-06-08T14:00:00Z",
+					"updated_at": "2015-06-08T15:00:00Z"
+				},
+				{
+					"client_id": "some-client",
+					"kind_id": "some-kind",
+					"requests_per_minute": 10,
+					"recipients_per_hour": 0,
+					"created_at": "2015-06-08T14:00:00Z",
+					"updated_at": "2015-06-08T14:00:00Z"
+				}
+			]
+		}`))
+		Expect(quotaFinder.ListCall.Receives.Database).To(Equal(database))
+	})
+
+	It("returns an empty list when there are no quotas", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{"quotas": []}`))
+	})
+
+	It("delegates errors to the error writer", func() {
+		quotaFinder.ListCall.Returns.Error = errors.New("BOOM!")
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Writer).To(Equal(writer))
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
@@ -0,0 +1,28 @@
+package quotas
+
+import "github.com/ryanmoran/stack"
+
+type muxer interface {
+	Handle(method, path string, handler stack.Handler, middleware ...stack.Middleware)
+}
+
+type Routes struct {
+	RequestCounter                  stack.Middleware
+	RequestLogging                  stack.Middleware
+	NotificationsAdminAuthenticator stack.Middleware
+	DatabaseAllocator               stack.Middleware
+
+	QuotaFinder  quotaFinder
+	QuotaUpdater quotaUpdater
+	ErrorWriter  errorWriter
+}
+
+func (r Routes) Register(m muxer) {
+	m.Handle("GET", "/quotas", NewListHandler(r.QuotaFinder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsAdminAuthenticator, r.DatabaseAllocator)
+
+	for _, path := range []string{"/quotas/{client_id}", "/quotas/{client_id}/{kind_id}"} {
+		m.Handle("GET", path, NewGetHandler(r.QuotaFinder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsAdminAuthenticator, r.DatabaseAllocator)
+		m.Handle("PUT", path, NewUpdateHandler(r.QuotaUpdater, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsAdminAuthenticator, r.DatabaseAllocator)
+		m.Handle("DELETE", path, NewDeleteHandler(r.QuotaUpdater, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsAdminAuthenticator, r.DatabaseAllocator)
+	}
+}
@@ -0,0 +1,73 @@
+package quotas_test
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/middleware"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/quotas"
+	"github.com/cloudfoundry-incubator/notifications/web"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Routes", func() {
+	var muxer web.Muxer
+
+	BeforeEach(func() {
+		muxer = web.NewMuxer()
+		quotas.Routes{
+			RequestCounter:                  middleware.RequestCounter{},
+			RequestLogging:                  middleware.RequestLogging{},
+			DatabaseAllocator:               middleware.DatabaseAllocator{},
+			NotificationsAdminAuthenticator: middleware.Authenticator{Scopes: []string{"notifications.admin"}},
+
+			ErrorWriter:  mocks.NewErrorWriter(),
+			QuotaFinder:  mocks.NewQuotaFinder(),
+			QuotaUpdater: mocks.NewQuotaUpdater(),
+		}.Register(muxer)
+	})
+
+	expectRoute := func(method, path string, handler interface{}) {
+		request, err := http.NewRequest(method, path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		s := muxer.Match(request).(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handler))
+		ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.Authenticator{}, middleware.DatabaseAllocator{})
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(ConsistOf([]string{"notifications.admin"}))
+	}
+
+	It("routes GET /quotas", func() {
+		expectRoute("GET", "/quotas", quotas.ListHandler{})
+	})
+
+	It("routes GET /quotas/{client_id}", func() {
+		expectRoute("GET", "/quotas/some-client", quotas.GetHandler{})
+	})
+
+	It("routes PUT /quotas/{client_id}", func() {
+		expectRoute("PUT", "/quotas/some-client", quotas.UpdateHandler{})
+	})
+
+	It("routes DELETE /quotas/{client_id}", func() {
+		expectRoute("DELETE", "/quotas/some-client", quotas.DeleteHandler{})
+	})
+
+	It("routes GET /quotas/{client_id}/{kind_id}", func() {
+		expectRoute("GET", "/quotas/some-client/some-kind", quotas.GetHandler{})
+	})
+
+	It("routes PUT /quotas/{client_id}/{kind_id}", func() {
+		expectRoute("PUT", "/quotas/some-client/some-kind", quotas.UpdateHandler{})
+	})
+
+	It("routes DELETE /quotas/{client_id}/{kind_id}", func() {
+		expectRoute("DELETE", "/quotas/some-client/some-kind", quotas.DeleteHandler{})
+	})
+})
@@ -0,0 +1,67 @@
+package quotas
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+type quotaUpdater interface {
+	Update(services.DatabaseInterface, models.Quota) (models.Quota, error)
+	Delete(services.DatabaseInterface, string, string) error
+}
+
+type UpdateHandler struct {
+	updater     quotaUpdater
+	errorWriter errorWriter
+}
+
+func NewUpdateHandler(updater quotaUpdater, errWriter errorWriter) UpdateHandler {
+	return UpdateHandler{
+		updater:     updater,
+		errorWriter: errWriter,
+	}
+}
+
+func (h UpdateHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	var params struct {
+		RequestsPerMinute int `json:"requests_per_minute"`
+		RecipientsPerHour int `json:"recipients_per_hour"`
+	}
+
+	err := json.NewDecoder(req.Body).Decode(&params)
+	if err != nil {
+		h.errorWriter.Write(w, webutil.ParseError{})
+		return
+	}
+
+	if params.RequestsPerMinute < 0 {
+		h.errorWriter.Write(w, webutil.ValidationError{Err: errors.New(`"requests_per_minute" must not be negative`)})
+		return
+	}
+
+	if params.RecipientsPerHour < 0 {
+		h.errorWriter.Write(w, webutil.ValidationError{Err: errors.New(`"recipients_per_hour" must not be negative`)})
+		return
+	}
+
+	clientID, kindID := parseQuotaPath(req.URL.Path)
+
+	quota, err := h.updater.Update(context.Get("database").(DatabaseInterface), models.Quota{
+		ClientID:          clientID,
+		KindID:            kindID,
+		RequestsPerMinute: params.RequestsPerMinute,
+		RecipientsPerHour: params.RecipientsPerHour,
+	})
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newQuotaDocument(quota))
+}
@@ -0,0 +1,114 @@
+package quotas_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/quotas"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UpdateHandler", func() {
+	var (
+		handler      quotas.UpdateHandler
+		errorWriter  *mocks.ErrorWriter
+		writer       *httptest.ResponseRecorder
+		quotaUpdater *mocks.QuotaUpdater
+		database     *mocks.Database
+		context      stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		quotaUpdater = mocks.NewQuotaUpdater()
+		quotaUpdater.UpdateCall.Returns.Quota = models.Quota{
+			ClientID:          "some-client",
+			RequestsPerMinute: 100,
+			RecipientsPerHour: 10000,
+			CreatedAt:         time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+			UpdatedAt:         time.Date(2015, 6, 8, 15, 0, 0, 0, time.UTC),
+		}
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = quotas.NewUpdateHandler(quotaUpdater, errorWriter)
+	})
+
+	put := func(path, body string) {
+		request, err := http.NewRequest("PUT", path, bytes.NewBufferString(body))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+	}
+
+	It("sets the quota and returns it", func() {
+		put("/quotas/some-client", `{"requests_per_minute": 100, "recipients_per_hour": 10000}`)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"client_id": "some-client",
+			"kind_id": "",
+			"requests_per_minute": 100,
+			"recipients_per_hour": 10000,
+			"created_at": "2015-06-08T14:00:00Z",
+			"updated_at": "2015-06-08T15:00:00Z"
+		}`))
+		Expect(quotaUpdater.UpdateCall.Receives.Database).To(Equal(database))
+		Expect(quotaUpdater.UpdateCall.Receives.Quota).To(Equal(models.Quota{
+			ClientID:          "some-client",
+			RequestsPerMinute: 100,
+			RecipientsPerHour: 10000,
+		}))
+	})
+
+	It("sets the quota of a kind", func() {
+		put("/quotas/some-client/some-kind", `{"requests_per_minute": 10}`)
+
+		Expect(quotaUpdater.UpdateCall.Receives.Quota).To(Equal(models.Quota{
+			ClientID:          "some-client",
+			KindID:            "some-kind",
+			RequestsPerMinute: 10,
+		}))
+	})
+
+	It("rejects a body that cannot be parsed", func() {
+		put("/quotas/some-client", `{"requests_per_minute": `)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(Equal(webutil.ParseError{}))
+		Expect(quotaUpdater.UpdateCall.WasCalled).To(BeFalse())
+	})
+
+	It("rejects a negative number of requests", func() {
+		put("/quotas/some-client", `{"requests_per_minute": -1}`)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New(`"requests_per_minute" must not be negative`)}))
+		Expect(quotaUpdater.UpdateCall.WasCalled).To(BeFalse())
+	})
+
+	It("rejects a negative number of recipients", func() {
+		put("/quotas/some-client", `{"recipients_per_hour": -1}`)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New(`"recipients_per_hour" must not be negative`)}))
+		Expect(quotaUpdater.UpdateCall.WasCalled).To(BeFalse())
+	})
+
+	It("delegates errors to the error writer", func() {
+		quotaUpdater.UpdateCall.Returns.Error = errors.New("BOOM!")
+
+		put("/quotas/some-client", `{}`)
+
+		Expect(errorWriter.WriteCall.Receives.Writer).To(Equal(writer))
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
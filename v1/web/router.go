@@ -8,21 +8,26 @@ import (
 
 	"github.com/cloudfoundry-incubator/notifications/cf"
 	"github.com/cloudfoundry-incubator/notifications/gobble"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
 	"github.com/cloudfoundry-incubator/notifications/uaa"
 	"github.com/cloudfoundry-incubator/notifications/util"
 	"github.com/cloudfoundry-incubator/notifications/v1/collections"
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/cloudfoundry-incubator/notifications/v1/services"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/clients"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/deadjobs"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/info"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/messages"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/middleware"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/notifications"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/notify"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/preferences"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/quotas"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/suppressions"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
 	"github.com/gorilla/mux"
+	"github.com/pivotal-golang/conceal"
 	"github.com/pivotal-golang/lager"
 	"github.com/rcrowley/go-metrics"
 	"github.com/rcrowley/go-metrics/exp"
@@ -47,40 +52,79 @@ type Config struct {
 	CORSOrigin           string
 	SQLDB                *sql.DB
 	QueueWaitMaxDuration int
+	MaxUsersBatchSize    int
+	EncryptionKey        []byte
 }
 
 func NewRouter(mx muxer, config Config) http.Handler {
+	cloak, err := conceal.NewCloak(config.EncryptionKey)
+	if err != nil {
+		panic(err)
+	}
+
 	guidGenerator := util.NewIDGenerator(rand.Reader)
 	clock := util.NewClock()
 
 	clientsRepo := models.NewClientsRepo()
 	kindsRepo := models.NewKindsRepo()
 	globalUnsubscribesRepo := models.NewGlobalUnsubscribesRepo()
+	suppressionsRepo := models.NewSuppressionsRepo()
 	preferencesRepo := models.NewPreferencesRepo()
 	unsubscribesRepo := models.NewUnsubscribesRepo()
 	messagesRepo := models.NewMessagesRepo(guidGenerator.Generate)
+	messageEventsRepo := models.NewMessageEventsRepo()
 	templatesRepo := models.NewTemplatesRepo()
+	templateVersionsRepo := models.NewTemplateVersionsRepo()
+	idempotencyKeysRepo := models.NewIdempotencyKeysRepo()
+	quotasRepo := models.NewQuotasRepo()
+	quotaUsagesRepo := models.NewQuotaUsagesRepo()
+	deliveryFrequenciesRepo := models.NewDeliveryFrequenciesRepo()
+	quietHoursRepo := models.NewQuietHoursRepo()
+	userLocalesRepo := models.NewUserLocalesRepo()
+	preferenceJobsRepo := models.NewPreferenceJobsRepo(guidGenerator.Generate)
+	preferenceChangesRepo := models.NewPreferenceChangesRepo()
 
 	registrar := services.NewRegistrar(clientsRepo, kindsRepo)
 	notificationsFinder := services.NewNotificationsFinder(clientsRepo, kindsRepo)
-	preferencesFinder := services.NewPreferencesFinder(preferencesRepo, globalUnsubscribesRepo)
-	preferenceUpdater := services.NewPreferenceUpdater(globalUnsubscribesRepo, unsubscribesRepo, kindsRepo)
+	preferencesFinder := services.NewPreferencesFinder(preferencesRepo, globalUnsubscribesRepo, deliveryFrequenciesRepo, quietHoursRepo, userLocalesRepo)
+	preferenceUpdater := services.NewPreferenceUpdater(globalUnsubscribesRepo, unsubscribesRepo, kindsRepo, deliveryFrequenciesRepo, quietHoursRepo, userLocalesRepo, preferenceChangesRepo)
 	notificationsUpdater := services.NewNotificationsUpdater(kindsRepo)
-	messageFinder := services.NewMessageFinder(messagesRepo)
+	messageFinder := services.NewMessageFinder(messagesRepo, messageEventsRepo)
+	messageLister := services.NewMessageLister(messagesRepo)
 
-	templatesCollection := collections.NewTemplatesCollection(clientsRepo, kindsRepo, templatesRepo)
+	templatesCollection := collections.NewTemplatesCollection(clientsRepo, kindsRepo, templatesRepo, templateVersionsRepo)
 
 	templateFinder := services.NewTemplateFinder(templatesRepo)
-	templateUpdater := services.NewTemplateUpdater(templatesRepo)
+	templateUpdater := services.NewTemplateUpdater(templatesRepo, templateVersionsRepo)
+	templateVersionsFinder := services.NewTemplateVersionsFinder(templatesRepo, templateVersionsRepo)
+	// Rendering a template needs no templates loader, since the templates
+	// are given along with the context they are rendered with.
+	templateRenderer := services.NewTemplateRenderer(templatesRepo, common.NewPackager(nil, cloak, "", true))
 	templateLister := services.NewTemplateLister(templatesRepo)
 
-	notifyObj := notify.NewNotify(notificationsFinder, registrar)
+	rateLimiter := services.NewRateLimiter(quotasRepo, quotaUsagesRepo, clock)
+	quotaFinder := services.NewQuotaFinder(quotasRepo)
+	quotaUpdater := services.NewQuotaUpdater(quotasRepo)
+
+	notifyObj := notify.NewNotify(notificationsFinder, registrar, idempotencyKeysRepo, rateLimiter)
 
 	gobbleQueue := gobble.NewQueue(gobble.NewDatabase(config.SQLDB), clock, gobble.Config{
 		WaitMaxDuration: time.Duration(config.QueueWaitMaxDuration) * time.Millisecond,
 	})
 
-	v1enqueuer := services.NewEnqueuer(gobbleQueue, messagesRepo, gobble.Initializer{})
+	v1enqueuer := services.NewEnqueuer(gobbleQueue, messagesRepo, messageEventsRepo, gobble.Initializer{}, rateLimiter)
+	messageCanceller := services.NewMessageCanceller(messagesRepo, messageEventsRepo, gobbleQueue)
+	deadJobFinder := services.NewDeadJobFinder(gobbleQueue)
+	deadJobReplayer := services.NewDeadJobReplayer(gobbleQueue)
+	deadJobPurger := services.NewDeadJobPurger(gobbleQueue)
+	preferenceJobCreator := services.NewPreferenceJobCreator(gobbleQueue, gobble.Initializer{}, preferenceJobsRepo, preferenceUpdater)
+	preferenceJobFinder := services.NewPreferenceJobFinder(preferenceJobsRepo)
+	preferenceChangesFinder := services.NewPreferenceChangesFinder(preferenceChangesRepo)
+	unsubscriber := services.NewUnsubscriber(cloak, preferenceUpdater)
+	kindPreferencesFinder := services.NewKindPreferencesFinder(kindsRepo, preferencesRepo)
+	suppressor := services.NewSuppressor(suppressionsRepo)
+	suppressionFinder := services.NewSuppressionFinder(suppressionsRepo)
+	suppressionRemover := services.NewSuppressionRemover(suppressionsRepo)
 
 	uaaClient := uaa.NewZonedUAAClient(config.UAAClientID, config.UAAClientSecret, config.VerifySSL, config.UAATokenValidator)
 	cloudController := cf.NewCloudController(config.CCHost, !config.VerifySSL)
@@ -92,6 +136,7 @@ func NewRouter(mx muxer, config Config) http.Handler {
 
 	emailStrategy := services.NewEmailStrategy(v1enqueuer)
 	userStrategy := services.NewUserStrategy(v1enqueuer)
+	usersStrategy := services.NewUsersStrategy(v1enqueuer)
 	spaceStrategy := services.NewSpaceStrategy(tokenLoader, spaceLoader, organizationLoader, findsUserIDs, v1enqueuer)
 	organizationStrategy := services.NewOrganizationStrategy(tokenLoader, organizationLoader, findsUserIDs, v1enqueuer)
 	everyoneStrategy := services.NewEveryoneStrategy(tokenLoader, allUsers, v1enqueuer)
@@ -123,9 +168,15 @@ func NewRouter(mx muxer, config Config) http.Handler {
 		NotificationPreferencesWriteAuthenticator: auth("notification_preferences.write"),
 		NotificationPreferencesAdminAuthenticator: auth("notification_preferences.admin"),
 
-		ErrorWriter:       errorWriter,
-		PreferencesFinder: preferencesFinder,
-		PreferenceUpdater: preferenceUpdater,
+		ErrorWriter:           errorWriter,
+		PreferencesFinder:     preferencesFinder,
+		PreferenceUpdater:     preferenceUpdater,
+		PreferenceJobCreator:  preferenceJobCreator,
+		PreferenceJobFinder:   preferenceJobFinder,
+		KindPreferencesFinder: kindPreferencesFinder,
+
+		PreferenceChangesFinder: preferenceChangesFinder,
+		Unsubscriber:            unsubscriber,
 	}.Register(mx)
 
 	clients.Routes{
@@ -143,9 +194,48 @@ func NewRouter(mx muxer, config Config) http.Handler {
 		RequestLogging:                               requestLogging,
 		DatabaseAllocator:                            databaseAllocator,
 		NotificationsWriteOrEmailsWriteAuthenticator: auth("notifications.write", "emails.write"),
+		NotificationsManageAuthenticator:             auth("notifications.manage"),
+
+		ErrorWriter:      errorWriter,
+		MessageFinder:    messageFinder,
+		MessageLister:    messageLister,
+		MessageCanceller: messageCanceller,
+	}.Register(mx)
+
+	deadjobs.Routes{
+		RequestCounter:                  requestCounter,
+		RequestLogging:                  requestLogging,
+		DatabaseAllocator:               databaseAllocator,
+		NotificationsAdminAuthenticator: auth("notifications.admin"),
+
+		ErrorWriter:     errorWriter,
+		DeadJobFinder:   deadJobFinder,
+		DeadJobReplayer: deadJobReplayer,
+		DeadJobPurger:   deadJobPurger,
+	}.Register(mx)
+
+	suppressions.Routes{
+		RequestCounter:                  requestCounter,
+		RequestLogging:                  requestLogging,
+		DatabaseAllocator:               databaseAllocator,
+		BouncesWriteAuthenticator:       auth("bounces.write"),
+		NotificationsAdminAuthenticator: auth("notifications.admin"),
+
+		ErrorWriter:        errorWriter,
+		Suppressor:         suppressor,
+		SuppressionFinder:  suppressionFinder,
+		SuppressionRemover: suppressionRemover,
+	}.Register(mx)
+
+	quotas.Routes{
+		RequestCounter:                  requestCounter,
+		RequestLogging:                  requestLogging,
+		DatabaseAllocator:               databaseAllocator,
+		NotificationsAdminAuthenticator: auth("notifications.admin"),
 
-		ErrorWriter:   errorWriter,
-		MessageFinder: messageFinder,
+		ErrorWriter:  errorWriter,
+		QuotaFinder:  quotaFinder,
+		QuotaUpdater: quotaUpdater,
 	}.Register(mx)
 
 	templates.Routes{
@@ -163,6 +253,9 @@ func NewRouter(mx muxer, config Config) http.Handler {
 		TemplateDeleter:           templatesCollection,
 		TemplateLister:            templateLister,
 		TemplateAssociationLister: templatesCollection,
+		TemplateVersionsFinder:    templateVersionsFinder,
+		TemplateRollbacker:        templateUpdater,
+		TemplateRenderer:          templateRenderer,
 	}.Register(mx)
 
 	notifications.Routes{
@@ -189,11 +282,13 @@ func NewRouter(mx muxer, config Config) http.Handler {
 		ErrorWriter:          errorWriter,
 		Notify:               notifyObj,
 		UserStrategy:         userStrategy,
+		UsersStrategy:        usersStrategy,
 		SpaceStrategy:        spaceStrategy,
 		OrganizationStrategy: organizationStrategy,
 		EveryoneStrategy:     everyoneStrategy,
 		UAAScopeStrategy:     uaaScopeStrategy,
 		EmailStrategy:        emailStrategy,
+		MaxUsersBatchSize:    config.MaxUsersBatchSize,
 	}.Register(mx)
 
 	return mx
@@ -0,0 +1,55 @@
+package suppressions
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+// BounceHandler lets the mail relay report bounces and complaints that
+// arrive after the message was accepted for delivery.
+type BounceHandler struct {
+	suppressor  suppressor
+	errorWriter errorWriter
+}
+
+func NewBounceHandler(suppressor suppressor, errWriter errorWriter) BounceHandler {
+	return BounceHandler{
+		suppressor:  suppressor,
+		errorWriter: errWriter,
+	}
+}
+
+func (h BounceHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	var params struct {
+		Email  string `json:"email"`
+		Type   string `json:"type"`
+		Detail string `json:"detail"`
+	}
+
+	err := json.NewDecoder(req.Body).Decode(&params)
+	if err != nil {
+		h.errorWriter.Write(w, webutil.ParseError{})
+		return
+	}
+
+	err = validateSuppression(params.Email, params.Type, "type", []string{
+		models.SuppressionReasonBounce,
+		models.SuppressionReasonComplaint,
+	})
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	_, err = h.suppressor.Suppress(context.Get("database").(DatabaseInterface), params.Email, params.Type, params.Detail)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,91 @@
+package suppressions_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/suppressions"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BounceHandler", func() {
+	var (
+		handler     suppressions.BounceHandler
+		errorWriter *mocks.ErrorWriter
+		writer      *httptest.ResponseRecorder
+		suppressor  *mocks.Suppressor
+		database    *mocks.Database
+		context     stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		suppressor = mocks.NewSuppressor()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = suppressions.NewBounceHandler(suppressor, errorWriter)
+	})
+
+	post := func(body string) {
+		request, err := http.NewRequest("POST", "/bounces", bytes.NewBufferString(body))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+	}
+
+	It("suppresses the reported address", func() {
+		post(`{"email": "user@example.com", "type": "complaint", "detail": "abuse report"}`)
+
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+		Expect(suppressor.SuppressCall.Receives.Database).To(Equal(database))
+		Expect(suppressor.SuppressCall.Receives.Email).To(Equal("user@example.com"))
+		Expect(suppressor.SuppressCall.Receives.Reason).To(Equal("complaint"))
+		Expect(suppressor.SuppressCall.Receives.Detail).To(Equal("abuse report"))
+	})
+
+	It("rejects a body that cannot be parsed", func() {
+		post(`{"email": `)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(Equal(webutil.ParseError{}))
+		Expect(suppressor.SuppressCall.WasCalled).To(BeFalse())
+	})
+
+	It("rejects a report without an email address", func() {
+		post(`{"type": "bounce"}`)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New(`"email" is a required field`)}))
+		Expect(suppressor.SuppressCall.WasCalled).To(BeFalse())
+	})
+
+	It("rejects a malformed email address", func() {
+		post(`{"email": "not-an-address", "type": "bounce"}`)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New(`"not-an-address" is not a valid email address`)}))
+	})
+
+	It("rejects an unknown report type", func() {
+		post(`{"email": "user@example.com", "type": "manual"}`)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New(`"type" must be one of bounce, complaint`)}))
+		Expect(suppressor.SuppressCall.WasCalled).To(BeFalse())
+	})
+
+	It("delegates errors to the error writer", func() {
+		suppressor.SuppressCall.Returns.Error = errors.New("BOOM!")
+
+		post(`{"email": "user@example.com", "type": "bounce"}`)
+
+		Expect(errorWriter.WriteCall.Receives.Writer).To(Equal(writer))
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
@@ -0,0 +1,84 @@
+package suppressions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+type suppressor interface {
+	Suppress(database services.DatabaseInterface, email, reason, detail string) (models.Suppression, error)
+}
+
+type CreateHandler struct {
+	suppressor  suppressor
+	errorWriter errorWriter
+}
+
+func NewCreateHandler(suppressor suppressor, errWriter errorWriter) CreateHandler {
+	return CreateHandler{
+		suppressor:  suppressor,
+		errorWriter: errWriter,
+	}
+}
+
+func (h CreateHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	var params struct {
+		Email  string `json:"email"`
+		Reason string `json:"reason"`
+		Detail string `json:"detail"`
+	}
+
+	err := json.NewDecoder(req.Body).Decode(&params)
+	if err != nil {
+		h.errorWriter.Write(w, webutil.ParseError{})
+		return
+	}
+
+	if params.Reason == "" {
+		params.Reason = models.SuppressionReasonManual
+	}
+
+	err = validateSuppression(params.Email, params.Reason, "reason", []string{
+		models.SuppressionReasonBounce,
+		models.SuppressionReasonComplaint,
+		models.SuppressionReasonManual,
+	})
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	suppression, err := h.suppressor.Suppress(context.Get("database").(DatabaseInterface), params.Email, params.Reason, params.Detail)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newSuppressionDocument(suppression))
+}
+
+func validateSuppression(email, reason, reasonField string, reasons []string) error {
+	if email == "" {
+		return webutil.ValidationError{Err: errors.New(`"email" is a required field`)}
+	}
+
+	if !strings.Contains(email, "@") {
+		return webutil.ValidationError{Err: fmt.Errorf("%q is not a valid email address", email)}
+	}
+
+	for _, valid := range reasons {
+		if reason == valid {
+			return nil
+		}
+	}
+
+	return webutil.ValidationError{Err: fmt.Errorf("%q must be one of %s", reasonField, strings.Join(reasons, ", "))}
+}
@@ -0,0 +1,99 @@
+package suppressions_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/suppressions"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CreateHandler", func() {
+	var (
+		handler     suppressions.CreateHandler
+		errorWriter *mocks.ErrorWriter
+		writer      *httptest.ResponseRecorder
+		suppressor  *mocks.Suppressor
+		database    *mocks.Database
+		context     stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		suppressor = mocks.NewSuppressor()
+		suppressor.SuppressCall.Returns.Suppression = models.Suppression{
+			Email:     "user@example.com",
+			Reason:    "manual",
+			Detail:    "requested by support",
+			CreatedAt: time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+		}
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = suppressions.NewCreateHandler(suppressor, errorWriter)
+	})
+
+	post := func(body string) {
+		request, err := http.NewRequest("POST", "/suppressions", bytes.NewBufferString(body))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+	}
+
+	It("suppresses the address and returns the suppression", func() {
+		post(`{"email": "user@example.com", "detail": "requested by support"}`)
+
+		Expect(writer.Code).To(Equal(http.StatusCreated))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"email": "user@example.com",
+			"reason": "manual",
+			"detail": "requested by support",
+			"created_at": "2015-06-08T14:00:00Z",
+			"updated_at": "2015-06-08T14:00:00Z"
+		}`))
+
+		Expect(suppressor.SuppressCall.Receives.Database).To(Equal(database))
+		Expect(suppressor.SuppressCall.Receives.Email).To(Equal("user@example.com"))
+		Expect(suppressor.SuppressCall.Receives.Reason).To(Equal("manual"))
+		Expect(suppressor.SuppressCall.Receives.Detail).To(Equal("requested by support"))
+	})
+
+	It("accepts an explicit reason", func() {
+		post(`{"email": "user@example.com", "reason": "bounce"}`)
+
+		Expect(suppressor.SuppressCall.Receives.Reason).To(Equal("bounce"))
+	})
+
+	It("rejects a body that cannot be parsed", func() {
+		post(`banana`)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(Equal(webutil.ParseError{}))
+	})
+
+	It("rejects an unknown reason", func() {
+		post(`{"email": "user@example.com", "reason": "bored"}`)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New(`"reason" must be one of bounce, complaint, manual`)}))
+		Expect(suppressor.SuppressCall.WasCalled).To(BeFalse())
+	})
+
+	It("delegates errors to the error writer", func() {
+		suppressor.SuppressCall.Returns.Error = errors.New("BOOM!")
+
+		post(`{"email": "user@example.com"}`)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
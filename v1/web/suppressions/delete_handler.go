@@ -0,0 +1,37 @@
+package suppressions
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/ryanmoran/stack"
+)
+
+type suppressionRemover interface {
+	Remove(services.DatabaseInterface, string) error
+}
+
+type DeleteHandler struct {
+	remover     suppressionRemover
+	errorWriter errorWriter
+}
+
+func NewDeleteHandler(remover suppressionRemover, errWriter errorWriter) DeleteHandler {
+	return DeleteHandler{
+		remover:     remover,
+		errorWriter: errWriter,
+	}
+}
+
+func (h DeleteHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	email := strings.Split(req.URL.Path, "/suppressions/")[1]
+
+	err := h.remover.Remove(context.Get("database").(DatabaseInterface), email)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
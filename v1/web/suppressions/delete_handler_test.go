@@ -0,0 +1,58 @@
+package suppressions_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/suppressions"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeleteHandler", func() {
+	var (
+		handler            suppressions.DeleteHandler
+		errorWriter        *mocks.ErrorWriter
+		writer             *httptest.ResponseRecorder
+		request            *http.Request
+		suppressionRemover *mocks.SuppressionRemover
+		database           *mocks.Database
+		context            stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		suppressionRemover = mocks.NewSuppressionRemover()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		var err error
+		request, err = http.NewRequest("DELETE", "/suppressions/user@example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = suppressions.NewDeleteHandler(suppressionRemover, errorWriter)
+	})
+
+	It("lifts the suppression", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusNoContent))
+		Expect(suppressionRemover.RemoveCall.Receives.Database).To(Equal(database))
+		Expect(suppressionRemover.RemoveCall.Receives.Email).To(Equal("user@example.com"))
+	})
+
+	It("delegates errors to the error writer", func() {
+		suppressionRemover.RemoveCall.Returns.Error = errors.New("BOOM!")
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Writer).To(Equal(writer))
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
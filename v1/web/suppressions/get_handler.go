@@ -0,0 +1,73 @@
+package suppressions
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/ryanmoran/stack"
+)
+
+type errorWriter interface {
+	Write(writer http.ResponseWriter, err error)
+}
+
+type suppressionFinder interface {
+	List(services.DatabaseInterface, int, int) (services.SuppressionList, error)
+	Find(services.DatabaseInterface, string) (models.Suppression, error)
+}
+
+type GetHandler struct {
+	finder      suppressionFinder
+	errorWriter errorWriter
+}
+
+func NewGetHandler(finder suppressionFinder, errWriter errorWriter) GetHandler {
+	return GetHandler{
+		finder:      finder,
+		errorWriter: errWriter,
+	}
+}
+
+func (h GetHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	email := strings.Split(req.URL.Path, "/suppressions/")[1]
+
+	suppression, err := h.finder.Find(context.Get("database").(DatabaseInterface), email)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newSuppressionDocument(suppression))
+}
+
+type suppressionDocument struct {
+	Email     string `json:"email"`
+	Reason    string `json:"reason"`
+	Detail    string `json:"detail"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func newSuppressionDocument(suppression models.Suppression) suppressionDocument {
+	return suppressionDocument{
+		Email:     suppression.Email,
+		Reason:    suppression.Reason,
+		Detail:    suppression.Detail,
+		CreatedAt: suppression.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: suppression.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, object interface{}) {
+	output, err := json.Marshal(object)
+	if err != nil {
+		panic(err) // No JSON we write into a response should ever panic
+	}
+
+	w.WriteHeader(status)
+	w.Write(output)
+}
@@ -0,0 +1,75 @@
+package suppressions_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/suppressions"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetHandler", func() {
+	var (
+		handler           suppressions.GetHandler
+		errorWriter       *mocks.ErrorWriter
+		writer            *httptest.ResponseRecorder
+		request           *http.Request
+		suppressionFinder *mocks.SuppressionFinder
+		database          *mocks.Database
+		context           stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		suppressionFinder = mocks.NewSuppressionFinder()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		var err error
+		request, err = http.NewRequest("GET", "/suppressions/user@example.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = suppressions.NewGetHandler(suppressionFinder, errorWriter)
+	})
+
+	It("returns the suppression for the address", func() {
+		suppressionFinder.FindCall.Returns.Suppression = models.Suppression{
+			Email:     "user@example.com",
+			Reason:    "complaint",
+			CreatedAt: time.Date(2015, 6, 8, 13, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2015, 6, 8, 13, 0, 0, 0, time.UTC),
+		}
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"email": "user@example.com",
+			"reason": "complaint",
+			"detail": "",
+			"created_at": "2015-06-08T13:00:00Z",
+			"updated_at": "2015-06-08T13:00:00Z"
+		}`))
+
+		Expect(suppressionFinder.FindCall.Receives.Database).To(Equal(database))
+		Expect(suppressionFinder.FindCall.Receives.Email).To(Equal("user@example.com"))
+	})
+
+	It("delegates errors to the error writer", func() {
+		suppressionFinder.FindCall.Returns.Error = errors.New("BOOM!")
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Writer).To(Equal(writer))
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
@@ -0,0 +1,56 @@
+package suppressions
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+const (
+	DefaultSuppressionsPerPage = 50
+	MaxSuppressionsPerPage     = 500
+)
+
+type ListHandler struct {
+	finder      suppressionFinder
+	errorWriter errorWriter
+}
+
+func NewListHandler(finder suppressionFinder, errWriter errorWriter) ListHandler {
+	return ListHandler{
+		finder:      finder,
+		errorWriter: errWriter,
+	}
+}
+
+func (h ListHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	page, perPage, err := webutil.ParsePagination(req, DefaultSuppressionsPerPage, MaxSuppressionsPerPage)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	list, err := h.finder.List(context.Get("database").(DatabaseInterface), page, perPage)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	var document struct {
+		Total        int                   `json:"total"`
+		Page         int                   `json:"page"`
+		PerPage      int                   `json:"per_page"`
+		Suppressions []suppressionDocument `json:"suppressions"`
+	}
+	document.Total = list.Total
+	document.Page = page
+	document.PerPage = perPage
+	document.Suppressions = []suppressionDocument{}
+
+	for _, suppression := range list.Suppressions {
+		document.Suppressions = append(document.Suppressions, newSuppressionDocument(suppression))
+	}
+
+	writeJSON(w, http.StatusOK, document)
+}
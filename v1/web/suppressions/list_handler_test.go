@@ -0,0 +1,114 @@
+package suppressions_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/suppressions"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ListHandler", func() {
+	var (
+		handler           suppressions.ListHandler
+		errorWriter       *mocks.ErrorWriter
+		writer            *httptest.ResponseRecorder
+		suppressionFinder *mocks.SuppressionFinder
+		database          *mocks.Database
+		context           stack.Context
+	)
+
+	BeforeEach(func() {
+		errorWriter = mocks.NewErrorWriter()
+		suppressionFinder = mocks.NewSuppressionFinder()
+		writer = httptest.NewRecorder()
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		handler = suppressions.NewListHandler(suppressionFinder, errorWriter)
+	})
+
+	It("returns the page of suppressions", func() {
+		suppressionFinder.ListCall.Returns.SuppressionList = services.SuppressionList{
+			Total: 3,
+			Suppressions: []models.Suppression{
+				{
+					Email:     "user@example.com",
+					Reason:    "bounce",
+					Detail:    "550 5.1.1 no such user",
+					CreatedAt: time.Date(2015, 6, 8, 13, 0, 0, 0, time.UTC),
+					UpdatedAt: time.Date(2015, 6, 8, 14, 0, 0, 0, time.UTC),
+				},
+			},
+		}
+
+		request, err := http.NewRequest("GET", "/suppressions?page=3&per_page=1", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"total": 3,
+			"page": 3,
+			"per_page": 1,
+			"suppressions": [
+				{
+					"email": "user@example.com",
+					"reason": "bounce",
+					"detail": "550 5.1.1 no such user",
+					"created_at": "2015-06-08T13:00:00Z",
+					"updated_at": "2015-06-08T14:00:00Z"
+				}
+			]
+		}`))
+
+		Expect(suppressionFinder.ListCall.Receives.Database).To(Equal(database))
+		Expect(suppressionFinder.ListCall.Receives.Page).To(Equal(3))
+		Expect(suppressionFinder.ListCall.Receives.PerPage).To(Equal(1))
+	})
+
+	It("defaults the page and page size", func() {
+		request, err := http.NewRequest("GET", "/suppressions", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"total": 0,
+			"page": 1,
+			"per_page": 50,
+			"suppressions": []
+		}`))
+	})
+
+	It("rejects an invalid page size", func() {
+		request, err := http.NewRequest("GET", "/suppressions?per_page=banana", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New(`"per_page" must be an integer between 1 and 500`)}))
+	})
+
+	It("delegates errors to the error writer", func() {
+		suppressionFinder.ListCall.Returns.Error = errors.New("BOOM!")
+
+		request, err := http.NewRequest("GET", "/suppressions", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(errors.New("BOOM!")))
+	})
+})
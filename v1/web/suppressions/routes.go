@@ -0,0 +1,29 @@
+package suppressions
+
+import "github.com/ryanmoran/stack"
+
+type muxer interface {
+	Handle(method, path string, handler stack.Handler, middleware ...stack.Middleware)
+}
+
+type Routes struct {
+	RequestCounter                  stack.Middleware
+	RequestLogging                  stack.Middleware
+	BouncesWriteAuthenticator       stack.Middleware
+	NotificationsAdminAuthenticator stack.Middleware
+	DatabaseAllocator               stack.Middleware
+
+	Suppressor         suppressor
+	SuppressionFinder  suppressionFinder
+	SuppressionRemover suppressionRemover
+	ErrorWriter        errorWriter
+}
+
+func (r Routes) Register(m muxer) {
+	m.Handle("POST", "/bounces", NewBounceHandler(r.Suppressor, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.BouncesWriteAuthenticator, r.DatabaseAllocator)
+
+	m.Handle("GET", "/suppressions", NewListHandler(r.SuppressionFinder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsAdminAuthenticator, r.DatabaseAllocator)
+	m.Handle("POST", "/suppressions", NewCreateHandler(r.Suppressor, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsAdminAuthenticator, r.DatabaseAllocator)
+	m.Handle("GET", "/suppressions/{email}", NewGetHandler(r.SuppressionFinder, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsAdminAuthenticator, r.DatabaseAllocator)
+	m.Handle("DELETE", "/suppressions/{email}", NewDeleteHandler(r.SuppressionRemover, r.ErrorWriter), r.RequestLogging, r.RequestCounter, r.NotificationsAdminAuthenticator, r.DatabaseAllocator)
+}
@@ -0,0 +1,67 @@
+package suppressions_test
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/middleware"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/suppressions"
+	"github.com/cloudfoundry-incubator/notifications/web"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Routes", func() {
+	var muxer web.Muxer
+
+	BeforeEach(func() {
+		muxer = web.NewMuxer()
+		suppressions.Routes{
+			RequestCounter:                  middleware.RequestCounter{},
+			RequestLogging:                  middleware.RequestLogging{},
+			DatabaseAllocator:               middleware.DatabaseAllocator{},
+			BouncesWriteAuthenticator:       middleware.Authenticator{Scopes: []string{"bounces.write"}},
+			NotificationsAdminAuthenticator: middleware.Authenticator{Scopes: []string{"notifications.admin"}},
+
+			ErrorWriter:        mocks.NewErrorWriter(),
+			Suppressor:         mocks.NewSuppressor(),
+			SuppressionFinder:  mocks.NewSuppressionFinder(),
+			SuppressionRemover: mocks.NewSuppressionRemover(),
+		}.Register(muxer)
+	})
+
+	expectRoute := func(method, path string, handler interface{}, scope string) {
+		request, err := http.NewRequest(method, path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		s := muxer.Match(request).(stack.Stack)
+		Expect(s.Handler).To(BeAssignableToTypeOf(handler))
+		ExpectToContainMiddlewareStack(s.Middleware, middleware.RequestLogging{}, middleware.RequestCounter{}, middleware.Authenticator{}, middleware.DatabaseAllocator{})
+
+		authenticator := s.Middleware[2].(middleware.Authenticator)
+		Expect(authenticator.Scopes).To(ConsistOf([]string{scope}))
+	}
+
+	It("routes POST /bounces", func() {
+		expectRoute("POST", "/bounces", suppressions.BounceHandler{}, "bounces.write")
+	})
+
+	It("routes GET /suppressions", func() {
+		expectRoute("GET", "/suppressions", suppressions.ListHandler{}, "notifications.admin")
+	})
+
+	It("routes POST /suppressions", func() {
+		expectRoute("POST", "/suppressions", suppressions.CreateHandler{}, "notifications.admin")
+	})
+
+	It("routes GET /suppressions/{email}", func() {
+		expectRoute("GET", "/suppressions/user@example.com", suppressions.GetHandler{}, "notifications.admin")
+	})
+
+	It("routes DELETE /suppressions/{email}", func() {
+		expectRoute("DELETE", "/suppressions/user@example.com", suppressions.DeleteHandler{}, "notifications.admin")
+	})
+})
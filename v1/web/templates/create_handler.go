@@ -6,6 +6,7 @@ import (
 
 	"github.com/cloudfoundry-incubator/notifications/v1/collections"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/ryanmoran/stack"
 )
 
@@ -14,7 +15,7 @@ type errorWriter interface {
 }
 
 type templateCreator interface {
-	Create(connection collections.ConnectionInterface, template collections.Template) (collections.Template, error)
+	Create(connection collections.ConnectionInterface, template collections.Template, clientID string) (collections.Template, error)
 }
 
 type CreateHandler struct {
@@ -39,12 +40,13 @@ func (h CreateHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, conte
 	connection := context.Get("database").(DatabaseInterface).Connection()
 
 	template, err := h.creator.Create(connection, collections.Template{
-		Name:     templateParams.Name,
-		Text:     templateParams.Text,
-		HTML:     templateParams.HTML,
-		Subject:  templateParams.Subject,
-		Metadata: string(templateParams.Metadata),
-	})
+		Name:          templateParams.Name,
+		Text:          templateParams.Text,
+		HTML:          templateParams.HTML,
+		Subject:       templateParams.Subject,
+		Metadata:      string(templateParams.Metadata),
+		Localizations: templateParams.LocalizationsJSON(),
+	}, clientIDFromToken(context))
 	if err != nil {
 		h.errorWriter.Write(w, webutil.TemplateCreateError{})
 		return
@@ -54,6 +56,18 @@ func (h CreateHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, conte
 	w.Write([]byte(`{"template_id":"` + template.ID + `"}`))
 }
 
+// clientIDFromToken returns the ID of the client whose token authorized the
+// request, or nothing when there is no token.
+func clientIDFromToken(context stack.Context) string {
+	token, ok := context.Get("token").(*jwt.Token)
+	if !ok {
+		return ""
+	}
+
+	clientID, _ := token.Claims.(jwt.MapClaims)["client_id"].(string)
+	return clientID
+}
+
 func writeJSON(w http.ResponseWriter, status int, object interface{}) {
 	output, err := json.Marshal(object)
 	if err != nil {
@@ -7,11 +7,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
 	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
 	"github.com/cloudfoundry-incubator/notifications/v1/collections"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
 	"github.com/cloudfoundry-incubator/notifications/valiant"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/ryanmoran/stack"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -60,6 +62,19 @@ var _ = Describe("CreateHandler", func() {
 			context = stack.NewContext()
 			context.Set("database", database)
 
+			tokenHeader := map[string]interface{}{
+				"alg": "RS256",
+			}
+			claims := jwt.MapClaims{
+				"client_id": "mister-client",
+				"exp":       int64(3404281214),
+			}
+			token, err := jwt.Parse(helpers.BuildToken(tokenHeader, claims), func(*jwt.Token) (interface{}, error) {
+				return helpers.UAAPublicKeyRSA, nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			context.Set("token", token)
+
 			request, err = http.NewRequest("POST", "/templates", body)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -70,18 +85,45 @@ var _ = Describe("CreateHandler", func() {
 			handler.ServeHTTP(writer, request, context)
 
 			Expect(creator.CreateCall.Receives.Connection).To(Equal(connection))
+			Expect(creator.CreateCall.Receives.ClientID).To(Equal("mister-client"))
 			Expect(creator.CreateCall.Receives.Template).To(Equal(collections.Template{
-				Name:     "Emergency Template",
-				Text:     "Message to: {{.To}}. Raptor Alert.",
-				HTML:     "<p>{{.ClientID}} you should run.</p>",
-				Subject:  "Raptor Containment Unit Breached",
-				Metadata: "{}",
+				Name:          "Emergency Template",
+				Text:          "Message to: {{.To}}. Raptor Alert.",
+				HTML:          "<p>{{.ClientID}} you should run.</p>",
+				Subject:       "Raptor Containment Unit Breached",
+				Metadata:      "{}",
+				Localizations: "{}",
 			}))
 
 			Expect(writer.Code).To(Equal(http.StatusCreated))
 			Expect(writer.Body.String()).To(MatchJSON(`{"template_id":"template-guid"}`))
 		})
 
+		It("passes the localizations of the template to its Creator", func() {
+			body := bytes.NewBuffer([]byte{})
+			err := json.NewEncoder(body).Encode(map[string]interface{}{
+				"name": "Emergency Template",
+				"html": "<p>{{.ClientID}} you should run.</p>",
+				"localizations": map[string]interface{}{
+					"fr": map[string]interface{}{
+						"subject": "Alerte",
+						"html":    "<p>{{.ClientID}} vous devriez courir.</p>",
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			request, err = http.NewRequest("POST", "/templates", body)
+			Expect(err).NotTo(HaveOccurred())
+
+			handler.ServeHTTP(writer, request, context)
+
+			Expect(writer.Code).To(Equal(http.StatusCreated))
+			Expect(creator.CreateCall.Receives.Template.Localizations).To(MatchJSON(`{
+				"fr": {"subject": "Alerte", "text": "", "html": "<p>{{.ClientID}} vous devriez courir.</p>"}
+			}`))
+		})
+
 		Context("when an errors occurs", func() {
 			It("Writes a validation error to the errorwriter when the request is missing the name field", func() {
 				request, err = http.NewRequest("POST", "/templates", bytes.NewBuffer([]byte(`{"html": "<p>gobble</p>"}`)))
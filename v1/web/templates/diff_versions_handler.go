@@ -0,0 +1,57 @@
+package templates
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+type DiffVersionsHandler struct {
+	finder      templateVersionsFinder
+	errorWriter errorWriter
+}
+
+func NewDiffVersionsHandler(finder templateVersionsFinder, errWriter errorWriter) DiffVersionsHandler {
+	return DiffVersionsHandler{
+		finder:      finder,
+		errorWriter: errWriter,
+	}
+}
+
+func (h DiffVersionsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	templateID, version, err := parseTemplateVersionPath(req)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	var from int
+	if value := req.URL.Query().Get("from"); value != "" {
+		from, err = strconv.Atoi(value)
+		if err != nil || from < 1 {
+			h.errorWriter.Write(w, webutil.ValidationError{Err: errors.New(`"from" must be the number of a version`)})
+			return
+		}
+	}
+
+	diff, err := h.finder.Diff(context.Get("database").(DatabaseInterface), templateID, version, from)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, diffDocument{
+		From:   diff.From,
+		To:     diff.To,
+		Fields: diff.Fields,
+	})
+}
+
+type diffDocument struct {
+	From   int                 `json:"from"`
+	To     int                 `json:"to"`
+	Fields map[string][]string `json:"fields"`
+}
@@ -0,0 +1,97 @@
+package templates_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiffVersionsHandler", func() {
+	var (
+		handler     templates.DiffVersionsHandler
+		writer      *httptest.ResponseRecorder
+		request     *http.Request
+		context     stack.Context
+		finder      *mocks.TemplateVersionsFinder
+		errorWriter *mocks.ErrorWriter
+		database    *mocks.Database
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		finder = mocks.NewTemplateVersionsFinder()
+		finder.DiffCall.Returns.Diff = services.TemplateDiff{
+			From: 2,
+			To:   4,
+			Fields: map[string][]string{
+				"subject": {"-old subject", "+new subject"},
+			},
+		}
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		request, err = http.NewRequest("GET", "/templates/some-template-id/versions/4/diff?from=2", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = templates.NewDiffVersionsHandler(finder, errorWriter)
+	})
+
+	It("writes out the difference between the versions", func() {
+		handler.ServeHTTP(writer, request, context)
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"from": 2,
+			"to": 4,
+			"fields": {
+				"subject": ["-old subject", "+new subject"]
+			}
+		}`))
+
+		Expect(finder.DiffCall.Receives.Database).To(Equal(database))
+		Expect(finder.DiffCall.Receives.TemplateID).To(Equal("some-template-id"))
+		Expect(finder.DiffCall.Receives.Version).To(Equal(4))
+		Expect(finder.DiffCall.Receives.From).To(Equal(2))
+	})
+
+	It("diffs from the previous version when from is not given", func() {
+		var err error
+		request, err = http.NewRequest("GET", "/templates/some-template-id/versions/4/diff", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(finder.DiffCall.Receives.From).To(Equal(0))
+	})
+
+	It("returns a validation error when from is not a version number", func() {
+		var err error
+		request, err = http.NewRequest("GET", "/templates/some-template-id/versions/4/diff?from=banana", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(webutil.ValidationError{Err: errors.New(`"from" must be the number of a version`)}))
+		Expect(finder.DiffCall.Receives.TemplateID).To(BeEmpty())
+	})
+
+	It("delegates errors to the error writer", func() {
+		finder.DiffCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+	})
+})
@@ -38,12 +38,18 @@ func (h GetDefaultHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, c
 		panic(err)
 	}
 
+	localizations, err := models.ParseLocalizations(template.Localizations)
+	if err != nil {
+		panic(err)
+	}
+
 	templateOutput := TemplateOutput{
-		Name:     template.Name,
-		Subject:  template.Subject,
-		HTML:     template.HTML,
-		Text:     template.Text,
-		Metadata: metadata,
+		Name:          template.Name,
+		Subject:       template.Subject,
+		HTML:          template.HTML,
+		Text:          template.Text,
+		Metadata:      metadata,
+		Localizations: localizations,
 	}
 
 	writeJSON(w, http.StatusOK, templateOutput)
@@ -55,7 +55,8 @@ var _ = Describe("GetDefaultHandler", func() {
 			"subject": "CF Notification: {{.Subject}}",
 			"text": "Default Template {{.Text}}",
 			"html": "<p>Default Template</p> {{.HTML}}",
-			"metadata": {}
+			"metadata": {},
+			"localizations": {}
 		}`))
 
 		Expect(templateFinder.FindByIDCall.Receives.Database).To(Equal(database))
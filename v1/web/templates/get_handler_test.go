@@ -40,6 +40,9 @@ var _ = Describe("GetHandler", func() {
 				Text:     "the template {{variable}}",
 				HTML:     "<p> the template {{variable}} </p>",
 				Metadata: `{"hello": "world"}`,
+				Localizations: `{
+					"fr": {"subject": "Tout sur {{.Subject}}", "text": "", "html": "<p>le template</p>"}
+				}`,
 			}
 			writer = httptest.NewRecorder()
 			errorWriter = mocks.NewErrorWriter()
@@ -74,12 +77,19 @@ var _ = Describe("GetHandler", func() {
 					panic(err)
 				}
 
-				Expect(template).To(HaveLen(5))
+				Expect(template).To(HaveLen(6))
 				Expect(template["name"]).To(Equal("The Name of The Template"))
 				Expect(template["subject"]).To(Equal("All about the {{.Subject}}"))
 				Expect(template["text"]).To(Equal("the template {{variable}}"))
 				Expect(template["html"]).To(Equal("<p> the template {{variable}} </p>"))
 				Expect(template["metadata"]).To(Equal(map[string]interface{}{"hello": "world"}))
+				Expect(template["localizations"]).To(Equal(map[string]interface{}{
+					"fr": map[string]interface{}{
+						"subject": "Tout sur {{.Subject}}",
+						"text":    "",
+						"html":    "<p>le template</p>",
+					},
+				}))
 			})
 		})
 
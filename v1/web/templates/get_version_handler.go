@@ -0,0 +1,63 @@
+package templates
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/ryanmoran/stack"
+)
+
+var templateVersionPath = regexp.MustCompile("^/templates/([^/]+)/versions/([^/]+)")
+
+type GetVersionHandler struct {
+	finder      templateVersionsFinder
+	errorWriter errorWriter
+}
+
+func NewGetVersionHandler(finder templateVersionsFinder, errWriter errorWriter) GetVersionHandler {
+	return GetVersionHandler{
+		finder:      finder,
+		errorWriter: errWriter,
+	}
+}
+
+func (h GetVersionHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	templateID, version, err := parseTemplateVersionPath(req)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	templateVersion, err := h.finder.Find(context.Get("database").(DatabaseInterface), templateID, version)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	document, err := newVersionDocument(templateVersion)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, document)
+}
+
+// parseTemplateVersionPath returns the template ID and version number in the
+// path of a request for a version of a template.
+func parseTemplateVersionPath(req *http.Request) (string, int, error) {
+	matches := templateVersionPath.FindStringSubmatch(req.URL.Path)
+	if matches == nil {
+		return "", 0, models.NotFoundError{Err: fmt.Errorf("No template version in %q", req.URL.Path)}
+	}
+
+	version, err := strconv.Atoi(matches[2])
+	if err != nil || version < 1 {
+		return "", 0, models.NotFoundError{Err: fmt.Errorf("Version %q of template with ID %q could not be found", matches[2], matches[1])}
+	}
+
+	return matches[1], version, nil
+}
@@ -0,0 +1,93 @@
+package templates_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GetVersionHandler", func() {
+	var (
+		handler     templates.GetVersionHandler
+		writer      *httptest.ResponseRecorder
+		request     *http.Request
+		context     stack.Context
+		finder      *mocks.TemplateVersionsFinder
+		errorWriter *mocks.ErrorWriter
+		database    *mocks.Database
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		finder = mocks.NewTemplateVersionsFinder()
+		finder.FindCall.Returns.Version = models.TemplateVersion{
+			TemplateID: "some-template-id",
+			Version:    3,
+			Name:       "some-name",
+			Subject:    "some-subject",
+			Text:       "some-text",
+			HTML:       "<p>some-html</p>",
+			Metadata:   `{"hello": "world"}`,
+			ClientID:   "some-client-id",
+			CreatedAt:  time.Date(2015, 8, 4, 10, 30, 0, 0, time.UTC),
+		}
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		request, err = http.NewRequest("GET", "/templates/some-template-id/versions/3", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = templates.NewGetVersionHandler(finder, errorWriter)
+	})
+
+	It("writes out the version of the template", func() {
+		handler.ServeHTTP(writer, request, context)
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"version": 3,
+			"name": "some-name",
+			"subject": "some-subject",
+			"text": "some-text",
+			"html": "<p>some-html</p>",
+			"metadata": {"hello": "world"},
+			"localizations": {},
+			"client_id": "some-client-id",
+			"created_at": "2015-08-04T10:30:00Z"
+		}`))
+
+		Expect(finder.FindCall.Receives.Database).To(Equal(database))
+		Expect(finder.FindCall.Receives.TemplateID).To(Equal("some-template-id"))
+		Expect(finder.FindCall.Receives.Version).To(Equal(3))
+	})
+
+	It("reports that the version cannot be found when it is not a number", func() {
+		var err error
+		request, err = http.NewRequest("GET", "/templates/some-template-id/versions/latest", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		Expect(finder.FindCall.Receives.TemplateID).To(BeEmpty())
+	})
+
+	It("delegates errors to the error writer", func() {
+		finder.FindCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+	})
+})
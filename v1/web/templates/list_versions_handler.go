@@ -0,0 +1,101 @@
+package templates
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/ryanmoran/stack"
+)
+
+var templateVersionsPath = regexp.MustCompile("^/templates/([^/]+)/versions")
+
+type templateVersionsFinder interface {
+	List(database services.DatabaseInterface, templateID string) ([]models.TemplateVersion, error)
+	Find(database services.DatabaseInterface, templateID string, version int) (models.TemplateVersion, error)
+	Diff(database services.DatabaseInterface, templateID string, version, from int) (services.TemplateDiff, error)
+}
+
+type ListVersionsHandler struct {
+	finder      templateVersionsFinder
+	errorWriter errorWriter
+}
+
+func NewListVersionsHandler(finder templateVersionsFinder, errWriter errorWriter) ListVersionsHandler {
+	return ListVersionsHandler{
+		finder:      finder,
+		errorWriter: errWriter,
+	}
+}
+
+func (h ListVersionsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	var templateID string
+	if matches := templateVersionsPath.FindStringSubmatch(req.URL.Path); matches != nil {
+		templateID = matches[1]
+	}
+
+	versions, err := h.finder.List(context.Get("database").(DatabaseInterface), templateID)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	document := versionsDocument{
+		Versions: []versionDocument{},
+	}
+	for _, version := range versions {
+		versionDocument, err := newVersionDocument(version)
+		if err != nil {
+			h.errorWriter.Write(w, err)
+			return
+		}
+
+		document.Versions = append(document.Versions, versionDocument)
+	}
+
+	writeJSON(w, http.StatusOK, document)
+}
+
+type versionsDocument struct {
+	Versions []versionDocument `json:"versions"`
+}
+
+type versionDocument struct {
+	Version       int                                    `json:"version"`
+	Name          string                                 `json:"name"`
+	Subject       string                                 `json:"subject"`
+	HTML          string                                 `json:"html"`
+	Text          string                                 `json:"text"`
+	Metadata      map[string]interface{}                 `json:"metadata"`
+	Localizations map[string]models.TemplateLocalization `json:"localizations"`
+	ClientID      string                                 `json:"client_id"`
+	CreatedAt     string                                 `json:"created_at"`
+}
+
+func newVersionDocument(version models.TemplateVersion) (versionDocument, error) {
+	var metadata map[string]interface{}
+	err := json.Unmarshal([]byte(version.Metadata), &metadata)
+	if err != nil {
+		return versionDocument{}, err
+	}
+
+	localizations, err := models.ParseLocalizations(version.Localizations)
+	if err != nil {
+		return versionDocument{}, err
+	}
+
+	return versionDocument{
+		Version:       version.Version,
+		Name:          version.Name,
+		Subject:       version.Subject,
+		HTML:          version.HTML,
+		Text:          version.Text,
+		Metadata:      metadata,
+		Localizations: localizations,
+		ClientID:      version.ClientID,
+		CreatedAt:     version.CreatedAt.UTC().Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,122 @@
+package templates_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ListVersionsHandler", func() {
+	var (
+		handler     templates.ListVersionsHandler
+		writer      *httptest.ResponseRecorder
+		request     *http.Request
+		context     stack.Context
+		finder      *mocks.TemplateVersionsFinder
+		errorWriter *mocks.ErrorWriter
+		database    *mocks.Database
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		finder = mocks.NewTemplateVersionsFinder()
+		finder.ListCall.Returns.Versions = []models.TemplateVersion{
+			{
+				TemplateID: "some-template-id",
+				Version:    2,
+				Name:       "some-name",
+				Subject:    "some-subject",
+				Text:       "some-text",
+				HTML:       "<p>some-html</p>",
+				Metadata:   `{"hello": "world"}`,
+				Localizations: `{
+					"fr": {"subject": "un-sujet", "text": "", "html": "<p>un-html</p>"}
+				}`,
+				ClientID:  "some-client-id",
+				CreatedAt: time.Date(2015, 8, 4, 10, 30, 0, 0, time.UTC),
+			},
+			{
+				TemplateID: "some-template-id",
+				Version:    1,
+				Name:       "some-name",
+				Subject:    "some-subject",
+				Text:       "some-text",
+				HTML:       "<p>first-html</p>",
+				Metadata:   "{}",
+				CreatedAt:  time.Date(2015, 8, 3, 9, 0, 0, 0, time.UTC),
+			},
+		}
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		request, err = http.NewRequest("GET", "/templates/some-template-id/versions", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = templates.NewListVersionsHandler(finder, errorWriter)
+	})
+
+	It("writes out the versions of the template", func() {
+		handler.ServeHTTP(writer, request, context)
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"versions": [
+				{
+					"version": 2,
+					"name": "some-name",
+					"subject": "some-subject",
+					"text": "some-text",
+					"html": "<p>some-html</p>",
+					"metadata": {"hello": "world"},
+					"localizations": {
+						"fr": {"subject": "un-sujet", "text": "", "html": "<p>un-html</p>"}
+					},
+					"client_id": "some-client-id",
+					"created_at": "2015-08-04T10:30:00Z"
+				},
+				{
+					"version": 1,
+					"name": "some-name",
+					"subject": "some-subject",
+					"text": "some-text",
+					"html": "<p>first-html</p>",
+					"metadata": {},
+					"localizations": {},
+					"client_id": "",
+					"created_at": "2015-08-03T09:00:00Z"
+				}
+			]
+		}`))
+
+		Expect(finder.ListCall.Receives.Database).To(Equal(database))
+		Expect(finder.ListCall.Receives.TemplateID).To(Equal("some-template-id"))
+	})
+
+	It("writes out an empty list when the template has no versions", func() {
+		finder.ListCall.Returns.Versions = []models.TemplateVersion{}
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{"versions": []}`))
+	})
+
+	It("delegates errors to the error writer", func() {
+		finder.ListCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+	})
+})
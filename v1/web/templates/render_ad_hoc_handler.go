@@ -0,0 +1,55 @@
+package templates
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/ryanmoran/stack"
+)
+
+type RenderAdHocHandler struct {
+	renderer    templateRenderer
+	errorWriter errorWriter
+}
+
+func NewRenderAdHocHandler(renderer templateRenderer, errWriter errorWriter) RenderAdHocHandler {
+	return RenderAdHocHandler{
+		renderer:    renderer,
+		errorWriter: errWriter,
+	}
+}
+
+func (h RenderAdHocHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	var params renderAdHocParams
+	err := decodeRenderParams(req.Body, &params)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	template := models.Template{
+		Subject: params.Template.Subject,
+		Text:    params.Template.Text,
+		HTML:    params.Template.HTML,
+	}
+	if template.Subject == "" {
+		template.Subject = "{{.Subject}}"
+	}
+
+	rendering, err := h.renderer.RenderTemplate(template, params.Context.MessageContext())
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newRenderingDocument(rendering))
+}
+
+type renderAdHocParams struct {
+	Template struct {
+		Subject string `json:"subject"`
+		Text    string `json:"text"`
+		HTML    string `json:"html"`
+	} `json:"template"`
+	Context renderContext `json:"context"`
+}
@@ -0,0 +1,104 @@
+package templates_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RenderAdHocHandler", func() {
+	var (
+		handler     templates.RenderAdHocHandler
+		writer      *httptest.ResponseRecorder
+		request     *http.Request
+		context     stack.Context
+		renderer    *mocks.TemplateRenderer
+		errorWriter *mocks.ErrorWriter
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		renderer = mocks.NewTemplateRenderer()
+		renderer.RenderTemplateCall.Returns.Rendering = services.TemplateRendering{
+			Subject: "Hello",
+			Text:    "Text for some-org",
+			MIME:    "Subject: Hello\n\nText for some-org",
+		}
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+		context = stack.NewContext()
+
+		request, err = http.NewRequest("POST", "/templates/render", bytes.NewBufferString(`{
+			"template": {
+				"text": "Text for {{.Organization}}",
+				"html": "<p>HTML for {{.Organization}}</p>"
+			},
+			"context": {
+				"subject": "Hello",
+				"text": "some text",
+				"organization": "some-org"
+			}
+		}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = templates.NewRenderAdHocHandler(renderer, errorWriter)
+	})
+
+	It("renders the given template against the sample context", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(renderer.RenderTemplateCall.Receives.Template).To(Equal(models.Template{
+			Subject: "{{.Subject}}",
+			Text:    "Text for {{.Organization}}",
+			HTML:    "<p>HTML for {{.Organization}}</p>",
+		}))
+		Expect(renderer.RenderTemplateCall.Receives.Context).To(Equal(common.MessageContext{
+			Subject:      "Hello",
+			Text:         "some text",
+			Organization: "some-org",
+		}))
+	})
+
+	It("writes out the rendered template", func() {
+		handler.ServeHTTP(writer, request, context)
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"subject": "Hello",
+			"text": "Text for some-org",
+			"html": "",
+			"mime": "Subject: Hello\n\nText for some-org"
+		}`))
+	})
+
+	It("returns a parse error when the body is not valid JSON", func() {
+		var err error
+		request, err = http.NewRequest("POST", "/templates/render", bytes.NewBufferString(`{"template": "nope"}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(BeAssignableToTypeOf(webutil.ParseError{}))
+	})
+
+	It("delegates template errors to the error writer", func() {
+		renderer.RenderTemplateCall.Returns.Error = common.TemplateErrors{
+			{Template: "html", Line: 1, Column: 13, Message: "at <.Organization.Name>: can't evaluate field Name in type string"},
+		}
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(Equal(common.TemplateErrors{
+			{Template: "html", Line: 1, Column: 13, Message: "at <.Organization.Name>: can't evaluate field Name in type string"},
+		}))
+	})
+})
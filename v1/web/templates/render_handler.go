@@ -0,0 +1,153 @@
+package templates
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+)
+
+var templateRenderPath = regexp.MustCompile("^/templates/([^/]+)/render$")
+
+type templateRenderer interface {
+	Render(database services.DatabaseInterface, templateID string, context common.MessageContext) (services.TemplateRendering, error)
+	RenderTemplate(template models.Template, context common.MessageContext) (services.TemplateRendering, error)
+}
+
+type RenderHandler struct {
+	renderer    templateRenderer
+	errorWriter errorWriter
+}
+
+func NewRenderHandler(renderer templateRenderer, errWriter errorWriter) RenderHandler {
+	return RenderHandler{
+		renderer:    renderer,
+		errorWriter: errWriter,
+	}
+}
+
+func (h RenderHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	var templateID string
+	if matches := templateRenderPath.FindStringSubmatch(req.URL.Path); matches != nil {
+		templateID = matches[1]
+	}
+
+	var params renderParams
+	err := decodeRenderParams(req.Body, &params)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	rendering, err := h.renderer.Render(context.Get("database").(DatabaseInterface), templateID, params.Context.MessageContext())
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newRenderingDocument(rendering))
+}
+
+type renderParams struct {
+	Context renderContext `json:"context"`
+}
+
+// renderContext is the sample data a template is rendered with, in the shape
+// of the context a notification is rendered with.
+type renderContext struct {
+	From              string `json:"from"`
+	ReplyTo           string `json:"reply_to"`
+	To                string `json:"to"`
+	Subject           string `json:"subject"`
+	Text              string `json:"text"`
+	HTML              string `json:"html"`
+	KindDescription   string `json:"kind_description"`
+	SourceDescription string `json:"source_description"`
+	UserGUID          string `json:"user_guid"`
+	ClientID          string `json:"client_id"`
+	MessageID         string `json:"message_id"`
+	Space             string `json:"space"`
+	SpaceGUID         string `json:"space_guid"`
+	Organization      string `json:"organization"`
+	OrganizationGUID  string `json:"organization_guid"`
+	OrganizationRole  string `json:"organization_role"`
+	UnsubscribeID     string `json:"unsubscribe_id"`
+	UnsubscribeURL    string `json:"unsubscribe_url"`
+	Scope             string `json:"scope"`
+	Endorsement       string `json:"endorsement"`
+	Domain            string `json:"domain"`
+}
+
+// MessageContext returns the sample data with the defaults a notification
+// would get.
+func (c renderContext) MessageContext() common.MessageContext {
+	context := common.MessageContext{
+		From:              c.From,
+		ReplyTo:           c.ReplyTo,
+		To:                c.To,
+		Subject:           c.Subject,
+		Text:              c.Text,
+		HTML:              c.HTML,
+		HTMLComponents:    common.HTML{BodyContent: c.HTML},
+		KindDescription:   c.KindDescription,
+		SourceDescription: c.SourceDescription,
+		UserGUID:          c.UserGUID,
+		ClientID:          c.ClientID,
+		MessageID:         c.MessageID,
+		Space:             c.Space,
+		SpaceGUID:         c.SpaceGUID,
+		Organization:      c.Organization,
+		OrganizationGUID:  c.OrganizationGUID,
+		OrganizationRole:  c.OrganizationRole,
+		UnsubscribeID:     c.UnsubscribeID,
+		UnsubscribeURL:    c.UnsubscribeURL,
+		Scope:             c.Scope,
+		Endorsement:       c.Endorsement,
+		Domain:            c.Domain,
+	}
+
+	if context.Subject == "" {
+		context.Subject = "[no subject]"
+	}
+
+	if context.SourceDescription == "" {
+		context.SourceDescription = context.ClientID
+	}
+
+	return context
+}
+
+// decodeRenderParams reads the params of a render request. An empty body
+// renders with empty sample data.
+func decodeRenderParams(body io.ReadCloser, params interface{}) error {
+	defer body.Close()
+
+	err := json.NewDecoder(body).Decode(params)
+	if err != nil && err != io.EOF {
+		return webutil.ParseError{}
+	}
+
+	return nil
+}
+
+type renderingDocument struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	HTML    string `json:"html"`
+	MIME    string `json:"mime"`
+}
+
+func newRenderingDocument(rendering services.TemplateRendering) renderingDocument {
+	return renderingDocument{
+		Subject: rendering.Subject,
+		Text:    rendering.Text,
+		HTML:    rendering.HTML,
+		MIME:    rendering.MIME,
+	}
+}
@@ -0,0 +1,135 @@
+package templates_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RenderHandler", func() {
+	var (
+		handler     templates.RenderHandler
+		writer      *httptest.ResponseRecorder
+		request     *http.Request
+		context     stack.Context
+		renderer    *mocks.TemplateRenderer
+		errorWriter *mocks.ErrorWriter
+		database    *mocks.Database
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		renderer = mocks.NewTemplateRenderer()
+		renderer.RenderCall.Returns.Rendering = services.TemplateRendering{
+			Subject: "CF Notification: Hello",
+			Text:    "Hello, world",
+			HTML:    "<html><p>Hello, world</p></html>",
+			MIME:    "Subject: CF Notification: Hello\n\nHello, world",
+		}
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		request, err = http.NewRequest("POST", "/templates/some-template-id/render", bytes.NewBufferString(`{
+			"context": {
+				"from": "no-reply@example.com",
+				"to": "user@example.com",
+				"subject": "Hello",
+				"text": "Hello, world",
+				"html": "<p>Hello, world</p>",
+				"client_id": "some-client",
+				"organization": "some-org",
+				"space": "some-space"
+			}
+		}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = templates.NewRenderHandler(renderer, errorWriter)
+	})
+
+	It("renders the template against the sample context", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(renderer.RenderCall.Receives.Database).To(Equal(database))
+		Expect(renderer.RenderCall.Receives.TemplateID).To(Equal("some-template-id"))
+		Expect(renderer.RenderCall.Receives.Context).To(Equal(common.MessageContext{
+			From:              "no-reply@example.com",
+			To:                "user@example.com",
+			Subject:           "Hello",
+			Text:              "Hello, world",
+			HTML:              "<p>Hello, world</p>",
+			HTMLComponents:    common.HTML{BodyContent: "<p>Hello, world</p>"},
+			ClientID:          "some-client",
+			SourceDescription: "some-client",
+			Organization:      "some-org",
+			Space:             "some-space",
+		}))
+	})
+
+	It("writes out the rendered template", func() {
+		handler.ServeHTTP(writer, request, context)
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"subject": "CF Notification: Hello",
+			"text": "Hello, world",
+			"html": "<html><p>Hello, world</p></html>",
+			"mime": "Subject: CF Notification: Hello\n\nHello, world"
+		}`))
+	})
+
+	It("renders with the defaults of a notification when there is no sample context", func() {
+		var err error
+		request, err = http.NewRequest("POST", "/templates/some-template-id/render", bytes.NewBuffer([]byte{}))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(renderer.RenderCall.Receives.Context).To(Equal(common.MessageContext{
+			Subject: "[no subject]",
+		}))
+	})
+
+	It("returns a parse error when the body is not valid JSON", func() {
+		var err error
+		request, err = http.NewRequest("POST", "/templates/some-template-id/render", bytes.NewBufferString(`{"context":`))
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(BeAssignableToTypeOf(webutil.ParseError{}))
+		Expect(renderer.RenderCall.Receives.TemplateID).To(BeEmpty())
+	})
+
+	It("delegates template errors to the error writer", func() {
+		renderer.RenderCall.Returns.Error = common.TemplateErrors{
+			{Template: "text", Line: 2, Column: 15, Message: `function "shout" not defined`},
+		}
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(Equal(common.TemplateErrors{
+			{Template: "text", Line: 2, Column: 15, Message: `function "shout" not defined`},
+		}))
+	})
+
+	It("delegates other errors to the error writer", func() {
+		renderer.RenderCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+	})
+})
@@ -0,0 +1,47 @@
+package templates
+
+import (
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/services"
+	"github.com/ryanmoran/stack"
+)
+
+type templateRollbacker interface {
+	Rollback(database services.DatabaseInterface, templateID string, version int, clientID string) (models.TemplateVersion, error)
+}
+
+type RollbackHandler struct {
+	rollbacker  templateRollbacker
+	errorWriter errorWriter
+}
+
+func NewRollbackHandler(rollbacker templateRollbacker, errWriter errorWriter) RollbackHandler {
+	return RollbackHandler{
+		rollbacker:  rollbacker,
+		errorWriter: errWriter,
+	}
+}
+
+func (h RollbackHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, context stack.Context) {
+	templateID, version, err := parseTemplateVersionPath(req)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	templateVersion, err := h.rollbacker.Rollback(context.Get("database").(DatabaseInterface), templateID, version, clientIDFromToken(context))
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	document, err := newVersionDocument(templateVersion)
+	if err != nil {
+		h.errorWriter.Write(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, document)
+}
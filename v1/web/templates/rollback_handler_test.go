@@ -0,0 +1,113 @@
+package templates_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
+	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
+	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ryanmoran/stack"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RollbackHandler", func() {
+	var (
+		handler     templates.RollbackHandler
+		writer      *httptest.ResponseRecorder
+		request     *http.Request
+		context     stack.Context
+		rollbacker  *mocks.TemplateUpdater
+		errorWriter *mocks.ErrorWriter
+		database    *mocks.Database
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		rollbacker = mocks.NewTemplateUpdater()
+		rollbacker.RollbackCall.Returns.Version = models.TemplateVersion{
+			TemplateID: "some-template-id",
+			Version:    5,
+			Name:       "some-name",
+			Subject:    "some-subject",
+			Text:       "some-text",
+			HTML:       "<p>some-html</p>",
+			Metadata:   "{}",
+			ClientID:   "mister-client",
+			CreatedAt:  time.Date(2015, 8, 4, 10, 30, 0, 0, time.UTC),
+		}
+		errorWriter = mocks.NewErrorWriter()
+		writer = httptest.NewRecorder()
+
+		database = mocks.NewDatabase()
+		context = stack.NewContext()
+		context.Set("database", database)
+
+		tokenHeader := map[string]interface{}{
+			"alg": "RS256",
+		}
+		claims := jwt.MapClaims{
+			"client_id": "mister-client",
+			"exp":       int64(3404281214),
+		}
+		token, err := jwt.Parse(helpers.BuildToken(tokenHeader, claims), func(*jwt.Token) (interface{}, error) {
+			return helpers.UAAPublicKeyRSA, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		context.Set("token", token)
+
+		request, err = http.NewRequest("POST", "/templates/some-template-id/versions/2/rollback", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = templates.NewRollbackHandler(rollbacker, errorWriter)
+	})
+
+	It("rolls the template back to the version on behalf of the client", func() {
+		handler.ServeHTTP(writer, request, context)
+
+		Expect(rollbacker.RollbackCall.Receives.Database).To(Equal(database))
+		Expect(rollbacker.RollbackCall.Receives.TemplateID).To(Equal("some-template-id"))
+		Expect(rollbacker.RollbackCall.Receives.Version).To(Equal(2))
+		Expect(rollbacker.RollbackCall.Receives.ClientID).To(Equal("mister-client"))
+	})
+
+	It("writes out the version recorded by the rollback", func() {
+		handler.ServeHTTP(writer, request, context)
+		Expect(writer.Code).To(Equal(http.StatusOK))
+		Expect(writer.Body.String()).To(MatchJSON(`{
+			"version": 5,
+			"name": "some-name",
+			"subject": "some-subject",
+			"text": "some-text",
+			"html": "<p>some-html</p>",
+			"metadata": {},
+			"localizations": {},
+			"client_id": "mister-client",
+			"created_at": "2015-08-04T10:30:00Z"
+		}`))
+	})
+
+	It("reports that the version cannot be found when it is not a number", func() {
+		var err error
+		request, err = http.NewRequest("POST", "/templates/some-template-id/versions/0/rollback", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(BeAssignableToTypeOf(models.NotFoundError{}))
+		Expect(rollbacker.RollbackCall.Receives.TemplateID).To(BeEmpty())
+	})
+
+	It("delegates errors to the error writer", func() {
+		rollbacker.RollbackCall.Returns.Error = models.NotFoundError{Err: errors.New("not found")}
+
+		handler.ServeHTTP(writer, request, context)
+		Expect(errorWriter.WriteCall.Receives.Error).To(MatchError(models.NotFoundError{Err: errors.New("not found")}))
+	})
+})
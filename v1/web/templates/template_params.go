@@ -4,19 +4,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"text/template"
+	"sort"
 
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
 	"github.com/cloudfoundry-incubator/notifications/valiant"
 )
 
 type TemplateParams struct {
-	Name     string          `json:"name" validate-required:"true"`
-	Text     string          `json:"text"`
-	HTML     string          `json:"html" validate-required:"true"`
-	Subject  string          `json:"subject"`
-	Metadata json.RawMessage `json:"metadata"`
+	Name          string                                 `json:"name" validate-required:"true"`
+	Text          string                                 `json:"text"`
+	HTML          string                                 `json:"html" validate-required:"true"`
+	Subject       string                                 `json:"subject"`
+	Metadata      json.RawMessage                        `json:"metadata"`
+	Localizations map[string]models.TemplateLocalization `json:"localizations"`
 }
 
 func NewTemplateParams(body io.ReadCloser) (TemplateParams, error) {
@@ -39,28 +41,71 @@ func NewTemplateParams(body io.ReadCloser) (TemplateParams, error) {
 		template.Metadata = json.RawMessage("{}")
 	}
 
-	err = template.validateSyntax()
+	template.setDefaults()
+
+	err = template.normalizeLocalizations()
 	if err != nil {
 		return TemplateParams{}, err
 	}
 
-	template.setDefaults()
+	err = template.validateTemplates()
+	if err != nil {
+		return TemplateParams{}, err
+	}
 
 	return template, nil
 }
 
-func (t TemplateParams) validateSyntax() error {
-	toValidate := map[string]string{
-		"Subject": t.Subject,
-		"Text":    t.Text,
-		"HTML":    t.HTML,
-	}
-
-	for field, contents := range toValidate {
-		_, err := template.New("test").Parse(contents)
+// normalizeLocalizations keys each localization by its canonical BCP 47
+// tag, requires it to have an HTML template and defaults its subject the
+// same way the template's own subject is defaulted.
+func (t *TemplateParams) normalizeLocalizations() error {
+	localizations := make(map[string]models.TemplateLocalization, len(t.Localizations))
+	for _, locale := range t.locales() {
+		tag, err := models.CanonicalLocale(locale)
 		if err != nil {
-			return webutil.ValidationError{Err: fmt.Errorf("%s syntax is malformed please check your braces", field)}
+			return webutil.ValidationError{Err: err}
+		}
+
+		if _, ok := localizations[tag]; ok {
+			return webutil.ValidationError{Err: fmt.Errorf("The locale '%s' has more than one localization", tag)}
+		}
+
+		localization := t.Localizations[locale]
+		if localization.HTML == "" {
+			return webutil.ValidationError{Err: fmt.Errorf("Missing required field 'html' of the '%s' localization", tag)}
+		}
+
+		if localization.Subject == "" {
+			localization.Subject = "{{.Subject}}"
 		}
+
+		localizations[tag] = localization
+	}
+
+	t.Localizations = localizations
+
+	return nil
+}
+
+// validateTemplates checks that the subject, text and HTML templates, and
+// those of every localization, parse and only refer to fields a
+// notification is rendered with. Every error found is returned.
+func (t TemplateParams) validateTemplates() error {
+	var templateErrs common.TemplateErrors
+	templateErrs = append(templateErrs, common.CheckTemplate("subject", t.Subject, false)...)
+	templateErrs = append(templateErrs, common.CheckTemplate("text", t.Text, false)...)
+	templateErrs = append(templateErrs, common.CheckTemplate("html", t.HTML, true)...)
+
+	for _, locale := range t.locales() {
+		localization := t.Localizations[locale]
+		templateErrs = append(templateErrs, common.CheckTemplate(locale+" subject", localization.Subject, false)...)
+		templateErrs = append(templateErrs, common.CheckTemplate(locale+" text", localization.Text, false)...)
+		templateErrs = append(templateErrs, common.CheckTemplate(locale+" html", localization.HTML, true)...)
+	}
+
+	if len(templateErrs) > 0 {
+		return templateErrs
 	}
 
 	return nil
@@ -68,12 +113,40 @@ func (t TemplateParams) validateSyntax() error {
 
 func (t TemplateParams) ToModel() models.Template {
 	return models.Template{
-		Name:     t.Name,
-		Text:     t.Text,
-		HTML:     t.HTML,
-		Subject:  t.Subject,
-		Metadata: string(t.Metadata),
+		Name:          t.Name,
+		Text:          t.Text,
+		HTML:          t.HTML,
+		Subject:       t.Subject,
+		Metadata:      string(t.Metadata),
+		Localizations: t.LocalizationsJSON(),
+	}
+}
+
+// LocalizationsJSON returns the localizations in the form they are stored
+// with the template.
+func (t TemplateParams) LocalizationsJSON() string {
+	if len(t.Localizations) == 0 {
+		return "{}"
 	}
+
+	localizations, err := json.Marshal(t.Localizations)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(localizations)
+}
+
+// locales returns the locales of the localizations in a stable order so that
+// errors are reported deterministically.
+func (t TemplateParams) locales() []string {
+	var locales []string
+	for locale := range t.Localizations {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	return locales
 }
 
 func (t *TemplateParams) setDefaults() {
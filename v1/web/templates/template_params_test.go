@@ -3,10 +3,11 @@ package templates_test
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"io"
 	"io/ioutil"
 
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
+	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
 
@@ -72,7 +73,9 @@ var _ = Describe("TemplateParams", func() {
 							Subject: "{{.bad}",
 						})
 						_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
-						Expect(err).To(MatchError(webutil.ValidationError{Err: errors.New("Subject syntax is malformed please check your braces")}))
+						Expect(err).To(Equal(common.TemplateErrors{
+							{Template: "subject", Line: 1, Column: 1, Message: "bad character U+007D '}'"},
+						}))
 					})
 				})
 
@@ -85,7 +88,9 @@ var _ = Describe("TemplateParams", func() {
 							Subject: "Great Subject",
 						})
 						_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
-						Expect(err).To(MatchError(webutil.ValidationError{Err: errors.New("Text syntax is malformed please check your braces")}))
+						Expect(err).To(Equal(common.TemplateErrors{
+							{Template: "text", Line: 1, Column: 17, Message: "bad character U+007D '}'"},
+						}))
 					})
 				})
 
@@ -98,9 +103,147 @@ var _ = Describe("TemplateParams", func() {
 							Subject: "Great Subject",
 						})
 						_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
-						Expect(err).To(MatchError(webutil.ValidationError{Err: errors.New("HTML syntax is malformed please check your braces")}))
+						Expect(err).To(Equal(common.TemplateErrors{
+							{Template: "html", Line: 1, Column: 1, Message: "bad character U+007D '}'"},
+						}))
 					})
 				})
+
+				It("returns an error for each template that is invalid", func() {
+					body := buildTemplateRequestBody(templates.TemplateParams{
+						Name:    "Template name",
+						Text:    "You should feel {{.BAD}",
+						HTML:    "{{.bad}",
+						Subject: "Great Subject",
+					})
+					_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
+					Expect(err).To(Equal(common.TemplateErrors{
+						{Template: "text", Line: 1, Column: 17, Message: "bad character U+007D '}'"},
+						{Template: "html", Line: 1, Column: 1, Message: "bad character U+007D '}'"},
+					}))
+				})
+			})
+
+			Context("when the template refers to fields a notification does not have", func() {
+				It("returns an error for each unknown field", func() {
+					body := buildTemplateRequestBody(templates.TemplateParams{
+						Name:    "Template name",
+						Text:    "Sent to {{.Orgnization}}",
+						HTML:    "<p>{{.Text}}</p>\n<p>{{.Spcae}}</p>",
+						Subject: "Great Subject",
+					})
+					_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
+					Expect(err).To(Equal(common.TemplateErrors{
+						{Template: "text", Line: 1, Column: 11, Message: "unknown field .Orgnization"},
+						{Template: "html", Line: 2, Column: 6, Message: "unknown field .Spcae"},
+					}))
+				})
+			})
+
+			Context("when the HTML template cannot be escaped", func() {
+				It("returns a template error", func() {
+					body := buildTemplateRequestBody(templates.TemplateParams{
+						Name:    "Template name",
+						HTML:    `<a href="{{.UnsubscribeURL}}>unsubscribe</a>`,
+						Subject: "Great Subject",
+					})
+					_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
+					Expect(err).To(Equal(common.TemplateErrors{
+						{Template: "html", Message: "ends in a non-text context"},
+					}))
+				})
+			})
+
+			Context("when the template has localizations", func() {
+				It("keys them by canonical locale and defaults their subjects", func() {
+					body, err := json.Marshal(map[string]interface{}{
+						"name": "Template name",
+						"html": "<p>{{.Text}}</p>",
+						"localizations": map[string]interface{}{
+							"pt_br": map[string]interface{}{
+								"subject": "Assunto",
+								"text":    "Olá {{.Text}}",
+								"html":    "<p>Olá {{.Text}}</p>",
+							},
+							"FR": map[string]interface{}{
+								"html": "<p>Bonjour {{.Text}}</p>",
+							},
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					parameters, err := templates.NewTemplateParams(ioutil.NopCloser(bytes.NewBuffer(body)))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(parameters.Localizations).To(Equal(map[string]models.TemplateLocalization{
+						"pt-BR": {
+							Subject: "Assunto",
+							Text:    "Olá {{.Text}}",
+							HTML:    "<p>Olá {{.Text}}</p>",
+						},
+						"fr": {
+							Subject: "{{.Subject}}",
+							HTML:    "<p>Bonjour {{.Text}}</p>",
+						},
+					}))
+				})
+
+				It("returns a validation error when a locale is not a BCP 47 language tag", func() {
+					body := buildTemplateRequestBody(templates.TemplateParams{
+						Name: "Template name",
+						HTML: "<p>{{.Text}}</p>",
+						Localizations: map[string]models.TemplateLocalization{
+							"not a locale": {HTML: "<p>{{.Text}}</p>"},
+						},
+					})
+					_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
+					Expect(err).To(BeAssignableToTypeOf(webutil.ValidationError{}))
+					Expect(err).To(MatchError("The locale 'not a locale' is not a BCP 47 language tag"))
+				})
+
+				It("returns a validation error when two locales are the same tag", func() {
+					body := buildTemplateRequestBody(templates.TemplateParams{
+						Name: "Template name",
+						HTML: "<p>{{.Text}}</p>",
+						Localizations: map[string]models.TemplateLocalization{
+							"pt-BR": {HTML: "<p>{{.Text}}</p>"},
+							"pt_br": {HTML: "<p>{{.Text}}</p>"},
+						},
+					})
+					_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
+					Expect(err).To(BeAssignableToTypeOf(webutil.ValidationError{}))
+					Expect(err).To(MatchError("The locale 'pt-BR' has more than one localization"))
+				})
+
+				It("returns a validation error when a localization has no html", func() {
+					body := buildTemplateRequestBody(templates.TemplateParams{
+						Name: "Template name",
+						HTML: "<p>{{.Text}}</p>",
+						Localizations: map[string]models.TemplateLocalization{
+							"fr": {Text: "Bonjour"},
+						},
+					})
+					_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
+					Expect(err).To(BeAssignableToTypeOf(webutil.ValidationError{}))
+					Expect(err).To(MatchError("Missing required field 'html' of the 'fr' localization"))
+				})
+
+				It("returns a template error for each problem in a localization", func() {
+					body := buildTemplateRequestBody(templates.TemplateParams{
+						Name: "Template name",
+						HTML: "<p>{{.Text}}</p>",
+						Localizations: map[string]models.TemplateLocalization{
+							"fr": {
+								Subject: "{{.bad}",
+								HTML:    "<p>{{.Spcae}}</p>",
+							},
+						},
+					})
+					_, err := templates.NewTemplateParams(ioutil.NopCloser(body))
+					Expect(err).To(Equal(common.TemplateErrors{
+						{Template: "fr subject", Line: 1, Column: 1, Message: "bad character U+007D '}'"},
+						{Template: "fr html", Line: 1, Column: 6, Message: "unknown field .Spcae"},
+					}))
+				})
 			})
 		})
 	})
@@ -121,8 +264,26 @@ var _ = Describe("TemplateParams", func() {
 			Expect(templateModel.HTML).To(Equal("<p>its foobar</p>"))
 			Expect(templateModel.Subject).To(Equal("Foobar Yah"))
 			Expect(templateModel.Metadata).To(MatchJSON(`{"some_property": "some_value"}`))
+			Expect(templateModel.Localizations).To(MatchJSON(`{}`))
 			Expect(templateModel.CreatedAt).To(BeZero())
 			Expect(templateModel.UpdatedAt).To(BeZero())
 		})
+
+		It("encodes the localizations as JSON", func() {
+			templateParams := templates.TemplateParams{
+				Name:     "The Foo to the Bar",
+				HTML:     "<p>its foobar</p>",
+				Subject:  "Foobar Yah",
+				Metadata: json.RawMessage(`{}`),
+				Localizations: map[string]models.TemplateLocalization{
+					"fr": {Subject: "Foobar Oui", HTML: "<p>c'est foobar</p>"},
+				},
+			}
+			templateModel := templateParams.ToModel()
+
+			Expect(templateModel.Localizations).To(MatchJSON(`{
+				"fr": {"subject": "Foobar Oui", "text": "", "html": "<p>c'est foobar</p>"}
+			}`))
+		})
 	})
 })
@@ -9,7 +9,7 @@ import (
 )
 
 type templateUpdater interface {
-	Update(database services.DatabaseInterface, templateID string, template models.Template) error
+	Update(database services.DatabaseInterface, templateID string, template models.Template, clientID string) error
 }
 
 type UpdateDefaultHandler struct {
@@ -31,7 +31,7 @@ func (h UpdateDefaultHandler) ServeHTTP(w http.ResponseWriter, req *http.Request
 		return
 	}
 
-	err = h.updater.Update(context.Get("database").(DatabaseInterface), models.DefaultTemplateID, template.ToModel())
+	err = h.updater.Update(context.Get("database").(DatabaseInterface), models.DefaultTemplateID, template.ToModel(), clientIDFromToken(context))
 	if err != nil {
 		h.errorWriter.Write(w, err)
 	}
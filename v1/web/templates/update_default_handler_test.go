@@ -6,11 +6,13 @@ import (
 	"net/http/httptest"
 	"strings"
 
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
 	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
 	"github.com/cloudfoundry-incubator/notifications/valiant"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/ryanmoran/stack"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -46,6 +48,19 @@ var _ = Describe("UpdateDefaultHandler", func() {
 		context = stack.NewContext()
 		context.Set("database", database)
 
+		tokenHeader := map[string]interface{}{
+			"alg": "RS256",
+		}
+		claims := jwt.MapClaims{
+			"client_id": "mister-client",
+			"exp":       int64(3404281214),
+		}
+		token, err := jwt.Parse(helpers.BuildToken(tokenHeader, claims), func(*jwt.Token) (interface{}, error) {
+			return helpers.UAAPublicKeyRSA, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		context.Set("token", token)
+
 		handler = templates.NewUpdateDefaultHandler(updater, errorWriter)
 	})
 
@@ -55,12 +70,14 @@ var _ = Describe("UpdateDefaultHandler", func() {
 		Expect(writer.Code).To(Equal(http.StatusNoContent))
 		Expect(updater.UpdateCall.Receives.Database).To(Equal(database))
 		Expect(updater.UpdateCall.Receives.TemplateID).To(Equal(models.DefaultTemplateID))
+		Expect(updater.UpdateCall.Receives.ClientID).To(Equal("mister-client"))
 		Expect(updater.UpdateCall.Receives.Template).To(Equal(models.Template{
-			Name:     "Defaultish Template",
-			Subject:  "{{.Subject}}",
-			HTML:     "<p>something</p>",
-			Text:     "something",
-			Metadata: `{"hello": true}`,
+			Name:          "Defaultish Template",
+			Subject:       "{{.Subject}}",
+			HTML:          "<p>something</p>",
+			Text:          "something",
+			Metadata:      `{"hello": true}`,
+			Localizations: "{}",
 		}))
 	})
 
@@ -28,7 +28,7 @@ func (h UpdateHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, conte
 		return
 	}
 
-	err = h.updater.Update(context.Get("database").(DatabaseInterface), templateID, templateParams.ToModel())
+	err = h.updater.Update(context.Get("database").(DatabaseInterface), templateID, templateParams.ToModel(), clientIDFromToken(context))
 	if err != nil {
 		h.errorWriter.Write(w, err)
 		return
@@ -6,11 +6,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 
+	"github.com/cloudfoundry-incubator/notifications/testing/helpers"
 	"github.com/cloudfoundry-incubator/notifications/testing/mocks"
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/templates"
 	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
 	"github.com/cloudfoundry-incubator/notifications/valiant"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/ryanmoran/stack"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -42,6 +44,19 @@ var _ = Describe("UpdateHandler", func() {
 			context = stack.NewContext()
 			context.Set("database", database)
 
+			tokenHeader := map[string]interface{}{
+				"alg": "RS256",
+			}
+			claims := jwt.MapClaims{
+				"client_id": "mister-client",
+				"exp":       int64(3404281214),
+			}
+			token, err := jwt.Parse(helpers.BuildToken(tokenHeader, claims), func(*jwt.Token) (interface{}, error) {
+				return helpers.UAAPublicKeyRSA, nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			context.Set("token", token)
+
 			handler = templates.NewUpdateHandler(updater, errorWriter)
 		})
 
@@ -51,12 +66,14 @@ var _ = Describe("UpdateHandler", func() {
 
 			Expect(updater.UpdateCall.Receives.Database).To(Equal(database))
 			Expect(updater.UpdateCall.Receives.TemplateID).To(Equal("a-template-id"))
+			Expect(updater.UpdateCall.Receives.ClientID).To(Equal("mister-client"))
 			Expect(updater.UpdateCall.Receives.Template).To(Equal(models.Template{
-				Name:     "An Interesting Template",
-				Subject:  "very interesting subject",
-				Text:     "Here's the msg {{.Text}}",
-				HTML:     "<p>turkey gobble</p>",
-				Metadata: "{}",
+				Name:          "An Interesting Template",
+				Subject:       "very interesting subject",
+				Text:          "Here's the msg {{.Text}}",
+				HTML:          "<p>turkey gobble</p>",
+				Metadata:      "{}",
+				Localizations: "{}",
 			}))
 		})
 
@@ -2,9 +2,12 @@ package webutil
 
 import (
 	"encoding/json"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/cloudfoundry-incubator/notifications/cf"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
 	"github.com/cloudfoundry-incubator/notifications/v1/collections"
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/cloudfoundry-incubator/notifications/v1/services"
@@ -17,24 +20,35 @@ func NewErrorWriter() ErrorWriter {
 }
 
 func (writer ErrorWriter) Write(w http.ResponseWriter, err error) {
-	switch err.(type) {
-	case UAAScopesError, CriticalNotificationError, collections.TemplateAssignmentError, MissingUserTokenError, ValidationError:
+	messages := []string{err.Error()}
+
+	switch e := err.(type) {
+	case UAAScopesError, CriticalNotificationError, collections.TemplateAssignmentError, MissingUserTokenError, ValidationError, common.TemplateError:
+		w.WriteHeader(422)
+	case common.TemplateErrors:
+		messages = []string{}
+		for _, templateErr := range e {
+			messages = append(messages, templateErr.Error())
+		}
 		w.WriteHeader(422)
 	case services.CCDownError:
 		w.WriteHeader(http.StatusBadGateway)
-	case services.CCNotFoundError, models.NotFoundError, cf.NotFoundError:
+	case services.CCNotFoundError, models.NotFoundError, cf.NotFoundError, services.InvalidUnsubscribeTokenError:
 		w.WriteHeader(http.StatusNotFound)
 	case ParseError, SchemaError:
 		w.WriteHeader(http.StatusBadRequest)
-	case models.DuplicateError:
+	case models.DuplicateError, IdempotencyKeyConflictError, services.MessageNotCancelableError:
 		w.WriteHeader(http.StatusConflict)
 	case services.DefaultScopeError:
 		w.WriteHeader(http.StatusNotAcceptable)
+	case services.QuotaExceededError:
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(e.RetryAfter.Seconds()))))
+		w.WriteHeader(http.StatusTooManyRequests)
 	default:
 		w.WriteHeader(http.StatusInternalServerError)
 	}
 
 	json.NewEncoder(w).Encode(map[string][]string{
-		"errors": []string{err.Error()},
+		"errors": messages,
 	})
 }
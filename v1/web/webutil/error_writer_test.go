@@ -4,8 +4,10 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	"github.com/cloudfoundry-incubator/notifications/cf"
+	"github.com/cloudfoundry-incubator/notifications/postal/common"
 	"github.com/cloudfoundry-incubator/notifications/v1/collections"
 	"github.com/cloudfoundry-incubator/notifications/v1/models"
 	"github.com/cloudfoundry-incubator/notifications/v1/services"
@@ -114,6 +116,14 @@ var _ = Describe("ErrorWriter", func() {
 		}`))
 	})
 
+	It("returns a 404 when an unsubscribe token is not valid", func() {
+		writer.Write(recorder, services.InvalidUnsubscribeTokenError{Err: errors.New("The unsubscribe token is not valid")})
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		Expect(recorder.Body).To(MatchJSON(`{
+			"errors": ["The unsubscribe token is not valid"]
+		}`))
+	})
+
 	It("returns a 406 when a record cannot be found", func() {
 		writer.Write(recorder, services.DefaultScopeError{})
 		Expect(recorder.Code).To(Equal(406))
@@ -138,6 +148,37 @@ var _ = Describe("ErrorWriter", func() {
 		}`))
 	})
 
+	It("returns a 422 when a template cannot be rendered", func() {
+		writer.Write(recorder, common.TemplateError{Template: "subject", Line: 1, Column: 14, Message: "bad character U+007D '}'"})
+		Expect(recorder.Code).To(Equal(422))
+		Expect(recorder.Body).To(MatchJSON(`{
+			"errors": ["subject template: line 1, column 14: bad character U+007D '}'"]
+		}`))
+	})
+
+	It("returns a 422 listing each error when several templates cannot be rendered", func() {
+		writer.Write(recorder, common.TemplateErrors{
+			{Template: "subject", Line: 1, Column: 14, Message: "bad character U+007D '}'"},
+			{Template: "html", Line: 2, Column: 5, Message: "at <.Nope>: can't evaluate field Nope"},
+		})
+		Expect(recorder.Code).To(Equal(422))
+		Expect(recorder.Body).To(MatchJSON(`{
+			"errors": [
+				"subject template: line 1, column 14: bad character U+007D '}'",
+				"html template: line 2, column 5: at <.Nope>: can't evaluate field Nope"
+			]
+		}`))
+	})
+
+	It("returns a 429 with a Retry-After header when a quota has been exceeded", func() {
+		writer.Write(recorder, services.QuotaExceededError{Err: errors.New("quota exceeded"), RetryAfter: 41500 * time.Millisecond})
+		Expect(recorder.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(recorder.Header().Get("Retry-After")).To(Equal("42"))
+		Expect(recorder.Body).To(MatchJSON(`{
+			"errors": ["quota exceeded"]
+		}`))
+	})
+
 	It("returns a 500 for unknown errors", func() {
 		writer.Write(recorder, errors.New("unknown error"))
 		Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
@@ -57,3 +57,11 @@ func NewCriticalNotificationError(kindID string) CriticalNotificationError {
 func (e CriticalNotificationError) Error() string {
 	return e.Err.Error()
 }
+
+type IdempotencyKeyConflictError struct {
+	Err error
+}
+
+func (e IdempotencyKeyConflictError) Error() string {
+	return e.Err.Error()
+}
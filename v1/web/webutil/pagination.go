@@ -0,0 +1,31 @@
+package webutil
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// ParsePagination reads the "page" and "per_page" query parameters of a list
+// request. Without them, the first page of defaultPerPage items is listed.
+func ParsePagination(req *http.Request, defaultPerPage, maxPerPage int) (int, int, error) {
+	query := req.URL.Query()
+	page, perPage := 1, defaultPerPage
+
+	var err error
+	if value := query.Get("page"); value != "" {
+		page, err = strconv.Atoi(value)
+		if err != nil || page < 1 {
+			return 0, 0, ValidationError{Err: fmt.Errorf(`"page" must be a positive integer`)}
+		}
+	}
+
+	if value := query.Get("per_page"); value != "" {
+		perPage, err = strconv.Atoi(value)
+		if err != nil || perPage < 1 || perPage > maxPerPage {
+			return 0, 0, ValidationError{Err: fmt.Errorf(`"per_page" must be an integer between 1 and %d`, maxPerPage)}
+		}
+	}
+
+	return page, perPage, nil
+}
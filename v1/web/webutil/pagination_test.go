@@ -0,0 +1,50 @@
+package webutil_test
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cloudfoundry-incubator/notifications/v1/web/webutil"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParsePagination", func() {
+	parse := func(query string) (int, int, error) {
+		request, err := http.NewRequest("GET", "/things?"+query, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		return webutil.ParsePagination(request, 50, 500)
+	}
+
+	It("reads the page and the number of items per page", func() {
+		page, perPage, err := parse("page=2&per_page=10")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(page).To(Equal(2))
+		Expect(perPage).To(Equal(10))
+	})
+
+	It("defaults to the first page of the default number of items", func() {
+		page, perPage, err := parse("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(page).To(Equal(1))
+		Expect(perPage).To(Equal(50))
+	})
+
+	It("rejects a page that is not a positive integer", func() {
+		_, _, err := parse("page=0")
+		Expect(err).To(MatchError(webutil.ValidationError{Err: errors.New(`"page" must be a positive integer`)}))
+
+		_, _, err = parse("page=two")
+		Expect(err).To(MatchError(webutil.ValidationError{Err: errors.New(`"page" must be a positive integer`)}))
+	})
+
+	It("rejects a number of items per page above the maximum", func() {
+		_, _, err := parse("per_page=501")
+		Expect(err).To(MatchError(webutil.ValidationError{Err: errors.New(`"per_page" must be an integer between 1 and 500`)}))
+
+		_, _, err = parse("per_page=0")
+		Expect(err).To(MatchError(webutil.ValidationError{Err: errors.New(`"per_page" must be an integer between 1 and 500`)}))
+	})
+})
@@ -18,6 +18,8 @@ func NewRouter(config Config) http.Handler {
 		CCHost:            config.CCHost,
 		CORSOrigin:        config.CORSOrigin,
 		SQLDB:             config.SQLDB,
+		MaxUsersBatchSize: config.MaxUsersBatchSize,
+		EncryptionKey:     config.EncryptionKey,
 	})
 
 	return VersionRouter{
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
 
@@ -17,9 +18,11 @@ type Config struct {
 	Port                 int
 	CORSOrigin           string
 	QueueWaitMaxDuration int
+	MaxUsersBatchSize    int
 	SQLDB                *sql.DB
 	Queue                gobble.QueueInterface
 	Logger               lager.Logger
+	EncryptionKey        []byte
 
 	UAATokenValidator *uaa.TokenValidator
 	UAAHost           string
@@ -29,16 +32,38 @@ type Config struct {
 	CCHost            string
 }
 
-type Server struct{}
+type Server struct {
+	config     Config
+	httpServer *http.Server
+}
 
-func NewServer() Server {
-	return Server{}
+func NewServer(config Config) Server {
+	return Server{
+		config: config,
+		httpServer: &http.Server{
+			Addr:    fmt.Sprintf(":%d", config.Port),
+			Handler: NewRouter(config),
+		},
+	}
 }
 
-func (s Server) Run(config Config) {
-	config.Logger.Info("listen-and-serve", lager.Data{
-		"port": config.Port,
+// Run serves requests until the server fails or is shut down. A server that
+// has been shut down returns without an error.
+func (s Server) Run() error {
+	s.config.Logger.Info("listen-and-serve", lager.Data{
+		"port": s.config.Port,
 	})
 
-	http.ListenAndServe(fmt.Sprintf(":%d", config.Port), NewRouter(config))
+	err := s.httpServer.ListenAndServe()
+	if err == http.ErrServerClosed {
+		return nil
+	}
+
+	return err
+}
+
+// Shutdown stops accepting connections and waits for requests that are
+// being served to complete, or for the context to be done.
+func (s Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
 }